@@ -133,6 +133,10 @@ const fetchReportSummary = (basePath: string, runID: string) => {
     });
 }
 
+const formatPercentage = (v: number) => {
+  return (Math.round((v || 0) * 10000) / 100).toString() + "%";
+}
+
 const skeleton = <Skeleton height={140} radius="md" animate={false}/>;
 
 
@@ -211,6 +215,23 @@ const ReportDetails = (props: ReportDetailsProps) => {
         {title: "Sell Vol", value: totalSellVolume.toString() + ` ${volumeUnit}`},
       ]}/>
 
+      <StatsGridIcons data={[
+        {
+          title: "Max Drawdown",
+          value: formatPercentage(reportSummary.maxDrawdown) + " / " +
+            moment.duration(reportSummary.maxDrawdownDuration / 1e6).humanize(),
+          dir: "down"
+        },
+        {
+          title: "Calmar Ratio",
+          value: (Math.round(reportSummary.calmarRatio * 100) / 100).toString(),
+          dir: reportSummary.calmarRatio >= 0 ? "up" : "down"
+        },
+        {title: "Exposure", value: formatPercentage(reportSummary.exposure)},
+        {title: "Turnover", value: (Math.round(reportSummary.turnover * 100) / 100).toString()},
+        {title: "Fee Drag", value: formatPercentage(reportSummary.feeDrag)},
+      ]}/>
+
       <Grid py="xl">
         <Grid.Col xs={6}>
           <Title order={6}>Initial Total Balances</Title>
@@ -33,6 +33,111 @@ export interface ReportSummary {
   finalTotalBalances: BalanceMap;
   symbolReports: SymbolReport[];
   manifests: Manifest[];
+
+  maxDrawdown: number;
+  // maxDrawdownDuration is in nanoseconds
+  maxDrawdownDuration: number;
+  annualizedReturn: number;
+  calmarRatio: number;
+  exposure: number;
+  turnover: number;
+  totalFeeInUSD: number;
+  feeDrag: number;
+
+  monteCarlo?: MonteCarloReport;
+  portfolio?: PortfolioReport;
+}
+
+export interface MonteCarloInterval {
+  mean: number;
+  median: number;
+  lower: number;
+  upper: number;
+}
+
+export interface MonteCarloResult {
+  method: 'shuffle' | 'bootstrap' | 'perturbation';
+  iterations: number;
+  finalEquity: MonteCarloInterval;
+  maxDrawdown: MonteCarloInterval;
+  riskOfRuin: number;
+}
+
+export interface MonteCarloReport {
+  numOfSamples: number;
+  confidenceLevel: number;
+  ruinThreshold: number;
+  initialEquity: number;
+  finalEquity: number;
+  maxDrawdown: number;
+  results: MonteCarloResult[];
+}
+
+export interface PortfolioInstanceReport {
+  id: string;
+  session: string;
+  strategy: string;
+  weight: number;
+  targetWeight: number;
+  initialEquity: number;
+  finalEquity: number;
+  netTransfer: number;
+  profit: number;
+  contribution: number;
+  return: number;
+  volatility: number;
+}
+
+export interface PortfolioTransfer {
+  session: string;
+  amount: number;
+}
+
+export interface PortfolioRebalance {
+  time: Date;
+  weights: number[];
+  transfers?: PortfolioTransfer[];
+}
+
+export interface PortfolioReport {
+  allocation: 'fixed' | 'riskParity' | 'volatilityTarget';
+  currency: string;
+  rebalanceInterval?: string;
+  returnInterval: string;
+  startTime: Date;
+  endTime: Date;
+  initialEquity: number;
+  finalEquity: number;
+  reserveEquity: number;
+  instances: PortfolioInstanceReport[];
+  correlation: number[][];
+  rebalances?: PortfolioRebalance[];
+}
+
+export interface EquityPoint {
+  time: Date;
+  equity: number;
+  drawdown: number;
+  exposed: boolean;
+}
+
+export interface EquityCurve {
+  interval: string;
+  points: EquityPoint[];
+}
+
+export interface TradeExcursion {
+  symbol: string;
+  side: string;
+  openTime: Date;
+  closeTime: Date;
+  entryPrice: number;
+  exitPrice: number;
+  quantity: number;
+  mae: number;
+  mfe: number;
+  profit: number;
+  closed: boolean;
 }
 
 export interface SymbolReport {
@@ -44,6 +149,13 @@ export interface SymbolReport {
   pnl: PnL;
   initialBalances: BalanceMap;
   finalBalances: BalanceMap;
+  quoteVolume: number;
+  turnover: number;
+  feeDrag: number;
+  exposure: number;
+  averageMAE: number;
+  averageMFE: number;
+  excursions?: TradeExcursion[];
 }
 
 
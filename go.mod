@@ -52,6 +52,7 @@ require (
 	github.com/webview/webview v0.0.0-20210216142346-e0bfdf0e5d90
 	github.com/x-cray/logrus-prefixed-formatter v0.5.2
 	github.com/zserge/lorca v0.1.9
+	go.starlark.net v0.0.0-20231121155337-90ade8b19d09
 	go.uber.org/multierr v1.7.0
 	golang.org/x/oauth2 v0.5.0
 	golang.org/x/sync v0.1.0
@@ -0,0 +1,24 @@
+-- +up
+CREATE TABLE `strategy_instance_navs`
+(
+    `gid`                  BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+
+    `strategy`             VARCHAR(32)     NOT NULL,
+    `strategy_instance_id` VARCHAR(64)     NOT NULL,
+    `session`              VARCHAR(30)     NOT NULL,
+    `time`                 DATETIME(3)     NOT NULL,
+
+    -- net_asset_value is the realized profit plus the unrealized profit minus the fee, in USD
+    `net_asset_value`      DECIMAL(32, 8)  NOT NULL,
+    `realized_profit`      DECIMAL(32, 8)  NOT NULL,
+    `unrealized_profit`    DECIMAL(32, 8)  NOT NULL,
+    `fee_in_usd`           DECIMAL(32, 8)  NOT NULL,
+    `position_value`       DECIMAL(32, 8)  NOT NULL,
+    `num_of_trades`        INT UNSIGNED    NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (`gid`),
+    INDEX `idx_strategy_instance_navs` (`strategy_instance_id`, `time`)
+);
+
+-- +down
+DROP TABLE IF EXISTS `strategy_instance_navs`;
@@ -0,0 +1,456 @@
+package backtest
+
+import (
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// TradesFileName is the file name of the back-test trades in the report directory
+const TradesFileName = "trades.json"
+
+// OrdersFileName is the file name of the back-test submitted orders in the report directory
+const OrdersFileName = "orders.json"
+
+// DefaultDriftMatchWindow is the default max time difference of the aligned live and back-test orders
+const DefaultDriftMatchWindow = 5 * time.Minute
+
+// DriftOrder is a submitted order of the live or the back-test run, the fill fields are merged from the trades of the
+// order. The trades of an order that is not stored are merged into an order submitted at the time of the first fill.
+type DriftOrder struct {
+	OrderID uint64            `json:"orderID"`
+	Symbol  string            `json:"symbol"`
+	Side    types.SideType    `json:"side"`
+	Type    types.OrderType   `json:"type,omitempty"`
+	Status  types.OrderStatus `json:"status,omitempty"`
+
+	// SubmitTime is the creation time of the order
+	SubmitTime     time.Time        `json:"submitTime"`
+	SubmitPrice    fixedpoint.Value `json:"submitPrice"`
+	SubmitQuantity fixedpoint.Value `json:"submitQuantity"`
+
+	// Time is the time of the first fill, it's zero if the order is not filled
+	Time time.Time `json:"time"`
+
+	// Price is the average fill price
+	Price fixedpoint.Value `json:"price"`
+
+	// Quantity is the filled quantity
+	Quantity fixedpoint.Value `json:"quantity"`
+
+	quoteQuantity fixedpoint.Value
+
+	// submitted is false if the order is merged from the trades only
+	submitted bool
+}
+
+func (o DriftOrder) Filled() bool {
+	return o.Quantity.Sign() > 0
+}
+
+// DriftMatch is a back-test order aligned with a live order of the same symbol and side
+type DriftMatch struct {
+	Symbol   string         `json:"symbol"`
+	Side     types.SideType `json:"side"`
+	Live     DriftOrder     `json:"live"`
+	Backtest DriftOrder     `json:"backtest"`
+
+	// Delay is the live submit time minus the back-test submit time
+	Delay time.Duration `json:"delay"`
+
+	// Slippage is the ratio of the live price worse than the back-test price, negative if the live price is better,
+	// it's zero unless both orders are filled
+	Slippage fixedpoint.Value `json:"slippage"`
+
+	// SlippageCost is the slippage in the quote currency of the live quantity
+	SlippageCost fixedpoint.Value `json:"slippageCost"`
+}
+
+// DriftDay is the drift of the orders and the profits of a day in UTC
+type DriftDay struct {
+	Date string `json:"date"`
+
+	// LiveOrders and BacktestOrders are the numbers of the submitted orders
+	LiveOrders     int `json:"liveOrders"`
+	BacktestOrders int `json:"backtestOrders"`
+	MatchedOrders  int `json:"matchedOrders"`
+
+	// BacktestUnfilled is the number of the back-test orders that are never filled
+	BacktestUnfilled int `json:"backtestUnfilled"`
+
+	// MissedFills is the number of the back-test filled orders that are not aligned with a live filled order
+	MissedFills int `json:"missedFills"`
+
+	// ExtraFills is the number of the live filled orders that are not aligned with a back-test filled order
+	ExtraFills int `json:"extraFills"`
+
+	AverageSlippage fixedpoint.Value `json:"averageSlippage"`
+	SlippageCost    fixedpoint.Value `json:"slippageCost"`
+
+	LiveProfit     fixedpoint.Value `json:"liveProfit"`
+	BacktestProfit fixedpoint.Value `json:"backtestProfit"`
+
+	// ProfitDivergence is the live net profit minus the back-test net profit
+	ProfitDivergence fixedpoint.Value `json:"profitDivergence"`
+
+	// CumulativeDivergence is the sum of the profit divergences until the day
+	CumulativeDivergence fixedpoint.Value `json:"cumulativeDivergence"`
+
+	slippageSum   fixedpoint.Value
+	slippageCount int
+}
+
+// DriftReport compares the submitted orders, the fills and the profits of a live strategy instance with the back-test
+// of the same config and period.
+//
+// The back-test orders are all the submitted orders of the back-test. The live orders are the stored orders of the
+// live trades, and the orders are stored only if they are filled, so the live orders that are never filled are not
+// compared, a back-test order that is not aligned can be either an order that is not placed or an order that is not
+// filled in the live run.
+type DriftReport struct {
+	StrategyInstanceID string        `json:"strategyInstanceID"`
+	StartTime          time.Time     `json:"startTime"`
+	EndTime            time.Time     `json:"endTime"`
+	MatchWindow        time.Duration `json:"matchWindow"`
+
+	LiveOrders     int `json:"liveOrders"`
+	BacktestOrders int `json:"backtestOrders"`
+	LiveTrades     int `json:"liveTrades"`
+	BacktestTrades int `json:"backtestTrades"`
+
+	LiveProfit       fixedpoint.Value `json:"liveProfit"`
+	BacktestProfit   fixedpoint.Value `json:"backtestProfit"`
+	ProfitDivergence fixedpoint.Value `json:"profitDivergence"`
+	AverageSlippage  fixedpoint.Value `json:"averageSlippage"`
+	SlippageCost     fixedpoint.Value `json:"slippageCost"`
+
+	Days    []DriftDay   `json:"days"`
+	Matches []DriftMatch `json:"matches,omitempty"`
+
+	// Missed are the back-test filled orders that are not aligned with a live filled order
+	Missed []DriftOrder `json:"missed,omitempty"`
+
+	// Extra are the live filled orders that are not aligned with a back-test filled order
+	Extra []DriftOrder `json:"extra,omitempty"`
+}
+
+// NewDriftReport aligns the live orders and the back-test orders of the same symbol and side, the back-test order is
+// matched with the live order of the nearest submit time within the match window. The orders are merged with the fills
+// of the trades, and the slippage is measured on the aligned orders that are both filled. The net profits are realized
+// by the average cost positions of each run, and are bucketed by the trade day in UTC.
+func NewDriftReport(
+	instanceID string, liveOrders []types.Order, liveTrades []types.Trade,
+	backtestOrders []types.Order, backtestTrades []types.Trade, markets types.MarketMap,
+	startTime, endTime time.Time, matchWindow time.Duration,
+) *DriftReport {
+	if matchWindow <= 0 {
+		matchWindow = DefaultDriftMatchWindow
+	}
+
+	report := &DriftReport{
+		StrategyInstanceID: instanceID,
+		StartTime:          startTime,
+		EndTime:            endTime,
+		MatchWindow:        matchWindow,
+		LiveTrades:         len(liveTrades),
+		BacktestTrades:     len(backtestTrades),
+	}
+
+	var days = make(map[string]*DriftDay)
+	for d := startTime.UTC().Truncate(24 * time.Hour); d.Before(endTime); d = d.Add(24 * time.Hour) {
+		date := d.Format(types.DateFormat)
+		days[date] = &DriftDay{Date: date}
+	}
+
+	dayOf := func(t time.Time) *DriftDay {
+		date := t.UTC().Format(types.DateFormat)
+		day, ok := days[date]
+		if !ok {
+			day = &DriftDay{Date: date}
+			days[date] = day
+		}
+		return day
+	}
+
+	for t, profit := range driftProfits(liveTrades, markets) {
+		dayOf(t).LiveProfit = dayOf(t).LiveProfit.Add(profit)
+	}
+
+	for t, profit := range driftProfits(backtestTrades, markets) {
+		dayOf(t).BacktestProfit = dayOf(t).BacktestProfit.Add(profit)
+	}
+
+	liveDriftOrders := mergeDriftOrders(liveOrders, liveTrades)
+	backtestDriftOrders := mergeDriftOrders(backtestOrders, backtestTrades)
+	report.LiveOrders = len(liveDriftOrders)
+	report.BacktestOrders = len(backtestDriftOrders)
+
+	for _, o := range liveDriftOrders {
+		dayOf(o.SubmitTime).LiveOrders++
+	}
+
+	matched := make([]bool, len(liveDriftOrders))
+	for _, bo := range backtestDriftOrders {
+		day := dayOf(bo.SubmitTime)
+		day.BacktestOrders++
+		if !bo.Filled() {
+			day.BacktestUnfilled++
+		}
+
+		// the live orders are sorted by the submit time, search from the start of the window
+		start := sort.Search(len(liveDriftOrders), func(i int) bool {
+			return !liveDriftOrders[i].SubmitTime.Before(bo.SubmitTime.Add(-matchWindow))
+		})
+
+		best := -1
+		for i := start; i < len(liveDriftOrders) && !liveDriftOrders[i].SubmitTime.After(bo.SubmitTime.Add(matchWindow)); i++ {
+			lo := liveDriftOrders[i]
+			if matched[i] || lo.Symbol != bo.Symbol || lo.Side != bo.Side {
+				continue
+			}
+
+			if best < 0 || absDuration(lo.SubmitTime.Sub(bo.SubmitTime)) < absDuration(liveDriftOrders[best].SubmitTime.Sub(bo.SubmitTime)) {
+				best = i
+			}
+		}
+
+		if best < 0 {
+			if bo.Filled() {
+				day.MissedFills++
+				report.Missed = append(report.Missed, bo)
+			}
+			continue
+		}
+
+		matched[best] = true
+		lo := liveDriftOrders[best]
+		match := newDriftMatch(lo, bo)
+		report.Matches = append(report.Matches, match)
+		day.MatchedOrders++
+
+		switch {
+		case lo.Filled() && bo.Filled():
+			day.slippageSum = day.slippageSum.Add(match.Slippage)
+			day.slippageCount++
+			day.SlippageCost = day.SlippageCost.Add(match.SlippageCost)
+
+		case bo.Filled():
+			day.MissedFills++
+			report.Missed = append(report.Missed, bo)
+
+		case lo.Filled():
+			dayOf(lo.SubmitTime).ExtraFills++
+			report.Extra = append(report.Extra, lo)
+		}
+	}
+
+	for i, lo := range liveDriftOrders {
+		if !matched[i] && lo.Filled() {
+			dayOf(lo.SubmitTime).ExtraFills++
+			report.Extra = append(report.Extra, lo)
+		}
+	}
+
+	for _, day := range days {
+		report.Days = append(report.Days, *day)
+	}
+
+	sort.Slice(report.Days, func(i, j int) bool {
+		return report.Days[i].Date < report.Days[j].Date
+	})
+
+	var slippageSum fixedpoint.Value
+	var slippageCount int
+	for i := range report.Days {
+		day := &report.Days[i]
+		if day.slippageCount > 0 {
+			day.AverageSlippage = day.slippageSum.Div(fixedpoint.NewFromInt(int64(day.slippageCount)))
+		}
+
+		day.ProfitDivergence = day.LiveProfit.Sub(day.BacktestProfit)
+		report.LiveProfit = report.LiveProfit.Add(day.LiveProfit)
+		report.BacktestProfit = report.BacktestProfit.Add(day.BacktestProfit)
+		report.ProfitDivergence = report.ProfitDivergence.Add(day.ProfitDivergence)
+		report.SlippageCost = report.SlippageCost.Add(day.SlippageCost)
+		day.CumulativeDivergence = report.ProfitDivergence
+		slippageSum = slippageSum.Add(day.slippageSum)
+		slippageCount += day.slippageCount
+	}
+
+	if slippageCount > 0 {
+		report.AverageSlippage = slippageSum.Div(fixedpoint.NewFromInt(int64(slippageCount)))
+	}
+
+	return report
+}
+
+func (r *DriftReport) Print() {
+	color.Green("DRIFT REPORT %s (%s ~ %s)", r.StrategyInstanceID,
+		r.StartTime.Format(time.RFC3339), r.EndTime.Format(time.RFC3339))
+	color.Green("===============================================")
+	color.Green("TRADES: %d LIVE, %d BACK-TEST", r.LiveTrades, r.BacktestTrades)
+	color.Green("ORDERS: %d LIVE, %d BACK-TEST, %d MATCHED, %d MISSED FILLS, %d EXTRA FILLS (WINDOW %s)",
+		r.LiveOrders, r.BacktestOrders, len(r.Matches), len(r.Missed), len(r.Extra), r.MatchWindow)
+	color.Green("SLIPPAGE: %s AVERAGE, %s COST", r.AverageSlippage.FormatPercentage(4), r.SlippageCost.FormatString(2))
+
+	printProfit := color.Green
+	if r.ProfitDivergence.Sign() < 0 {
+		printProfit = color.Red
+	}
+	printProfit("NET PROFIT: %s LIVE, %s BACK-TEST, %s DIVERGENCE",
+		r.LiveProfit.FormatString(2), r.BacktestProfit.FormatString(2), r.ProfitDivergence.FormatString(2))
+
+	for _, day := range r.Days {
+		printDay := color.Green
+		if day.ProfitDivergence.Sign() < 0 {
+			printDay = color.Red
+		}
+
+		printDay("  %s ORDERS %d/%d MATCHED %d UNFILLED %d MISSED %d EXTRA %d SLIPPAGE %s PROFIT %s/%s DIVERGENCE %s (CUMULATIVE %s)",
+			day.Date, day.LiveOrders, day.BacktestOrders, day.MatchedOrders, day.BacktestUnfilled, day.MissedFills,
+			day.ExtraFills, day.AverageSlippage.FormatPercentage(4), day.LiveProfit.FormatString(2),
+			day.BacktestProfit.FormatString(2), day.ProfitDivergence.FormatString(2),
+			day.CumulativeDivergence.FormatString(2))
+	}
+}
+
+func newDriftMatch(live, backtest DriftOrder) DriftMatch {
+	match := DriftMatch{
+		Symbol:   live.Symbol,
+		Side:     live.Side,
+		Live:     live,
+		Backtest: backtest,
+		Delay:    live.SubmitTime.Sub(backtest.SubmitTime),
+	}
+
+	if !live.Filled() || !backtest.Filled() || backtest.Price.IsZero() {
+		return match
+	}
+
+	// paying more for a buy order, or getting less for a sell order is the positive slippage
+	diff := live.Price.Sub(backtest.Price)
+	if live.Side == types.SideTypeSell {
+		diff = diff.Neg()
+	}
+
+	match.Slippage = diff.Div(backtest.Price)
+	match.SlippageCost = diff.Mul(live.Quantity)
+	return match
+}
+
+// mergeDriftOrders merges the trades into the submitted orders, the trades of an order that is not given are merged
+// into an order submitted at the time of the first fill. The orders are sorted by the submit time.
+func mergeDriftOrders(submittedOrders []types.Order, trades []types.Trade) []DriftOrder {
+	type orderKey struct {
+		symbol  string
+		orderID uint64
+	}
+
+	var orders []*DriftOrder
+	var index = make(map[orderKey]*DriftOrder)
+	for _, o := range submittedOrders {
+		key := orderKey{symbol: o.Symbol, orderID: o.OrderID}
+		if _, ok := index[key]; ok {
+			continue
+		}
+
+		order := &DriftOrder{
+			OrderID:        o.OrderID,
+			Symbol:         o.Symbol,
+			Side:           o.Side,
+			Type:           o.Type,
+			Status:         o.Status,
+			SubmitTime:     o.CreationTime.Time(),
+			SubmitPrice:    o.Price,
+			SubmitQuantity: o.Quantity,
+			submitted:      true,
+		}
+		index[key] = order
+		orders = append(orders, order)
+	}
+
+	for _, trade := range trades {
+		key := orderKey{symbol: trade.Symbol, orderID: trade.OrderID}
+		order, ok := index[key]
+		if !ok || trade.OrderID == 0 {
+			order = &DriftOrder{
+				OrderID:    trade.OrderID,
+				Symbol:     trade.Symbol,
+				Side:       trade.Side,
+				SubmitTime: trade.Time.Time(),
+			}
+			index[key] = order
+			orders = append(orders, order)
+		}
+
+		if order.Time.IsZero() || trade.Time.Time().Before(order.Time) {
+			order.Time = trade.Time.Time()
+			if !order.submitted {
+				order.SubmitTime = order.Time
+			}
+		}
+
+		order.Quantity = order.Quantity.Add(trade.Quantity)
+		order.quoteQuantity = order.quoteQuantity.Add(trade.Price.Mul(trade.Quantity))
+	}
+
+	var merged = make([]DriftOrder, len(orders))
+	for i, order := range orders {
+		if !order.Quantity.IsZero() {
+			order.Price = order.quoteQuantity.Div(order.Quantity)
+		}
+		merged[i] = *order
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].SubmitTime.Before(merged[j].SubmitTime)
+	})
+	return merged
+}
+
+// driftProfits replays the trades by the average cost positions, and returns the net profits by the trade time
+func driftProfits(trades []types.Trade, markets types.MarketMap) map[time.Time]fixedpoint.Value {
+	sorted := make([]types.Trade, len(trades))
+	copy(sorted, trades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Time().Before(sorted[j].Time.Time())
+	})
+
+	type positionKey struct {
+		exchange types.ExchangeName
+		symbol   string
+	}
+
+	positions := make(map[positionKey]*types.Position)
+	profits := make(map[time.Time]fixedpoint.Value)
+	for _, trade := range sorted {
+		key := positionKey{exchange: trade.Exchange, symbol: trade.Symbol}
+		position, ok := positions[key]
+		if !ok {
+			if market, ok := markets[trade.Symbol]; ok {
+				position = types.NewPositionFromMarket(market)
+			} else {
+				position = types.NewPosition(trade.Symbol, "", "")
+			}
+			positions[key] = position
+		}
+
+		if _, netProfit, madeProfit := position.AddTrade(trade); madeProfit {
+			t := trade.Time.Time()
+			profits[t] = profits[t].Add(netProfit)
+		}
+	}
+
+	return profits
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
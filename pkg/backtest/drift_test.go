@@ -0,0 +1,173 @@
+package backtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+func driftTrade(id, orderID uint64, side types.SideType, price, quantity float64, t time.Time) types.Trade {
+	return types.Trade{
+		ID:            id,
+		OrderID:       orderID,
+		Exchange:      types.ExchangeBinance,
+		Symbol:        "BTCUSDT",
+		Side:          side,
+		IsBuyer:       side == types.SideTypeBuy,
+		Price:         fixedpoint.NewFromFloat(price),
+		Quantity:      fixedpoint.NewFromFloat(quantity),
+		QuoteQuantity: fixedpoint.NewFromFloat(price * quantity),
+		FeeCurrency:   "USDT",
+		Time:          types.Time(t),
+	}
+}
+
+func driftOrder(orderID uint64, side types.SideType, price float64, status types.OrderStatus, t time.Time) types.Order {
+	return types.Order{
+		SubmitOrder: types.SubmitOrder{
+			Symbol:   "BTCUSDT",
+			Side:     side,
+			Type:     types.OrderTypeLimit,
+			Price:    fixedpoint.NewFromFloat(price),
+			Quantity: fixedpoint.One,
+		},
+		Exchange:     types.ExchangeBinance,
+		OrderID:      orderID,
+		Status:       status,
+		CreationTime: types.Time(t),
+	}
+}
+
+func TestNewDriftReport(t *testing.T) {
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(2 * 24 * time.Hour)
+	markets := types.MarketMap{
+		"BTCUSDT": {Symbol: "BTCUSDT", BaseCurrency: "BTC", QuoteCurrency: "USDT"},
+	}
+
+	backtestTrades := []types.Trade{
+		driftTrade(1, 1, types.SideTypeBuy, 20000, 1, startTime.Add(time.Hour)),
+		driftTrade(2, 2, types.SideTypeSell, 21000, 1, startTime.Add(2*time.Hour)),
+
+		// missed in the live run
+		driftTrade(3, 3, types.SideTypeBuy, 20000, 1, startTime.Add(25*time.Hour)),
+		driftTrade(4, 4, types.SideTypeSell, 20500, 1, startTime.Add(26*time.Hour)),
+	}
+
+	liveTrades := []types.Trade{
+		// the buy order is filled by two trades one minute later with a higher price
+		driftTrade(11, 101, types.SideTypeBuy, 20010, 0.5, startTime.Add(time.Hour+time.Minute)),
+		driftTrade(12, 101, types.SideTypeBuy, 20030, 0.5, startTime.Add(time.Hour+2*time.Minute)),
+		driftTrade(13, 102, types.SideTypeSell, 20958, 1, startTime.Add(2*time.Hour-time.Minute)),
+
+		// not in the back-test, the sell order out of the window is extra as well
+		driftTrade(14, 103, types.SideTypeBuy, 20000, 1, startTime.Add(30*time.Hour)),
+		driftTrade(15, 104, types.SideTypeSell, 20100, 1, startTime.Add(27*time.Hour)),
+	}
+
+	report := NewDriftReport("grid2:BTCUSDT", nil, liveTrades, nil, backtestTrades, markets, startTime, endTime, 0)
+	require.Len(t, report.Days, 2)
+	require.Len(t, report.Matches, 2)
+	require.Len(t, report.Missed, 2)
+	require.Len(t, report.Extra, 2)
+
+	assert.Equal(t, DefaultDriftMatchWindow, report.MatchWindow)
+	assert.Equal(t, 5, report.LiveTrades)
+	assert.Equal(t, 4, report.BacktestTrades)
+
+	buy := report.Matches[0]
+	assert.Equal(t, types.SideTypeBuy, buy.Side)
+	assert.Equal(t, "20020", buy.Live.Price.String())
+	assert.Equal(t, "1", buy.Live.Quantity.String())
+	assert.Equal(t, time.Minute, buy.Delay)
+	assert.Equal(t, "0.001", buy.Slippage.String())
+	assert.Equal(t, "20", buy.SlippageCost.String())
+
+	sell := report.Matches[1]
+	assert.Equal(t, -time.Minute, sell.Delay)
+	assert.Equal(t, "0.002", sell.Slippage.String())
+	assert.Equal(t, "42", sell.SlippageCost.String())
+
+	day1 := report.Days[0]
+	assert.Equal(t, "2024-01-01", day1.Date)
+	assert.Equal(t, 2, day1.LiveOrders)
+	assert.Equal(t, 2, day1.BacktestOrders)
+	assert.Equal(t, 2, day1.MatchedOrders)
+	assert.Equal(t, "0.0015", day1.AverageSlippage.String())
+	assert.Equal(t, "62", day1.SlippageCost.String())
+	assert.Equal(t, "1000", day1.BacktestProfit.String())
+	assert.Equal(t, "938", day1.LiveProfit.String())
+	assert.Equal(t, "-62", day1.ProfitDivergence.String())
+
+	day2 := report.Days[1]
+	assert.Equal(t, 2, day2.MissedFills)
+	assert.Equal(t, 2, day2.ExtraFills)
+	assert.Equal(t, "500", day2.BacktestProfit.String())
+	assert.Equal(t, "100", day2.LiveProfit.String())
+	assert.Equal(t, "-462", day2.CumulativeDivergence.String())
+
+	assert.Equal(t, "-462", report.ProfitDivergence.String())
+	assert.Equal(t, "0.0015", report.AverageSlippage.String())
+}
+
+func TestNewDriftReport_SubmittedOrders(t *testing.T) {
+	startTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(24 * time.Hour)
+
+	backtestOrders := []types.Order{
+		driftOrder(1, types.SideTypeBuy, 20000, types.OrderStatusFilled, startTime.Add(time.Hour)),
+
+		// the orders are never filled in the back-test
+		driftOrder(2, types.SideTypeSell, 21000, types.OrderStatusCanceled, startTime.Add(2*time.Hour)),
+		driftOrder(3, types.SideTypeBuy, 19000, types.OrderStatusNew, startTime.Add(3*time.Hour)),
+	}
+	backtestTrades := []types.Trade{
+		driftTrade(1, 1, types.SideTypeBuy, 20000, 1, startTime.Add(time.Hour+30*time.Minute)),
+	}
+
+	liveOrders := []types.Order{
+		driftOrder(101, types.SideTypeBuy, 20000, types.OrderStatusFilled, startTime.Add(time.Hour+time.Minute)),
+		driftOrder(102, types.SideTypeSell, 21000, types.OrderStatusFilled, startTime.Add(2*time.Hour+time.Minute)),
+	}
+	liveTrades := []types.Trade{
+		// the buy order is filled later than the back-test
+		driftTrade(11, 101, types.SideTypeBuy, 20000, 1, startTime.Add(time.Hour+40*time.Minute)),
+
+		// the sell order is filled in the live run only
+		driftTrade(12, 102, types.SideTypeSell, 21000, 1, startTime.Add(2*time.Hour+10*time.Minute)),
+	}
+
+	report := NewDriftReport("grid2:BTCUSDT", liveOrders, liveTrades, backtestOrders, backtestTrades, nil, startTime, endTime, 0)
+	assert.Equal(t, 2, report.LiveOrders)
+	assert.Equal(t, 3, report.BacktestOrders)
+	require.Len(t, report.Matches, 2)
+	require.Len(t, report.Extra, 1)
+	assert.Empty(t, report.Missed)
+
+	// the orders are aligned by the submit time
+	buy := report.Matches[0]
+	assert.Equal(t, time.Minute, buy.Delay)
+	assert.Equal(t, startTime.Add(time.Hour+40*time.Minute), buy.Live.Time)
+	assert.Equal(t, "20000", buy.Backtest.SubmitPrice.String())
+	assert.True(t, buy.Slippage.IsZero())
+
+	sell := report.Matches[1]
+	assert.Equal(t, types.OrderStatusCanceled, sell.Backtest.Status)
+	assert.False(t, sell.Backtest.Filled())
+	assert.True(t, sell.Live.Filled())
+	assert.Equal(t, uint64(102), report.Extra[0].OrderID)
+
+	require.Len(t, report.Days, 1)
+	day := report.Days[0]
+	assert.Equal(t, 2, day.LiveOrders)
+	assert.Equal(t, 3, day.BacktestOrders)
+	assert.Equal(t, 2, day.MatchedOrders)
+	assert.Equal(t, 2, day.BacktestUnfilled)
+	assert.Equal(t, 0, day.MissedFills)
+	assert.Equal(t, 1, day.ExtraFills)
+}
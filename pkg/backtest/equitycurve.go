@@ -0,0 +1,231 @@
+package backtest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"time"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// EquityCurveFileName is the file name of the equity curve in the report directory
+const EquityCurveFileName = "equity_curve.json"
+
+const yearDuration = 365 * 24 * time.Hour
+
+// maxAnnualizedReturn is the bound of the annualized return that can be represented by fixedpoint.Value
+const maxAnnualizedReturn = 1e9
+
+// EquityPoint is the equity of all the sessions marked to market at the kline close
+type EquityPoint struct {
+	Time   time.Time        `json:"time"`
+	Equity fixedpoint.Value `json:"equity"`
+
+	// Drawdown is the ratio of the equity below the previous peak
+	Drawdown fixedpoint.Value `json:"drawdown"`
+
+	// Exposed is true if any position is open at the time
+	Exposed bool `json:"exposed"`
+}
+
+// EquityCurve is the equity curve sampled by the interval, the equity is in USD
+type EquityCurve struct {
+	Interval types.Interval `json:"interval"`
+	Points   []EquityPoint  `json:"points"`
+}
+
+func ReadEquityCurve(filename string) (*EquityCurve, error) {
+	o, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var curve EquityCurve
+	err = json.Unmarshal(o, &curve)
+	return &curve, err
+}
+
+// EquityCurveStats is the statistics of the equity curve, it's calculated from the equity of every kline close
+// instead of the sampled curve
+type EquityCurveStats struct {
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+
+	InitialEquity fixedpoint.Value `json:"initialEquity"`
+	FinalEquity   fixedpoint.Value `json:"finalEquity"`
+	PeakEquity    fixedpoint.Value `json:"peakEquity"`
+	AverageEquity fixedpoint.Value `json:"averageEquity"`
+
+	// MaxDrawdown is the max ratio of the equity below the previous peak
+	MaxDrawdown fixedpoint.Value `json:"maxDrawdown"`
+
+	// MaxDrawdownPeakTime and MaxDrawdownTroughTime are the peak time and the trough time of the max drawdown
+	MaxDrawdownPeakTime   time.Time `json:"maxDrawdownPeakTime"`
+	MaxDrawdownTroughTime time.Time `json:"maxDrawdownTroughTime"`
+
+	// MaxDrawdownDuration is the longest duration from a peak to the recovery of the peak,
+	// the drawdown that is not recovered lasts until the end time
+	MaxDrawdownDuration types.Duration `json:"maxDrawdownDuration"`
+
+	AnnualizedReturn fixedpoint.Value `json:"annualizedReturn"`
+
+	// CalmarRatio is the annualized return divided by the max drawdown
+	CalmarRatio fixedpoint.Value `json:"calmarRatio"`
+
+	// Exposure is the ratio of the time that any position is open
+	Exposure fixedpoint.Value `json:"exposure"`
+}
+
+// EquityCurveRecorder records the equity at every kline close, the equity curve is sampled by the interval
+// while the statistics are calculated from every recorded equity.
+type EquityCurveRecorder struct {
+	curve EquityCurve
+
+	sampleInterval time.Duration
+	lastSampled    time.Time
+
+	// current is the equity point of the latest kline close time, it's committed when the time moves forward,
+	// so that the klines of the other symbols or the other sessions closed at the same time are included.
+	current *EquityPoint
+	last    *EquityPoint
+
+	numOfPoints int
+	sumOfEquity float64
+
+	stats EquityCurveStats
+
+	peakTime        time.Time
+	drawdownStart   time.Time
+	exposedDuration time.Duration
+}
+
+func NewEquityCurveRecorder(sampleInterval types.Interval) *EquityCurveRecorder {
+	return &EquityCurveRecorder{
+		curve: EquityCurve{
+			Interval: sampleInterval,
+		},
+		sampleInterval: sampleInterval.Duration(),
+	}
+}
+
+// Record records the equity of the kline close time, the equity of the same time is overwritten
+func (r *EquityCurveRecorder) Record(t time.Time, equity fixedpoint.Value, exposed bool) {
+	if r.current != nil && !r.current.Time.Equal(t) {
+		r.commit(*r.current)
+	}
+
+	r.current = &EquityPoint{
+		Time:    t,
+		Equity:  equity,
+		Exposed: exposed,
+	}
+}
+
+// Finish commits the last equity point and includes it in the equity curve
+func (r *EquityCurveRecorder) Finish() {
+	if r.current != nil {
+		r.commit(*r.current)
+		r.current = nil
+	}
+
+	if r.last == nil {
+		return
+	}
+
+	if n := len(r.curve.Points); n == 0 || !r.curve.Points[n-1].Time.Equal(r.last.Time) {
+		r.curve.Points = append(r.curve.Points, *r.last)
+	}
+
+	if !r.drawdownStart.IsZero() {
+		r.updateDrawdownDuration(r.last.Time.Sub(r.drawdownStart))
+	}
+}
+
+func (r *EquityCurveRecorder) Curve() *EquityCurve {
+	return &r.curve
+}
+
+// Stats returns the statistics of the recorded equity, it should be called after Finish
+func (r *EquityCurveRecorder) Stats() EquityCurveStats {
+	stats := r.stats
+	if r.numOfPoints == 0 {
+		return stats
+	}
+
+	stats.AverageEquity = fixedpoint.NewFromFloat(r.sumOfEquity / float64(r.numOfPoints))
+
+	period := stats.EndTime.Sub(stats.StartTime)
+	if period <= 0 {
+		return stats
+	}
+
+	stats.Exposure = fixedpoint.NewFromFloat(float64(r.exposedDuration) / float64(period))
+
+	if stats.InitialEquity.Sign() > 0 && stats.FinalEquity.Sign() > 0 {
+		totalReturn := stats.FinalEquity.Div(stats.InitialEquity).Float64()
+		annualizedReturn := math.Pow(totalReturn, float64(yearDuration)/float64(period)) - 1.0
+
+		// the return of a short period could overflow after being annualized
+		if math.Abs(annualizedReturn) < maxAnnualizedReturn {
+			stats.AnnualizedReturn = fixedpoint.NewFromFloat(annualizedReturn)
+		}
+	}
+
+	if stats.MaxDrawdown.Sign() > 0 {
+		stats.CalmarRatio = stats.AnnualizedReturn.Div(stats.MaxDrawdown)
+	}
+
+	return stats
+}
+
+func (r *EquityCurveRecorder) commit(p EquityPoint) {
+	if r.last == nil {
+		r.stats.StartTime = p.Time
+		r.stats.InitialEquity = p.Equity
+		r.stats.PeakEquity = p.Equity
+		r.peakTime = p.Time
+	} else if r.last.Exposed {
+		r.exposedDuration += p.Time.Sub(r.last.Time)
+	}
+
+	if p.Equity.Compare(r.stats.PeakEquity) >= 0 {
+		if !r.drawdownStart.IsZero() {
+			r.updateDrawdownDuration(p.Time.Sub(r.drawdownStart))
+			r.drawdownStart = time.Time{}
+		}
+
+		r.stats.PeakEquity = p.Equity
+		r.peakTime = p.Time
+	} else if r.stats.PeakEquity.Sign() > 0 {
+		if r.drawdownStart.IsZero() {
+			r.drawdownStart = r.peakTime
+		}
+
+		p.Drawdown = r.stats.PeakEquity.Sub(p.Equity).Div(r.stats.PeakEquity)
+		if p.Drawdown.Compare(r.stats.MaxDrawdown) > 0 {
+			r.stats.MaxDrawdown = p.Drawdown
+			r.stats.MaxDrawdownPeakTime = r.peakTime
+			r.stats.MaxDrawdownTroughTime = p.Time
+		}
+	}
+
+	r.stats.EndTime = p.Time
+	r.stats.FinalEquity = p.Equity
+	r.numOfPoints++
+	r.sumOfEquity += p.Equity.Float64()
+
+	if len(r.curve.Points) == 0 || p.Time.Sub(r.lastSampled) >= r.sampleInterval {
+		r.curve.Points = append(r.curve.Points, p)
+		r.lastSampled = p.Time
+	}
+
+	r.last = &p
+}
+
+func (r *EquityCurveRecorder) updateDrawdownDuration(duration time.Duration) {
+	if duration > r.stats.MaxDrawdownDuration.Duration() {
+		r.stats.MaxDrawdownDuration = types.Duration(duration)
+	}
+}
@@ -0,0 +1,112 @@
+package backtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+func TestEquityCurveRecorder(t *testing.T) {
+	startTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	recorder := NewEquityCurveRecorder(types.Interval1d)
+
+	record := func(hours int, equity float64, exposed bool) {
+		recorder.Record(startTime.Add(time.Duration(hours)*time.Hour), fixedpoint.NewFromFloat(equity), exposed)
+	}
+
+	record(0, 1000.0, false)
+	record(12, 1100.0, true)
+
+	// the equity of the same time is overwritten by the kline of the other symbol
+	record(24, 900.0, true)
+	record(24, 880.0, true)
+
+	record(36, 1000.0, false)
+	record(48, 1150.0, false)
+	record(60, 1100.0, false)
+	recorder.Finish()
+
+	stats := recorder.Stats()
+	assert.Equal(t, "1000", stats.InitialEquity.String())
+	assert.Equal(t, "1100", stats.FinalEquity.String())
+	assert.Equal(t, "1150", stats.PeakEquity.String())
+	assert.Equal(t, "0.2", stats.MaxDrawdown.String(), "(1100 - 880) / 1100")
+	assert.Equal(t, startTime.Add(12*time.Hour), stats.MaxDrawdownPeakTime)
+	assert.Equal(t, startTime.Add(24*time.Hour), stats.MaxDrawdownTroughTime)
+	assert.Equal(t, 36*time.Hour, stats.MaxDrawdownDuration.Duration(), "from the peak at 12h to the recovery at 48h")
+	assert.Equal(t, "0.4", stats.Exposure.String(), "exposed from 12h to 36h in 60 hours")
+	assert.Equal(t, "1038.33333333", stats.AverageEquity.String())
+	assert.True(t, stats.AnnualizedReturn.Sign() > 0)
+	assert.Equal(t, stats.AnnualizedReturn.Div(stats.MaxDrawdown), stats.CalmarRatio)
+
+	curve := recorder.Curve()
+	if assert.Len(t, curve.Points, 4) {
+		assert.Equal(t, startTime, curve.Points[0].Time)
+		assert.Equal(t, startTime.Add(24*time.Hour), curve.Points[1].Time)
+		assert.Equal(t, "880", curve.Points[1].Equity.String())
+		assert.Equal(t, "0.2", curve.Points[1].Drawdown.String())
+		assert.Equal(t, startTime.Add(48*time.Hour), curve.Points[2].Time)
+		assert.Equal(t, startTime.Add(60*time.Hour), curve.Points[3].Time, "the last point is always included")
+	}
+}
+
+func TestExcursionRecorder(t *testing.T) {
+	market := getTestMarket()
+	position := types.NewPositionFromMarket(market)
+	recorder := NewExcursionRecorder(position)
+	startTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	addTrade := func(minutes int, side types.SideType, price, quantity float64) {
+		trade := types.Trade{
+			Symbol:        market.Symbol,
+			Side:          side,
+			IsBuyer:       side == types.SideTypeBuy,
+			Price:         fixedpoint.NewFromFloat(price),
+			Quantity:      fixedpoint.NewFromFloat(quantity),
+			QuoteQuantity: fixedpoint.NewFromFloat(price * quantity),
+			Time:          types.Time(startTime.Add(time.Duration(minutes) * time.Minute)),
+		}
+		profit, _, _ := position.AddTrade(trade)
+		recorder.HandleTrade(trade, profit)
+	}
+
+	kline := func(high, low float64) types.KLine {
+		return types.KLine{Symbol: market.Symbol, High: fixedpoint.NewFromFloat(high), Low: fixedpoint.NewFromFloat(low)}
+	}
+
+	addTrade(0, types.SideTypeBuy, 20000.0, 0.1)
+	recorder.HandleKLine(kline(20500.0, 19800.0))
+	recorder.HandleKLine(kline(21000.0, 19900.0))
+	addTrade(30, types.SideTypeSell, 20800.0, 0.1)
+
+	// short position that is still open at the end
+	addTrade(60, types.SideTypeSell, 21000.0, 0.2)
+	recorder.HandleKLine(kline(21100.0, 20700.0))
+	recorder.Finish(startTime.Add(120 * time.Minute))
+
+	excursions := recorder.Excursions()
+	if assert.Len(t, excursions, 2) {
+		long := excursions[0]
+		assert.Equal(t, types.SideTypeBuy, long.Side)
+		assert.True(t, long.Closed)
+		assert.Equal(t, "-20", long.MAE.String())
+		assert.Equal(t, "100", long.MFE.String())
+		assert.Equal(t, "80", long.Profit.String())
+
+		short := excursions[1]
+		assert.Equal(t, types.SideTypeSell, short.Side)
+		assert.False(t, short.Closed)
+		assert.Equal(t, "0.2", short.Quantity.String())
+		assert.Equal(t, "-20", short.MAE.String())
+		assert.Equal(t, "60", short.MFE.String())
+	}
+
+	mae, mfe := AverageExcursions(excursions)
+	assert.Equal(t, "-20", mae.String())
+	assert.Equal(t, "80", mfe.String())
+	assert.Equal(t, "0.75", ExposureRatio(excursions, startTime, startTime.Add(120*time.Minute)).String())
+}
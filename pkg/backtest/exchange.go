@@ -41,6 +41,7 @@ import (
 	"github.com/c9s/bbgo/pkg/cache"
 
 	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
 	"github.com/c9s/bbgo/pkg/service"
 	"github.com/c9s/bbgo/pkg/types"
 )
@@ -75,6 +76,15 @@ type Exchange struct {
 	markets types.MarketMap
 
 	Src *ExchangeDataSource
+
+	userDataStream types.StandardStreamEmitter
+
+	// initialBalances are the balances the account started with
+	initialBalances types.BalanceMap
+
+	// parent is the exchange that feeds the market data to the sub-account
+	parent      *Exchange
+	subAccounts []*Exchange
 }
 
 func NewExchange(
@@ -109,12 +119,106 @@ func NewExchange(
 		currentTime:    startTime,
 		closedOrders:   make(map[string][]types.Order),
 		trades:         make(map[string][]types.Trade),
+
+		initialBalances: balances.Copy(),
 	}
 
 	e.resetMatchingBooks()
 	return e, nil
 }
 
+// NewSubAccount creates a sub-account that shares the market data of the exchange, but matches its orders with
+// its own account and matching books. The given balances are moved from the exchange account to the sub-account.
+func (e *Exchange) NewSubAccount(balances types.BalanceMap) (*Exchange, error) {
+	if e.parent != nil {
+		return nil, errors.New("can not create a sub-account of a sub-account")
+	}
+
+	for currency, balance := range balances {
+		b, ok := e.account.Balance(currency)
+		if !ok || b.Available.Compare(balance.Available) < 0 {
+			return nil, fmt.Errorf("insufficient %s balance for the sub-account: want %s, available %s",
+				currency, balance.Available.String(), b.Available.String())
+		}
+	}
+
+	account := &types.Account{
+		MakerFeeRate: e.account.MakerFeeRate,
+		TakerFeeRate: e.account.TakerFeeRate,
+		AccountType:  e.account.AccountType,
+	}
+	account.UpdateBalances(balances)
+
+	for currency, balance := range balances {
+		e.account.AddBalance(currency, balance.Available.Neg())
+		if b, ok := e.initialBalances[currency]; ok {
+			b.Available = b.Available.Sub(balance.Available)
+			e.initialBalances[currency] = b
+		}
+	}
+
+	sub := &Exchange{
+		sourceName:     e.sourceName,
+		publicExchange: e.publicExchange,
+		markets:        e.markets,
+		srv:            e.srv,
+		config:         e.config,
+		account:        account,
+		currentTime:    e.currentTime,
+		closedOrders:   make(map[string][]types.Order),
+		trades:         make(map[string][]types.Trade),
+
+		initialBalances: balances.Copy(),
+		parent:          e,
+	}
+
+	sub.resetMatchingBooks()
+	e.subAccounts = append(e.subAccounts, sub)
+	return sub, nil
+}
+
+// Parent returns the exchange of the sub-account, nil if it's not a sub-account
+func (e *Exchange) Parent() *Exchange {
+	return e.parent
+}
+
+func (e *Exchange) SubAccounts() []*Exchange {
+	return e.subAccounts
+}
+
+// InitialBalances returns the balances the account started with
+func (e *Exchange) InitialBalances() types.BalanceMap {
+	return e.initialBalances.Copy()
+}
+
+// Transfer moves the available balance to the other account, and emits the balance updates to the user data streams
+func (e *Exchange) Transfer(to *Exchange, currency string, amount fixedpoint.Value) error {
+	if amount.Sign() <= 0 {
+		return fmt.Errorf("transfer amount must be positive, %s given", amount.String())
+	}
+
+	balance, ok := e.account.Balance(currency)
+	if !ok || balance.Available.Compare(amount) < 0 {
+		return fmt.Errorf("insufficient %s balance to transfer: want %s, available %s",
+			currency, amount.String(), balance.Available.String())
+	}
+
+	e.account.AddBalance(currency, amount.Neg())
+	to.account.AddBalance(currency, amount)
+
+	for _, ex := range []*Exchange{e, to} {
+		if ex.userDataStream == nil {
+			continue
+		}
+
+		if b, ok := ex.account.Balance(currency); ok {
+			ex.userDataStream.EmitBalanceUpdate(types.BalanceMap{currency: b})
+		}
+	}
+
+	return nil
+}
+
 func (e *Exchange) addTrade(trade types.Trade) {
 	e.tradesMutex.Lock()
 	e.trades[trade.Symbol] = append(e.trades[trade.Symbol], trade)
@@ -329,6 +433,8 @@ func (e *Exchange) matchingBook(symbol string) (*SimplePriceMatching, bool) {
 }
 
 func (e *Exchange) BindUserData(userDataStream types.StandardStreamEmitter) {
+	e.userDataStream = userDataStream
+
 	userDataStream.OnTradeUpdate(func(trade types.Trade) {
 		e.addTrade(trade)
 	})
@@ -357,8 +463,13 @@ func (e *Exchange) SubscribeMarketData(
 		loadedIntervals[it] = struct{}{}
 	}
 
-	// collect subscriptions
-	for _, sub := range e.MarketDataStream.GetSubscriptions() {
+	// collect subscriptions, the sub-accounts are fed by this exchange
+	subscriptions := e.MarketDataStream.GetSubscriptions()
+	for _, subAccount := range e.subAccounts {
+		subscriptions = append(subscriptions, subAccount.MarketDataStream.GetSubscriptions()...)
+	}
+
+	for _, sub := range subscriptions {
 		loadedSymbols[sub.Symbol] = struct{}{}
 
 		switch sub.Channel {
@@ -412,6 +523,11 @@ func (e *Exchange) SubscribeMarketData(
 }
 
 func (e *Exchange) ConsumeKLine(k types.KLine, requiredInterval types.Interval) {
+	// the sub-accounts are processed first, so that the callbacks see the updated sub-accounts
+	for _, subAccount := range e.subAccounts {
+		subAccount.ConsumeKLine(k, requiredInterval)
+	}
+
 	matching, ok := e.matchingBook(k.Symbol)
 	if !ok {
 		log.Errorf("matching book of %s is not initialized", k.Symbol)
@@ -432,6 +548,12 @@ func (e *Exchange) ConsumeKLine(k types.KLine, requiredInterval types.Interval)
 		matching.nextKLine = &k
 		for _, kline := range matching.klineCache {
 			e.MarketDataStream.EmitKLineClosed(kline)
+
+			// the callbacks of the sub-accounts are called by the source of the parent
+			if e.parent != nil {
+				continue
+			}
+
 			for _, h := range e.Src.Callbacks {
 				h(kline, e.Src)
 			}
@@ -443,6 +565,12 @@ func (e *Exchange) ConsumeKLine(k types.KLine, requiredInterval types.Interval)
 }
 
 func (e *Exchange) CloseMarketData() error {
+	for _, subAccount := range e.subAccounts {
+		if err := subAccount.CloseMarketData(); err != nil {
+			return err
+		}
+	}
+
 	if err := e.MarketDataStream.Close(); err != nil {
 		log.WithError(err).Error("stream close error")
 		return err
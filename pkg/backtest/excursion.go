@@ -0,0 +1,170 @@
+package backtest
+
+import (
+	"time"
+
+	"github.com/c9s/bbgo/pkg/core"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// TradeExcursion is the max adverse excursion (MAE) and the max favorable excursion (MFE) of a position
+// round trip, from opening the position to closing it
+type TradeExcursion struct {
+	Symbol string `json:"symbol"`
+
+	// Side is buy for the long position and sell for the short position
+	Side types.SideType `json:"side"`
+
+	OpenTime  time.Time `json:"openTime"`
+	CloseTime time.Time `json:"closeTime"`
+
+	// EntryPrice is the average cost of the position
+	EntryPrice fixedpoint.Value `json:"entryPrice"`
+	ExitPrice  fixedpoint.Value `json:"exitPrice,omitempty"`
+
+	// Quantity is the max base quantity of the position
+	Quantity fixedpoint.Value `json:"quantity"`
+
+	// MAE is the worst unrealized profit of the position, it's zero or negative
+	MAE fixedpoint.Value `json:"mae"`
+
+	// MFE is the best unrealized profit of the position, it's zero or positive
+	MFE fixedpoint.Value `json:"mfe"`
+
+	// Profit is the realized profit of the round trip
+	Profit fixedpoint.Value `json:"profit"`
+
+	// Closed is false if the position is still open at the end of the back-test
+	Closed bool `json:"closed"`
+}
+
+// ExcursionRecorder records the excursions of the position round trips, the unrealized profit is
+// marked by the high and the low prices of the klines
+type ExcursionRecorder struct {
+	position *types.Position
+
+	current    *TradeExcursion
+	excursions []TradeExcursion
+}
+
+func NewExcursionRecorder(position *types.Position) *ExcursionRecorder {
+	return &ExcursionRecorder{
+		position: position,
+	}
+}
+
+// BindTradeCollector updates the excursions by the trades processed by the trade collector of the position
+func (r *ExcursionRecorder) BindTradeCollector(collector *core.TradeCollector) {
+	collector.OnTrade(func(trade types.Trade, profit fixedpoint.Value, netProfit fixedpoint.Value) {
+		r.HandleTrade(trade, profit)
+	})
+}
+
+// HandleTrade should be called after the trade is added to the position
+func (r *ExcursionRecorder) HandleTrade(trade types.Trade, profit fixedpoint.Value) {
+	base := r.position.GetBase()
+	closed := base.IsZero() || r.position.IsDust(trade.Price)
+
+	if r.current != nil {
+		r.current.Profit = r.current.Profit.Add(profit)
+
+		if closed || base.Sign() != sideSign(r.current.Side) {
+			r.current.CloseTime = trade.Time.Time()
+			r.current.ExitPrice = trade.Price
+			r.current.Closed = true
+			r.excursions = append(r.excursions, *r.current)
+			r.current = nil
+		}
+	}
+
+	if closed {
+		return
+	}
+
+	if r.current == nil {
+		side := types.SideTypeBuy
+		if base.Sign() < 0 {
+			side = types.SideTypeSell
+		}
+
+		r.current = &TradeExcursion{
+			Symbol:   trade.Symbol,
+			Side:     side,
+			OpenTime: trade.Time.Time(),
+		}
+	}
+
+	r.current.EntryPrice = r.position.AverageCost
+	r.current.Quantity = fixedpoint.Max(r.current.Quantity, base.Abs())
+	r.mark(trade.Price)
+}
+
+// HandleKLine marks the unrealized profit of the open position by the high and the low prices
+func (r *ExcursionRecorder) HandleKLine(k types.KLine) {
+	if r.current == nil || k.Symbol != r.current.Symbol {
+		return
+	}
+
+	r.mark(k.High)
+	r.mark(k.Low)
+}
+
+// Finish records the position that is still open at the end time
+func (r *ExcursionRecorder) Finish(endTime time.Time) {
+	if r.current == nil {
+		return
+	}
+
+	r.current.CloseTime = endTime
+	r.excursions = append(r.excursions, *r.current)
+	r.current = nil
+}
+
+func (r *ExcursionRecorder) Excursions() []TradeExcursion {
+	return r.excursions
+}
+
+func (r *ExcursionRecorder) mark(price fixedpoint.Value) {
+	pnl := price.Sub(r.current.EntryPrice).Mul(r.position.GetBase())
+	r.current.MAE = fixedpoint.Min(r.current.MAE, pnl)
+	r.current.MFE = fixedpoint.Max(r.current.MFE, pnl)
+}
+
+func sideSign(side types.SideType) int {
+	if side == types.SideTypeSell {
+		return -1
+	}
+
+	return 1
+}
+
+// AverageExcursions returns the average MAE and MFE of the excursions
+func AverageExcursions(excursions []TradeExcursion) (mae, mfe fixedpoint.Value) {
+	if len(excursions) == 0 {
+		return fixedpoint.Zero, fixedpoint.Zero
+	}
+
+	for _, e := range excursions {
+		mae = mae.Add(e.MAE)
+		mfe = mfe.Add(e.MFE)
+	}
+
+	n := fixedpoint.NewFromInt(int64(len(excursions)))
+	return mae.Div(n), mfe.Div(n)
+}
+
+// ExposureRatio returns the ratio of the time that the positions of the excursions are open
+func ExposureRatio(excursions []TradeExcursion, startTime, endTime time.Time) fixedpoint.Value {
+	period := endTime.Sub(startTime)
+	if period <= 0 {
+		return fixedpoint.Zero
+	}
+
+	var exposed time.Duration
+	for _, e := range excursions {
+		exposed += e.CloseTime.Sub(e.OpenTime)
+	}
+
+	return fixedpoint.NewFromFloat(float64(exposed) / float64(period))
+}
@@ -0,0 +1,237 @@
+package backtest
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	chartWidth        = 1000
+	chartPaddingLeft  = 80
+	chartPaddingRight = 10
+	chartPaddingTop   = 10
+	chartPaddingBtm   = 24
+	chartGridLines    = 5
+)
+
+type chartPoint struct {
+	Time  time.Time
+	Value float64
+}
+
+type chartSeriesStyle int
+
+const (
+	chartSeriesLine chartSeriesStyle = iota
+	chartSeriesStep
+	chartSeriesArea
+)
+
+type chartSeries struct {
+	Name   string
+	Color  string
+	Style  chartSeriesStyle
+	Points []chartPoint
+}
+
+type chartMarker struct {
+	Time  time.Time
+	Value float64
+	Color string
+
+	// Up draws the triangle pointing up
+	Up    bool
+	Title string
+}
+
+// svgChart renders the time series as an inline svg, so that the report does not need any javascript library
+type svgChart struct {
+	Height  int
+	Series  []chartSeries
+	Markers []chartMarker
+
+	// Format formats the values of the y axis
+	Format func(v float64) string
+
+	minTime, maxTime   time.Time
+	minValue, maxValue float64
+}
+
+func (c *svgChart) bounds() bool {
+	first := true
+	update := func(t time.Time, v float64) {
+		if first {
+			c.minTime, c.maxTime = t, t
+			c.minValue, c.maxValue = v, v
+			first = false
+			return
+		}
+
+		if t.Before(c.minTime) {
+			c.minTime = t
+		}
+		if t.After(c.maxTime) {
+			c.maxTime = t
+		}
+
+		c.minValue = math.Min(c.minValue, v)
+		c.maxValue = math.Max(c.maxValue, v)
+	}
+
+	for _, s := range c.Series {
+		for _, p := range s.Points {
+			update(p.Time, p.Value)
+		}
+
+		// the area is filled to zero
+		if s.Style == chartSeriesArea && len(s.Points) > 0 {
+			update(s.Points[0].Time, 0)
+		}
+	}
+
+	for _, m := range c.Markers {
+		update(m.Time, m.Value)
+	}
+
+	if first {
+		return false
+	}
+
+	if c.maxValue == c.minValue {
+		c.maxValue += 1
+		c.minValue -= 1
+	} else {
+		margin := (c.maxValue - c.minValue) * 0.05
+		c.maxValue += margin
+		c.minValue -= margin
+	}
+
+	if !c.maxTime.After(c.minTime) {
+		c.maxTime = c.minTime.Add(time.Minute)
+	}
+
+	return true
+}
+
+func (c *svgChart) x(t time.Time) float64 {
+	plotWidth := float64(chartWidth - chartPaddingLeft - chartPaddingRight)
+	return chartPaddingLeft + float64(t.Sub(c.minTime))/float64(c.maxTime.Sub(c.minTime))*plotWidth
+}
+
+func (c *svgChart) y(v float64) float64 {
+	plotHeight := float64(c.Height - chartPaddingTop - chartPaddingBtm)
+	return chartPaddingTop + (c.maxValue-v)/(c.maxValue-c.minValue)*plotHeight
+}
+
+func (c *svgChart) Render() template.HTML {
+	if !c.bounds() {
+		return template.HTML(`<p class="empty">no data</p>`)
+	}
+
+	format := c.Format
+	if format == nil {
+		format = formatChartValue
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg class="chart" viewBox="0 0 %d %d" preserveAspectRatio="none">`, chartWidth, c.Height)
+
+	// grid lines and the labels of the y axis
+	for i := 0; i <= chartGridLines; i++ {
+		v := c.minValue + (c.maxValue-c.minValue)*float64(i)/chartGridLines
+		y := c.y(v)
+		fmt.Fprintf(&sb, `<line class="grid" x1="%d" y1="%.1f" x2="%d" y2="%.1f"/>`, chartPaddingLeft, y, chartWidth-chartPaddingRight, y)
+		fmt.Fprintf(&sb, `<text class="label" x="%d" y="%.1f" text-anchor="end">%s</text>`, chartPaddingLeft-6, y+4, html.EscapeString(format(v)))
+	}
+
+	// the labels of the x axis
+	timeLayout := "2006-01-02"
+	if c.maxTime.Sub(c.minTime) < 72*time.Hour {
+		timeLayout = "01-02 15:04"
+	}
+
+	for i := 0; i <= chartGridLines; i++ {
+		t := c.minTime.Add(time.Duration(float64(c.maxTime.Sub(c.minTime)) * float64(i) / chartGridLines))
+		anchor := "middle"
+		if i == 0 {
+			anchor = "start"
+		} else if i == chartGridLines {
+			anchor = "end"
+		}
+
+		fmt.Fprintf(&sb, `<text class="label" x="%.1f" y="%d" text-anchor="%s">%s</text>`, c.x(t), c.Height-6, anchor, t.Format(timeLayout))
+	}
+
+	for _, s := range c.Series {
+		c.renderSeries(&sb, s)
+	}
+
+	for _, m := range c.Markers {
+		x, y := c.x(m.Time), c.y(m.Value)
+		points := fmt.Sprintf("%.1f,%.1f %.1f,%.1f %.1f,%.1f", x-5, y+8, x+5, y+8, x, y)
+		if !m.Up {
+			points = fmt.Sprintf("%.1f,%.1f %.1f,%.1f %.1f,%.1f", x-5, y-8, x+5, y-8, x, y)
+		}
+
+		fmt.Fprintf(&sb, `<polygon points="%s" fill="%s"><title>%s</title></polygon>`, points, m.Color, html.EscapeString(m.Title))
+	}
+
+	sb.WriteString(`</svg>`)
+	return template.HTML(sb.String())
+}
+
+func (c *svgChart) renderSeries(sb *strings.Builder, s chartSeries) {
+	if len(s.Points) == 0 {
+		return
+	}
+
+	var path strings.Builder
+	for i, p := range s.Points {
+		x, y := c.x(p.Time), c.y(p.Value)
+		if i == 0 {
+			fmt.Fprintf(&path, "M%.1f,%.1f", x, y)
+			continue
+		}
+
+		if s.Style == chartSeriesStep {
+			fmt.Fprintf(&path, " H%.1f V%.1f", x, y)
+		} else {
+			fmt.Fprintf(&path, " L%.1f,%.1f", x, y)
+		}
+	}
+
+	if s.Style == chartSeriesArea {
+		zero := c.y(0)
+		fmt.Fprintf(&path, " L%.1f,%.1f L%.1f,%.1f Z", c.x(s.Points[len(s.Points)-1].Time), zero, c.x(s.Points[0].Time), zero)
+		fmt.Fprintf(sb, `<path d="%s" fill="%s" fill-opacity="0.3" stroke="%s" stroke-width="1"><title>%s</title></path>`,
+			path.String(), s.Color, s.Color, html.EscapeString(s.Name))
+		return
+	}
+
+	fmt.Fprintf(sb, `<path d="%s" fill="none" stroke="%s" stroke-width="1.5"><title>%s</title></path>`,
+		path.String(), s.Color, html.EscapeString(s.Name))
+}
+
+// formatChartValue formats the value with the precision by its magnitude
+func formatChartValue(v float64) string {
+	abs := math.Abs(v)
+	switch {
+	case abs >= 1000:
+		return strconv.FormatFloat(v, 'f', 0, 64)
+	case abs >= 1:
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	case abs == 0:
+		return "0"
+	}
+
+	return strconv.FormatFloat(v, 'g', 4, 64)
+}
+
+func formatChartPercentage(v float64) string {
+	return strconv.FormatFloat(v*100, 'f', 2, 64) + "%"
+}
@@ -0,0 +1,192 @@
+package backtest
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// HTMLReportFileName is the file name of the static html report in the report directory
+const HTMLReportFileName = "report.html"
+
+//go:embed templates/report.html.tmpl
+var htmlReportTemplateContent string
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"percentage": func(v fixedpoint.Value) string {
+		return v.FormatPercentage(2)
+	},
+	"number": func(v fixedpoint.Value) string {
+		return v.FormatString(4)
+	},
+	"signClass": func(v fixedpoint.Value) string {
+		switch v.Sign() {
+		case 1:
+			return "positive"
+		case -1:
+			return "negative"
+		}
+		return ""
+	},
+}).Parse(htmlReportTemplateContent))
+
+// HTMLSymbolReport is the chart data of a symbol in the static html report
+type HTMLSymbolReport struct {
+	Session string
+	Report  *SessionSymbolReport
+
+	// KLines are drawn as the price line of the symbol
+	KLines []types.KLine
+
+	// Trades are drawn as the markers on the price line, and they are accumulated as the position over time
+	Trades []types.Trade
+}
+
+// HTMLReport is the data of the self-contained html report, the charts are rendered as inline svg,
+// so the report can be viewed without the network or the backtest-report app
+type HTMLReport struct {
+	Title       string
+	Summary     *SummaryReport
+	EquityCurve *EquityCurve
+	Symbols     []HTMLSymbolReport
+}
+
+type htmlSymbolView struct {
+	Title         string
+	Report        *SessionSymbolReport
+	NumOfTrades   int
+	PriceChart    template.HTML
+	PositionChart template.HTML
+}
+
+type htmlReportView struct {
+	Title         string
+	Summary       *SummaryReport
+	EquityChart   template.HTML
+	DrawdownChart template.HTML
+	Symbols       []htmlSymbolView
+}
+
+// WriteHTMLReport renders the report into a single html document
+func WriteHTMLReport(w io.Writer, report *HTMLReport) error {
+	if report.Summary == nil {
+		return fmt.Errorf("the summary report is required for the html report")
+	}
+
+	view := htmlReportView{
+		Title:   report.Title,
+		Summary: report.Summary,
+	}
+
+	if view.Title == "" {
+		view.Title = FormatSessionName(report.Summary.Sessions, report.Summary.Symbols, report.Summary.StartTime, report.Summary.EndTime)
+	}
+
+	if report.EquityCurve != nil {
+		view.EquityChart, view.DrawdownChart = renderEquityCharts(report.EquityCurve)
+	}
+
+	for _, symbolReport := range report.Symbols {
+		view.Symbols = append(view.Symbols, htmlSymbolView{
+			Title:         fmt.Sprintf("%s %s", symbolReport.Session, symbolReport.Report.Symbol),
+			Report:        symbolReport.Report,
+			NumOfTrades:   len(symbolReport.Trades),
+			PriceChart:    renderPriceChart(symbolReport.KLines, symbolReport.Trades),
+			PositionChart: renderPositionChart(symbolReport.Report, symbolReport.Trades),
+		})
+	}
+
+	return htmlReportTemplate.Execute(w, view)
+}
+
+// WriteHTMLReportFile writes the html report to the file
+func WriteHTMLReportFile(filename string, report *HTMLReport) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	if err := WriteHTMLReport(f, report); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func renderEquityCharts(curve *EquityCurve) (equityChart, drawdownChart template.HTML) {
+	equity := chartSeries{Name: "Equity (USD)", Color: "#2b6cb0"}
+	drawdown := chartSeries{Name: "Drawdown", Color: "#c53030", Style: chartSeriesArea}
+	for _, p := range curve.Points {
+		equity.Points = append(equity.Points, chartPoint{Time: p.Time, Value: p.Equity.Float64()})
+		drawdown.Points = append(drawdown.Points, chartPoint{Time: p.Time, Value: -p.Drawdown.Float64()})
+	}
+
+	equityChart = (&svgChart{Height: 300, Series: []chartSeries{equity}}).Render()
+	drawdownChart = (&svgChart{Height: 160, Series: []chartSeries{drawdown}, Format: formatChartPercentage}).Render()
+	return equityChart, drawdownChart
+}
+
+func renderPriceChart(klines []types.KLine, trades []types.Trade) template.HTML {
+	price := chartSeries{Name: "Close Price", Color: "#4a5568"}
+	for _, k := range klines {
+		price.Points = append(price.Points, chartPoint{Time: k.EndTime.Time(), Value: k.Close.Float64()})
+	}
+
+	var markers []chartMarker
+	for _, trade := range trades {
+		marker := chartMarker{
+			Time:  trade.Time.Time(),
+			Value: trade.Price.Float64(),
+			Color: "#2f855a",
+			Up:    true,
+			Title: fmt.Sprintf("%s %s %s @ %s, %s",
+				trade.Side, trade.Quantity.String(), trade.Symbol, trade.Price.String(),
+				trade.Time.Time().Format("2006-01-02 15:04:05")),
+		}
+
+		if trade.Side == types.SideTypeSell {
+			marker.Color = "#c53030"
+			marker.Up = false
+		}
+
+		markers = append(markers, marker)
+	}
+
+	return (&svgChart{Height: 360, Series: []chartSeries{price}, Markers: markers}).Render()
+}
+
+// renderPositionChart renders the base position accumulated from the trades
+func renderPositionChart(report *SessionSymbolReport, trades []types.Trade) template.HTML {
+	if len(trades) == 0 {
+		return (&svgChart{}).Render()
+	}
+
+	sorted := make([]types.Trade, len(trades))
+	copy(sorted, trades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Time().Before(sorted[j].Time.Time())
+	})
+
+	position := chartSeries{Name: "Position (" + report.Market.BaseCurrency + ")", Color: "#6b46c1", Style: chartSeriesStep}
+
+	base := fixedpoint.Zero
+	position.Points = append(position.Points, chartPoint{Time: sorted[0].Time.Time(), Value: 0})
+	for _, trade := range sorted {
+		if trade.Side == types.SideTypeBuy {
+			base = base.Add(trade.Quantity)
+		} else {
+			base = base.Sub(trade.Quantity)
+		}
+
+		position.Points = append(position.Points, chartPoint{Time: trade.Time.Time(), Value: base.Float64()})
+	}
+
+	return (&svgChart{Height: 200, Series: []chartSeries{position}}).Render()
+}
@@ -0,0 +1,79 @@
+package backtest
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/accounting/pnl"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+func TestWriteHTMLReport(t *testing.T) {
+	startTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	recorder := NewEquityCurveRecorder(types.Interval1h)
+	var klines []types.KLine
+	for i := 0; i < 24; i++ {
+		closeTime := startTime.Add(time.Duration(i+1) * time.Hour)
+		price := fixedpoint.NewFromFloat(20000.0 + float64(i)*10.0)
+		klines = append(klines, types.KLine{
+			Symbol:   "BTCUSDT",
+			Interval: types.Interval1h,
+			EndTime:  types.Time(closeTime),
+			Close:    price,
+		})
+		recorder.Record(closeTime, fixedpoint.NewFromFloat(10000.0-float64(i%5)*10.0), i > 3)
+	}
+	recorder.Finish()
+
+	trades := []types.Trade{
+		{ID: 1, Symbol: "BTCUSDT", Side: types.SideTypeBuy, Price: fixedpoint.NewFromFloat(20030.0), Quantity: fixedpoint.NewFromFloat(0.1), Time: types.Time(startTime.Add(4 * time.Hour))},
+		{ID: 2, Symbol: "BTCUSDT", Side: types.SideTypeSell, Price: fixedpoint.NewFromFloat(20200.0), Quantity: fixedpoint.NewFromFloat(0.1), Time: types.Time(startTime.Add(20 * time.Hour))},
+	}
+
+	summary := &SummaryReport{
+		StartTime: startTime,
+		EndTime:   startTime.Add(24 * time.Hour),
+		Sessions:  []string{"binance"},
+		Symbols:   []string{"BTCUSDT"},
+	}
+	summary.SetEquityCurveStats(recorder.Stats())
+
+	var buf bytes.Buffer
+	err := WriteHTMLReport(&buf, &HTMLReport{
+		Summary:     summary,
+		EquityCurve: recorder.Curve(),
+		Symbols: []HTMLSymbolReport{
+			{
+				Session: "binance",
+				Report: &SessionSymbolReport{
+					Symbol: "BTCUSDT",
+					Market: types.Market{Symbol: "BTCUSDT", BaseCurrency: "BTC", QuoteCurrency: "USDT"},
+					PnL:    &pnl.AverageCostPnLReport{Profit: fixedpoint.NewFromFloat(17.0)},
+				},
+				KLines: klines,
+				Trades: trades,
+			},
+		},
+	})
+	if assert.NoError(t, err) {
+		out := buf.String()
+		assert.Contains(t, out, "binance_BTCUSDT_2023-01-01T00_00-2023-01-02T00_00")
+		assert.Contains(t, out, "binance BTCUSDT")
+		assert.Contains(t, out, "<title>BUY 0.1 BTCUSDT @ 20030, 2023-01-01 04:00:00</title>")
+		assert.Contains(t, out, "<title>SELL 0.1 BTCUSDT @ 20200, 2023-01-01 20:00:00</title>")
+		assert.Contains(t, out, "<title>Position (BTC)</title>")
+		assert.Contains(t, out, "<title>Drawdown</title>")
+
+		// the report is self-contained
+		assert.NotContains(t, out, "<script")
+		assert.NotContains(t, out, "http://")
+		assert.NotContains(t, out, "https://")
+	}
+
+	assert.Error(t, WriteHTMLReport(&buf, &HTMLReport{}), "the summary report is required")
+}
@@ -0,0 +1,338 @@
+package backtest
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+type MonteCarloMethod string
+
+const (
+	// MonteCarloShuffle shuffles the order of the trade returns, the final equity is the same as the back-test,
+	// only the path and the drawdown change
+	MonteCarloShuffle MonteCarloMethod = "shuffle"
+
+	// MonteCarloBootstrap resamples the trade returns with replacement
+	MonteCarloBootstrap MonteCarloMethod = "bootstrap"
+
+	// MonteCarloPerturbation keeps the order of the trade returns and deducts the random slippage and fee
+	MonteCarloPerturbation MonteCarloMethod = "perturbation"
+)
+
+// MonteCarloSample is a realized profit of the back-test trades, the trades of the same order are merged
+type MonteCarloSample struct {
+	Time      time.Time        `json:"time"`
+	Symbol    string           `json:"symbol"`
+	NetProfit fixedpoint.Value `json:"netProfit"`
+
+	// Return is the net profit divided by the equity before the profit
+	Return float64 `json:"return"`
+
+	// Volume is the traded quote volume of the position round trip divided by the equity before the profit,
+	// the random slippage and fee are charged on it
+	Volume float64 `json:"volume"`
+}
+
+// MonteCarloInterval is the distribution of a simulated metric, Lower and Upper are the bounds of the confidence interval
+type MonteCarloInterval struct {
+	Mean   fixedpoint.Value `json:"mean"`
+	Median fixedpoint.Value `json:"median"`
+	Lower  fixedpoint.Value `json:"lower"`
+	Upper  fixedpoint.Value `json:"upper"`
+}
+
+type MonteCarloResult struct {
+	Method      MonteCarloMethod   `json:"method"`
+	Iterations  int                `json:"iterations"`
+	FinalEquity MonteCarloInterval `json:"finalEquity"`
+	MaxDrawdown MonteCarloInterval `json:"maxDrawdown"`
+
+	// RiskOfRuin is the ratio of the simulations that lose the ruin threshold of the initial equity
+	RiskOfRuin fixedpoint.Value `json:"riskOfRuin"`
+}
+
+// MonteCarloReport is the robustness analysis of the back-test trades
+type MonteCarloReport struct {
+	NumOfSamples    int              `json:"numOfSamples"`
+	ConfidenceLevel fixedpoint.Value `json:"confidenceLevel"`
+	RuinThreshold   fixedpoint.Value `json:"ruinThreshold"`
+
+	// InitialEquity, FinalEquity and MaxDrawdown are of the original trade sequence
+	InitialEquity fixedpoint.Value `json:"initialEquity"`
+	FinalEquity   fixedpoint.Value `json:"finalEquity"`
+	MaxDrawdown   fixedpoint.Value `json:"maxDrawdown"`
+
+	Results []MonteCarloResult `json:"results"`
+}
+
+func (r *MonteCarloReport) Result(method MonteCarloMethod) *MonteCarloResult {
+	for i := range r.Results {
+		if r.Results[i].Method == method {
+			return &r.Results[i]
+		}
+	}
+
+	return nil
+}
+
+func (r *MonteCarloReport) Print() {
+	color.Green("MONTE CARLO ANALYSIS (%d PROFITS, %s CONFIDENCE)", r.NumOfSamples, r.ConfidenceLevel.FormatPercentage(0))
+	color.Green("===============================================")
+	color.Green("INITIAL EQUITY: %s, FINAL EQUITY: %s, MAX DRAWDOWN: %s",
+		r.InitialEquity.FormatString(2), r.FinalEquity.FormatString(2), r.MaxDrawdown.FormatPercentage(2))
+
+	for _, result := range r.Results {
+		color.Green("%s (%d ITERATIONS)", result.Method, result.Iterations)
+		color.Green("  FINAL EQUITY: %s ~ %s (MEDIAN %s)",
+			result.FinalEquity.Lower.FormatString(2), result.FinalEquity.Upper.FormatString(2), result.FinalEquity.Median.FormatString(2))
+		color.Green("  MAX DRAWDOWN: %s ~ %s (MEDIAN %s)",
+			result.MaxDrawdown.Lower.FormatPercentage(2), result.MaxDrawdown.Upper.FormatPercentage(2), result.MaxDrawdown.Median.FormatPercentage(2))
+
+		if result.RiskOfRuin.Sign() > 0 {
+			color.Red("  RISK OF RUIN: %s (LOSING %s)", result.RiskOfRuin.FormatPercentage(2), r.RuinThreshold.FormatPercentage(0))
+		} else {
+			color.Green("  RISK OF RUIN: %s (LOSING %s)", result.RiskOfRuin.FormatPercentage(2), r.RuinThreshold.FormatPercentage(0))
+		}
+	}
+}
+
+// NewMonteCarloSamples replays the trades by the average cost positions, and returns the realized profits as
+// the samples and the trade stats of the profits. The trades are sorted by the trade time.
+func NewMonteCarloSamples(trades []types.Trade, markets types.MarketMap, initialEquity fixedpoint.Value) ([]MonteCarloSample, *types.TradeStats) {
+	sorted := make([]types.Trade, len(trades))
+	copy(sorted, trades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Time().Before(sorted[j].Time.Time())
+	})
+
+	type positionKey struct {
+		exchange types.ExchangeName
+		symbol   string
+	}
+
+	positions := make(map[positionKey]*types.Position)
+	volumes := make(map[positionKey]fixedpoint.Value)
+	tradeStats := types.NewTradeStats("")
+
+	var samples []MonteCarloSample
+	var lastKey positionKey
+	var lastOrderID uint64
+
+	equity := initialEquity
+	for _, trade := range sorted {
+		key := positionKey{exchange: trade.Exchange, symbol: trade.Symbol}
+		position, ok := positions[key]
+		if !ok {
+			if market, ok := markets[trade.Symbol]; ok {
+				position = types.NewPositionFromMarket(market)
+			} else {
+				position = types.NewPosition(trade.Symbol, "", "")
+			}
+			positions[key] = position
+		}
+
+		volumes[key] = volumes[key].Add(trade.QuoteQuantity)
+
+		profit, netProfit, madeProfit := position.AddTrade(trade)
+		if !madeProfit {
+			continue
+		}
+
+		tradeStats.Add(&types.Profit{
+			Symbol:        trade.Symbol,
+			Profit:        profit,
+			NetProfit:     netProfit,
+			OrderID:       trade.OrderID,
+			Side:          trade.Side,
+			Price:         trade.Price,
+			Quantity:      trade.Quantity,
+			QuoteQuantity: trade.QuoteQuantity,
+			TradedAt:      trade.Time.Time(),
+		})
+
+		// the trades of the same order are merged into one sample
+		n := len(samples)
+		if n > 0 && trade.OrderID > 0 && trade.OrderID == lastOrderID && key == lastKey {
+			samples[n-1].NetProfit = samples[n-1].NetProfit.Add(netProfit)
+		} else {
+			samples = append(samples, MonteCarloSample{
+				Time:      trade.Time.Time(),
+				Symbol:    trade.Symbol,
+				NetProfit: netProfit,
+			})
+			n++
+		}
+
+		equityBefore := equity.Sub(samples[n-1].NetProfit).Add(netProfit)
+		if equityBefore.Sign() > 0 {
+			samples[n-1].Return = samples[n-1].NetProfit.Float64() / equityBefore.Float64()
+			samples[n-1].Volume += volumes[key].Float64() / equityBefore.Float64()
+		}
+
+		equity = equity.Add(netProfit)
+		volumes[key] = fixedpoint.Zero
+		lastKey = key
+		lastOrderID = trade.OrderID
+	}
+
+	return samples, tradeStats
+}
+
+type monteCarloPath struct {
+	finalEquity float64
+	maxDrawdown float64
+	ruined      bool
+}
+
+// simulateMonteCarloPath compounds the returns from the initial equity
+func simulateMonteCarloPath(returns []float64, initialEquity, ruinEquity float64) monteCarloPath {
+	equity := initialEquity
+	peak := initialEquity
+
+	var path monteCarloPath
+	for _, r := range returns {
+		equity = math.Max(equity*(1.0+r), 0)
+		if equity > peak {
+			peak = equity
+		} else if peak > 0 {
+			path.maxDrawdown = math.Max(path.maxDrawdown, (peak-equity)/peak)
+		}
+
+		if equity <= ruinEquity {
+			path.ruined = true
+		}
+	}
+
+	path.finalEquity = equity
+	return path
+}
+
+// RunMonteCarlo runs the shuffle, the bootstrap and the perturbation simulations of the samples, the perturbation
+// is skipped if both the slippage and the fee rate are zero
+func RunMonteCarlo(samples []MonteCarloSample, initialEquity fixedpoint.Value, config bbgo.BacktestMonteCarlo) *MonteCarloReport {
+	config = config.WithDefaults()
+
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rnd := rand.New(rand.NewSource(seed))
+
+	returns := make([]float64, len(samples))
+	for i, sample := range samples {
+		returns[i] = sample.Return
+	}
+
+	initial := initialEquity.Float64()
+	ruinEquity := initial * (1.0 - config.RuinThreshold.Float64())
+	original := simulateMonteCarloPath(returns, initial, ruinEquity)
+
+	report := &MonteCarloReport{
+		NumOfSamples:    len(samples),
+		ConfidenceLevel: config.ConfidenceLevel,
+		RuinThreshold:   config.RuinThreshold,
+		InitialEquity:   initialEquity,
+		FinalEquity:     fixedpoint.NewFromFloat(original.finalEquity),
+		MaxDrawdown:     fixedpoint.NewFromFloat(original.maxDrawdown),
+	}
+
+	if len(samples) == 0 {
+		return report
+	}
+
+	simulate := func(method MonteCarloMethod, generate func(buf []float64)) {
+		paths := make([]monteCarloPath, config.Iterations)
+		buf := make([]float64, len(returns))
+		for i := range paths {
+			generate(buf)
+			paths[i] = simulateMonteCarloPath(buf, initial, ruinEquity)
+		}
+
+		report.Results = append(report.Results, newMonteCarloResult(method, paths, config.ConfidenceLevel.Float64()))
+	}
+
+	simulate(MonteCarloShuffle, func(buf []float64) {
+		copy(buf, returns)
+		rnd.Shuffle(len(buf), func(i, j int) {
+			buf[i], buf[j] = buf[j], buf[i]
+		})
+	})
+
+	simulate(MonteCarloBootstrap, func(buf []float64) {
+		for i := range buf {
+			buf[i] = returns[rnd.Intn(len(returns))]
+		}
+	})
+
+	slippage, feeRate := config.Slippage.Float64(), config.FeeRate.Float64()
+	if slippage > 0 || feeRate > 0 {
+		simulate(MonteCarloPerturbation, func(buf []float64) {
+			for i, sample := range samples {
+				buf[i] = sample.Return - sample.Volume*(rnd.Float64()*slippage+rnd.Float64()*feeRate)
+			}
+		})
+	}
+
+	return report
+}
+
+func newMonteCarloResult(method MonteCarloMethod, paths []monteCarloPath, confidenceLevel float64) MonteCarloResult {
+	finalEquities := make([]float64, len(paths))
+	maxDrawdowns := make([]float64, len(paths))
+	ruined := 0
+	for i, path := range paths {
+		finalEquities[i] = path.finalEquity
+		maxDrawdowns[i] = path.maxDrawdown
+		if path.ruined {
+			ruined++
+		}
+	}
+
+	return MonteCarloResult{
+		Method:      method,
+		Iterations:  len(paths),
+		FinalEquity: newMonteCarloInterval(finalEquities, confidenceLevel),
+		MaxDrawdown: newMonteCarloInterval(maxDrawdowns, confidenceLevel),
+		RiskOfRuin:  fixedpoint.NewFromFloat(float64(ruined) / float64(len(paths))),
+	}
+}
+
+func newMonteCarloInterval(values []float64, confidenceLevel float64) MonteCarloInterval {
+	sort.Float64s(values)
+
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+
+	tail := (1.0 - confidenceLevel) / 2.0
+	return MonteCarloInterval{
+		Mean:   fixedpoint.NewFromFloat(sum / float64(len(values))),
+		Median: fixedpoint.NewFromFloat(percentile(values, 0.5)),
+		Lower:  fixedpoint.NewFromFloat(percentile(values, tail)),
+		Upper:  fixedpoint.NewFromFloat(percentile(values, 1.0-tail)),
+	}
+}
+
+// percentile returns the linear interpolated percentile of the sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	pos := p * float64(len(sorted)-1)
+	i := int(math.Floor(pos))
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+
+	return sorted[i] + (sorted[i+1]-sorted[i])*(pos-float64(i))
+}
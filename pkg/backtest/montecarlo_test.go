@@ -0,0 +1,122 @@
+package backtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+func TestNewMonteCarloSamples(t *testing.T) {
+	startTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	market := types.Market{Symbol: "BTCUSDT", BaseCurrency: "BTC", QuoteCurrency: "USDT"}
+
+	trade := func(id uint64, orderID uint64, hours int, side types.SideType, price, quantity float64) types.Trade {
+		return types.Trade{
+			ID:            id,
+			OrderID:       orderID,
+			Exchange:      types.ExchangeBinance,
+			Symbol:        "BTCUSDT",
+			Side:          side,
+			IsBuyer:       side == types.SideTypeBuy,
+			Price:         fixedpoint.NewFromFloat(price),
+			Quantity:      fixedpoint.NewFromFloat(quantity),
+			QuoteQuantity: fixedpoint.NewFromFloat(price * quantity),
+			FeeCurrency:   "USDT",
+			Time:          types.Time(startTime.Add(time.Duration(hours) * time.Hour)),
+		}
+	}
+
+	trades := []types.Trade{
+		// the trades are sorted by the time
+		trade(3, 2, 2, types.SideTypeSell, 11000.0, 0.05),
+		trade(1, 1, 0, types.SideTypeBuy, 10000.0, 0.1),
+		trade(4, 2, 3, types.SideTypeSell, 11000.0, 0.05),
+		trade(5, 3, 4, types.SideTypeBuy, 10000.0, 0.1),
+		trade(6, 4, 5, types.SideTypeSell, 9000.0, 0.1),
+	}
+
+	samples, tradeStats := NewMonteCarloSamples(trades, types.MarketMap{"BTCUSDT": market}, fixedpoint.NewFromFloat(1000.0))
+	if assert.Len(t, samples, 2, "the trades of the same order are merged") {
+		assert.Equal(t, "100", samples[0].NetProfit.String())
+		assert.InDelta(t, 0.1, samples[0].Return, 1e-9)
+		assert.InDelta(t, 2.1, samples[0].Volume, 1e-9, "(1000 + 550 + 550) / 1000")
+
+		assert.Equal(t, "-100", samples[1].NetProfit.String())
+		assert.InDelta(t, -100.0/1100.0, samples[1].Return, 1e-9)
+	}
+
+	assert.Equal(t, 1, tradeStats.NumOfLossTrade)
+	assert.Equal(t, "0", tradeStats.TotalNetProfit.String())
+}
+
+func TestRunMonteCarlo(t *testing.T) {
+	var samples []MonteCarloSample
+	for i := 0; i < 20; i++ {
+		r := 0.05
+		if i%4 == 3 {
+			r = -0.1
+		}
+		samples = append(samples, MonteCarloSample{Return: r, Volume: 2.0})
+	}
+
+	config := bbgo.BacktestMonteCarlo{
+		Iterations: 500,
+		Seed:       1,
+		Slippage:   fixedpoint.NewFromFloat(0.001),
+		FeeRate:    fixedpoint.NewFromFloat(0.001),
+	}
+
+	report := RunMonteCarlo(samples, fixedpoint.NewFromFloat(1000.0), config)
+	assert.Equal(t, 20, report.NumOfSamples)
+	assert.Equal(t, "0.95", report.ConfidenceLevel.String())
+	assert.Len(t, report.Results, 3)
+
+	shuffle := report.Result(MonteCarloShuffle)
+	if assert.NotNil(t, shuffle) {
+		assert.Equal(t, 500, shuffle.Iterations)
+
+		// the order of the returns does not change the compounded final equity
+		assert.InDelta(t, report.FinalEquity.Float64(), shuffle.FinalEquity.Lower.Float64(), 1e-6)
+		assert.InDelta(t, report.FinalEquity.Float64(), shuffle.FinalEquity.Upper.Float64(), 1e-6)
+		assert.True(t, shuffle.MaxDrawdown.Upper.Compare(shuffle.MaxDrawdown.Lower) > 0)
+		assert.True(t, shuffle.MaxDrawdown.Upper.Compare(report.MaxDrawdown) >= 0)
+	}
+
+	bootstrap := report.Result(MonteCarloBootstrap)
+	if assert.NotNil(t, bootstrap) {
+		assert.True(t, bootstrap.FinalEquity.Upper.Compare(bootstrap.FinalEquity.Lower) > 0)
+		assert.True(t, bootstrap.FinalEquity.Median.Compare(bootstrap.FinalEquity.Lower) >= 0)
+	}
+
+	perturbation := report.Result(MonteCarloPerturbation)
+	if assert.NotNil(t, perturbation) {
+		assert.True(t, perturbation.FinalEquity.Upper.Compare(report.FinalEquity) < 0, "the slippage and the fee reduce the equity")
+	}
+
+	// the same seed gives the same result
+	assert.Equal(t, report, RunMonteCarlo(samples, fixedpoint.NewFromFloat(1000.0), config))
+
+	// losing 20% at every trade ruins the account
+	ruined := RunMonteCarlo([]MonteCarloSample{{Return: -0.2}, {Return: -0.2}, {Return: -0.2}, {Return: -0.2}}, fixedpoint.NewFromFloat(1000.0), config)
+	for _, result := range ruined.Results {
+		assert.Equal(t, "1", result.RiskOfRuin.String(), result.Method)
+	}
+
+	// the perturbation is skipped without the slippage and the fee
+	config.Slippage, config.FeeRate = fixedpoint.Zero, fixedpoint.Zero
+	assert.Nil(t, RunMonteCarlo(samples, fixedpoint.NewFromFloat(1000.0), config).Result(MonteCarloPerturbation))
+}
+
+func Test_percentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5}
+	assert.Equal(t, 1.0, percentile(values, 0))
+	assert.Equal(t, 3.0, percentile(values, 0.5))
+	assert.Equal(t, 1.4, percentile(values, 0.1))
+	assert.Equal(t, 5.0, percentile(values, 1.0))
+	assert.Equal(t, 0.0, percentile(nil, 0.5))
+}
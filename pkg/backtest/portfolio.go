@@ -0,0 +1,518 @@
+package backtest
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/dynamic"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// PortfolioReportFileName is the file name of the portfolio report in the report directory
+const PortfolioReportFileName = "portfolio.json"
+
+// PortfolioInstance is a strategy instance that trades with its own sub-account in the portfolio back-test
+type PortfolioInstance struct {
+	Strategy bbgo.SingleExchangeStrategy
+	Session  *bbgo.ExchangeSession
+
+	// Weight is the configured weight of the instance
+	Weight fixedpoint.Value
+
+	exchange *Exchange
+
+	initialEquity, lastEquity, netTransfer fixedpoint.Value
+
+	// growth is the product of the sub-period returns since the last sample, the sub-periods are split by the transfers
+	growth float64
+
+	returns []float64
+}
+
+// transfer links the return before the transfer, so that the transferred amount is not counted as the return
+func (instance *PortfolioInstance) transfer(equity, amount fixedpoint.Value) {
+	if instance.lastEquity.Sign() > 0 {
+		instance.growth *= equity.Div(instance.lastEquity).Float64()
+	}
+
+	instance.lastEquity = equity.Add(amount)
+	instance.netTransfer = instance.netTransfer.Add(amount)
+}
+
+// NewPortfolioInstance creates the instance of the strategy running on the session of a sub-account
+func NewPortfolioInstance(strategy bbgo.SingleExchangeStrategy, session *bbgo.ExchangeSession, weight fixedpoint.Value) (*PortfolioInstance, error) {
+	ex, ok := session.Exchange.(*Exchange)
+	if !ok || ex.Parent() == nil {
+		return nil, fmt.Errorf("session %s is not a back-test sub-account", session.Name)
+	}
+
+	return &PortfolioInstance{
+		Strategy: strategy,
+		Session:  session,
+		Weight:   weight,
+		exchange: ex,
+	}, nil
+}
+
+// PortfolioTransfer is a transfer between the sub-account of the instance and the reserve,
+// the positive amount is transferred to the sub-account
+type PortfolioTransfer struct {
+	Session string           `json:"session"`
+	Amount  fixedpoint.Value `json:"amount"`
+}
+
+type PortfolioRebalance struct {
+	Time      time.Time           `json:"time"`
+	Weights   []fixedpoint.Value  `json:"weights"`
+	Transfers []PortfolioTransfer `json:"transfers,omitempty"`
+}
+
+type PortfolioInstanceReport struct {
+	ID       string `json:"id"`
+	Session  string `json:"session"`
+	Strategy string `json:"strategy"`
+
+	// Weight is the configured weight, TargetWeight is the weight of the last rebalance
+	Weight       fixedpoint.Value `json:"weight"`
+	TargetWeight fixedpoint.Value `json:"targetWeight"`
+
+	InitialEquity fixedpoint.Value `json:"initialEquity"`
+	FinalEquity   fixedpoint.Value `json:"finalEquity"`
+	NetTransfer   fixedpoint.Value `json:"netTransfer"`
+
+	// Profit is the final equity minus the initial equity and the net transfer
+	Profit fixedpoint.Value `json:"profit"`
+
+	// Contribution is the profit divided by the initial equity of the portfolio
+	Contribution fixedpoint.Value `json:"contribution"`
+
+	// Return is the time-weighted return, so that the transfers are not counted as the return
+	Return fixedpoint.Value `json:"return"`
+
+	// Volatility is the annualized standard deviation of the returns
+	Volatility fixedpoint.Value `json:"volatility"`
+}
+
+type PortfolioReport struct {
+	Allocation        bbgo.BacktestAllocation `json:"allocation"`
+	Currency          string                  `json:"currency"`
+	RebalanceInterval types.Interval          `json:"rebalanceInterval,omitempty"`
+	ReturnInterval    types.Interval          `json:"returnInterval"`
+
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+
+	InitialEquity fixedpoint.Value `json:"initialEquity"`
+	FinalEquity   fixedpoint.Value `json:"finalEquity"`
+
+	// ReserveEquity is the final equity of the balances not allocated to any instance
+	ReserveEquity fixedpoint.Value `json:"reserveEquity"`
+
+	Instances []PortfolioInstanceReport `json:"instances"`
+
+	// Correlation is the correlation matrix of the instance returns, in the order of the instances
+	Correlation [][]fixedpoint.Value `json:"correlation"`
+
+	Rebalances []PortfolioRebalance `json:"rebalances,omitempty"`
+}
+
+func (r *PortfolioReport) Print() {
+	color.Green("PORTFOLIO (%s ALLOCATION, %d INSTANCES)", r.Allocation, len(r.Instances))
+	color.Green("===============================================")
+	color.Green("INITIAL EQUITY: %s, FINAL EQUITY: %s, RESERVE: %s, REBALANCES: %d",
+		r.InitialEquity.FormatString(2), r.FinalEquity.FormatString(2), r.ReserveEquity.FormatString(2), len(r.Rebalances))
+
+	for i, instance := range r.Instances {
+		color.Green("#%d %s (%s)", i, instance.ID, instance.Session)
+		color.Green("  WEIGHT: %s, TARGET WEIGHT: %s",
+			instance.Weight.FormatPercentage(2), instance.TargetWeight.FormatPercentage(2))
+		color.Green("  EQUITY: %s -> %s, NET TRANSFER: %s",
+			instance.InitialEquity.FormatString(2), instance.FinalEquity.FormatString(2), instance.NetTransfer.FormatString(2))
+
+		if instance.Profit.Sign() < 0 {
+			color.Red("  PROFIT: %s, CONTRIBUTION: %s, RETURN: %s, VOLATILITY: %s",
+				instance.Profit.FormatString(2), instance.Contribution.FormatPercentage(2),
+				instance.Return.FormatPercentage(2), instance.Volatility.FormatPercentage(2))
+		} else {
+			color.Green("  PROFIT: %s, CONTRIBUTION: %s, RETURN: %s, VOLATILITY: %s",
+				instance.Profit.FormatString(2), instance.Contribution.FormatPercentage(2),
+				instance.Return.FormatPercentage(2), instance.Volatility.FormatPercentage(2))
+		}
+	}
+
+	if len(r.Correlation) > 1 {
+		color.Green("CORRELATION")
+		for i, row := range r.Correlation {
+			line := ""
+			for _, v := range row {
+				line += fmt.Sprintf(" %6s", v.FormatString(2))
+			}
+			color.Green("  #%d%s", i, line)
+		}
+	}
+}
+
+// PortfolioAllocator samples the returns of the instances, and moves the capital between the sub-accounts
+// and the reserves (the parent accounts) by the allocation method
+type PortfolioAllocator struct {
+	config    bbgo.BacktestPortfolio
+	instances []*PortfolioInstance
+
+	// reserves are the sessions of the parent accounts
+	reserves map[*Exchange]*bbgo.ExchangeSession
+
+	startTime, lastTime, lastSampleTime time.Time
+	nextSample, nextRebalance           time.Time
+	initialEquity                       fixedpoint.Value
+	weights                             []float64
+	rebalances                          []PortfolioRebalance
+}
+
+// NewPortfolioAllocator creates the allocator, the config should be filled with the defaults and validated
+func NewPortfolioAllocator(config bbgo.BacktestPortfolio, instances []*PortfolioInstance, reserves []*bbgo.ExchangeSession) *PortfolioAllocator {
+	a := &PortfolioAllocator{
+		config:    config,
+		instances: instances,
+		reserves:  make(map[*Exchange]*bbgo.ExchangeSession),
+	}
+
+	for _, session := range reserves {
+		if ex, ok := session.Exchange.(*Exchange); ok {
+			a.reserves[ex] = session
+		}
+	}
+
+	for _, instance := range instances {
+		a.weights = append(a.weights, instance.Weight.Float64())
+	}
+
+	return a
+}
+
+// Update samples the returns and rebalances the capital when the intervals are reached. It should be called
+// at every kline close of the required interval, the calls of the same time are ignored.
+func (a *PortfolioAllocator) Update(t time.Time) {
+	if !a.lastTime.IsZero() && !t.After(a.lastTime) {
+		return
+	}
+	a.lastTime = t
+
+	if a.startTime.IsZero() {
+		a.startTime = t
+		a.lastSampleTime = t
+		a.initialEquity = a.reserveEquity(t)
+		for _, instance := range a.instances {
+			instance.initialEquity = instanceEquity(instance, t)
+			instance.lastEquity = instance.initialEquity
+			instance.growth = 1
+			a.initialEquity = a.initialEquity.Add(instance.initialEquity)
+		}
+
+		a.nextSample = t.Add(a.config.ReturnInterval.Duration())
+		if a.config.RebalanceInterval != "" {
+			a.nextRebalance = t.Add(a.config.RebalanceInterval.Duration())
+		}
+		return
+	}
+
+	if !t.Before(a.nextSample) {
+		a.sample(t)
+		for !a.nextSample.After(t) {
+			a.nextSample = a.nextSample.Add(a.config.ReturnInterval.Duration())
+		}
+	}
+
+	if a.config.RebalanceInterval != "" && !t.Before(a.nextRebalance) {
+		a.rebalance(t)
+		for !a.nextRebalance.After(t) {
+			a.nextRebalance = a.nextRebalance.Add(a.config.RebalanceInterval.Duration())
+		}
+	}
+}
+
+// sample appends the returns of the instances, the transfers since the last sample are excluded from the returns
+func (a *PortfolioAllocator) sample(t time.Time) {
+	a.lastSampleTime = t
+	for _, instance := range a.instances {
+		equity := instanceEquity(instance, t)
+
+		if instance.lastEquity.Sign() > 0 {
+			instance.growth *= equity.Div(instance.lastEquity).Float64()
+		}
+
+		instance.returns = append(instance.returns, instance.growth-1)
+		instance.lastEquity = equity
+		instance.growth = 1
+	}
+}
+
+// targetWeights returns the target weights of the instances by the allocation method, the configured weights are
+// used until there are enough returns to estimate the volatility
+func (a *PortfolioAllocator) targetWeights() []float64 {
+	weights := make([]float64, len(a.instances))
+	for i, instance := range a.instances {
+		weights[i] = instance.Weight.Float64()
+	}
+
+	if a.config.Allocation == bbgo.BacktestAllocationFixed {
+		return weights
+	}
+
+	volatilities := make([]float64, len(a.instances))
+	for i, instance := range a.instances {
+		returns := instance.returns
+		if len(returns) > a.config.Lookback {
+			returns = returns[len(returns)-a.config.Lookback:]
+		}
+
+		if len(returns) < 2 {
+			return weights
+		}
+
+		volatilities[i] = stdev(returns)
+	}
+
+	switch a.config.Allocation {
+	case bbgo.BacktestAllocationRiskParity:
+		// the weights are inversely proportional to the volatility, and the sum of the configured weights is kept
+		var totalWeight, totalInverse float64
+		for i, v := range volatilities {
+			if v == 0 {
+				return weights
+			}
+
+			totalWeight += weights[i]
+			totalInverse += 1 / v
+		}
+
+		for i, v := range volatilities {
+			weights[i] = totalWeight * (1 / v) / totalInverse
+		}
+
+	case bbgo.BacktestAllocationVolatilityTarget:
+		target := a.config.TargetVolatility.Float64()
+		periods := annualPeriods(a.config.ReturnInterval)
+		for i, v := range volatilities {
+			annualized := v * math.Sqrt(periods)
+			if annualized > target {
+				weights[i] *= target / annualized
+			}
+		}
+	}
+
+	return weights
+}
+
+// rebalance moves the surplus of the instances to the reserves first, and then fills the deficits from the
+// reserves of the same exchange
+func (a *PortfolioAllocator) rebalance(t time.Time) {
+	weights := a.targetWeights()
+	a.weights = weights
+
+	equities := make([]fixedpoint.Value, len(a.instances))
+	total := a.reserveEquity(t)
+	for i, instance := range a.instances {
+		equities[i] = instanceEquity(instance, t)
+		total = total.Add(equities[i])
+	}
+
+	rebalance := PortfolioRebalance{Time: t}
+	for _, w := range weights {
+		rebalance.Weights = append(rebalance.Weights, fixedpoint.NewFromFloat(w))
+	}
+
+	threshold := total.Mul(a.config.Threshold)
+	diffs := make([]fixedpoint.Value, len(a.instances))
+	for i := range a.instances {
+		diffs[i] = total.Mul(fixedpoint.NewFromFloat(weights[i])).Sub(equities[i])
+	}
+
+	transfer := func(i int, from, to *Exchange, amount fixedpoint.Value) {
+		if err := from.Transfer(to, a.config.Currency, amount); err != nil {
+			log.WithError(err).Errorf("portfolio rebalance transfer error")
+			return
+		}
+
+		instance := a.instances[i]
+		if from == instance.exchange {
+			amount = amount.Neg()
+		}
+
+		instance.transfer(equities[i], amount)
+		rebalance.Transfers = append(rebalance.Transfers, PortfolioTransfer{Session: instance.Session.Name, Amount: amount})
+	}
+
+	for i, instance := range a.instances {
+		if diffs[i].Sign() >= 0 || diffs[i].Abs().Compare(threshold) < 0 {
+			continue
+		}
+
+		amount := fixedpoint.Min(diffs[i].Neg(), availableBalance(instance.exchange, a.config.Currency))
+		if amount.Sign() > 0 {
+			transfer(i, instance.exchange, instance.exchange.Parent(), amount)
+		}
+	}
+
+	for i, instance := range a.instances {
+		if diffs[i].Sign() <= 0 || diffs[i].Compare(threshold) < 0 {
+			continue
+		}
+
+		reserve := instance.exchange.Parent()
+		amount := fixedpoint.Min(diffs[i], availableBalance(reserve, a.config.Currency))
+		if amount.Sign() > 0 {
+			transfer(i, reserve, instance.exchange, amount)
+		}
+	}
+
+	a.rebalances = append(a.rebalances, rebalance)
+}
+
+func (a *PortfolioAllocator) reserveEquity(t time.Time) fixedpoint.Value {
+	equity := fixedpoint.Zero
+	for ex, session := range a.reserves {
+		equity = equity.Add(ex.account.Balances().Assets(session.AllLastPrices(), t).InUSD())
+	}
+	return equity
+}
+
+// Report samples the last returns and creates the report of the portfolio
+func (a *PortfolioAllocator) Report(endTime time.Time) *PortfolioReport {
+	if a.startTime.IsZero() {
+		return nil
+	}
+
+	if a.lastTime.After(a.lastSampleTime) {
+		a.sample(a.lastTime)
+	}
+
+	report := &PortfolioReport{
+		Allocation:        a.config.Allocation,
+		Currency:          a.config.Currency,
+		RebalanceInterval: a.config.RebalanceInterval,
+		ReturnInterval:    a.config.ReturnInterval,
+		StartTime:         a.startTime,
+		EndTime:           endTime,
+		InitialEquity:     a.initialEquity,
+		ReserveEquity:     a.reserveEquity(a.lastTime),
+		Rebalances:        a.rebalances,
+	}
+
+	report.FinalEquity = report.ReserveEquity
+	periods := annualPeriods(a.config.ReturnInterval)
+	for i, instance := range a.instances {
+		finalEquity := instanceEquity(instance, a.lastTime)
+		profit := finalEquity.Sub(instance.initialEquity).Sub(instance.netTransfer)
+
+		compounded := 1.0
+		for _, r := range instance.returns {
+			compounded *= 1 + r
+		}
+
+		instanceReport := PortfolioInstanceReport{
+			ID:            dynamic.CallID(instance.Strategy),
+			Session:       instance.Session.Name,
+			Strategy:      instance.Strategy.ID(),
+			Weight:        instance.Weight,
+			TargetWeight:  fixedpoint.NewFromFloat(a.weights[i]),
+			InitialEquity: instance.initialEquity,
+			FinalEquity:   finalEquity,
+			NetTransfer:   instance.netTransfer,
+			Profit:        profit,
+			Return:        fixedpoint.NewFromFloat(compounded - 1),
+			Volatility:    fixedpoint.NewFromFloat(stdev(instance.returns) * math.Sqrt(periods)),
+		}
+
+		if a.initialEquity.Sign() > 0 {
+			instanceReport.Contribution = profit.Div(a.initialEquity)
+		}
+
+		report.Instances = append(report.Instances, instanceReport)
+		report.FinalEquity = report.FinalEquity.Add(finalEquity)
+	}
+
+	report.Correlation = make([][]fixedpoint.Value, len(a.instances))
+	for i := range a.instances {
+		report.Correlation[i] = make([]fixedpoint.Value, len(a.instances))
+		for j := range a.instances {
+			report.Correlation[i][j] = fixedpoint.NewFromFloat(correlation(a.instances[i].returns, a.instances[j].returns))
+		}
+	}
+
+	return report
+}
+
+func instanceEquity(instance *PortfolioInstance, t time.Time) fixedpoint.Value {
+	return instance.exchange.account.Balances().Assets(instance.Session.AllLastPrices(), t).InUSD()
+}
+
+func availableBalance(ex *Exchange, currency string) fixedpoint.Value {
+	if b, ok := ex.account.Balance(currency); ok {
+		return b.Available
+	}
+	return fixedpoint.Zero
+}
+
+// annualPeriods returns the number of the intervals in a year
+func annualPeriods(interval types.Interval) float64 {
+	return float64(365*24*time.Hour) / float64(interval.Duration())
+}
+
+// stdev returns the sample standard deviation
+func stdev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	sum := 0.0
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+
+	return math.Sqrt(sum / float64(len(values)-1))
+}
+
+// correlation returns the pearson correlation of the two series, zero if any of them has no variance
+func correlation(x, y []float64) float64 {
+	n := len(x)
+	if len(y) < n {
+		n = len(y)
+	}
+
+	if n < 2 {
+		return 0
+	}
+
+	var meanX, meanY float64
+	for i := 0; i < n; i++ {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= float64(n)
+	meanY /= float64(n)
+
+	var cov, varX, varY float64
+	for i := 0; i < n; i++ {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+
+	if varX == 0 || varY == 0 {
+		return 0
+	}
+
+	return cov / math.Sqrt(varX*varY)
+}
@@ -0,0 +1,192 @@
+package backtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+type portfolioTestStrategy struct {
+	id string
+}
+
+func (s *portfolioTestStrategy) ID() string {
+	return "test"
+}
+
+func (s *portfolioTestStrategy) InstanceID() string {
+	return "test:" + s.id
+}
+
+func (s *portfolioTestStrategy) Run(ctx context.Context, orderExecutor bbgo.OrderExecutor, session *bbgo.ExchangeSession) error {
+	return nil
+}
+
+func usdtBalances(amount float64) types.BalanceMap {
+	return types.BalanceMap{
+		"USDT": {Currency: "USDT", Available: fixedpoint.NewFromFloat(amount)},
+	}
+}
+
+func newTestPortfolio(t *testing.T, config bbgo.BacktestPortfolio, weights ...float64) (*PortfolioAllocator, *Exchange, []*PortfolioInstance) {
+	account := &types.Account{}
+	account.UpdateBalances(usdtBalances(10000))
+
+	parent := &Exchange{
+		account:         account,
+		config:          &bbgo.Backtest{},
+		markets:         types.MarketMap{},
+		initialBalances: usdtBalances(10000),
+	}
+
+	var instances []*PortfolioInstance
+	for i, w := range weights {
+		sub, err := parent.NewSubAccount(usdtBalances(10000 * w))
+		require.NoError(t, err)
+
+		session := bbgo.NewExchangeSession(string(rune('a'+i)), sub)
+		instance, err := NewPortfolioInstance(&portfolioTestStrategy{id: session.Name}, session, fixedpoint.NewFromFloat(w))
+		require.NoError(t, err)
+		instances = append(instances, instance)
+	}
+
+	config = config.WithDefaults(len(weights))
+	require.NoError(t, config.Validate(len(weights)))
+
+	reserve := bbgo.NewExchangeSession("reserve", parent)
+	return NewPortfolioAllocator(config, instances, []*bbgo.ExchangeSession{reserve}), parent, instances
+}
+
+func TestExchange_NewSubAccount(t *testing.T) {
+	_, parent, instances := newTestPortfolio(t, bbgo.BacktestPortfolio{}, 0.5, 0.3)
+
+	assert.Equal(t, "2000", availableBalance(parent, "USDT").String())
+	assert.Equal(t, "2000", parent.InitialBalances()["USDT"].Available.String())
+	assert.Equal(t, "5000", availableBalance(instances[0].exchange, "USDT").String())
+	assert.Equal(t, "3000", instances[1].exchange.InitialBalances()["USDT"].Available.String())
+	assert.Len(t, parent.SubAccounts(), 2)
+
+	_, err := parent.NewSubAccount(usdtBalances(3000))
+	assert.Error(t, err, "insufficient balance")
+
+	_, err = instances[0].exchange.NewSubAccount(usdtBalances(1))
+	assert.Error(t, err, "nested sub-account")
+
+	assert.NoError(t, parent.Transfer(instances[1].exchange, "USDT", fixedpoint.NewFromFloat(500)))
+	assert.Equal(t, "1500", availableBalance(parent, "USDT").String())
+	assert.Equal(t, "3500", availableBalance(instances[1].exchange, "USDT").String())
+	assert.Error(t, parent.Transfer(instances[1].exchange, "USDT", fixedpoint.NewFromFloat(2000)))
+}
+
+func TestPortfolioAllocator_FixedRebalance(t *testing.T) {
+	allocator, parent, instances := newTestPortfolio(t, bbgo.BacktestPortfolio{
+		RebalanceInterval: types.Interval1w,
+	}, 0.5, 0.5)
+
+	startTime := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	allocator.Update(startTime)
+
+	// the first instance earns 1000 and the second instance loses 1000 in the first day
+	instances[0].exchange.account.AddBalance("USDT", fixedpoint.NewFromFloat(1000))
+	instances[1].exchange.account.AddBalance("USDT", fixedpoint.NewFromFloat(-1000))
+	for d := 1; d <= 7; d++ {
+		allocator.Update(startTime.Add(time.Duration(d) * 24 * time.Hour))
+	}
+
+	// rebalanced back to the equal weights
+	assert.Equal(t, "5000", availableBalance(instances[0].exchange, "USDT").String())
+	assert.Equal(t, "5000", availableBalance(instances[1].exchange, "USDT").String())
+	assert.Equal(t, "0", availableBalance(parent, "USDT").String())
+
+	// the same time is ignored
+	allocator.Update(startTime.Add(7 * 24 * time.Hour))
+
+	instances[0].exchange.account.AddBalance("USDT", fixedpoint.NewFromFloat(500))
+	instances[1].exchange.account.AddBalance("USDT", fixedpoint.NewFromFloat(-500))
+	allocator.Update(startTime.Add(8 * 24 * time.Hour))
+
+	report := allocator.Report(startTime.Add(8 * 24 * time.Hour))
+	require.NotNil(t, report)
+	require.Len(t, report.Instances, 2)
+	require.Len(t, report.Rebalances, 1)
+
+	assert.Equal(t, "10000", report.InitialEquity.String())
+	assert.Equal(t, "10000", report.FinalEquity.String())
+	assert.Equal(t, "test:a", report.Instances[0].ID)
+	assert.Equal(t, "-1000", report.Instances[0].NetTransfer.String())
+	assert.Equal(t, "1500", report.Instances[0].Profit.String())
+	assert.Equal(t, "0.15", report.Instances[0].Contribution.String())
+	assert.Equal(t, "-1500", report.Instances[1].Profit.String())
+
+	// the transfers are excluded from the returns: (1 + 0.2) * (1 + 0.1) - 1
+	assert.InDelta(t, 0.32, report.Instances[0].Return.Float64(), 1e-6)
+	assert.InDelta(t, -0.28, report.Instances[1].Return.Float64(), 1e-6)
+
+	// the returns of the two instances are opposite
+	assert.InDelta(t, 1.0, report.Correlation[0][0].Float64(), 1e-6)
+	assert.InDelta(t, -1.0, report.Correlation[0][1].Float64(), 1e-6)
+}
+
+func TestPortfolioAllocator_RiskParity(t *testing.T) {
+	allocator, _, instances := newTestPortfolio(t, bbgo.BacktestPortfolio{
+		Allocation:        bbgo.BacktestAllocationRiskParity,
+		RebalanceInterval: types.Interval1w,
+	}, 0.5, 0.5)
+
+	startTime := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	allocator.Update(startTime)
+
+	// the second instance is twice as volatile as the first instance
+	for d := 1; d <= 6; d++ {
+		sign := float64(1 - 2*(d%2))
+		instances[0].exchange.account.AddBalance("USDT", fixedpoint.NewFromFloat(sign*50))
+		instances[1].exchange.account.AddBalance("USDT", fixedpoint.NewFromFloat(sign*100))
+		allocator.Update(startTime.Add(time.Duration(d) * 24 * time.Hour))
+	}
+
+	weights := allocator.targetWeights()
+	assert.InDelta(t, 2.0/3.0, weights[0], 0.01)
+	assert.InDelta(t, 1.0/3.0, weights[1], 0.01)
+	assert.InDelta(t, 1.0, weights[0]+weights[1], 1e-9)
+}
+
+func TestPortfolioAllocator_VolatilityTarget(t *testing.T) {
+	allocator, _, instances := newTestPortfolio(t, bbgo.BacktestPortfolio{
+		Allocation:       bbgo.BacktestAllocationVolatilityTarget,
+		TargetVolatility: fixedpoint.NewFromFloat(0.5),
+	}, 0.5, 0.5)
+
+	startTime := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	allocator.Update(startTime)
+
+	// only the first instance has volatile returns
+	for d := 1; d <= 6; d++ {
+		sign := float64(1 - 2*(d%2))
+		instances[0].exchange.account.AddBalance("USDT", fixedpoint.NewFromFloat(sign*250))
+		allocator.Update(startTime.Add(time.Duration(d) * 24 * time.Hour))
+	}
+
+	weights := allocator.targetWeights()
+	assert.Less(t, weights[0], 0.5)
+	assert.Equal(t, 0.5, weights[1])
+}
+
+func TestBacktestPortfolio_Validate(t *testing.T) {
+	config := bbgo.BacktestPortfolio{}.WithDefaults(4)
+	assert.NoError(t, config.Validate(4))
+	assert.Equal(t, "0.25", config.Weights[0].String())
+	assert.Error(t, config.Validate(3))
+
+	config = bbgo.BacktestPortfolio{Weights: []fixedpoint.Value{fixedpoint.NewFromFloat(0.8), fixedpoint.NewFromFloat(0.8)}}.WithDefaults(2)
+	assert.Error(t, config.Validate(2))
+
+	config = bbgo.BacktestPortfolio{Allocation: bbgo.BacktestAllocationVolatilityTarget}.WithDefaults(2)
+	assert.Error(t, config.Validate(2))
+}
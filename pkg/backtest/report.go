@@ -49,8 +49,28 @@ type SummaryReport struct {
 	TotalGrossProfit fixedpoint.Value `json:"totalGrossProfit,omitempty"`
 	TotalGrossLoss   fixedpoint.Value `json:"totalGrossLoss,omitempty"`
 
+	// the statistics of the equity curve marked to market at every kline close
+	MaxDrawdown         fixedpoint.Value `json:"maxDrawdown"`
+	MaxDrawdownDuration types.Duration   `json:"maxDrawdownDuration"`
+	AnnualizedReturn    fixedpoint.Value `json:"annualizedReturn"`
+	CalmarRatio         fixedpoint.Value `json:"calmarRatio"`
+	Exposure            fixedpoint.Value `json:"exposure"`
+
+	// Turnover is the traded quote volume divided by the average equity
+	Turnover fixedpoint.Value `json:"turnover"`
+
+	// TotalFeeInUSD is the trading fee of all the symbols, and FeeDrag is the ratio of the fee to the initial equity
+	TotalFeeInUSD fixedpoint.Value `json:"totalFeeInUSD"`
+	FeeDrag       fixedpoint.Value `json:"feeDrag"`
+
 	SymbolReports []SessionSymbolReport `json:"symbolReports,omitempty"`
 
+	// MonteCarlo is the monte carlo analysis of the trades, it's enabled by the backtest.monteCarlo config
+	MonteCarlo *MonteCarloReport `json:"monteCarlo,omitempty"`
+
+	// Portfolio is the allocation report of the strategy instances, it's enabled by the backtest.portfolio config
+	Portfolio *PortfolioReport `json:"portfolio,omitempty"`
+
 	Manifests Manifests `json:"manifests,omitempty"`
 }
 
@@ -83,6 +103,20 @@ type SessionSymbolReport struct {
 	Sortino         fixedpoint.Value          `json:"sortinoRatio"`
 	ProfitFactor    fixedpoint.Value          `json:"profitFactor"`
 	WinningRatio    fixedpoint.Value          `json:"winningRatio"`
+
+	// QuoteVolume is the traded quote volume, and Turnover is the quote volume divided by the initial equity
+	QuoteVolume fixedpoint.Value `json:"quoteVolume"`
+	Turnover    fixedpoint.Value `json:"turnover"`
+
+	// FeeDrag is the ratio of the trading fee to the initial equity
+	FeeDrag fixedpoint.Value `json:"feeDrag"`
+
+	// Exposure is the ratio of the time that the position is open
+	Exposure fixedpoint.Value `json:"exposure"`
+
+	AverageMAE fixedpoint.Value `json:"averageMAE"`
+	AverageMFE fixedpoint.Value `json:"averageMFE"`
+	Excursions []TradeExcursion `json:"excursions,omitempty"`
 }
 
 func (r *SessionSymbolReport) InitialEquityValue() fixedpoint.Value {
@@ -93,6 +127,13 @@ func (r *SessionSymbolReport) FinalEquityValue() fixedpoint.Value {
 	return InQuoteAsset(r.FinalBalances, r.Market, r.LastPrice)
 }
 
+// SetExcursions sets the excursions of the position round trips, the average MAE, MFE and the exposure
+func (r *SessionSymbolReport) SetExcursions(excursions []TradeExcursion, startTime, endTime time.Time) {
+	r.Excursions = excursions
+	r.AverageMAE, r.AverageMFE = AverageExcursions(excursions)
+	r.Exposure = ExposureRatio(excursions, startTime, endTime)
+}
+
 func (r *SessionSymbolReport) Print(wantBaseAssetBaseline bool) {
 	color.Green("%s %s PROFIT AND LOSS REPORT", r.Exchange, r.Symbol)
 	color.Green("===============================================")
@@ -133,6 +174,14 @@ func (r *SessionSymbolReport) Print(wantBaseAssetBaseline bool) {
 		color.Red("REALIZED SORTINO RATIO: %s", r.Sortino.FormatString(4))
 	}
 
+	color.Green("EXPOSURE: %s", r.Exposure.FormatPercentage(2))
+	color.Green("TURNOVER: %s (QUOTE VOLUME %s %s)", r.Turnover.FormatString(4), r.QuoteVolume.FormatString(2), r.Market.QuoteCurrency)
+	color.Green("FEE DRAG: %s", r.FeeDrag.FormatPercentage(4))
+	color.Green("AVERAGE MAE: %s %s, AVERAGE MFE: %s %s (%d POSITION ROUND TRIPS)",
+		r.AverageMAE.FormatString(4), r.Market.QuoteCurrency,
+		r.AverageMFE.FormatString(4), r.Market.QuoteCurrency,
+		len(r.Excursions))
+
 	if wantBaseAssetBaseline {
 		if r.LastPrice.Compare(r.StartPrice) > 0 {
 			color.Green("%s BASE ASSET PERFORMANCE: +%s (= (%s - %s) / %s)",
@@ -152,6 +201,48 @@ func (r *SessionSymbolReport) Print(wantBaseAssetBaseline bool) {
 	}
 }
 
+// SetEquityCurveStats sets the statistics of the equity curve, the turnover and the fee drag are calculated
+// from the symbol reports, so it should be called after the symbol reports are added
+func (r *SummaryReport) SetEquityCurveStats(stats EquityCurveStats) {
+	r.MaxDrawdown = stats.MaxDrawdown
+	r.MaxDrawdownDuration = stats.MaxDrawdownDuration
+	r.AnnualizedReturn = stats.AnnualizedReturn
+	r.CalmarRatio = stats.CalmarRatio
+	r.Exposure = stats.Exposure
+
+	quoteVolume := fixedpoint.Zero
+	r.TotalFeeInUSD = fixedpoint.Zero
+	for _, symbolReport := range r.SymbolReports {
+		quoteVolume = quoteVolume.Add(symbolReport.QuoteVolume)
+		if symbolReport.PnL != nil {
+			r.TotalFeeInUSD = r.TotalFeeInUSD.Add(symbolReport.PnL.FeeInUSD)
+		}
+	}
+
+	if stats.AverageEquity.Sign() > 0 {
+		r.Turnover = quoteVolume.Div(stats.AverageEquity)
+	}
+
+	if stats.InitialEquity.Sign() > 0 {
+		r.FeeDrag = r.TotalFeeInUSD.Div(stats.InitialEquity)
+	}
+}
+
+func (r *SummaryReport) PrintEquityCurveStats() {
+	color.Green("MAX DRAWDOWN: %s (DURATION %s)", r.MaxDrawdown.FormatPercentage(2), r.MaxDrawdownDuration.Duration().String())
+	color.Green("ANNUALIZED RETURN: %s", r.AnnualizedReturn.FormatPercentage(2))
+
+	if r.CalmarRatio.Sign() > 0 {
+		color.Green("CALMAR RATIO: %s", r.CalmarRatio.FormatString(4))
+	} else {
+		color.Red("CALMAR RATIO: %s", r.CalmarRatio.FormatString(4))
+	}
+
+	color.Green("EXPOSURE: %s", r.Exposure.FormatPercentage(2))
+	color.Green("TURNOVER: %s", r.Turnover.FormatString(4))
+	color.Green("FEE DRAG: %s (%s USD)", r.FeeDrag.FormatPercentage(4), r.TotalFeeInUSD.FormatString(2))
+}
+
 const SessionTimeFormat = "2006-01-02T15_04"
 
 // FormatSessionName returns the back-test session name
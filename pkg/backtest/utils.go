@@ -39,6 +39,17 @@ func InitializeExchangeSources(sessions map[string]*bbgo.ExchangeSession, startT
 	for _, session := range sessions {
 		backtestEx := session.Exchange.(*Exchange)
 
+		// the sub-account is fed by its parent exchange, so it has no kline channel
+		if backtestEx.Parent() != nil {
+			src := &ExchangeDataSource{
+				Exchange: backtestEx,
+				Session:  session,
+			}
+			backtestEx.Src = src
+			exchangeSources = append(exchangeSources, src)
+			continue
+		}
+
 		c, err := backtestEx.SubscribeMarketData(startTime, endTime, requiredInterval, extraIntervals...)
 		if err != nil {
 			return exchangeSources, err
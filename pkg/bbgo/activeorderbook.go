@@ -35,6 +35,10 @@ type ActiveOrderBook struct {
 	mu sync.Mutex
 
 	cancelOrderWaitTime time.Duration
+
+	// useCancelAllOrdersApi cancels all the orders by the cancel-all API of the exchange,
+	// the open orders of the symbol that are not in the book are canceled as well.
+	useCancelAllOrdersApi bool
 }
 
 func NewActiveOrderBook(symbol string) *ActiveOrderBook {
@@ -51,6 +55,10 @@ func (b *ActiveOrderBook) SetCancelOrderWaitTime(duration time.Duration) {
 	b.cancelOrderWaitTime = duration
 }
 
+func (b *ActiveOrderBook) SetUseCancelAllOrdersApi(enabled bool) {
+	b.useCancelAllOrdersApi = enabled
+}
+
 func (b *ActiveOrderBook) MarshalJSON() ([]byte, error) {
 	orders := b.Backup()
 	return json.Marshal(orders)
@@ -133,7 +141,8 @@ func (b *ActiveOrderBook) waitAllClear(ctx context.Context, waitTime, timeout ti
 func (b *ActiveOrderBook) FastCancel(ctx context.Context, ex types.Exchange, orders ...types.Order) error {
 	// if no orders are given, set to cancelAll
 	hasSymbol := b.Symbol != ""
-	if len(orders) == 0 {
+	cancelAll := len(orders) == 0
+	if cancelAll {
 		orders = b.Orders()
 	} else {
 		// simple check on given input
@@ -151,7 +160,7 @@ func (b *ActiveOrderBook) FastCancel(ctx context.Context, ex types.Exchange, ord
 
 	log.Debugf("[ActiveOrderBook] no wait cancelling %s orders...", b.Symbol)
 	// since ctx might be canceled, we should use background context here
-	if err := ex.CancelOrders(context.Background(), orders...); err != nil {
+	if _, err := b.cancelOrders(context.Background(), ex, cancelAll, orders...); err != nil {
 		log.WithError(err).Errorf("[ActiveOrderBook] no wait can not cancel %s orders", b.Symbol)
 	}
 
@@ -161,6 +170,16 @@ func (b *ActiveOrderBook) FastCancel(ctx context.Context, ex types.Exchange, ord
 	return nil
 }
 
+// cancelOrders cancels all the orders of the book by the cancel-all API of the exchange if it's enabled,
+// otherwise, the orders are canceled by the batch API
+func (b *ActiveOrderBook) cancelOrders(ctx context.Context, ex types.Exchange, cancelAll bool, orders ...types.Order) ([]types.Order, error) {
+	if cancelAll && b.useCancelAllOrdersApi && b.Symbol != "" {
+		return CancelAllOrdersOfSymbol(ctx, ex, b.Symbol, orders...)
+	}
+
+	return BatchCancelOrder(ctx, ex, orders...)
+}
+
 // GracefulCancel cancels the active orders gracefully
 func (b *ActiveOrderBook) GracefulCancel(ctx context.Context, ex types.Exchange, specifiedOrders ...types.Order) error {
 	cancelAll := false
@@ -198,8 +217,8 @@ func (b *ActiveOrderBook) GracefulCancel(ctx context.Context, ex types.Exchange,
 		// time.Sleep(SentOrderWaitTime)
 
 		// since ctx might be canceled, we should use background context here
-		if err := ex.CancelOrders(context.Background(), orders...); err != nil {
-			log.WithError(err).Warnf("[ActiveOrderBook] can not cancel %s orders", b.Symbol)
+		if failedOrders, err := b.cancelOrders(context.Background(), ex, cancelAll, orders...); err != nil {
+			log.WithError(err).Warnf("[ActiveOrderBook] can not cancel %d/%d %s orders", len(failedOrders), len(orders), b.Symbol)
 		}
 
 		log.Debugf("[ActiveOrderBook] waiting %s for %s orders to be cancelled...", waitTime, b.Symbol)
@@ -426,11 +445,34 @@ func (b *ActiveOrderBook) add(order types.Order) {
 		if order.Status != types.OrderStatusNew {
 			// emit the order update handle function to trigger callback
 			b.Update(order)
+		} else {
+			b.EmitNew(order)
 		}
 
 	} else {
 		b.orders.Add(order)
+		b.EmitNew(order)
+	}
+}
+
+// Replace replaces the old order with the new order, it's used for tracking the order ID change of the amended order.
+// If the order ID is not changed, the existing order is updated.
+func (b *ActiveOrderBook) Replace(oldOrder, newOrder types.Order) {
+	b.mu.Lock()
+	if oldOrder.OrderID == newOrder.OrderID {
+		b.orders.Update(newOrder)
+		b.mu.Unlock()
+		b.C.Emit()
+		return
 	}
+
+	b.orders.Remove(oldOrder.OrderID)
+	b.mu.Unlock()
+
+	log.Debugf("[ActiveOrderBook] order #%d is replaced by order #%d", oldOrder.OrderID, newOrder.OrderID)
+
+	b.add(newOrder)
+	b.C.Emit()
 }
 
 func (b *ActiveOrderBook) Exists(order types.Order) bool {
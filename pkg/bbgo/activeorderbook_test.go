@@ -99,3 +99,40 @@ func Test_isNewerUpdateTime(t *testing.T) {
 	ret := isNewerOrderUpdateTime(a, b)
 	assert.True(t, ret)
 }
+
+func TestActiveOrderBook_Replace(t *testing.T) {
+	ob := NewActiveOrderBook("BTCUSDT")
+
+	order1 := types.Order{
+		OrderID: 1,
+		SubmitOrder: types.SubmitOrder{
+			Symbol:   "BTCUSDT",
+			Side:     types.SideTypeBuy,
+			Type:     types.OrderTypeLimit,
+			Quantity: Number("0.01"),
+			Price:    Number(19000.0),
+		},
+		Status: types.OrderStatusNew,
+	}
+	ob.Add(order1)
+
+	// the order ID is not changed
+	order1b := order1
+	order1b.Price = Number(19100.0)
+	ob.Replace(order1, order1b)
+	if o, ok := ob.Get(1); assert.True(t, ok) {
+		assert.Equal(t, Number(19100.0), o.Price)
+	}
+
+	// the order is replaced with a new order ID
+	order2 := order1b
+	order2.OrderID = 2
+	order2.Price = Number(19200.0)
+	ob.Replace(order1b, order2)
+
+	assert.Equal(t, 1, ob.NumOfOrders())
+	assert.False(t, ob.Exists(order1))
+	if o, ok := ob.Get(2); assert.True(t, ok) {
+		assert.Equal(t, Number(19200.0), o.Price)
+	}
+}
@@ -18,6 +18,10 @@ func BootstrapEnvironmentLightweight(ctx context.Context, environ *Environment,
 		environ.SetLogging(userConfig.Logging)
 	}
 
+	if userConfig.StrategyNAV != nil {
+		environ.ConfigureStrategyNAV(userConfig.StrategyNAV)
+	}
+
 	if userConfig.Persistence != nil {
 		if err := ConfigurePersistence(ctx, environ, userConfig.Persistence); err != nil {
 			return errors.Wrap(err, "persistence configure error")
@@ -46,6 +50,10 @@ func BootstrapEnvironment(ctx context.Context, environ *Environment, userConfig
 		environ.SetLogging(userConfig.Logging)
 	}
 
+	if userConfig.StrategyNAV != nil {
+		environ.ConfigureStrategyNAV(userConfig.StrategyNAV)
+	}
+
 	if userConfig.Persistence != nil {
 		if err := ConfigurePersistence(ctx, environ, userConfig.Persistence); err != nil {
 			return errors.Wrap(err, "persistence configure error")
@@ -78,6 +78,38 @@ type TelegramNotification struct {
 	Broadcast bool `json:"broadcast" yaml:"broadcast"`
 }
 
+type DiscordNotification struct {
+	// WebhookURL is the default webhook url, it can also be set by the env var DISCORD_WEBHOOK_URL
+	WebhookURL string `json:"webhookUrl,omitempty" yaml:"webhookUrl,omitempty"`
+	Username   string `json:"username,omitempty" yaml:"username,omitempty"`
+
+	// Channels maps the routed channel names to the webhook urls
+	Channels map[string]string `json:"channels,omitempty" yaml:"channels,omitempty"`
+}
+
+type EmailNotification struct {
+	Host string `json:"host" yaml:"host"`
+	Port int    `json:"port" yaml:"port"`
+
+	// Username is the smtp username, the password is set by the env var SMTP_PASSWORD
+	Username string `json:"username,omitempty" yaml:"username,omitempty"`
+
+	From          string   `json:"from" yaml:"from"`
+	To            []string `json:"to" yaml:"to"`
+	SubjectPrefix string   `json:"subjectPrefix,omitempty" yaml:"subjectPrefix,omitempty"`
+
+	// Channels maps the routed channel names to the recipients
+	Channels map[string][]string `json:"channels,omitempty" yaml:"channels,omitempty"`
+}
+
+type WebhookNotification struct {
+	URL     string            `json:"url" yaml:"url"`
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+
+	// Channels maps the routed channel names to the webhook urls
+	Channels map[string]string `json:"channels,omitempty" yaml:"channels,omitempty"`
+}
+
 type NotificationSwitches struct {
 	Trade       bool `json:"trade" yaml:"trade"`
 	Position    bool `json:"position" yaml:"position"`
@@ -85,10 +117,28 @@ type NotificationSwitches struct {
 	SubmitOrder bool `json:"submitOrder" yaml:"submitOrder"`
 }
 
+// NotificationThrottleConfig configures the throttle of the notifications, the warning and the error notifications
+// bypass the rate limit and the digest.
+type NotificationThrottleConfig struct {
+	// Interval and Burst are the rate limit of each channel, e.g., interval 3s with burst 5
+	Interval types.Duration `json:"interval,omitempty" yaml:"interval,omitempty"`
+	Burst    int            `json:"burst,omitempty" yaml:"burst,omitempty"`
+
+	// DedupWindow drops the identical notifications sent to the same channel within the window
+	DedupWindow types.Duration `json:"dedupWindow,omitempty" yaml:"dedupWindow,omitempty"`
+
+	// DigestInterval batches the low priority notifications, e.g., trades and profits, into a digest sent every interval
+	DigestInterval types.Duration `json:"digestInterval,omitempty" yaml:"digestInterval,omitempty"`
+}
+
 type NotificationConfig struct {
-	Slack    *SlackNotification    `json:"slack,omitempty" yaml:"slack,omitempty"`
-	Telegram *TelegramNotification `json:"telegram,omitempty" yaml:"telegram,omitempty"`
-	Switches *NotificationSwitches `json:"switches" yaml:"switches"`
+	Slack    *SlackNotification          `json:"slack,omitempty" yaml:"slack,omitempty"`
+	Telegram *TelegramNotification       `json:"telegram,omitempty" yaml:"telegram,omitempty"`
+	Discord  *DiscordNotification        `json:"discord,omitempty" yaml:"discord,omitempty"`
+	Email    *EmailNotification          `json:"email,omitempty" yaml:"email,omitempty"`
+	Webhook  *WebhookNotification        `json:"webhook,omitempty" yaml:"webhook,omitempty"`
+	Throttle *NotificationThrottleConfig `json:"throttle,omitempty" yaml:"throttle,omitempty"`
+	Switches *NotificationSwitches       `json:"switches" yaml:"switches"`
 }
 
 type LoggingConfig struct {
@@ -151,6 +201,167 @@ type Backtest struct {
 
 	// sync 1 second interval KLines
 	SyncSecKLines bool `json:"syncSecKLines,omitempty" yaml:"syncSecKLines,omitempty"`
+
+	// MonteCarlo runs the monte carlo analysis of the back-test trades and adds the result to the summary report
+	MonteCarlo *BacktestMonteCarlo `json:"monteCarlo,omitempty" yaml:"monteCarlo,omitempty"`
+
+	// Portfolio runs each strategy instance with its own sub-account, and allocates the capital between them
+	Portfolio *BacktestPortfolio `json:"portfolio,omitempty" yaml:"portfolio,omitempty"`
+}
+
+// BacktestMonteCarlo is the config of the monte carlo analysis, the realized profits of the back-test trades are
+// shuffled, resampled and perturbed by the random slippage and fee to estimate the confidence intervals
+type BacktestMonteCarlo struct {
+	// Iterations is the number of the simulations of each method, default 1000
+	Iterations int `json:"iterations,omitempty" yaml:"iterations,omitempty"`
+
+	// Seed is the seed of the random source, the current time is used if it's zero
+	Seed int64 `json:"seed,omitempty" yaml:"seed,omitempty"`
+
+	// Slippage is the max ratio of the random slippage applied to the traded amount, e.g. 0.001
+	Slippage fixedpoint.Value `json:"slippage,omitempty" yaml:"slippage,omitempty"`
+
+	// FeeRate is the max ratio of the random extra fee applied to the traded amount, e.g. 0.0005
+	FeeRate fixedpoint.Value `json:"feeRate,omitempty" yaml:"feeRate,omitempty"`
+
+	// RuinThreshold is the ratio of the initial equity lost that is counted as ruin, default 0.5
+	RuinThreshold fixedpoint.Value `json:"ruinThreshold,omitempty" yaml:"ruinThreshold,omitempty"`
+
+	// ConfidenceLevel is the confidence level of the intervals, default 0.95
+	ConfidenceLevel fixedpoint.Value `json:"confidenceLevel,omitempty" yaml:"confidenceLevel,omitempty"`
+}
+
+// WithDefaults returns the copy of the config with the default values filled
+func (c BacktestMonteCarlo) WithDefaults() BacktestMonteCarlo {
+	if c.Iterations <= 0 {
+		c.Iterations = 1000
+	}
+
+	if c.RuinThreshold.IsZero() {
+		c.RuinThreshold = fixedpoint.NewFromFloat(0.5)
+	}
+
+	if c.ConfidenceLevel.IsZero() {
+		c.ConfidenceLevel = fixedpoint.NewFromFloat(0.95)
+	}
+
+	return c
+}
+
+type BacktestAllocation string
+
+const (
+	// BacktestAllocationFixed allocates the capital by the configured weights
+	BacktestAllocationFixed BacktestAllocation = "fixed"
+
+	// BacktestAllocationRiskParity allocates the capital inversely proportional to the volatility of the instance returns
+	BacktestAllocationRiskParity BacktestAllocation = "riskParity"
+
+	// BacktestAllocationVolatilityTarget scales down the weight of the instance of which the annualized volatility
+	// is higher than the target volatility, the capital not allocated stays in the reserve
+	BacktestAllocationVolatilityTarget BacktestAllocation = "volatilityTarget"
+)
+
+// BacktestPortfolio is the config of the portfolio back-test. Each strategy instance of exchangeStrategies trades
+// with its own sub-account, the balances of the session are split by the weights, and the capital is moved between
+// the sub-accounts and the reserve (the balances not allocated) at every rebalance.
+type BacktestPortfolio struct {
+	// Allocation is the allocation method, default fixed
+	Allocation BacktestAllocation `json:"allocation,omitempty" yaml:"allocation,omitempty"`
+
+	// Weights are the weights of the strategy instances in the order of exchangeStrategies, default equal weights.
+	// The capital stays in the reserve if the sum of the weights is less than 1.
+	Weights []fixedpoint.Value `json:"weights,omitempty" yaml:"weights,omitempty"`
+
+	// Currency is the currency transferred between the sub-accounts, default USDT
+	Currency string `json:"currency,omitempty" yaml:"currency,omitempty"`
+
+	// RebalanceInterval is the interval of rebalancing, e.g. 1w. The capital is not rebalanced if it's empty.
+	RebalanceInterval types.Interval `json:"rebalanceInterval,omitempty" yaml:"rebalanceInterval,omitempty"`
+
+	// ReturnInterval is the interval of the instance returns used by the volatility and the correlation, default 1d
+	ReturnInterval types.Interval `json:"returnInterval,omitempty" yaml:"returnInterval,omitempty"`
+
+	// Lookback is the number of the recent returns used to estimate the volatility, default 30
+	Lookback int `json:"lookback,omitempty" yaml:"lookback,omitempty"`
+
+	// TargetVolatility is the annualized volatility target of each instance, required by the volatilityTarget allocation
+	TargetVolatility fixedpoint.Value `json:"targetVolatility,omitempty" yaml:"targetVolatility,omitempty"`
+
+	// Threshold is the ratio of the total equity, the instance is not rebalanced if the difference to its
+	// target allocation is less than it, default 0.01
+	Threshold fixedpoint.Value `json:"threshold,omitempty" yaml:"threshold,omitempty"`
+}
+
+// WithDefaults returns the copy of the config with the default values filled
+func (c BacktestPortfolio) WithDefaults(numOfInstances int) BacktestPortfolio {
+	if c.Allocation == "" {
+		c.Allocation = BacktestAllocationFixed
+	}
+
+	if len(c.Weights) == 0 && numOfInstances > 0 {
+		w := fixedpoint.One.Div(fixedpoint.NewFromInt(int64(numOfInstances)))
+		for i := 0; i < numOfInstances; i++ {
+			c.Weights = append(c.Weights, w)
+		}
+	}
+
+	if c.Currency == "" {
+		c.Currency = "USDT"
+	}
+
+	if c.ReturnInterval == "" {
+		c.ReturnInterval = types.Interval1d
+	}
+
+	if c.Lookback <= 0 {
+		c.Lookback = 30
+	}
+
+	if c.Threshold.IsZero() {
+		c.Threshold = fixedpoint.NewFromFloat(0.01)
+	}
+
+	return c
+}
+
+// Validate validates the config with the defaults filled
+func (c BacktestPortfolio) Validate(numOfInstances int) error {
+	switch c.Allocation {
+	case BacktestAllocationFixed, BacktestAllocationRiskParity:
+	case BacktestAllocationVolatilityTarget:
+		if c.TargetVolatility.Sign() <= 0 {
+			return fmt.Errorf("portfolio: targetVolatility is required by the %s allocation", c.Allocation)
+		}
+	default:
+		return fmt.Errorf("portfolio: unsupported allocation %q", c.Allocation)
+	}
+
+	if len(c.Weights) != numOfInstances {
+		return fmt.Errorf("portfolio: %d weights are given, but there are %d strategy instances", len(c.Weights), numOfInstances)
+	}
+
+	total := fixedpoint.Zero
+	for _, w := range c.Weights {
+		if w.Sign() < 0 {
+			return fmt.Errorf("portfolio: weight can not be negative, %s given", w.String())
+		}
+		total = total.Add(w)
+	}
+
+	if total.Compare(fixedpoint.One) > 0 {
+		return fmt.Errorf("portfolio: the sum of the weights %s is greater than 1", total.String())
+	}
+
+	if _, ok := types.SupportedIntervals[c.RebalanceInterval]; c.RebalanceInterval != "" && !ok {
+		return fmt.Errorf("portfolio: unsupported rebalanceInterval %s", c.RebalanceInterval)
+	}
+
+	if _, ok := types.SupportedIntervals[c.ReturnInterval]; !ok {
+		return fmt.Errorf("portfolio: unsupported returnInterval %s", c.ReturnInterval)
+	}
+
+	return nil
 }
 
 func (b *Backtest) GetAccount(n string) BacktestAccount {
@@ -366,6 +577,8 @@ type Config struct {
 
 	Logging *LoggingConfig `json:"logging,omitempty"`
 
+	StrategyNAV *StrategyNAVConfig `json:"strategyNAV,omitempty" yaml:"strategyNAV,omitempty"`
+
 	ExchangeStrategies      []ExchangeStrategyMount `json:"-" yaml:"-"`
 	CrossExchangeStrategies []CrossExchangeStrategy `json:"-" yaml:"-"`
 
@@ -0,0 +1,213 @@
+package bbgo
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// defaultConsolidatedBookDepth is the depth copied from each venue book when the book is consolidated
+const defaultConsolidatedBookDepth = 50
+
+// ConsolidatedPriceLevel is a price level of a venue in the consolidated order book
+type ConsolidatedPriceLevel struct {
+	// Session is the session name of the venue
+	Session string
+
+	// Symbol is the symbol of the venue, e.g., BTCUSDT or BTCUSDC
+	Symbol string
+
+	// Price is the price of the venue in the venue quote currency
+	Price fixedpoint.Value
+
+	// Volume is the base volume of the price level
+	Volume fixedpoint.Value
+
+	// NormalizedPrice is the price converted to the quote currency of the consolidated book
+	NormalizedPrice fixedpoint.Value
+
+	// EffectivePrice is the normalized price after the taker fee of the venue, the effective price of the asks
+	// is the cost to buy one base unit, and the effective price of the bids is the proceeds of selling one base unit.
+	EffectivePrice fixedpoint.Value
+}
+
+// VenueOrderBook is the order book of a venue, both types.StreamOrderBook and types.SliceOrderBook satisfy it
+type VenueOrderBook interface {
+	CopyDepth(depth int) types.OrderBook
+}
+
+// consolidatedBookVenue is a venue order book of the consolidated order book
+type consolidatedBookVenue struct {
+	session      *ExchangeSession
+	sessionName  string
+	market       types.Market
+	book         VenueOrderBook
+	takerFeeRate fixedpoint.Value
+}
+
+// ConsolidatedOrderBook merges the order books of the same asset pair across the sessions.
+// The prices of the venues are normalized to the quote currency of the consolidated book through the
+// conversion rates, e.g., USDC -> USDT, and adjusted by the taker fee rate of each venue.
+type ConsolidatedOrderBook struct {
+	BaseCurrency  string
+	QuoteCurrency string
+
+	// Depth is the depth copied from each venue book, default is 50
+	Depth int
+
+	mu              sync.Mutex
+	venues          []*consolidatedBookVenue
+	conversionRates map[string]fixedpoint.Value
+}
+
+func NewConsolidatedOrderBook(baseCurrency, quoteCurrency string) *ConsolidatedOrderBook {
+	return &ConsolidatedOrderBook{
+		BaseCurrency:  baseCurrency,
+		QuoteCurrency: quoteCurrency,
+		Depth:         defaultConsolidatedBookDepth,
+		conversionRates: map[string]fixedpoint.Value{
+			quoteCurrency: fixedpoint.One,
+		},
+	}
+}
+
+// SetConversionRate sets the rate that converts one unit of the given quote currency to the quote currency
+// of the consolidated book, e.g., SetConversionRate("TWD", 1/30.0) for a USDT consolidated book.
+func (b *ConsolidatedOrderBook) SetConversionRate(currency string, rate fixedpoint.Value) {
+	b.mu.Lock()
+	b.conversionRates[currency] = rate
+	b.mu.Unlock()
+}
+
+func (b *ConsolidatedOrderBook) ConversionRate(currency string) (fixedpoint.Value, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	rate, ok := b.conversionRates[currency]
+	return rate, ok
+}
+
+// AddSession adds the stream order book of the given symbol in the session, the book channel of the symbol
+// should be subscribed by the session. The taker fee rate of the session is used for the effective prices.
+func (b *ConsolidatedOrderBook) AddSession(session *ExchangeSession, symbol string) error {
+	book, ok := session.OrderBook(symbol)
+	if !ok {
+		return fmt.Errorf("order book %s is not found in session %s, please subscribe the book channel", symbol, session.Name)
+	}
+
+	return b.AddSessionBook(session, symbol, book)
+}
+
+// AddSessionBook adds the given order book of the symbol, the market, the taker fee rate and the balances
+// are from the session. It's used when the order book is not maintained by the session.
+func (b *ConsolidatedOrderBook) AddSessionBook(session *ExchangeSession, symbol string, book VenueOrderBook) error {
+	market, ok := session.Market(symbol)
+	if !ok {
+		return fmt.Errorf("market %s is not found in session %s", symbol, session.Name)
+	}
+
+	return b.addVenue(&consolidatedBookVenue{
+		session:      session,
+		sessionName:  session.Name,
+		market:       market,
+		book:         book,
+		takerFeeRate: session.TakerFeeRate,
+	})
+}
+
+// AddBook adds an order book of the venue without the session
+func (b *ConsolidatedOrderBook) AddBook(sessionName string, market types.Market, book VenueOrderBook, takerFeeRate fixedpoint.Value) error {
+	return b.addVenue(&consolidatedBookVenue{
+		sessionName:  sessionName,
+		market:       market,
+		book:         book,
+		takerFeeRate: takerFeeRate,
+	})
+}
+
+func (b *ConsolidatedOrderBook) addVenue(venue *consolidatedBookVenue) error {
+	if venue.market.BaseCurrency != b.BaseCurrency {
+		return fmt.Errorf("market %s base currency %s does not match the consolidated book base currency %s",
+			venue.market.Symbol, venue.market.BaseCurrency, b.BaseCurrency)
+	}
+
+	if _, ok := b.ConversionRate(venue.market.QuoteCurrency); !ok {
+		return fmt.Errorf("conversion rate of %s to %s is not defined", venue.market.QuoteCurrency, b.QuoteCurrency)
+	}
+
+	b.mu.Lock()
+	b.venues = append(b.venues, venue)
+	b.mu.Unlock()
+	return nil
+}
+
+// SideBook returns the merged price levels of the given side sorted by the effective price,
+// the asks are sorted from the lowest cost, and the bids are sorted from the highest proceeds.
+func (b *ConsolidatedOrderBook) SideBook(side types.SideType) []ConsolidatedPriceLevel {
+	b.mu.Lock()
+	venues := b.venues
+	depth := b.Depth
+	b.mu.Unlock()
+
+	var levels []ConsolidatedPriceLevel
+	for _, venue := range venues {
+		rate, _ := b.ConversionRate(venue.market.QuoteCurrency)
+
+		for _, pv := range venue.book.CopyDepth(depth).SideBook(side) {
+			normalizedPrice := pv.Price.Mul(rate)
+
+			var effectivePrice fixedpoint.Value
+			if side == types.SideTypeSell {
+				effectivePrice = normalizedPrice.Mul(fixedpoint.One.Add(venue.takerFeeRate))
+			} else {
+				effectivePrice = normalizedPrice.Mul(fixedpoint.One.Sub(venue.takerFeeRate))
+			}
+
+			levels = append(levels, ConsolidatedPriceLevel{
+				Session:         venue.sessionName,
+				Symbol:          venue.market.Symbol,
+				Price:           pv.Price,
+				Volume:          pv.Volume,
+				NormalizedPrice: normalizedPrice,
+				EffectivePrice:  effectivePrice,
+			})
+		}
+	}
+
+	sort.SliceStable(levels, func(i, j int) bool {
+		if side == types.SideTypeSell {
+			return levels[i].EffectivePrice.Compare(levels[j].EffectivePrice) < 0
+		}
+
+		return levels[i].EffectivePrice.Compare(levels[j].EffectivePrice) > 0
+	})
+
+	return levels
+}
+
+// BestBidAndAsk returns the best effective bid and ask across the venues
+func (b *ConsolidatedOrderBook) BestBidAndAsk() (bid, ask ConsolidatedPriceLevel, ok bool) {
+	bids := b.SideBook(types.SideTypeBuy)
+	asks := b.SideBook(types.SideTypeSell)
+	if len(bids) == 0 || len(asks) == 0 {
+		return bid, ask, false
+	}
+
+	return bids[0], asks[0], true
+}
+
+func (b *ConsolidatedOrderBook) venue(sessionName, symbol string) (*consolidatedBookVenue, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, venue := range b.venues {
+		if venue.sessionName == sessionName && venue.market.Symbol == symbol {
+			return venue, true
+		}
+	}
+
+	return nil, false
+}
@@ -23,8 +23,11 @@ import (
 	"github.com/c9s/bbgo/pkg/exchange"
 	"github.com/c9s/bbgo/pkg/fixedpoint"
 	"github.com/c9s/bbgo/pkg/interact"
+	"github.com/c9s/bbgo/pkg/notifier/discordnotifier"
+	"github.com/c9s/bbgo/pkg/notifier/emailnotifier"
 	"github.com/c9s/bbgo/pkg/notifier/slacknotifier"
 	"github.com/c9s/bbgo/pkg/notifier/telegramnotifier"
+	"github.com/c9s/bbgo/pkg/notifier/webhooknotifier"
 	"github.com/c9s/bbgo/pkg/service"
 	googleservice "github.com/c9s/bbgo/pkg/service/google"
 	"github.com/c9s/bbgo/pkg/slack/slacklog"
@@ -94,6 +97,12 @@ type Environment struct {
 	DepositService    *service.DepositService
 	PersistentService *service.PersistenceServiceFacade
 
+	StrategyInstanceNAVService *service.StrategyInstanceNAVService
+	StrategyInstanceNAVTracker *StrategyInstanceNAVTracker
+
+	// StrategyEventHub broadcasts the strategy events, the events are not published if it's nil
+	StrategyEventHub *StrategyEventHub
+
 	// external services
 	GoogleSpreadSheetService *googleservice.SpreadSheetService
 
@@ -205,6 +214,7 @@ func (environ *Environment) ConfigureDatabaseDriver(ctx context.Context, driver
 	environ.MarginService = &service.MarginService{DB: db}
 	environ.WithdrawService = &service.WithdrawService{DB: db}
 	environ.DepositService = &service.DepositService{DB: db}
+	environ.StrategyInstanceNAVService = &service.StrategyInstanceNAVService{DB: db}
 	environ.SyncService = &service.SyncService{
 		TradeService:    environ.TradeService,
 		OrderService:    environ.OrderService,
@@ -237,6 +247,14 @@ func (environ *Environment) ConfigureService(ctx context.Context, srvConfig *Ser
 	return nil
 }
 
+// ConfigureStrategyNAV tracks the net asset value of the strategy instances of the configured sessions
+func (environ *Environment) ConfigureStrategyNAV(config *StrategyNAVConfig) {
+	environ.StrategyInstanceNAVTracker = NewStrategyInstanceNAVTracker(environ.StrategyInstanceNAVService, config.Interval)
+	for _, session := range environ.sessions {
+		environ.StrategyInstanceNAVTracker.BindSession(session)
+	}
+}
+
 func (environ *Environment) ConfigureExchangeSessions(userConfig *Config) error {
 	// if sessions are not defined, we detect the sessions automatically
 	if len(userConfig.Sessions) == 0 {
@@ -666,6 +684,22 @@ func (environ *Environment) ConfigureNotificationSystem(ctx context.Context, use
 		}
 	}
 
+	if conf := userConfig.Notifications.Discord; conf != nil {
+		environ.setupDiscord(conf)
+	}
+
+	if conf := userConfig.Notifications.Email; conf != nil {
+		environ.setupEmail(conf)
+	}
+
+	if conf := userConfig.Notifications.Webhook; conf != nil {
+		environ.setupWebhook(conf)
+	}
+
+	if conf := userConfig.Notifications.Throttle; conf != nil {
+		environ.setupNotificationThrottle(ctx, conf)
+	}
+
 	if userConfig.Notifications != nil {
 		if err := environ.ConfigureNotification(userConfig.Notifications); err != nil {
 			return err
@@ -873,6 +907,82 @@ func (environ *Environment) setupSlack(userConfig *Config, slackToken string, pe
 	interact.AddMessenger(messenger)
 }
 
+func (environ *Environment) setupNotificationThrottle(ctx context.Context, conf *NotificationThrottleConfig) {
+	log.Debugf("setting up notification throttle: %+v", conf)
+	Notification.SetThrottle(NewNotificationThrottle(*conf))
+
+	if conf.DigestInterval > 0 {
+		go Notification.RunDigest(ctx, conf.DigestInterval.Duration())
+
+		OnShutdown(ctx, func(ctx context.Context, wg *sync.WaitGroup) {
+			defer wg.Done()
+			Notification.FlushDigests()
+		})
+	}
+}
+
+func (environ *Environment) setupDiscord(conf *DiscordNotification) {
+	webhookURL := conf.WebhookURL
+	if len(webhookURL) == 0 {
+		webhookURL = viper.GetString("discord-webhook-url")
+	}
+
+	if len(webhookURL) == 0 && len(conf.Channels) == 0 {
+		log.Error("discord webhook url is not defined, please set DISCORD_WEBHOOK_URL or the webhookUrl option")
+		return
+	}
+
+	var opts = []discordnotifier.Option{
+		discordnotifier.WithChannels(conf.Channels),
+	}
+
+	if len(conf.Username) > 0 {
+		opts = append(opts, discordnotifier.WithUsername(conf.Username))
+	}
+
+	log.Debugf("adding discord notifier")
+	Notification.AddNotifier(discordnotifier.New(webhookURL, opts...))
+}
+
+func (environ *Environment) setupEmail(conf *EmailNotification) {
+	if len(conf.Host) == 0 || len(conf.From) == 0 {
+		log.Error("email notification requires the smtp host and the from address")
+		return
+	}
+
+	port := conf.Port
+	if port == 0 {
+		port = 587
+	}
+
+	var opts = []emailnotifier.Option{
+		emailnotifier.WithChannels(conf.Channels),
+	}
+
+	if len(conf.Username) > 0 {
+		opts = append(opts, emailnotifier.WithAuth(conf.Username, viper.GetString("smtp-password")))
+	}
+
+	if len(conf.SubjectPrefix) > 0 {
+		opts = append(opts, emailnotifier.WithSubjectPrefix(conf.SubjectPrefix))
+	}
+
+	log.Debugf("adding email notifier with smtp server %s:%d", conf.Host, port)
+	Notification.AddNotifier(emailnotifier.New(conf.Host, port, conf.From, conf.To, opts...))
+}
+
+func (environ *Environment) setupWebhook(conf *WebhookNotification) {
+	if len(conf.URL) == 0 && len(conf.Channels) == 0 {
+		log.Error("webhook notification url is not defined")
+		return
+	}
+
+	log.Debugf("adding webhook notifier")
+	Notification.AddNotifier(webhooknotifier.New(conf.URL,
+		webhooknotifier.WithChannels(conf.Channels),
+		webhooknotifier.WithHeaders(conf.Headers)))
+}
+
 func (environ *Environment) setupTelegram(
 	userConfig *Config, telegramBotToken string, persistence service.PersistenceService,
 ) error {
@@ -43,6 +43,7 @@ type ExitMethod struct {
 	RoiTakeProfit          *RoiTakeProfit          `json:"roiTakeProfit"`
 	TrailingStop           *TrailingStop2          `json:"trailingStop"`
 	HigherHighLowerLowStop *HigherHighLowerLowStop `json:"higherHighLowerLowStopLoss"`
+	StagedExit             *StagedExit             `json:"stagedExit"`
 
 	// Exit methods for short positions
 	// =================================================
@@ -93,6 +94,11 @@ func (e ExitMethod) String() string {
 		buf.WriteString("hhllStop: " + string(b) + ", ")
 	}
 
+	if e.StagedExit != nil {
+		b, _ := json.Marshal(e.StagedExit)
+		buf.WriteString("stagedExit: " + string(b) + ", ")
+	}
+
 	return buf.String()
 }
 
@@ -154,4 +160,8 @@ func (m *ExitMethod) Bind(session *ExchangeSession, orderExecutor *GeneralOrderE
 	if m.HigherHighLowerLowStop != nil {
 		m.HigherHighLowerLowStop.Bind(session, orderExecutor)
 	}
+
+	if m.StagedExit != nil {
+		m.StagedExit.Bind(session, orderExecutor)
+	}
 }
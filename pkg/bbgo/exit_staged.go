@@ -0,0 +1,335 @@
+package bbgo
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/service"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+const stagedExitTag = "stagedExit"
+
+// TakeProfitStage is one stage of the staged exit
+type TakeProfitStage struct {
+	// ROI is the profit ratio to trigger this stage, e.g. 2%
+	ROI fixedpoint.Value `json:"roi"`
+
+	// ClosePosition is the percentage of the current position to be closed, e.g. 50%
+	ClosePosition fixedpoint.Value `json:"closePosition"`
+
+	// BreakEven moves the stop price to the average cost after this stage is triggered
+	BreakEven bool `json:"breakEven"`
+
+	// TrailingStop activates the trailing stop after this stage is triggered
+	TrailingStop bool `json:"trailingStop"`
+}
+
+// StagedTrailingStop trails the remaining position after it's activated
+type StagedTrailingStop struct {
+	// ActivationRatio activates the trailing stop when the ROI reaches this ratio,
+	// the trailing stop can also be activated by the take profit stage.
+	ActivationRatio fixedpoint.Value `json:"activationRatio,omitempty"`
+
+	// CallbackRate is the callback rate from the highest price (or the lowest price for short position)
+	CallbackRate fixedpoint.Value `json:"callbackRate"`
+}
+
+// StagedExitState is the persisted state of the staged exit,
+// it's reset when the position is closed.
+type StagedExitState struct {
+	// Side is the side of the position, buy for long position and sell for short position
+	Side types.SideType `json:"side,omitempty"`
+
+	// Stage is the number of the take profit stages that are already triggered
+	Stage int `json:"stage"`
+
+	// StopPrice is the current stop price, it's set by the ATR stop or moved by the break-even stage
+	StopPrice fixedpoint.Value `json:"stopPrice"`
+
+	// TrailingStopActivated is true when the trailing stop is activated
+	TrailingStopActivated bool `json:"trailingStopActivated"`
+
+	// ExtremePrice is the highest price (long) or the lowest price (short) since the trailing stop is activated
+	ExtremePrice fixedpoint.Value `json:"extremePrice"`
+
+	// Bars is the number of the closed klines since the position is opened
+	Bars int `json:"bars"`
+}
+
+func (st StagedExitState) IsOpen() bool {
+	return st.Side != ""
+}
+
+// StagedExit coordinates the take profit stages, the stop loss, the trailing stop and the time-based exit
+// of one position. For example,
+//
+//	stage 1: close 50% at +2% and move the stop to the break-even price
+//	stage 2: activate the trailing stop for the remaining position
+//
+// Unlike the other exit methods, the stages are triggered in order and only once for each position.
+// The state is persisted with the strategy instance ID, so that the triggered stages are not forgotten after restart.
+type StagedExit struct {
+	Symbol string `json:"symbol"`
+
+	// Interval is the kline interval for checking the price, counting the bars and calculating the ATR
+	Interval types.Interval `json:"interval"`
+
+	// TakeProfits are the take profit stages, they are triggered in order
+	TakeProfits []TakeProfitStage `json:"takeProfits"`
+
+	// StopLoss is the stop loss ROI ratio, e.g. 2% closes the position when the ROI goes below -2%
+	StopLoss fixedpoint.Value `json:"stopLoss,omitempty"`
+
+	// ATRMultiplier sets the initial stop price to average cost -/+ ATR * multiplier
+	ATRMultiplier fixedpoint.Value `json:"atrMultiplier,omitempty"`
+
+	// ATRWindow is the window of the ATR indicator
+	ATRWindow int `json:"atrWindow,omitempty"`
+
+	TrailingStop *StagedTrailingStop `json:"trailingStop,omitempty"`
+
+	// MaxHoldingBars closes the position after N closed klines of the interval
+	MaxHoldingBars int `json:"maxHoldingBars,omitempty"`
+
+	state StagedExitState
+
+	session       *ExchangeSession
+	orderExecutor *GeneralOrderExecutor
+	store         service.Store
+
+	atr interface {
+		Last(i int) float64
+	}
+
+	// closePosition is the function for closing the position, it's replaced in the tests
+	closePosition func(ctx context.Context, percentage fixedpoint.Value, tags ...string) error
+}
+
+func (s *StagedExit) Subscribe(session *ExchangeSession) {
+	if s.Interval == "" {
+		s.Interval = types.Interval1m
+	}
+
+	session.Subscribe(types.KLineChannel, s.Symbol, types.SubscribeOptions{Interval: s.Interval})
+}
+
+func (s *StagedExit) Bind(session *ExchangeSession, orderExecutor *GeneralOrderExecutor) {
+	if s.Interval == "" {
+		s.Interval = types.Interval1m
+	}
+
+	if s.ATRWindow == 0 {
+		s.ATRWindow = 14
+	}
+
+	s.session = session
+	s.orderExecutor = orderExecutor
+	s.closePosition = orderExecutor.ClosePosition
+
+	position := orderExecutor.Position()
+	s.store = orderExecutor.PersistenceService().NewStore("state", position.StrategyInstanceID, stagedExitTag, s.Symbol)
+	if err := s.store.Load(&s.state); err != nil && err != service.ErrPersistenceNotExists {
+		log.WithError(err).Errorf("[stagedExit] unable to load the state")
+	}
+
+	if s.ATRMultiplier.Sign() > 0 {
+		s.atr = session.Indicators(s.Symbol).ATR(s.Interval, s.ATRWindow)
+	}
+
+	session.MarketDataStream.OnKLineClosed(types.KLineWith(s.Symbol, s.Interval, func(kline types.KLine) {
+		if s.state.IsOpen() {
+			s.state.Bars++
+			s.saveState()
+		}
+
+		if err := s.checkPrice(kline.Close, position); err != nil {
+			log.WithError(err).Errorf("[stagedExit] check price error")
+		}
+	}))
+
+	session.MarketDataStream.OnKLine(types.KLineWith(s.Symbol, s.Interval, func(kline types.KLine) {
+		if err := s.checkPrice(kline.Close, position); err != nil {
+			log.WithError(err).Errorf("[stagedExit] check price error")
+		}
+	}))
+
+	if !IsBackTesting && enableMarketTradeStop {
+		session.MarketDataStream.OnMarketTrade(types.TradeWith(position.Symbol, func(trade types.Trade) {
+			if err := s.checkPrice(trade.Price, position); err != nil {
+				log.WithError(err).Errorf("[stagedExit] check price error")
+			}
+		}))
+	}
+}
+
+// State returns the current state of the staged exit
+func (s *StagedExit) State() StagedExitState {
+	return s.state
+}
+
+func (s *StagedExit) saveState() {
+	if s.store == nil {
+		return
+	}
+
+	if err := s.store.Save(s.state); err != nil {
+		log.WithError(err).Errorf("[stagedExit] unable to save the state")
+	}
+}
+
+// openState initializes the state for the new position
+func (s *StagedExit) openState(position *types.Position) {
+	s.state = StagedExitState{Side: types.SideTypeBuy}
+	if position.IsShort() {
+		s.state.Side = types.SideTypeSell
+	}
+
+	if s.atr != nil && s.ATRMultiplier.Sign() > 0 {
+		if atr := s.atr.Last(0); atr > 0 {
+			offset := fixedpoint.NewFromFloat(atr).Mul(s.ATRMultiplier)
+			if s.state.Side == types.SideTypeBuy {
+				s.state.StopPrice = position.AverageCost.Sub(offset)
+			} else {
+				s.state.StopPrice = position.AverageCost.Add(offset)
+			}
+		}
+	}
+
+	s.saveState()
+}
+
+// isStopped checks if the price crosses the stop price
+func (s *StagedExit) isStopped(price fixedpoint.Value) bool {
+	if s.state.StopPrice.IsZero() {
+		return false
+	}
+
+	if s.state.Side == types.SideTypeBuy {
+		return price.Compare(s.state.StopPrice) <= 0
+	}
+
+	return price.Compare(s.state.StopPrice) >= 0
+}
+
+func (s *StagedExit) exit(percentage fixedpoint.Value, reason string) error {
+	return s.closePosition(context.Background(), percentage, stagedExitTag, reason)
+}
+
+func (s *StagedExit) checkPrice(price fixedpoint.Value, position *types.Position) error {
+	if position.IsClosed() || position.IsDust(price) {
+		// reset the state when the position is closed
+		if s.state.IsOpen() {
+			s.state = StagedExitState{}
+			s.saveState()
+		}
+		return nil
+	}
+
+	if position.IsClosing() {
+		return nil
+	}
+
+	// the position is flipped or newly opened
+	if !s.state.IsOpen() ||
+		(s.state.Side == types.SideTypeBuy && position.IsShort()) ||
+		(s.state.Side == types.SideTypeSell && position.IsLong()) {
+		s.openState(position)
+	}
+
+	roi := position.ROI(price)
+
+	if s.StopLoss.Sign() > 0 && roi.Compare(s.StopLoss.Neg()) <= 0 {
+		Notify("[stagedExit] %s stop loss triggered by ROI %s/%s, price = %f", s.Symbol, roi.Percentage(), s.StopLoss.Neg().Percentage(), price.Float64())
+		return s.exit(fixedpoint.One, "stopLoss")
+	}
+
+	if s.isStopped(price) {
+		Notify("[stagedExit] %s stop price %f triggered, price = %f", s.Symbol, s.state.StopPrice.Float64(), price.Float64())
+		return s.exit(fixedpoint.One, "stopPrice")
+	}
+
+	if s.MaxHoldingBars > 0 && s.state.Bars >= s.MaxHoldingBars {
+		Notify("[stagedExit] %s time exit triggered after %d bars", s.Symbol, s.state.Bars)
+		return s.exit(fixedpoint.One, "timeExit")
+	}
+
+	// only one stage is triggered per check since the position is updated asynchronously
+	if s.state.Stage < len(s.TakeProfits) {
+		stage := s.TakeProfits[s.state.Stage]
+		if roi.Compare(stage.ROI) >= 0 {
+			Notify("[stagedExit] %s take profit stage #%d triggered by ROI %s/%s, price = %f", s.Symbol, s.state.Stage+1, roi.Percentage(), stage.ROI.Percentage(), price.Float64())
+
+			s.state.Stage++
+			if stage.BreakEven {
+				s.state.StopPrice = position.AverageCost
+			}
+
+			if stage.TrailingStop {
+				s.activateTrailingStop(price)
+			}
+
+			s.saveState()
+
+			percentage := stage.ClosePosition
+			if percentage.IsZero() {
+				return nil
+			}
+
+			return s.exit(percentage, fmt.Sprintf("takeProfit%d", s.state.Stage))
+		}
+	}
+
+	return s.checkTrailingStop(price, roi)
+}
+
+func (s *StagedExit) activateTrailingStop(price fixedpoint.Value) {
+	if s.state.TrailingStopActivated {
+		return
+	}
+
+	s.state.TrailingStopActivated = true
+	s.state.ExtremePrice = price
+}
+
+func (s *StagedExit) checkTrailingStop(price, roi fixedpoint.Value) error {
+	if s.TrailingStop == nil || s.TrailingStop.CallbackRate.IsZero() {
+		return nil
+	}
+
+	if !s.state.TrailingStopActivated {
+		if s.TrailingStop.ActivationRatio.IsZero() || roi.Compare(s.TrailingStop.ActivationRatio) < 0 {
+			return nil
+		}
+
+		s.activateTrailingStop(price)
+		s.saveState()
+	}
+
+	var change fixedpoint.Value
+	if s.state.Side == types.SideTypeBuy {
+		if price.Compare(s.state.ExtremePrice) > 0 {
+			s.state.ExtremePrice = price
+			s.saveState()
+		}
+
+		change = s.state.ExtremePrice.Sub(price).Div(s.state.ExtremePrice)
+	} else {
+		if price.Compare(s.state.ExtremePrice) < 0 {
+			s.state.ExtremePrice = price
+			s.saveState()
+		}
+
+		change = price.Sub(s.state.ExtremePrice).Div(s.state.ExtremePrice)
+	}
+
+	if change.Compare(s.TrailingStop.CallbackRate) >= 0 {
+		Notify("[stagedExit] %s trailing stop triggered, extreme price = %f, price = %f", s.Symbol, s.state.ExtremePrice.Float64(), price.Float64())
+		return s.exit(fixedpoint.One, "trailingStop")
+	}
+
+	return nil
+}
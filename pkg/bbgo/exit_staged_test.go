@@ -0,0 +1,128 @@
+package bbgo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+	"github.com/c9s/bbgo/pkg/types/mocks"
+)
+
+func TestStagedExit(t *testing.T) {
+	market := getTestMarket()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockEx := mocks.NewMockExchange(mockCtrl)
+	mockEx.EXPECT().NewStream().Return(&types.StandardStream{}).Times(2)
+
+	session := NewExchangeSession("test", mockEx)
+	session.markets[market.Symbol] = market
+
+	position := types.NewPositionFromMarket(market)
+	position.AverageCost = fixedpoint.NewFromFloat(20000.0)
+	position.Base = fixedpoint.NewFromFloat(1.0)
+
+	orderExecutor := NewGeneralOrderExecutor(session, "BTCUSDT", "test", "test-staged-exit", position)
+
+	newStagedExit := func() *StagedExit {
+		return &StagedExit{
+			Symbol:   "BTCUSDT",
+			Interval: types.Interval1m,
+			TakeProfits: []TakeProfitStage{
+				{ROI: fixedpoint.NewFromFloat(0.02), ClosePosition: fixedpoint.NewFromFloat(0.5), BreakEven: true},
+				{ROI: fixedpoint.NewFromFloat(0.05), TrailingStop: true},
+			},
+			StopLoss:     fixedpoint.NewFromFloat(0.03),
+			TrailingStop: &StagedTrailingStop{CallbackRate: fixedpoint.NewFromFloat(0.01)},
+		}
+	}
+
+	var closed []fixedpoint.Value
+	closePosition := func(ctx context.Context, percentage fixedpoint.Value, tags ...string) error {
+		closed = append(closed, percentage)
+		position.Base = position.Base.Sub(position.Base.Mul(percentage))
+		return nil
+	}
+
+	exit := newStagedExit()
+	exit.Bind(session, orderExecutor)
+	exit.closePosition = closePosition
+
+	assert.NoError(t, exit.checkPrice(fixedpoint.NewFromFloat(20000.0), position))
+	assert.Empty(t, closed)
+	assert.Equal(t, types.SideTypeBuy, exit.State().Side)
+
+	// stage 1: +2% closes 50% and moves the stop to the break-even price
+	assert.NoError(t, exit.checkPrice(fixedpoint.NewFromFloat(20400.0), position))
+	assert.Equal(t, []fixedpoint.Value{fixedpoint.NewFromFloat(0.5)}, closed)
+	assert.Equal(t, 1, exit.State().Stage)
+	assert.Equal(t, fixedpoint.NewFromFloat(20000.0), exit.State().StopPrice)
+
+	// the stage is not triggered again
+	assert.NoError(t, exit.checkPrice(fixedpoint.NewFromFloat(20500.0), position))
+	assert.Len(t, closed, 1)
+
+	// restart: the state is loaded from the persistence store
+	exit = newStagedExit()
+	exit.Bind(session, orderExecutor)
+	exit.closePosition = closePosition
+	assert.Equal(t, 1, exit.State().Stage)
+	assert.Equal(t, fixedpoint.NewFromFloat(20000.0), exit.State().StopPrice)
+
+	// stage 2: +5% activates the trailing stop
+	assert.NoError(t, exit.checkPrice(fixedpoint.NewFromFloat(21000.0), position))
+	assert.Len(t, closed, 1)
+	assert.True(t, exit.State().TrailingStopActivated)
+
+	assert.NoError(t, exit.checkPrice(fixedpoint.NewFromFloat(22000.0), position))
+	assert.Equal(t, fixedpoint.NewFromFloat(22000.0), exit.State().ExtremePrice)
+	assert.Len(t, closed, 1)
+
+	// 22000 * (1 - 1%) = 21780
+	assert.NoError(t, exit.checkPrice(fixedpoint.NewFromFloat(21780.0), position))
+	assert.Equal(t, []fixedpoint.Value{fixedpoint.NewFromFloat(0.5), fixedpoint.One}, closed)
+
+	// the state is reset after the position is closed
+	assert.NoError(t, exit.checkPrice(fixedpoint.NewFromFloat(21780.0), position))
+	assert.False(t, exit.State().IsOpen())
+}
+
+func TestStagedExit_StopPriceAndTimeExit(t *testing.T) {
+	market := getTestMarket()
+
+	position := types.NewPositionFromMarket(market)
+	position.AverageCost = fixedpoint.NewFromFloat(20000.0)
+	position.Base = fixedpoint.NewFromFloat(-1.0)
+
+	var tags [][]string
+	exit := &StagedExit{
+		Symbol:         "BTCUSDT",
+		MaxHoldingBars: 3,
+		closePosition: func(ctx context.Context, percentage fixedpoint.Value, t ...string) error {
+			tags = append(tags, t)
+			return nil
+		},
+	}
+
+	assert.NoError(t, exit.checkPrice(fixedpoint.NewFromFloat(20000.0), position))
+	assert.Equal(t, types.SideTypeSell, exit.State().Side)
+
+	// the stop price of the short position is above the average cost
+	exit.state.StopPrice = fixedpoint.NewFromFloat(20500.0)
+	assert.NoError(t, exit.checkPrice(fixedpoint.NewFromFloat(20400.0), position))
+	assert.Empty(t, tags)
+
+	assert.NoError(t, exit.checkPrice(fixedpoint.NewFromFloat(20500.0), position))
+	assert.Equal(t, [][]string{{"stagedExit", "stopPrice"}}, tags)
+
+	exit.state.StopPrice = fixedpoint.Zero
+	exit.state.Bars = 3
+	assert.NoError(t, exit.checkPrice(fixedpoint.NewFromFloat(20000.0), position))
+	assert.Equal(t, []string{"stagedExit", "timeExit"}, tags[1])
+}
@@ -2,11 +2,16 @@ package bbgo
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"path"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
 
 	"github.com/c9s/bbgo/pkg/dynamic"
 	"github.com/c9s/bbgo/pkg/fixedpoint"
@@ -39,20 +44,40 @@ type modifyPositionContext struct {
 	value     fixedpoint.Value
 }
 
+type ordersContext struct {
+	signature string
+	books     []*ActiveOrderBook
+}
+
+type setParameterContext struct {
+	signature string
+	strategy  SingleExchangeStrategy
+	name      string
+}
+
+type chartContext struct {
+	signature string
+}
+
 type CoreInteraction struct {
 	environment *Environment
 	trader      *Trader
 
-	exchangeStrategies    map[string]SingleExchangeStrategy
-	closePositionContext  closePositionContext
-	modifyPositionContext modifyPositionContext
+	exchangeStrategies       map[string]SingleExchangeStrategy
+	exchangeStrategySessions map[string]*ExchangeSession
+	closePositionContext     closePositionContext
+	modifyPositionContext    modifyPositionContext
+	ordersContext            ordersContext
+	setParameterContext      setParameterContext
+	chartContext             chartContext
 }
 
 func NewCoreInteraction(environment *Environment, trader *Trader) *CoreInteraction {
 	return &CoreInteraction{
-		environment:        environment,
-		trader:             trader,
-		exchangeStrategies: make(map[string]SingleExchangeStrategy),
+		environment:              environment,
+		trader:                   trader,
+		exchangeStrategies:       make(map[string]SingleExchangeStrategy),
+		exchangeStrategySessions: make(map[string]*ExchangeSession),
 	}
 }
 
@@ -509,6 +534,281 @@ func (it *CoreInteraction) Commands(i *interact.Interact) {
 		reply.Message(fmt.Sprintf("Position of strategy %s modified.", it.modifyPositionContext.signature))
 		return nil
 	})
+
+	i.PrivateCommand("/orders", "List and Cancel Active Orders", func(reply interact.Reply) error {
+		if strategies, err := filterStrategies(it.exchangeStrategies, func(s SingleExchangeStrategy) bool {
+			return len(collectActiveOrderBooks(s)) > 0
+		}); err == nil && len(strategies) > 0 {
+			reply.AddMultipleButtons(generateStrategyButtonsForm(strategies))
+			reply.Message("Please choose one strategy")
+		} else {
+			reply.Message("No strategy has active orders")
+		}
+		return nil
+	}).Next(func(signature string, reply interact.Reply) error {
+		strategy, ok := it.exchangeStrategies[signature]
+		if !ok {
+			reply.Message("Strategy not found")
+			return fmt.Errorf("strategy %s not found", signature)
+		}
+
+		books := collectActiveOrderBooks(strategy)
+		var orders []types.Order
+		for _, book := range books {
+			orders = append(orders, book.Orders()...)
+		}
+
+		if len(orders) == 0 {
+			reply.Message(fmt.Sprintf("Strategy %s has no active orders", signature))
+			if kc, ok := reply.(interact.KeyboardController); ok {
+				kc.RemoveKeyboard()
+			}
+			return fmt.Errorf("strategy %s has no active orders", signature)
+		}
+
+		it.ordersContext.signature = signature
+		it.ordersContext.books = books
+
+		orders = types.SortOrdersByPrice(orders, true)
+		message := fmt.Sprintf("Active orders of %s:\n", signature)
+		for _, order := range orders {
+			message += fmt.Sprintf("- #%d %s %s %s @ %s\n", order.OrderID, order.Symbol, order.Side, order.Quantity.String(), order.Price.String())
+			reply.AddButton(fmt.Sprintf("#%d", order.OrderID), "order", fmt.Sprintf("#%d", order.OrderID))
+		}
+
+		reply.AddButton("all", "order", "all")
+		reply.Message(message + "Choose the order to cancel, or all to cancel all the orders")
+		return nil
+	}).Next(func(target string, reply interact.Reply) error {
+		if kc, ok := reply.(interact.KeyboardController); ok {
+			kc.RemoveKeyboard()
+		}
+
+		session, ok := it.exchangeStrategySessions[it.ordersContext.signature]
+		if !ok {
+			reply.Message("Session not found")
+			return fmt.Errorf("session of strategy %s not found", it.ordersContext.signature)
+		}
+
+		ctx := context.Background()
+		if target == "all" {
+			for _, book := range it.ordersContext.books {
+				if err := book.GracefulCancel(ctx, session.Exchange); err != nil {
+					reply.Message(fmt.Sprintf("Failed to cancel the orders, %s", err.Error()))
+					return err
+				}
+			}
+
+			reply.Message(fmt.Sprintf("All the orders of %s are canceled", it.ordersContext.signature))
+			return nil
+		}
+
+		orderID, err := strconv.ParseUint(strings.TrimPrefix(target, "#"), 10, 64)
+		if err != nil {
+			reply.Message(fmt.Sprintf("%q is not a valid order ID", target))
+			return err
+		}
+
+		for _, book := range it.ordersContext.books {
+			order, ok := book.Get(orderID)
+			if !ok {
+				continue
+			}
+
+			if err := book.GracefulCancel(ctx, session.Exchange, order); err != nil {
+				reply.Message(fmt.Sprintf("Failed to cancel the order, %s", err.Error()))
+				return err
+			}
+
+			reply.Message(fmt.Sprintf("Order #%d is canceled", orderID))
+			return nil
+		}
+
+		reply.Message(fmt.Sprintf("Order #%d is not an active order", orderID))
+		return fmt.Errorf("order %d not found", orderID)
+	})
+
+	i.PrivateCommand("/pnl", "Show Strategy PnL", func(reply interact.Reply) error {
+		if strategies, err := filterStrategies(it.exchangeStrategies, func(s SingleExchangeStrategy) bool {
+			return findProfitStats(s) != nil
+		}); err == nil && len(strategies) > 0 {
+			reply.AddMultipleButtons(generateStrategyButtonsForm(strategies))
+			reply.Message("Please choose one strategy")
+		} else {
+			reply.Message("No strategy has ProfitStats")
+		}
+		return nil
+	}).Next(func(signature string, reply interact.Reply) error {
+		if kc, ok := reply.(interact.KeyboardController); ok {
+			kc.RemoveKeyboard()
+		}
+
+		strategy, ok := it.exchangeStrategies[signature]
+		if !ok {
+			reply.Message("Strategy not found")
+			return fmt.Errorf("strategy %s not found", signature)
+		}
+
+		profitStats := findProfitStats(strategy)
+		if profitStats == nil {
+			reply.Message(fmt.Sprintf("Strategy %s has no ProfitStats", signature))
+			return fmt.Errorf("strategy %s has no ProfitStats", signature)
+		}
+
+		reply.Message(formatPnLBreakdown(signature, profitStats))
+		return nil
+	})
+
+	i.PrivateCommand("/grid", "Show Grid Pins and Fills", func(reply interact.Reply) error {
+		if strategies, err := filterStrategiesByInterface(it.exchangeStrategies, (*GridStatusReader)(nil)); err == nil && len(strategies) > 0 {
+			reply.AddMultipleButtons(generateStrategyButtonsForm(strategies))
+			reply.Message("Please choose one strategy")
+		} else {
+			reply.Message("No strategy supports GridStatusReader")
+		}
+		return nil
+	}).Next(func(signature string, reply interact.Reply) error {
+		if kc, ok := reply.(interact.KeyboardController); ok {
+			kc.RemoveKeyboard()
+		}
+
+		strategy, ok := it.exchangeStrategies[signature]
+		if !ok {
+			reply.Message("Strategy not found")
+			return fmt.Errorf("strategy %s not found", signature)
+		}
+
+		reader, implemented := strategy.(GridStatusReader)
+		if !implemented {
+			reply.Message(fmt.Sprintf("Strategy %s does not support GridStatusReader", signature))
+			return fmt.Errorf("strategy %s does not implement GridStatusReader", signature)
+		}
+
+		reply.Message(reader.GridStatus().PlainText())
+		return nil
+	})
+
+	i.PrivateCommand("/set", "Set Strategy Parameter", func(reply interact.Reply) error {
+		if strategies, err := filterStrategies(it.exchangeStrategies, func(s SingleExchangeStrategy) bool {
+			_, isLocker := s.(sync.Locker)
+			return isLocker && len(modifiableFields(s)) > 0
+		}); err == nil && len(strategies) > 0 {
+			reply.AddMultipleButtons(generateStrategyButtonsForm(strategies))
+			reply.Message("Please choose one strategy")
+		} else {
+			reply.Message("No strategy has modifiable parameters")
+		}
+		return nil
+	}).Next(func(signature string, reply interact.Reply) error {
+		strategy, ok := it.exchangeStrategies[signature]
+		if !ok {
+			reply.Message("Strategy not found")
+			return fmt.Errorf("strategy %s not found", signature)
+		}
+
+		it.setParameterContext.signature = signature
+		it.setParameterContext.strategy = strategy
+
+		reply.Message("Please choose the parameter to change")
+		for _, name := range modifiableFields(strategy) {
+			reply.AddButton(name, "parameter", name)
+		}
+		return nil
+	}).Next(func(name string, reply interact.Reply) error {
+		value, err := getModifiableFieldValue(it.setParameterContext.strategy, name)
+		if err != nil {
+			reply.Message(err.Error())
+			return err
+		}
+
+		it.setParameterContext.name = name
+
+		if kc, ok := reply.(interact.KeyboardController); ok {
+			kc.RemoveKeyboard()
+		}
+
+		reply.Message(fmt.Sprintf("Please enter the new value of %s, current value: %s", name, value))
+		return nil
+	}).Next(func(value string, reply interact.Reply) error {
+		setCtx := it.setParameterContext
+		oldValue, newValue, err := setModifiableFieldValue(setCtx.strategy, setCtx.name, value)
+		if err != nil {
+			reply.Message(fmt.Sprintf("Failed to set %s, %s", setCtx.name, err.Error()))
+			return err
+		}
+
+		log.Infof("strategy %s parameter %s is changed from %s to %s", setCtx.signature, setCtx.name, oldValue, newValue)
+		reply.Message(fmt.Sprintf("Parameter %s of strategy %s is changed from %s to %s", setCtx.name, setCtx.signature, oldValue, newValue))
+		return nil
+	})
+
+	i.PrivateCommand("/chart", "Show Price and Trades Chart", func(reply interact.Reply) error {
+		if strategies, err := filterStrategies(it.exchangeStrategies, func(s SingleExchangeStrategy) bool {
+			_, ok := dynamic.LookupSymbolField(reflect.ValueOf(s))
+			return ok
+		}); err == nil && len(strategies) > 0 {
+			reply.AddMultipleButtons(generateStrategyButtonsForm(strategies))
+			reply.Message("Please choose one strategy")
+		} else {
+			reply.Message("No strategy has the symbol field")
+		}
+		return nil
+	}).Next(func(signature string, reply interact.Reply) error {
+		if _, ok := it.exchangeStrategies[signature]; !ok {
+			reply.Message("Strategy not found")
+			return fmt.Errorf("strategy %s not found", signature)
+		}
+
+		it.chartContext.signature = signature
+
+		reply.Message("Please choose the kline interval")
+		for _, interval := range []types.Interval{types.Interval5m, types.Interval15m, types.Interval1h, types.Interval4h, types.Interval1d} {
+			reply.AddButton(interval.String(), "interval", interval.String())
+		}
+		return nil
+	}).Next(func(intervalStr string, reply interact.Reply) error {
+		if kc, ok := reply.(interact.KeyboardController); ok {
+			kc.RemoveKeyboard()
+		}
+
+		interval := types.Interval(intervalStr)
+		if _, ok := types.SupportedIntervals[interval]; !ok {
+			reply.Message(fmt.Sprintf("%q is not a valid interval", intervalStr))
+			return fmt.Errorf("%q is not a valid interval", intervalStr)
+		}
+
+		signature := it.chartContext.signature
+		strategy := it.exchangeStrategies[signature]
+		session, ok := it.exchangeStrategySessions[signature]
+		if !ok {
+			reply.Message("Session not found")
+			return fmt.Errorf("session of strategy %s not found", signature)
+		}
+
+		symbol, _ := dynamic.LookupSymbolField(reflect.ValueOf(strategy))
+		klines, err := session.Exchange.QueryKLines(context.Background(), symbol, interval, types.KLineQueryOptions{Limit: 100})
+		if err != nil {
+			reply.Message(fmt.Sprintf("Failed to query the klines, %s", err.Error()))
+			return err
+		}
+
+		buffer, err := renderTradeChart(fmt.Sprintf("%s %s", signature, interval), interval, klines, strategyTrades(session, strategy, symbol))
+		if err != nil {
+			reply.Message(fmt.Sprintf("Failed to render the chart, %s", err.Error()))
+			return err
+		}
+
+		// send the chart back to the session of the command instead of broadcasting it through the notifiers
+		photoReply, ok := reply.(interact.PhotoReply)
+		if !ok {
+			reply.Message("The messenger does not support sending the chart")
+			return fmt.Errorf("the reply %T does not implement interact.PhotoReply", reply)
+		}
+
+		photoReply.SendPhoto(buffer)
+		reply.Message(fmt.Sprintf("The %s chart of %s is sent", interval, signature))
+		return nil
+	})
 }
 
 func (it *CoreInteraction) Initialize() error {
@@ -522,6 +822,9 @@ func (it *CoreInteraction) Initialize() error {
 
 			key := sessionID + "." + signature
 			it.exchangeStrategies[key] = strategy
+			if session, ok := it.environment.Session(sessionID); ok {
+				it.exchangeStrategySessions[key] = session
+			}
 		}
 	}
 	return nil
@@ -633,3 +936,160 @@ func generateStrategyButtonsForm(strategies map[string]SingleExchangeStrategy) [
 
 	return buttonsForm
 }
+
+// collectActiveOrderBooks returns the active order books of the strategy fields and the order executor fields
+func collectActiveOrderBooks(strategy interface{}) (books []*ActiveOrderBook) {
+	var found = make(map[*ActiveOrderBook]struct{})
+	add := func(book *ActiveOrderBook) {
+		if book == nil {
+			return
+		}
+
+		if _, ok := found[book]; !ok {
+			found[book] = struct{}{}
+			books = append(books, book)
+		}
+	}
+
+	iterateStrategyFields(strategy, func(fv reflect.Value) {
+		switch v := fv.Interface().(type) {
+		case *GeneralOrderExecutor:
+			if v != nil {
+				add(v.ActiveMakerOrders())
+			}
+
+		case *ActiveOrderBook:
+			add(v)
+		}
+	})
+	return books
+}
+
+func findProfitStats(strategy interface{}) (profitStats *types.ProfitStats) {
+	iterateStrategyFields(strategy, func(fv reflect.Value) {
+		if v, ok := fv.Interface().(*types.ProfitStats); ok && v != nil && profitStats == nil {
+			profitStats = v
+		}
+	})
+	return profitStats
+}
+
+func formatPnLBreakdown(signature string, s *types.ProfitStats) string {
+	message := fmt.Sprintf("%s %s PnL\n", signature, s.Symbol)
+	message += fmt.Sprintf("Today: %s %s (net %s %s)\n",
+		s.TodayPnL.String(), s.QuoteCurrency, s.TodayNetProfit.String(), s.QuoteCurrency)
+	message += fmt.Sprintf("This Week: %s %s (net %s %s)\n",
+		s.ThisWeekPnL.String(), s.QuoteCurrency, s.ThisWeekNetProfit.String(), s.QuoteCurrency)
+	message += fmt.Sprintf("All Time: %s %s (net %s %s) since %s",
+		s.AccumulatedPnL.String(), s.QuoteCurrency, s.AccumulatedNetProfit.String(), s.QuoteCurrency,
+		time.Unix(s.AccumulatedSince, 0).Local().Format(time.RFC822))
+	return message
+}
+
+// strategyTrades returns the trades of the strategy orders if the strategy has an order executor,
+// otherwise, all the trades of the symbol collected by the session are returned
+func strategyTrades(session *ExchangeSession, strategy interface{}, symbol string) (trades []types.Trade) {
+	tradeSlice, ok := session.Trades[symbol]
+	if !ok {
+		return nil
+	}
+
+	var executors []*GeneralOrderExecutor
+	iterateStrategyFields(strategy, func(fv reflect.Value) {
+		if v, ok := fv.Interface().(*GeneralOrderExecutor); ok && v != nil {
+			executors = append(executors, v)
+		}
+	})
+
+	for _, trade := range tradeSlice.Copy() {
+		if len(executors) == 0 {
+			trades = append(trades, trade)
+			continue
+		}
+
+		for _, executor := range executors {
+			if executor.OrderStore().Exists(trade.OrderID) {
+				trades = append(trades, trade)
+				break
+			}
+		}
+	}
+
+	return trades
+}
+
+// modifiableFields returns the json names of the fields with the modifiable:"true" tag
+func modifiableFields(strategy interface{}) (names []string) {
+	dynamic.GetModifiableFields(reflect.ValueOf(strategy), func(tagName, name string) {
+		names = append(names, tagName)
+	})
+	return names
+}
+
+func lookupModifiableField(strategy interface{}, tagName string) (reflect.Value, error) {
+	var fieldName string
+	dynamic.GetModifiableFields(reflect.ValueOf(strategy), func(t, name string) {
+		if t == tagName {
+			fieldName = name
+		}
+	})
+
+	if fieldName == "" {
+		return reflect.Value{}, fmt.Errorf("parameter %s is not modifiable", tagName)
+	}
+
+	field, ok := dynamic.GetModifiableField(reflect.ValueOf(strategy).Elem(), fieldName)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("parameter %s is not modifiable", tagName)
+	}
+
+	return field, nil
+}
+
+func getModifiableFieldValue(strategy interface{}, tagName string) (string, error) {
+	field, err := lookupModifiableField(strategy, tagName)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := json.Marshal(field.Interface())
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// setModifiableFieldValue sets the modifiable field by the json value,
+// the value of the string field can be given without the quotes.
+// The strategy must implement sync.Locker, the field is set with the lock held, so that the change does not race
+// with the strategy goroutines reading the field with the same lock.
+func setModifiableFieldValue(strategy interface{}, tagName, value string) (oldValue, newValue string, err error) {
+	locker, ok := strategy.(sync.Locker)
+	if !ok {
+		return "", "", fmt.Errorf("strategy %T does not implement sync.Locker, the parameters can not be changed safely", strategy)
+	}
+
+	locker.Lock()
+	defer locker.Unlock()
+
+	oldValue, err = getModifiableFieldValue(strategy, tagName)
+	if err != nil {
+		return "", "", err
+	}
+
+	field, _ := lookupModifiableField(strategy, tagName)
+	x := reflect.New(field.Type())
+	if err := json.Unmarshal([]byte(value), x.Interface()); err != nil {
+		if field.Kind() != reflect.String {
+			return "", "", fmt.Errorf("invalid value %s: %w", value, err)
+		}
+
+		x.Elem().SetString(value)
+	}
+
+	field.Set(x.Elem())
+
+	newValue, err = getModifiableFieldValue(strategy, tagName)
+	return oldValue, newValue, err
+}
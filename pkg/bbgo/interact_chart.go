@@ -0,0 +1,72 @@
+package bbgo
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/wcharczuk/go-chart/v2"
+	"github.com/wcharczuk/go-chart/v2/drawing"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// renderTradeChart renders the close prices of the klines and the trades in the kline time range as a png image
+func renderTradeChart(title string, interval types.Interval, klines []types.KLine, trades []types.Trade) (*bytes.Buffer, error) {
+	if len(klines) < 2 {
+		return nil, fmt.Errorf("at least 2 klines are required for the chart, got %d", len(klines))
+	}
+
+	canvas := types.NewCanvas(title, interval)
+
+	price := chart.TimeSeries{Name: "close"}
+	for _, k := range klines {
+		price.XValues = append(price.XValues, k.EndTime.Time())
+		price.YValues = append(price.YValues, k.Close.Float64())
+	}
+	canvas.Series = append(canvas.Series, price)
+
+	startTime := klines[0].StartTime.Time()
+	buys := newTradeSeries("buy", drawing.ColorGreen)
+	sells := newTradeSeries("sell", drawing.ColorRed)
+	for _, trade := range trades {
+		tradeTime := trade.Time.Time()
+		if tradeTime.Before(startTime) {
+			continue
+		}
+
+		if trade.Side == types.SideTypeBuy {
+			buys.XValues = append(buys.XValues, tradeTime)
+			buys.YValues = append(buys.YValues, trade.Price.Float64())
+		} else {
+			sells.XValues = append(sells.XValues, tradeTime)
+			sells.YValues = append(sells.YValues, trade.Price.Float64())
+		}
+	}
+
+	// go-chart can not render the series without any value
+	for _, series := range []chart.TimeSeries{buys, sells} {
+		if len(series.XValues) > 0 {
+			canvas.Series = append(canvas.Series, series)
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := canvas.Render(chart.PNG, &buffer); err != nil {
+		return nil, err
+	}
+
+	return &buffer, nil
+}
+
+func newTradeSeries(name string, color drawing.Color) chart.TimeSeries {
+	return chart.TimeSeries{
+		Name: name,
+		Style: chart.Style{
+			StrokeWidth: chart.Disabled,
+			DotWidth:    5,
+			DotColor:    color,
+		},
+		XValues: []time.Time{},
+	}
+}
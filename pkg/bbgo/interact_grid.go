@@ -0,0 +1,60 @@
+package bbgo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// GridStatusReader is implemented by the grid strategies for showing the grid by the /grid command
+type GridStatusReader interface {
+	GridStatus() *GridStatus
+}
+
+// GridPin is a grid price and the active order placed on it
+type GridPin struct {
+	Price fixedpoint.Value
+	Order *types.Order
+}
+
+type GridStatus struct {
+	Symbol        string
+	QuoteCurrency string
+
+	// Pins are the grid pins from high to low
+	Pins []GridPin
+
+	// RecentFills are the recent filled orders, the latest one goes first
+	RecentFills []types.Order
+
+	ArbitrageCount int
+	Profit         fixedpoint.Value
+}
+
+func (s *GridStatus) PlainText() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s Grid (%d pins)\n", s.Symbol, len(s.Pins)))
+	for _, pin := range s.Pins {
+		if pin.Order == nil {
+			sb.WriteString(fmt.Sprintf("%s -\n", pin.Price.String()))
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("%s %s %s\n", pin.Price.String(), pin.Order.Side, pin.Order.Quantity.String()))
+	}
+
+	sb.WriteString(fmt.Sprintf("Arbitrage Count %d\n", s.ArbitrageCount))
+	sb.WriteString(fmt.Sprintf("Profit %s %s\n", s.Profit.String(), s.QuoteCurrency))
+
+	if len(s.RecentFills) > 0 {
+		sb.WriteString("Recent Fills:\n")
+		for _, order := range s.RecentFills {
+			sb.WriteString(fmt.Sprintf("- %s %s %s @ %s\n",
+				order.UpdateTime.Time().Format("2006-01-02 15:04:05"), order.Side, order.Quantity.String(), order.Price.String()))
+		}
+	}
+
+	return sb.String()
+}
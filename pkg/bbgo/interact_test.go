@@ -3,7 +3,9 @@ package bbgo
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -56,3 +58,135 @@ func Test_testInterface(t *testing.T) {
 	ok := testInterface(s, (*PositionCloser)(nil))
 	assert.True(t, ok)
 }
+
+type modifiableStrategy struct {
+	Symbol      string           `json:"symbol"`
+	Quantity    fixedpoint.Value `json:"quantity" modifiable:"true"`
+	Debug       bool             `json:"debug" modifiable:"true"`
+	Tag         string           `json:"tag" modifiable:"true"`
+	ProfitStats *types.ProfitStats
+
+	orderExecutor *GeneralOrderExecutor
+	Orders        *ActiveOrderBook
+
+	sync.Mutex
+}
+
+func Test_setModifiableFieldValue(t *testing.T) {
+	s := &modifiableStrategy{Symbol: "BTCUSDT", Quantity: fixedpoint.NewFromFloat(0.1)}
+	assert.Equal(t, []string{"quantity", "debug", "tag"}, modifiableFields(s))
+
+	oldValue, newValue, err := setModifiableFieldValue(s, "quantity", "0.25")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "0.10000000", oldValue)
+		assert.Equal(t, "0.25000000", newValue)
+		assert.Equal(t, "0.25", s.Quantity.String())
+	}
+
+	_, _, err = setModifiableFieldValue(s, "debug", "true")
+	assert.NoError(t, err)
+	assert.True(t, s.Debug)
+
+	_, newValue, err = setModifiableFieldValue(s, "tag", "grid")
+	if assert.NoError(t, err) {
+		assert.Equal(t, `"grid"`, newValue)
+		assert.Equal(t, "grid", s.Tag)
+	}
+
+	_, _, err = setModifiableFieldValue(s, "debug", "yes")
+	assert.Error(t, err)
+
+	_, _, err = setModifiableFieldValue(s, "symbol", "ETHUSDT")
+	assert.Error(t, err)
+	assert.Equal(t, "BTCUSDT", s.Symbol)
+
+	// the strategy without the lock can not be changed
+	unlocked := &struct {
+		Quantity fixedpoint.Value `json:"quantity" modifiable:"true"`
+	}{Quantity: fixedpoint.NewFromFloat(0.1)}
+	_, _, err = setModifiableFieldValue(unlocked, "quantity", "0.25")
+	assert.Error(t, err)
+	assert.Equal(t, "0.1", unlocked.Quantity.String())
+}
+
+func Test_setModifiableFieldValue_Lock(t *testing.T) {
+	s := &modifiableStrategy{Symbol: "BTCUSDT", Quantity: fixedpoint.NewFromFloat(0.1)}
+
+	// the strategy goroutine reads the field with the lock held
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			s.Lock()
+			_ = s.Quantity.String()
+			s.Unlock()
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		_, _, err := setModifiableFieldValue(s, "quantity", fmt.Sprintf("0.%d", i+1))
+		assert.NoError(t, err)
+	}
+
+	<-done
+}
+
+func Test_collectActiveOrderBooks(t *testing.T) {
+	s := &modifiableStrategy{Symbol: "BTCUSDT"}
+	assert.Empty(t, collectActiveOrderBooks(s))
+
+	s.Orders = NewActiveOrderBook("BTCUSDT")
+	books := collectActiveOrderBooks(s)
+	if assert.Len(t, books, 1) {
+		assert.Equal(t, s.Orders, books[0])
+	}
+}
+
+func Test_formatPnLBreakdown(t *testing.T) {
+	s := &modifiableStrategy{Symbol: "BTCUSDT"}
+	assert.Nil(t, findProfitStats(s))
+
+	s.ProfitStats = types.NewProfitStats(getTestMarket())
+	s.ProfitStats.AddProfit(types.Profit{
+		Profit:    fixedpoint.NewFromFloat(10.5),
+		NetProfit: fixedpoint.NewFromFloat(10.0),
+		TradedAt:  time.Now(),
+	})
+
+	profitStats := findProfitStats(s)
+	if assert.NotNil(t, profitStats) {
+		message := formatPnLBreakdown("binance.test", profitStats)
+		assert.Contains(t, message, "Today: 10.5 USDT (net 10 USDT)")
+		assert.Contains(t, message, "This Week: 10.5 USDT (net 10 USDT)")
+		assert.Contains(t, message, "All Time: 10.5 USDT (net 10 USDT)")
+	}
+}
+
+func Test_renderTradeChart(t *testing.T) {
+	now := time.Now().Truncate(time.Minute)
+
+	var klines []types.KLine
+	for i := 0; i < 10; i++ {
+		startTime := now.Add(time.Duration(i-10) * time.Minute)
+		klines = append(klines, types.KLine{
+			Symbol:    "BTCUSDT",
+			Interval:  types.Interval1m,
+			StartTime: types.Time(startTime),
+			EndTime:   types.Time(startTime.Add(time.Minute - time.Millisecond)),
+			Close:     fixedpoint.NewFromInt(int64(19000 + i*10)),
+		})
+	}
+
+	trades := []types.Trade{
+		{Side: types.SideTypeBuy, Price: fixedpoint.NewFromInt(19010), Time: types.Time(now.Add(-8 * time.Minute))},
+		{Side: types.SideTypeSell, Price: fixedpoint.NewFromInt(19080), Time: types.Time(now.Add(-2 * time.Minute))},
+	}
+
+	buffer, err := renderTradeChart("BTCUSDT", types.Interval1m, klines, trades)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "\x89PNG", string(buffer.Bytes()[:4]))
+	}
+
+	_, err = renderTradeChart("BTCUSDT", types.Interval1m, klines[:1], trades)
+	assert.Error(t, err)
+}
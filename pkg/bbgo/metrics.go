@@ -1,6 +1,10 @@
 package bbgo
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
 
 var (
 	metricsConnectionStatus = prometheus.NewGaugeVec(
@@ -97,8 +101,87 @@ var (
 			"currency",  // for balance
 		},
 	)
+
+	metricsOrderBookIntegrityIssues = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "bbgo_orderbook_integrity_issues_total",
+			Help: "bbgo order book integrity issues, e.g., checksum mismatch, crossed book or stale book",
+		},
+		[]string{
+			"exchange", // exchange name
+			"symbol",   // symbol of the order book
+			"issue",    // issue: checksum_mismatch, crossed or stale
+		},
+	)
+
+	metricsNotificationsDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "bbgo_notifications_dropped_total",
+			Help: "bbgo notifications dropped by the notification throttle",
+		},
+		[]string{
+			"channel", // the routed channel, empty for the default channel
+			"reason",  // reason: rate_limit or duplicate
+		},
+	)
+
+	strategyNAVLabels = []string{"strategy", "strategy_instance_id", "session"}
+
+	metricsStrategyNAV = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "bbgo_strategy_nav",
+			Help: "bbgo strategy instance net asset value in USD",
+		},
+		strategyNAVLabels,
+	)
+
+	metricsStrategyRealizedProfit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "bbgo_strategy_realized_profit",
+			Help: "bbgo strategy instance realized profit in USD",
+		},
+		strategyNAVLabels,
+	)
+
+	metricsStrategyUnrealizedProfit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "bbgo_strategy_unrealized_profit",
+			Help: "bbgo strategy instance unrealized profit in USD",
+		},
+		strategyNAVLabels,
+	)
+
+	metricsStrategyFee = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "bbgo_strategy_fee",
+			Help: "bbgo strategy instance trading fee in USD",
+		},
+		strategyNAVLabels,
+	)
+
+	metricsStrategyPositionValue = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "bbgo_strategy_position_value",
+			Help: "bbgo strategy instance open position value in USD",
+		},
+		strategyNAVLabels,
+	)
 )
 
+func updateStrategyNAVMetrics(nav types.StrategyInstanceNAV) {
+	labels := prometheus.Labels{
+		"strategy":             nav.Strategy,
+		"strategy_instance_id": nav.StrategyInstanceID,
+		"session":              nav.Session,
+	}
+
+	metricsStrategyNAV.With(labels).Set(nav.NetAssetValue.Float64())
+	metricsStrategyRealizedProfit.With(labels).Set(nav.RealizedProfit.Float64())
+	metricsStrategyUnrealizedProfit.With(labels).Set(nav.UnrealizedProfit.Float64())
+	metricsStrategyFee.With(labels).Set(nav.FeeInUSD.Float64())
+	metricsStrategyPositionValue.With(labels).Set(nav.PositionValue.Float64())
+}
+
 func init() {
 	prometheus.MustRegister(
 		metricsConnectionStatus,
@@ -108,5 +191,12 @@ func init() {
 		metricsTradesTotal,
 		metricsTradingVolume,
 		metricsLastUpdateTimeBalance,
+		metricsOrderBookIntegrityIssues,
+		metricsNotificationsDropped,
+		metricsStrategyNAV,
+		metricsStrategyRealizedProfit,
+		metricsStrategyUnrealizedProfit,
+		metricsStrategyFee,
+		metricsStrategyPositionValue,
 	)
 }
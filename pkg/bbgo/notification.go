@@ -2,6 +2,8 @@ package bbgo
 
 import (
 	"bytes"
+	"context"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -52,6 +54,8 @@ type Notifiability struct {
 	SessionChannelRouter *PatternChannelRouter `json:"-"`
 	SymbolChannelRouter  *PatternChannelRouter `json:"-"`
 	ObjectChannelRouter  *ObjectChannelRouter  `json:"-"`
+
+	throttle *NotificationThrottle
 }
 
 // RouteSymbol routes symbol name to channel
@@ -83,18 +87,35 @@ func (m *Notifiability) AddNotifier(notifier Notifier) {
 	m.notifiers = append(m.notifiers, notifier)
 }
 
+// SetThrottle sets the throttle that filters the notifications before they are sent to the notifiers
+func (m *Notifiability) SetThrottle(throttle *NotificationThrottle) {
+	m.throttle = throttle
+}
+
 func (m *Notifiability) Notify(obj interface{}, args ...interface{}) {
+	severity, args := notificationSeverity(obj, args)
+
 	if str, ok := obj.(string); ok {
 		simpleArgs := util.FilterSimpleArgs(args)
 		logrus.Infof(str, simpleArgs...)
 	}
 
+	if m.throttle != nil && !m.throttle.Allow("", severity, obj, args...) {
+		return
+	}
+
 	for _, n := range m.notifiers {
 		n.Notify(obj, args...)
 	}
 }
 
 func (m *Notifiability) NotifyTo(channel string, obj interface{}, args ...interface{}) {
+	severity, args := notificationSeverity(obj, args)
+
+	if m.throttle != nil && !m.throttle.Allow(channel, severity, obj, args...) {
+		return
+	}
+
 	for _, n := range m.notifiers {
 		n.NotifyTo(channel, obj, args...)
 	}
@@ -111,3 +132,36 @@ func (m *Notifiability) SendPhotoTo(channel string, buffer *bytes.Buffer) {
 		n.SendPhotoTo(channel, buffer)
 	}
 }
+
+// FlushDigests sends the collected digests of the throttle to their channels
+func (m *Notifiability) FlushDigests() {
+	if m.throttle == nil {
+		return
+	}
+
+	for channel, digest := range m.throttle.FlushDigests() {
+		for _, n := range m.notifiers {
+			if len(channel) == 0 {
+				n.Notify(digest)
+			} else {
+				n.NotifyTo(channel, digest)
+			}
+		}
+	}
+}
+
+// RunDigest flushes the digests every interval until the context is canceled
+func (m *Notifiability) RunDigest(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			m.FlushDigests()
+		}
+	}
+}
@@ -0,0 +1,436 @@
+package bbgo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"github.com/slack-go/slack"
+	"golang.org/x/time/rate"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/style"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// NotificationSeverity is the severity of the notification. It can be passed as an argument to Notify,
+// e.g., bbgo.Notify("order submission failed: %v", err, bbgo.NotificationError)
+type NotificationSeverity int
+
+const (
+	// NotificationLow is the low priority notification, e.g., trades and profits, it's batched into the digest
+	// when the digest is enabled
+	NotificationLow NotificationSeverity = iota
+	NotificationInfo
+	NotificationWarning
+
+	// NotificationError bypasses the rate limit and the digest
+	NotificationError
+)
+
+func (s NotificationSeverity) String() string {
+	switch s {
+	case NotificationLow:
+		return "low"
+	case NotificationInfo:
+		return "info"
+	case NotificationWarning:
+		return "warning"
+	case NotificationError:
+		return "error"
+	}
+
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// ProfitDigester is implemented by the strategy profit objects that can be summarized in the notification digest
+type ProfitDigester interface {
+	// DigestProfit returns the strategy name, the profit currency and the profit
+	DigestProfit() (strategy, currency string, profit fixedpoint.Value)
+}
+
+// notificationSeverity returns the severity of the notification and the arguments without the severity.
+// The severity argument takes precedence, otherwise the errors are NotificationError, and the trades
+// and the profits are NotificationLow.
+func notificationSeverity(obj interface{}, args []interface{}) (NotificationSeverity, []interface{}) {
+	for i, arg := range args {
+		if severity, ok := arg.(NotificationSeverity); ok {
+			filtered := make([]interface{}, 0, len(args)-1)
+			filtered = append(filtered, args[:i]...)
+			for _, arg := range args[i+1:] {
+				if _, ok := arg.(NotificationSeverity); !ok {
+					filtered = append(filtered, arg)
+				}
+			}
+
+			return severity, filtered
+		}
+	}
+
+	if _, ok := obj.(error); ok {
+		return NotificationError, args
+	}
+
+	for _, arg := range args {
+		if _, ok := arg.(error); ok {
+			return NotificationError, args
+		}
+	}
+
+	switch obj.(type) {
+	case types.Trade, *types.Trade, types.Profit, *types.Profit, ProfitDigester:
+		return NotificationLow, args
+	}
+
+	return NotificationInfo, args
+}
+
+// NotificationThrottle throttles the notifications of each channel, it drops the duplicated notifications
+// within the dedup window, rate limits the info and the low priority notifications and batches the
+// low priority notifications into the digest.
+type NotificationThrottle struct {
+	config NotificationThrottleConfig
+
+	mu           sync.Mutex
+	limiters     map[string]*rate.Limiter
+	sentMessages map[string]time.Time
+	lastPruned   time.Time
+	digests      map[string]*NotificationDigest
+
+	// now is used for testing
+	now func() time.Time
+}
+
+func NewNotificationThrottle(config NotificationThrottleConfig) *NotificationThrottle {
+	if config.Burst <= 0 {
+		config.Burst = 1
+	}
+
+	return &NotificationThrottle{
+		config:       config,
+		limiters:     make(map[string]*rate.Limiter),
+		sentMessages: make(map[string]time.Time),
+		digests:      make(map[string]*NotificationDigest),
+		now:          time.Now,
+	}
+}
+
+// Allow returns true if the notification should be sent to the channel now. The low priority notifications
+// are collected by the digest when the digest is enabled.
+func (t *NotificationThrottle) Allow(channel string, severity NotificationSeverity, obj interface{}, args ...interface{}) bool {
+	now := t.now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if window := t.config.DedupWindow.Duration(); window > 0 {
+		t.pruneSentMessages(now, window)
+
+		key := channel + "\x00" + notificationMessage(obj, args)
+		if sentTime, ok := t.sentMessages[key]; ok && now.Sub(sentTime) < window {
+			t.drop(channel, "duplicate")
+			return false
+		}
+
+		t.sentMessages[key] = now
+	}
+
+	if severity >= NotificationWarning {
+		return true
+	}
+
+	if severity == NotificationLow && t.config.DigestInterval > 0 {
+		digest, ok := t.digests[channel]
+		if !ok {
+			digest = NewNotificationDigest(now)
+			t.digests[channel] = digest
+		}
+
+		digest.Add(obj)
+		return false
+	}
+
+	if t.config.Interval > 0 {
+		limiter, ok := t.limiters[channel]
+		if !ok {
+			limiter = rate.NewLimiter(rate.Every(t.config.Interval.Duration()), t.config.Burst)
+			t.limiters[channel] = limiter
+		}
+
+		if !limiter.AllowN(now, 1) {
+			t.drop(channel, "rate_limit")
+			return false
+		}
+	}
+
+	return true
+}
+
+// FlushDigests returns the collected digests of the channels and resets them
+func (t *NotificationThrottle) FlushDigests() map[string]*NotificationDigest {
+	now := t.now()
+
+	t.mu.Lock()
+	digests := t.digests
+	t.digests = make(map[string]*NotificationDigest)
+	t.mu.Unlock()
+
+	for channel, digest := range digests {
+		if digest.IsEmpty() {
+			delete(digests, channel)
+			continue
+		}
+
+		digest.EndTime = now
+	}
+
+	return digests
+}
+
+func (t *NotificationThrottle) drop(channel, reason string) {
+	logrus.Debugf("notification to channel %q is dropped: %s", channel, reason)
+	metricsNotificationsDropped.With(prometheus.Labels{
+		"channel": channel,
+		"reason":  reason,
+	}).Inc()
+}
+
+func (t *NotificationThrottle) pruneSentMessages(now time.Time, window time.Duration) {
+	if now.Sub(t.lastPruned) < window {
+		return
+	}
+
+	for key, sentTime := range t.sentMessages {
+		if now.Sub(sentTime) >= window {
+			delete(t.sentMessages, key)
+		}
+	}
+
+	t.lastPruned = now
+}
+
+// notificationMessage renders the notification as the dedup key
+func notificationMessage(obj interface{}, args []interface{}) string {
+	var sb strings.Builder
+	for _, a := range append([]interface{}{obj}, args...) {
+		switch v := a.(type) {
+		case types.PlainText:
+			sb.WriteString(v.PlainText())
+		case types.Stringer:
+			sb.WriteString(v.String())
+		default:
+			sb.WriteString(fmt.Sprintf("%+v", v))
+		}
+
+		sb.WriteByte('\x00')
+	}
+
+	return sb.String()
+}
+
+// NotificationTradeDigest is the trade summary of a strategy and a symbol
+type NotificationTradeDigest struct {
+	Strategy    string
+	Symbol      string
+	Count       int
+	BuyVolume   fixedpoint.Value
+	SellVolume  fixedpoint.Value
+	QuoteVolume fixedpoint.Value
+}
+
+// NotificationProfitDigest is the profit summary of a strategy
+type NotificationProfitDigest struct {
+	Strategy  string
+	Currency  string
+	Count     int
+	Profit    fixedpoint.Value
+	NetProfit fixedpoint.Value
+}
+
+// NotificationDigest is the summary of the low priority notifications within the digest interval
+type NotificationDigest struct {
+	StartTime time.Time
+	EndTime   time.Time
+
+	Trades  map[string]*NotificationTradeDigest
+	Profits map[string]*NotificationProfitDigest
+
+	// Others is the number of the other low priority notifications
+	Others int
+}
+
+func NewNotificationDigest(startTime time.Time) *NotificationDigest {
+	return &NotificationDigest{
+		StartTime: startTime,
+		Trades:    make(map[string]*NotificationTradeDigest),
+		Profits:   make(map[string]*NotificationProfitDigest),
+	}
+}
+
+func (d *NotificationDigest) IsEmpty() bool {
+	return len(d.Trades) == 0 && len(d.Profits) == 0 && d.Others == 0
+}
+
+// Add accumulates the notification object into the digest
+func (d *NotificationDigest) Add(obj interface{}) {
+	switch o := obj.(type) {
+	case types.Trade:
+		d.addTrade(o)
+	case *types.Trade:
+		d.addTrade(*o)
+	case types.Profit:
+		d.addProfit(profitStrategy(o), o.QuoteCurrency, o.Profit, o.NetProfit)
+	case *types.Profit:
+		d.addProfit(profitStrategy(*o), o.QuoteCurrency, o.Profit, o.NetProfit)
+	case ProfitDigester:
+		strategy, currency, profit := o.DigestProfit()
+		d.addProfit(strategy, currency, profit, profit)
+	default:
+		d.Others++
+	}
+}
+
+func (d *NotificationDigest) addTrade(trade types.Trade) {
+	strategy := trade.StrategyID.String
+	key := strategy + ":" + trade.Symbol
+	td, ok := d.Trades[key]
+	if !ok {
+		td = &NotificationTradeDigest{Strategy: strategy, Symbol: trade.Symbol}
+		d.Trades[key] = td
+	}
+
+	td.Count++
+	td.QuoteVolume = td.QuoteVolume.Add(trade.QuoteQuantity)
+	if trade.Side == types.SideTypeBuy {
+		td.BuyVolume = td.BuyVolume.Add(trade.Quantity)
+	} else {
+		td.SellVolume = td.SellVolume.Add(trade.Quantity)
+	}
+}
+
+func (d *NotificationDigest) addProfit(strategy, currency string, profit, netProfit fixedpoint.Value) {
+	key := strategy + ":" + currency
+	pd, ok := d.Profits[key]
+	if !ok {
+		pd = &NotificationProfitDigest{Strategy: strategy, Currency: currency}
+		d.Profits[key] = pd
+	}
+
+	pd.Count++
+	pd.Profit = pd.Profit.Add(profit)
+	pd.NetProfit = pd.NetProfit.Add(netProfit)
+}
+
+func (d *NotificationDigest) title() string {
+	return fmt.Sprintf("Digest %s - %s", d.StartTime.Format(time.RFC3339), d.EndTime.Format(time.RFC3339))
+}
+
+func (d *NotificationDigest) profitDigests() (profits []*NotificationProfitDigest) {
+	for _, pd := range d.Profits {
+		profits = append(profits, pd)
+	}
+
+	sort.Slice(profits, func(i, j int) bool {
+		return profits[i].Strategy+profits[i].Currency < profits[j].Strategy+profits[j].Currency
+	})
+	return profits
+}
+
+func (d *NotificationDigest) tradeDigests() (trades []*NotificationTradeDigest) {
+	for _, td := range d.Trades {
+		trades = append(trades, td)
+	}
+
+	sort.Slice(trades, func(i, j int) bool {
+		return trades[i].Strategy+trades[i].Symbol < trades[j].Strategy+trades[j].Symbol
+	})
+	return trades
+}
+
+func (d *NotificationDigest) SlackAttachment() slack.Attachment {
+	var fields []slack.AttachmentField
+	var color = style.GreenColor
+
+	for _, pd := range d.profitDigests() {
+		if pd.Profit.Sign() < 0 {
+			color = style.RedColor
+		}
+
+		fields = append(fields, slack.AttachmentField{
+			Title: digestStrategyName(pd.Strategy) + " PnL",
+			Value: fmt.Sprintf("%s %s (net %s %s, %d profits)",
+				style.PnLSignString(pd.Profit), pd.Currency,
+				style.PnLSignString(pd.NetProfit), pd.Currency,
+				pd.Count),
+			Short: true,
+		})
+	}
+
+	for _, td := range d.tradeDigests() {
+		fields = append(fields, slack.AttachmentField{
+			Title: digestStrategyName(td.Strategy) + " " + td.Symbol + " Trades",
+			Value: fmt.Sprintf("%d trades, buy %s, sell %s, quote volume %s",
+				td.Count, td.BuyVolume.String(), td.SellVolume.String(), td.QuoteVolume.String()),
+			Short: true,
+		})
+	}
+
+	if d.Others > 0 {
+		fields = append(fields, slack.AttachmentField{
+			Title: "Other Notifications",
+			Value: fmt.Sprintf("%d", d.Others),
+			Short: true,
+		})
+	}
+
+	return slack.Attachment{
+		Title:  d.title(),
+		Color:  color,
+		Fields: fields,
+	}
+}
+
+func (d *NotificationDigest) PlainText() string {
+	var sb strings.Builder
+	sb.WriteString(d.title())
+
+	for _, pd := range d.profitDigests() {
+		sb.WriteString(fmt.Sprintf("\n%s PnL: %s %s (net %s %s, %d profits)",
+			digestStrategyName(pd.Strategy),
+			style.PnLSignString(pd.Profit), pd.Currency,
+			style.PnLSignString(pd.NetProfit), pd.Currency,
+			pd.Count))
+	}
+
+	for _, td := range d.tradeDigests() {
+		sb.WriteString(fmt.Sprintf("\n%s %s: %d trades, buy %s, sell %s, quote volume %s",
+			digestStrategyName(td.Strategy), td.Symbol,
+			td.Count, td.BuyVolume.String(), td.SellVolume.String(), td.QuoteVolume.String()))
+	}
+
+	if d.Others > 0 {
+		sb.WriteString(fmt.Sprintf("\n%d other notifications", d.Others))
+	}
+
+	return sb.String()
+}
+
+func profitStrategy(profit types.Profit) string {
+	if len(profit.StrategyInstanceID) > 0 {
+		return profit.StrategyInstanceID
+	}
+
+	return profit.Strategy
+}
+
+func digestStrategyName(strategy string) string {
+	if len(strategy) == 0 {
+		return "unknown"
+	}
+
+	return strategy
+}
@@ -0,0 +1,151 @@
+package bbgo
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/c9s/bbgo/pkg/testing/testhelper"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+type recordedNotification struct {
+	channel string
+	obj     interface{}
+	args    []interface{}
+}
+
+type recordNotifier struct {
+	notifications []recordedNotification
+}
+
+func (n *recordNotifier) NotifyTo(channel string, obj interface{}, args ...interface{}) {
+	n.notifications = append(n.notifications, recordedNotification{channel: channel, obj: obj, args: args})
+}
+
+func (n *recordNotifier) Notify(obj interface{}, args ...interface{}) {
+	n.NotifyTo("", obj, args...)
+}
+
+func (n *recordNotifier) SendPhotoTo(channel string, buffer *bytes.Buffer) {}
+
+func (n *recordNotifier) SendPhoto(buffer *bytes.Buffer) {}
+
+func newThrottledNotifiability(config NotificationThrottleConfig, now *time.Time) (*Notifiability, *recordNotifier) {
+	throttle := NewNotificationThrottle(config)
+	throttle.now = func() time.Time { return *now }
+
+	notifier := &recordNotifier{}
+	m := &Notifiability{}
+	m.AddNotifier(notifier)
+	m.SetThrottle(throttle)
+	return m, notifier
+}
+
+func Test_notificationSeverity(t *testing.T) {
+	severity, args := notificationSeverity("order failed: %s", []interface{}{"timeout", NotificationWarning})
+	assert.Equal(t, NotificationWarning, severity)
+	assert.Equal(t, []interface{}{"timeout"}, args)
+
+	severity, _ = notificationSeverity("order failed: %v", []interface{}{errors.New("timeout")})
+	assert.Equal(t, NotificationError, severity)
+
+	severity, _ = notificationSeverity(types.Trade{}, nil)
+	assert.Equal(t, NotificationLow, severity)
+
+	severity, _ = notificationSeverity(&types.Profit{}, nil)
+	assert.Equal(t, NotificationLow, severity)
+
+	severity, _ = notificationSeverity("started", nil)
+	assert.Equal(t, NotificationInfo, severity)
+}
+
+func TestNotificationThrottle_RateLimit(t *testing.T) {
+	now := time.Date(2023, 10, 18, 10, 0, 0, 0, time.UTC)
+	m, notifier := newThrottledNotifiability(NotificationThrottleConfig{
+		Interval: types.Duration(time.Minute),
+		Burst:    2,
+	}, &now)
+
+	for i := 0; i < 5; i++ {
+		m.NotifyTo("#btc", "message %d", i)
+	}
+	assert.Len(t, notifier.notifications, 2, "the burst is 2")
+
+	// the other channel has its own limit
+	m.NotifyTo("#eth", "message")
+	assert.Len(t, notifier.notifications, 3)
+
+	// the errors bypass the rate limit
+	m.NotifyTo("#btc", "order failed: %v", errors.New("insufficient balance"))
+	assert.Len(t, notifier.notifications, 4)
+
+	now = now.Add(time.Minute)
+	m.NotifyTo("#btc", "message")
+	assert.Len(t, notifier.notifications, 5)
+}
+
+func TestNotificationThrottle_Dedup(t *testing.T) {
+	now := time.Date(2023, 10, 18, 10, 0, 0, 0, time.UTC)
+	m, notifier := newThrottledNotifiability(NotificationThrottleConfig{
+		DedupWindow: types.Duration(time.Minute),
+	}, &now)
+
+	m.Notify("connection lost: %s", "okex", NotificationError)
+	m.Notify("connection lost: %s", "okex", NotificationError)
+	m.Notify("connection lost: %s", "binance", NotificationError)
+	if assert.Len(t, notifier.notifications, 2) {
+		assert.Equal(t, []interface{}{"okex"}, notifier.notifications[0].args, "the severity argument is removed")
+	}
+
+	now = now.Add(time.Minute)
+	m.Notify("connection lost: %s", "okex", NotificationError)
+	assert.Len(t, notifier.notifications, 3)
+}
+
+func TestNotificationThrottle_Digest(t *testing.T) {
+	now := time.Date(2023, 10, 18, 10, 0, 0, 0, time.UTC)
+	m, notifier := newThrottledNotifiability(NotificationThrottleConfig{
+		DigestInterval: types.Duration(time.Hour),
+	}, &now)
+
+	m.Notify(types.Trade{ID: 1, Symbol: "BTCUSDT", Side: types.SideTypeBuy, Quantity: Number(0.1), QuoteQuantity: Number(3000.0)})
+	m.Notify(types.Trade{ID: 2, Symbol: "BTCUSDT", Side: types.SideTypeSell, Quantity: Number(0.1), QuoteQuantity: Number(3010.0)})
+	m.Notify(&types.Profit{Strategy: "grid2", QuoteCurrency: "USDT", Profit: Number(10.0), NetProfit: Number(8.0)})
+	m.Notify(&types.Profit{Strategy: "grid2", QuoteCurrency: "USDT", Profit: Number(-2.0), NetProfit: Number(-3.0)})
+	m.Notify("grid2 started")
+	assert.Len(t, notifier.notifications, 1, "only the info notification is sent")
+
+	now = now.Add(time.Hour)
+	m.FlushDigests()
+	if assert.Len(t, notifier.notifications, 2) {
+		digest, ok := notifier.notifications[1].obj.(*NotificationDigest)
+		if assert.True(t, ok) {
+			assert.Equal(t, now, digest.EndTime)
+
+			td := digest.Trades[":BTCUSDT"]
+			if assert.NotNil(t, td) {
+				assert.Equal(t, 2, td.Count)
+				assert.Equal(t, Number(0.1), td.BuyVolume)
+				assert.Equal(t, Number(0.1), td.SellVolume)
+				assert.Equal(t, Number(6010.0), td.QuoteVolume)
+			}
+
+			pd := digest.Profits["grid2:USDT"]
+			if assert.NotNil(t, pd) {
+				assert.Equal(t, 2, pd.Count)
+				assert.Equal(t, Number(8.0), pd.Profit)
+				assert.Equal(t, Number(5.0), pd.NetProfit)
+			}
+
+			assert.Contains(t, digest.PlainText(), "grid2 PnL: +8 USDT (net +5 USDT, 2 profits)")
+		}
+	}
+
+	// the empty digests are not sent
+	m.FlushDigests()
+	assert.Len(t, notifier.notifications, 2)
+}
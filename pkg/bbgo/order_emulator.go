@@ -0,0 +1,701 @@
+package bbgo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"go.uber.org/multierr"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/service"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// emulatedOrderIDOffset is the start of the emulated order IDs,
+// it's used for avoiding the conflict with the exchange order IDs
+const emulatedOrderIDOffset = uint64(1) << 62
+
+type EmulatedOrderType string
+
+const (
+	// EmulatedOrderTypeStopMarket submits a market order when the stop price is touched
+	EmulatedOrderTypeStopMarket EmulatedOrderType = "STOP_MARKET"
+
+	// EmulatedOrderTypeStopLimit submits a limit order when the stop price is touched
+	EmulatedOrderTypeStopLimit EmulatedOrderType = "STOP_LIMIT"
+
+	// EmulatedOrderTypeTakeProfit submits a limit order when the limit price is touched
+	EmulatedOrderTypeTakeProfit EmulatedOrderType = "TAKE_PROFIT"
+
+	// EmulatedOrderTypeTrailingStop submits a market order when the price goes back from the highest (or the lowest) price by the callback rate
+	EmulatedOrderTypeTrailingStop EmulatedOrderType = "TRAILING_STOP"
+)
+
+var ErrUnsupportedEmulatedOrderType = errors.New("unsupported emulated order type")
+
+// EmulatedOrder is an order held by the OrderEmulator, the real order is submitted when it's triggered.
+type EmulatedOrder struct {
+	ID   uint64            `json:"id"`
+	Type EmulatedOrderType `json:"type"`
+
+	// SubmitOrder is the order to submit when it's triggered,
+	// the order type is converted to market or limit when submitting.
+	SubmitOrder types.SubmitOrder `json:"submitOrder"`
+
+	// GroupID links the OCO orders, the other orders of the group are canceled when one of them is triggered
+	GroupID uint64 `json:"groupID,omitempty"`
+
+	// ParentOrderID is the exchange order ID of the bracket entry order,
+	// the emulated order is inactive until the parent order is filled.
+	ParentOrderID uint64 `json:"parentOrderID,omitempty"`
+
+	// CallbackRate is the callback rate of the trailing stop order
+	CallbackRate fixedpoint.Value `json:"callbackRate,omitempty"`
+
+	// ActivationPrice activates the trailing stop order when the price is touched, optional
+	ActivationPrice fixedpoint.Value `json:"activationPrice,omitempty"`
+
+	// Activated is true when the trailing stop order is activated
+	Activated bool `json:"activated,omitempty"`
+
+	// ExtremePrice is the highest price (sell) or the lowest price (buy) since the trailing stop order is activated
+	ExtremePrice fixedpoint.Value `json:"extremePrice,omitempty"`
+
+	CreationTime time.Time `json:"creationTime"`
+}
+
+func (o *EmulatedOrder) IsActive() bool {
+	return o.ParentOrderID == 0
+}
+
+// Order converts the emulated order to types.Order for the active order book
+func (o *EmulatedOrder) Order(exchange types.ExchangeName, status types.OrderStatus) types.Order {
+	submitOrder := o.SubmitOrder
+	switch o.Type {
+	case EmulatedOrderTypeStopMarket:
+		submitOrder.Type = types.OrderTypeStopMarket
+	case EmulatedOrderTypeStopLimit:
+		submitOrder.Type = types.OrderTypeStopLimit
+	case EmulatedOrderTypeTrailingStop:
+		submitOrder.Type = types.OrderTypeStopMarket
+		submitOrder.StopPrice = o.trailingStopPrice()
+	}
+
+	return types.Order{
+		SubmitOrder:  submitOrder,
+		Exchange:     exchange,
+		OrderID:      o.ID,
+		Status:       status,
+		IsWorking:    o.IsActive(),
+		CreationTime: types.Time(o.CreationTime),
+		UpdateTime:   types.Time(time.Now()),
+	}
+}
+
+func (o *EmulatedOrder) trailingStopPrice() fixedpoint.Value {
+	if !o.Activated || o.ExtremePrice.IsZero() {
+		return fixedpoint.Zero
+	}
+
+	if o.SubmitOrder.Side == types.SideTypeSell {
+		return o.ExtremePrice.Mul(fixedpoint.One.Sub(o.CallbackRate))
+	}
+
+	return o.ExtremePrice.Mul(fixedpoint.One.Add(o.CallbackRate))
+}
+
+// update updates the trailing stop state and checks if the order is triggered by the given bid/ask price.
+// sell orders are triggered by the bid price, and buy orders are triggered by the ask price.
+func (o *EmulatedOrder) update(bid, ask fixedpoint.Value) (triggered bool) {
+	side := o.SubmitOrder.Side
+	price := ask
+	if side == types.SideTypeSell {
+		price = bid
+	}
+
+	if price.IsZero() {
+		return false
+	}
+
+	switch o.Type {
+	case EmulatedOrderTypeStopMarket, EmulatedOrderTypeStopLimit:
+		if side == types.SideTypeSell {
+			return price.Compare(o.SubmitOrder.StopPrice) <= 0
+		}
+		return price.Compare(o.SubmitOrder.StopPrice) >= 0
+
+	case EmulatedOrderTypeTakeProfit:
+		if side == types.SideTypeSell {
+			return price.Compare(o.SubmitOrder.Price) >= 0
+		}
+		return price.Compare(o.SubmitOrder.Price) <= 0
+
+	case EmulatedOrderTypeTrailingStop:
+		if !o.Activated {
+			if !o.ActivationPrice.IsZero() {
+				if side == types.SideTypeSell && price.Compare(o.ActivationPrice) < 0 {
+					return false
+				} else if side == types.SideTypeBuy && price.Compare(o.ActivationPrice) > 0 {
+					return false
+				}
+			}
+
+			o.Activated = true
+			o.ExtremePrice = price
+		}
+
+		if side == types.SideTypeSell {
+			o.ExtremePrice = fixedpoint.Max(o.ExtremePrice, price)
+			return price.Compare(o.trailingStopPrice()) <= 0
+		}
+
+		o.ExtremePrice = fixedpoint.Min(o.ExtremePrice, price)
+		return price.Compare(o.trailingStopPrice()) >= 0
+	}
+
+	return false
+}
+
+// newSubmitOrder returns the real order to submit
+func (o *EmulatedOrder) newSubmitOrder() types.SubmitOrder {
+	submitOrder := o.SubmitOrder
+	submitOrder.StopPrice = fixedpoint.Zero
+
+	switch o.Type {
+	case EmulatedOrderTypeStopMarket, EmulatedOrderTypeTrailingStop:
+		submitOrder.Type = types.OrderTypeMarket
+		submitOrder.Price = fixedpoint.Zero
+	case EmulatedOrderTypeStopLimit, EmulatedOrderTypeTakeProfit:
+		submitOrder.Type = types.OrderTypeLimit
+	}
+
+	return submitOrder
+}
+
+type orderEmulatorState struct {
+	LastID uint64           `json:"lastID"`
+	Orders []*EmulatedOrder `json:"orders"`
+}
+
+// OrderEmulator emulates the stop, stop-limit, trailing-stop, OCO and bracket orders on the client side
+// for the exchanges that don't support them. The emulated orders are triggered by the market trades and the book ticker,
+// and the real orders are submitted through the order executor when they are triggered.
+//
+// The emulated orders are added to the ActiveOrders() order book, an emulated order is marked as filled when it's triggered.
+// When the emulator is bound to a GeneralOrderExecutor, the emulated orders are persisted with the strategy instance ID.
+//
+//go:generate callbackgen -type OrderEmulator
+type OrderEmulator struct {
+	Symbol string
+
+	session       *ExchangeSession
+	orderExecutor OrderExecutor
+	store         service.Store
+
+	mu     sync.Mutex
+	lastID uint64
+	orders map[uint64]*EmulatedOrder
+
+	// bookUpdates are the pending active order book updates, they are applied after the lock is released
+	bookUpdates []types.Order
+
+	// filledOrders collects the filled order IDs while the bracket entry order is being submitted
+	filledOrders       map[uint64]struct{}
+	submittingBrackets int
+
+	activeOrders *ActiveOrderBook
+
+	triggerCallbacks []func(order EmulatedOrder, createdOrders types.OrderSlice)
+
+	logger log.FieldLogger
+}
+
+func NewOrderEmulator(symbol string) *OrderEmulator {
+	return &OrderEmulator{
+		Symbol:       symbol,
+		orders:       make(map[uint64]*EmulatedOrder),
+		activeOrders: NewActiveOrderBook(symbol),
+		logger:       log.WithFields(log.Fields{"symbol": symbol, "component": "orderEmulator"}),
+	}
+}
+
+func (e *OrderEmulator) Subscribe(session *ExchangeSession) {
+	if IsBackTesting {
+		session.Subscribe(types.KLineChannel, e.Symbol, types.SubscribeOptions{Interval: types.Interval1m})
+		return
+	}
+
+	session.Subscribe(types.MarketTradeChannel, e.Symbol, types.SubscribeOptions{})
+	session.Subscribe(types.BookTickerChannel, e.Symbol, types.SubscribeOptions{})
+}
+
+// SetStore sets the persistence store of the emulated orders and loads the persisted orders
+func (e *OrderEmulator) SetStore(store service.Store) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.store = store
+
+	var state orderEmulatorState
+	if err := store.Load(&state); err != nil {
+		if err == service.ErrPersistenceNotExists {
+			return nil
+		}
+		return err
+	}
+
+	e.lastID = state.LastID
+	for _, o := range state.Orders {
+		e.orders[o.ID] = o
+		e.activeOrders.Add(o.Order(e.exchangeName(), types.OrderStatusNew))
+	}
+
+	e.logger.Infof("loaded %d emulated orders", len(state.Orders))
+	return nil
+}
+
+func (e *OrderEmulator) Bind(session *ExchangeSession, orderExecutor OrderExecutor) {
+	e.session = session
+	e.orderExecutor = orderExecutor
+
+	if generalOrderExecutor, ok := orderExecutor.(*GeneralOrderExecutor); ok && e.store == nil {
+		store := generalOrderExecutor.PersistenceService().NewStore("state", generalOrderExecutor.strategyInstanceID, "order_emulator", e.Symbol)
+		if err := e.SetStore(store); err != nil {
+			e.logger.WithError(err).Errorf("unable to load the emulated orders")
+		}
+	}
+
+	if IsBackTesting {
+		// the backtest exchange only emits the closed klines
+		f := func(kline types.KLine) {
+			e.update(kline.Close, kline.Close)
+		}
+		session.MarketDataStream.OnKLineClosed(types.KLineWith(e.Symbol, types.Interval1m, f))
+		session.MarketDataStream.OnKLine(types.KLineWith(e.Symbol, types.Interval1m, f))
+	} else {
+		session.MarketDataStream.OnMarketTrade(types.TradeWith(e.Symbol, func(trade types.Trade) {
+			e.update(trade.Price, trade.Price)
+		}))
+
+		session.MarketDataStream.OnBookTickerUpdate(func(bookTicker types.BookTicker) {
+			if bookTicker.Symbol == e.Symbol {
+				e.update(bookTicker.Buy, bookTicker.Sell)
+			}
+		})
+	}
+
+	session.UserDataStream.OnOrderUpdate(func(order types.Order) {
+		if order.Symbol == e.Symbol && order.Status == types.OrderStatusFilled {
+			e.activateChildren(order.OrderID)
+		}
+	})
+}
+
+func (e *OrderEmulator) exchangeName() types.ExchangeName {
+	if e.session == nil {
+		return ""
+	}
+	return e.session.ExchangeName
+}
+
+// ActiveOrders returns the active order book of the emulated orders
+func (e *OrderEmulator) ActiveOrders() *ActiveOrderBook {
+	return e.activeOrders
+}
+
+// Orders returns the emulated orders sorted by the order ID
+func (e *OrderEmulator) Orders() []EmulatedOrder {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var orders []EmulatedOrder
+	for _, o := range e.orders {
+		orders = append(orders, *o)
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].ID < orders[j].ID
+	})
+	return orders
+}
+
+func (e *OrderEmulator) save() {
+	if e.store == nil {
+		return
+	}
+
+	state := orderEmulatorState{LastID: e.lastID}
+	for _, o := range e.orders {
+		state.Orders = append(state.Orders, o)
+	}
+
+	if err := e.store.Save(state); err != nil {
+		e.logger.WithError(err).Errorf("unable to save the emulated orders")
+	}
+}
+
+// unlock releases the lock and applies the pending active order book updates,
+// so that the order book callbacks can call the emulator methods.
+func (e *OrderEmulator) unlock() {
+	updates := e.bookUpdates
+	e.bookUpdates = nil
+	e.mu.Unlock()
+
+	for _, o := range updates {
+		e.activeOrders.Update(o)
+	}
+}
+
+func (e *OrderEmulator) nextID() uint64 {
+	e.lastID++
+	return emulatedOrderIDOffset + e.lastID
+}
+
+func (e *OrderEmulator) newOrder(orderType EmulatedOrderType, submitOrder types.SubmitOrder) (*EmulatedOrder, error) {
+	if submitOrder.Symbol == "" {
+		submitOrder.Symbol = e.Symbol
+	} else if submitOrder.Symbol != e.Symbol {
+		return nil, fmt.Errorf("emulated order symbol %s does not match the emulator symbol %s", submitOrder.Symbol, e.Symbol)
+	}
+
+	if submitOrder.Quantity.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid emulated order quantity %s", submitOrder.Quantity.String())
+	}
+
+	switch orderType {
+	case EmulatedOrderTypeStopMarket, EmulatedOrderTypeStopLimit:
+		if submitOrder.StopPrice.Sign() <= 0 {
+			return nil, errors.New("stop price is required for the emulated stop order")
+		}
+
+		if orderType == EmulatedOrderTypeStopLimit && submitOrder.Price.Sign() <= 0 {
+			return nil, errors.New("price is required for the emulated stop limit order")
+		}
+
+	case EmulatedOrderTypeTakeProfit:
+		if submitOrder.Price.Sign() <= 0 {
+			return nil, errors.New("price is required for the emulated take profit order")
+		}
+
+	case EmulatedOrderTypeTrailingStop:
+
+	default:
+		return nil, errors.Wrap(ErrUnsupportedEmulatedOrderType, string(orderType))
+	}
+
+	return &EmulatedOrder{
+		Type:         orderType,
+		SubmitOrder:  submitOrder,
+		CreationTime: time.Now(),
+	}, nil
+}
+
+// add adds the emulated orders, the caller must hold the lock
+func (e *OrderEmulator) add(orders ...*EmulatedOrder) {
+	for _, o := range orders {
+		o.ID = e.nextID()
+		e.orders[o.ID] = o
+		e.activeOrders.Add(o.Order(e.exchangeName(), types.OrderStatusNew))
+	}
+
+	e.save()
+}
+
+// SubmitStopOrder submits an emulated stop order, the order type must be STOP_MARKET or STOP_LIMIT
+func (e *OrderEmulator) SubmitStopOrder(submitOrder types.SubmitOrder) (*EmulatedOrder, error) {
+	orderType := EmulatedOrderTypeStopMarket
+	if submitOrder.Type == types.OrderTypeStopLimit {
+		orderType = EmulatedOrderTypeStopLimit
+	}
+
+	o, err := e.newOrder(orderType, submitOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	e.add(o)
+	e.mu.Unlock()
+	return o, nil
+}
+
+// SubmitTrailingStopOrder submits an emulated trailing stop order,
+// the activation price is optional, the trailing stop is activated immediately if it's zero.
+func (e *OrderEmulator) SubmitTrailingStopOrder(submitOrder types.SubmitOrder, callbackRate, activationPrice fixedpoint.Value) (*EmulatedOrder, error) {
+	if callbackRate.Sign() <= 0 || callbackRate.Compare(fixedpoint.One) >= 0 {
+		return nil, fmt.Errorf("invalid callback rate %s", callbackRate.String())
+	}
+
+	o, err := e.newOrder(EmulatedOrderTypeTrailingStop, submitOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	o.CallbackRate = callbackRate
+	o.ActivationPrice = activationPrice
+
+	e.mu.Lock()
+	e.add(o)
+	e.mu.Unlock()
+	return o, nil
+}
+
+// SubmitOCOOrder submits the take profit order (limit price) and the stop order (stop price) as one-cancels-the-other orders.
+// The stop order type can be STOP_MARKET or STOP_LIMIT.
+func (e *OrderEmulator) SubmitOCOOrder(takeProfit, stop types.SubmitOrder) ([]*EmulatedOrder, error) {
+	orders, err := e.newOCOOrders(takeProfit, stop)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	e.add(orders...)
+	orders[0].GroupID = orders[0].ID
+	orders[1].GroupID = orders[0].ID
+	e.save()
+	e.mu.Unlock()
+	return orders, nil
+}
+
+func (e *OrderEmulator) newOCOOrders(takeProfit, stop types.SubmitOrder) ([]*EmulatedOrder, error) {
+	takeProfitOrder, err := e.newOrder(EmulatedOrderTypeTakeProfit, takeProfit)
+	if err != nil {
+		return nil, err
+	}
+
+	stopOrderType := EmulatedOrderTypeStopMarket
+	if stop.Type == types.OrderTypeStopLimit {
+		stopOrderType = EmulatedOrderTypeStopLimit
+	}
+
+	stopOrder, err := e.newOrder(stopOrderType, stop)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*EmulatedOrder{takeProfitOrder, stopOrder}, nil
+}
+
+// SubmitBracketOrder submits the entry order through the order executor,
+// and the take profit price and the stop price are placed as OCO orders after the entry order is filled.
+func (e *OrderEmulator) SubmitBracketOrder(ctx context.Context, entry types.SubmitOrder, takeProfitPrice, stopPrice fixedpoint.Value) (*types.Order, []*EmulatedOrder, error) {
+	exitSide := types.SideTypeSell
+	if entry.Side == types.SideTypeSell {
+		exitSide = types.SideTypeBuy
+	}
+
+	exit := types.SubmitOrder{
+		Symbol:           entry.Symbol,
+		Side:             exitSide,
+		Quantity:         entry.Quantity,
+		Market:           entry.Market,
+		Tag:              entry.Tag,
+		MarginSideEffect: types.SideEffectTypeAutoRepay,
+	}
+
+	takeProfit := exit
+	takeProfit.Price = takeProfitPrice
+
+	stop := exit
+	stop.Type = types.OrderTypeStopMarket
+	stop.StopPrice = stopPrice
+
+	orders, err := e.newOCOOrders(takeProfit, stop)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// collect the filled orders during the submission since the order update might be received before SubmitOrders returns
+	e.mu.Lock()
+	if e.filledOrders == nil {
+		e.filledOrders = make(map[uint64]struct{})
+	}
+	e.submittingBrackets++
+	e.mu.Unlock()
+
+	createdOrders, err := e.orderExecutor.SubmitOrders(ctx, entry)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	filledOrders := e.filledOrders
+	e.submittingBrackets--
+	if e.submittingBrackets == 0 {
+		e.filledOrders = nil
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(createdOrders) == 0 {
+		return nil, nil, errors.New("bracket entry order is not created")
+	}
+
+	entryOrder := createdOrders[0]
+	if _, filled := filledOrders[entryOrder.OrderID]; !filled && entryOrder.Status != types.OrderStatusFilled {
+		for _, o := range orders {
+			o.ParentOrderID = entryOrder.OrderID
+		}
+	}
+
+	e.add(orders...)
+	orders[0].GroupID = orders[0].ID
+	orders[1].GroupID = orders[0].ID
+	e.save()
+
+	return &entryOrder, orders, nil
+}
+
+// activateChildren activates the emulated orders of the filled bracket entry order
+func (e *OrderEmulator) activateChildren(parentOrderID uint64) {
+	e.mu.Lock()
+	defer e.unlock()
+
+	if e.filledOrders != nil {
+		e.filledOrders[parentOrderID] = struct{}{}
+	}
+
+	changed := false
+	for _, o := range e.orders {
+		if o.ParentOrderID == parentOrderID {
+			o.ParentOrderID = 0
+			e.bookUpdates = append(e.bookUpdates, o.Order(e.exchangeName(), types.OrderStatusNew))
+			changed = true
+		}
+	}
+
+	if changed {
+		e.save()
+	}
+}
+
+// Cancel cancels the emulated orders by the order IDs,
+// the OCO orders in the same group are canceled together.
+// The order IDs already canceled with their group in the same call are ignored.
+func (e *OrderEmulator) Cancel(orderIDs ...uint64) error {
+	e.mu.Lock()
+	defer e.unlock()
+
+	var err error
+	canceled := make(map[uint64]struct{})
+	for _, orderID := range orderIDs {
+		if _, ok := canceled[orderID]; ok {
+			continue
+		}
+
+		o, ok := e.orders[orderID]
+		if !ok {
+			err = multierr.Append(err, fmt.Errorf("emulated order %d not found", orderID))
+			continue
+		}
+
+		e.remove(o, types.OrderStatusCanceled)
+		canceled[o.ID] = struct{}{}
+		for _, id := range e.cancelGroup(o) {
+			canceled[id] = struct{}{}
+		}
+	}
+
+	// save the canceled orders even if some of the order IDs are not found
+	e.save()
+	return err
+}
+
+// CancelAll cancels all the emulated orders
+func (e *OrderEmulator) CancelAll() {
+	e.mu.Lock()
+	defer e.unlock()
+
+	for _, o := range e.orders {
+		e.remove(o, types.OrderStatusCanceled)
+	}
+
+	e.save()
+}
+
+// remove removes the emulated order and updates the active order book, the caller must hold the lock
+func (e *OrderEmulator) remove(o *EmulatedOrder, status types.OrderStatus) {
+	delete(e.orders, o.ID)
+	e.bookUpdates = append(e.bookUpdates, o.Order(e.exchangeName(), status))
+}
+
+// cancelGroup cancels the other orders in the same OCO group and returns their IDs, the caller must hold the lock
+func (e *OrderEmulator) cancelGroup(order *EmulatedOrder) (orderIDs []uint64) {
+	if order.GroupID == 0 {
+		return nil
+	}
+
+	for _, o := range e.orders {
+		if o.GroupID == order.GroupID {
+			e.remove(o, types.OrderStatusCanceled)
+			orderIDs = append(orderIDs, o.ID)
+		}
+	}
+
+	return orderIDs
+}
+
+// update checks the emulated orders with the bid/ask price and submits the triggered orders
+func (e *OrderEmulator) update(bid, ask fixedpoint.Value) {
+	var triggered []*EmulatedOrder
+
+	e.mu.Lock()
+	changed := false
+	for _, o := range e.orders {
+		if !o.IsActive() {
+			continue
+		}
+
+		extremePrice := o.ExtremePrice
+		if o.update(bid, ask) {
+			triggered = append(triggered, o)
+		} else if o.ExtremePrice != extremePrice {
+			changed = true
+		}
+	}
+
+	// sort the triggered orders to make the result deterministic
+	sort.Slice(triggered, func(i, j int) bool {
+		return triggered[i].ID < triggered[j].ID
+	})
+
+	var submitting []*EmulatedOrder
+	for _, o := range triggered {
+		// the order might be canceled by the other order in the same group
+		if _, ok := e.orders[o.ID]; !ok {
+			continue
+		}
+
+		e.remove(o, types.OrderStatusFilled)
+		e.cancelGroup(o)
+		submitting = append(submitting, o)
+	}
+
+	if changed || len(submitting) > 0 {
+		e.save()
+	}
+	e.unlock()
+
+	for _, o := range submitting {
+		e.submit(o)
+	}
+}
+
+func (e *OrderEmulator) submit(o *EmulatedOrder) {
+	submitOrder := o.newSubmitOrder()
+	e.logger.Infof("emulated %s order #%d is triggered, submitting %s", o.Type, o.ID, submitOrder.String())
+
+	createdOrders, err := e.orderExecutor.SubmitOrders(context.Background(), submitOrder)
+	if err != nil {
+		e.logger.WithError(err).Errorf("unable to submit the triggered emulated order #%d", o.ID)
+		Notify("[orderEmulator] unable to submit the triggered %s order #%d: %v", o.Type, o.ID, err)
+	}
+
+	e.EmitTrigger(*o, createdOrders)
+}
@@ -0,0 +1,301 @@
+package bbgo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/service"
+	"github.com/c9s/bbgo/pkg/types"
+	"github.com/c9s/bbgo/pkg/types/mocks"
+)
+
+type testOrderExecutor struct {
+	submitOrders []types.SubmitOrder
+}
+
+func (e *testOrderExecutor) SubmitOrders(ctx context.Context, submitOrders ...types.SubmitOrder) (createdOrders types.OrderSlice, err error) {
+	for _, submitOrder := range submitOrders {
+		e.submitOrders = append(e.submitOrders, submitOrder)
+		createdOrders = append(createdOrders, types.Order{
+			SubmitOrder: submitOrder,
+			OrderID:     uint64(len(e.submitOrders)),
+			Status:      types.OrderStatusNew,
+		})
+	}
+	return createdOrders, nil
+}
+
+func (e *testOrderExecutor) CancelOrders(ctx context.Context, orders ...types.Order) error {
+	return nil
+}
+
+func newTestOrderEmulator(t *testing.T, store service.Store) (*OrderEmulator, *ExchangeSession, *testOrderExecutor) {
+	mockCtrl := gomock.NewController(t)
+	mockEx := mocks.NewMockExchange(mockCtrl)
+	mockEx.EXPECT().NewStream().Return(&types.StandardStream{}).Times(2)
+
+	session := NewExchangeSession("test", mockEx)
+	executor := &testOrderExecutor{}
+
+	emulator := NewOrderEmulator("BTCUSDT")
+	assert.NoError(t, emulator.SetStore(store))
+	emulator.Bind(session, executor)
+	return emulator, session, executor
+}
+
+func emitTestMarketTrade(session *ExchangeSession, price float64) {
+	session.MarketDataStream.(*types.StandardStream).EmitMarketTrade(types.Trade{
+		Symbol: "BTCUSDT",
+		Price:  fixedpoint.NewFromFloat(price),
+	})
+}
+
+func TestOrderEmulator_StopOrder(t *testing.T) {
+	store := service.NewMemoryService().NewStore("test", "stop")
+	emulator, session, executor := newTestOrderEmulator(t, store)
+
+	o, err := emulator.SubmitStopOrder(types.SubmitOrder{
+		Side:      types.SideTypeSell,
+		Type:      types.OrderTypeStopLimit,
+		Quantity:  fixedpoint.NewFromFloat(1.0),
+		Price:     fixedpoint.NewFromFloat(18900.0),
+		StopPrice: fixedpoint.NewFromFloat(19000.0),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, EmulatedOrderTypeStopLimit, o.Type)
+	assert.Equal(t, 1, emulator.ActiveOrders().NumOfOrders())
+
+	var triggered []EmulatedOrder
+	emulator.OnTrigger(func(order EmulatedOrder, createdOrders types.OrderSlice) {
+		triggered = append(triggered, order)
+	})
+
+	emitTestMarketTrade(session, 19500.0)
+	assert.Empty(t, executor.submitOrders)
+
+	// the book ticker bid price triggers the sell stop order
+	session.MarketDataStream.(*types.StandardStream).EmitBookTickerUpdate(types.BookTicker{
+		Symbol: "BTCUSDT",
+		Buy:    fixedpoint.NewFromFloat(19000.0),
+		Sell:   fixedpoint.NewFromFloat(19001.0),
+	})
+
+	if assert.Len(t, executor.submitOrders, 1) {
+		assert.Equal(t, types.OrderTypeLimit, executor.submitOrders[0].Type)
+		assert.Equal(t, "BTCUSDT", executor.submitOrders[0].Symbol)
+		assert.Equal(t, fixedpoint.NewFromFloat(18900.0), executor.submitOrders[0].Price)
+		assert.True(t, executor.submitOrders[0].StopPrice.IsZero())
+	}
+
+	assert.Len(t, triggered, 1)
+	assert.Equal(t, 0, emulator.ActiveOrders().NumOfOrders())
+	assert.Empty(t, emulator.Orders())
+
+	// triggered order is not triggered again
+	emitTestMarketTrade(session, 18000.0)
+	assert.Len(t, executor.submitOrders, 1)
+}
+
+func TestOrderEmulator_BackTesting(t *testing.T) {
+	IsBackTesting = true
+	defer func() {
+		IsBackTesting = false
+	}()
+
+	emulator, session, executor := newTestOrderEmulator(t, service.NewMemoryService().NewStore("test", "backtest"))
+
+	_, err := emulator.SubmitStopOrder(types.SubmitOrder{
+		Side:      types.SideTypeSell,
+		Type:      types.OrderTypeStopMarket,
+		Quantity:  fixedpoint.NewFromFloat(1.0),
+		StopPrice: fixedpoint.NewFromFloat(19000.0),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	emitClosedKLine := func(price float64) {
+		session.MarketDataStream.(*types.StandardStream).EmitKLineClosed(types.KLine{
+			Symbol:   "BTCUSDT",
+			Interval: types.Interval1m,
+			Close:    fixedpoint.NewFromFloat(price),
+			Closed:   true,
+		})
+	}
+
+	emitClosedKLine(19500.0)
+	assert.Empty(t, executor.submitOrders)
+
+	// the backtest exchange only emits the closed klines
+	emitClosedKLine(18900.0)
+	if assert.Len(t, executor.submitOrders, 1) {
+		assert.Equal(t, types.OrderTypeMarket, executor.submitOrders[0].Type)
+	}
+	assert.Empty(t, emulator.Orders())
+}
+
+func TestOrderEmulator_OCOAndRestore(t *testing.T) {
+	store := service.NewMemoryService().NewStore("test", "oco")
+	emulator, _, _ := newTestOrderEmulator(t, store)
+
+	orders, err := emulator.SubmitOCOOrder(types.SubmitOrder{
+		Side:     types.SideTypeSell,
+		Quantity: fixedpoint.NewFromFloat(1.0),
+		Price:    fixedpoint.NewFromFloat(21000.0),
+	}, types.SubmitOrder{
+		Side:      types.SideTypeSell,
+		Type:      types.OrderTypeStopMarket,
+		Quantity:  fixedpoint.NewFromFloat(1.0),
+		StopPrice: fixedpoint.NewFromFloat(19000.0),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, orders[0].GroupID, orders[1].GroupID)
+
+	// restart: the emulated orders are loaded from the store
+	emulator, session, executor := newTestOrderEmulator(t, store)
+	assert.Len(t, emulator.Orders(), 2)
+	assert.Equal(t, 2, emulator.ActiveOrders().NumOfOrders())
+
+	var canceled []types.Order
+	emulator.ActiveOrders().OnCanceled(func(o types.Order) {
+		canceled = append(canceled, o)
+	})
+
+	emitTestMarketTrade(session, 21000.0)
+	if assert.Len(t, executor.submitOrders, 1) {
+		assert.Equal(t, types.OrderTypeLimit, executor.submitOrders[0].Type)
+		assert.Equal(t, fixedpoint.NewFromFloat(21000.0), executor.submitOrders[0].Price)
+	}
+
+	// the stop order is canceled by the take profit order
+	if assert.Len(t, canceled, 1) {
+		assert.Equal(t, orders[1].ID, canceled[0].OrderID)
+	}
+	assert.Empty(t, emulator.Orders())
+
+	emitTestMarketTrade(session, 18000.0)
+	assert.Len(t, executor.submitOrders, 1)
+}
+
+func TestOrderEmulator_CancelOCO(t *testing.T) {
+	store := service.NewMemoryService().NewStore("test", "cancel-oco")
+	emulator, _, _ := newTestOrderEmulator(t, store)
+
+	orders, err := emulator.SubmitOCOOrder(types.SubmitOrder{
+		Side:     types.SideTypeSell,
+		Quantity: fixedpoint.NewFromFloat(1.0),
+		Price:    fixedpoint.NewFromFloat(21000.0),
+	}, types.SubmitOrder{
+		Side:      types.SideTypeSell,
+		Type:      types.OrderTypeStopMarket,
+		Quantity:  fixedpoint.NewFromFloat(1.0),
+		StopPrice: fixedpoint.NewFromFloat(19000.0),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	stopOrder, err := emulator.SubmitStopOrder(types.SubmitOrder{
+		Side:      types.SideTypeSell,
+		Type:      types.OrderTypeStopMarket,
+		Quantity:  fixedpoint.NewFromFloat(1.0),
+		StopPrice: fixedpoint.NewFromFloat(18000.0),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// both orders of the OCO group can be passed together
+	assert.NoError(t, emulator.Cancel(orders[0].ID, orders[1].ID))
+	assert.Len(t, emulator.Orders(), 1)
+
+	// the unknown order ID does not stop the other orders from being canceled
+	assert.Error(t, emulator.Cancel(orders[0].ID, stopOrder.ID))
+	assert.Empty(t, emulator.Orders())
+
+	// the cancellation is saved
+	emulator, _, _ = newTestOrderEmulator(t, store)
+	assert.Empty(t, emulator.Orders())
+}
+
+func TestOrderEmulator_TrailingStop(t *testing.T) {
+	store := service.NewMemoryService().NewStore("test", "trailing")
+	emulator, session, executor := newTestOrderEmulator(t, store)
+
+	_, err := emulator.SubmitTrailingStopOrder(types.SubmitOrder{
+		Side:     types.SideTypeBuy,
+		Quantity: fixedpoint.NewFromFloat(1.0),
+	}, fixedpoint.NewFromFloat(0.01), fixedpoint.NewFromFloat(19000.0))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// not activated
+	emitTestMarketTrade(session, 20000.0)
+	assert.False(t, emulator.Orders()[0].Activated)
+
+	emitTestMarketTrade(session, 19000.0)
+	emitTestMarketTrade(session, 18000.0)
+	assert.True(t, emulator.Orders()[0].Activated)
+	assert.Equal(t, fixedpoint.NewFromFloat(18000.0), emulator.Orders()[0].ExtremePrice)
+
+	// 18000 * 1.01 = 18180
+	emitTestMarketTrade(session, 18100.0)
+	assert.Empty(t, executor.submitOrders)
+
+	emitTestMarketTrade(session, 18180.0)
+	if assert.Len(t, executor.submitOrders, 1) {
+		assert.Equal(t, types.OrderTypeMarket, executor.submitOrders[0].Type)
+		assert.Equal(t, types.SideTypeBuy, executor.submitOrders[0].Side)
+	}
+}
+
+func TestOrderEmulator_BracketOrder(t *testing.T) {
+	store := service.NewMemoryService().NewStore("test", "bracket")
+	emulator, session, executor := newTestOrderEmulator(t, store)
+
+	entryOrder, orders, err := emulator.SubmitBracketOrder(context.Background(), types.SubmitOrder{
+		Symbol:   "BTCUSDT",
+		Side:     types.SideTypeBuy,
+		Type:     types.OrderTypeLimit,
+		Quantity: fixedpoint.NewFromFloat(1.0),
+		Price:    fixedpoint.NewFromFloat(20000.0),
+	}, fixedpoint.NewFromFloat(21000.0), fixedpoint.NewFromFloat(19000.0))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Len(t, executor.submitOrders, 1)
+	assert.Len(t, orders, 2)
+	for _, o := range orders {
+		assert.Equal(t, entryOrder.OrderID, o.ParentOrderID)
+		assert.Equal(t, types.SideTypeSell, o.SubmitOrder.Side)
+	}
+
+	// the exit orders are inactive before the entry order is filled
+	emitTestMarketTrade(session, 18000.0)
+	assert.Len(t, executor.submitOrders, 1)
+
+	filledOrder := *entryOrder
+	filledOrder.Status = types.OrderStatusFilled
+	session.UserDataStream.(*types.StandardStream).EmitOrderUpdate(filledOrder)
+	for _, o := range emulator.Orders() {
+		assert.True(t, o.IsActive())
+	}
+
+	emitTestMarketTrade(session, 18000.0)
+	if assert.Len(t, executor.submitOrders, 2) {
+		assert.Equal(t, types.OrderTypeMarket, executor.submitOrders[1].Type)
+		assert.Equal(t, types.SideTypeSell, executor.submitOrders[1].Side)
+	}
+	assert.Empty(t, emulator.Orders())
+}
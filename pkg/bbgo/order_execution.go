@@ -76,7 +76,8 @@ func (e *ExchangeOrderExecutionRouter) CancelOrdersTo(ctx context.Context, sessi
 		return fmt.Errorf("exchange session %s not found", session)
 	}
 
-	return es.Exchange.CancelOrders(ctx, orders...)
+	_, err := BatchCancelOrder(ctx, es.Exchange, orders...)
+	return err
 }
 
 // ExchangeOrderExecutor is an order executor wrapper for single exchange instance.
@@ -112,7 +113,8 @@ func (e *ExchangeOrderExecutor) CancelOrders(ctx context.Context, orders ...type
 	for _, order := range orders {
 		log.Infof("cancelling order: %s", order)
 	}
-	return e.Session.Exchange.CancelOrders(ctx, orders...)
+	_, err := BatchCancelOrder(ctx, e.Session.Exchange, orders...)
+	return err
 }
 
 type BasicRiskController struct {
@@ -309,8 +311,13 @@ func (c *BasicRiskController) ProcessOrders(session *ExchangeSession, orders ...
 
 type OrderCallback func(order types.Order)
 
-// BatchPlaceOrder
+// BatchPlaceOrder places the orders one by one, or with the native batch API if the exchange implements
+// types.ExchangeBatchOrderService. The indexes of the failed submit orders are returned.
 func BatchPlaceOrder(ctx context.Context, exchange types.Exchange, orderCallback OrderCallback, submitOrders ...types.SubmitOrder) (types.OrderSlice, []int, error) {
+	if service, ok := exchange.(types.ExchangeBatchOrderService); ok && len(submitOrders) > 1 {
+		return batchSubmitOrders(ctx, service, orderCallback, submitOrders...)
+	}
+
 	var createdOrders types.OrderSlice
 	var err error
 
@@ -334,6 +341,92 @@ func BatchPlaceOrder(ctx context.Context, exchange types.Exchange, orderCallback
 	return createdOrders, errIndexes, err
 }
 
+func batchSubmitOrders(ctx context.Context, service types.ExchangeBatchOrderService, orderCallback OrderCallback, submitOrders ...types.SubmitOrder) (types.OrderSlice, []int, error) {
+	createdOrders, errIndexes, err := service.BatchSubmitOrders(ctx, submitOrders...)
+
+	failed := make(map[int]struct{}, len(errIndexes))
+	for _, idx := range errIndexes {
+		failed[idx] = struct{}{}
+	}
+
+	// the created orders are returned in the same sequence as the successful submit orders,
+	// so that we can copy the order tag from the submit order.
+	var succeededIndexes []int
+	for i := range submitOrders {
+		if _, ok := failed[i]; !ok {
+			succeededIndexes = append(succeededIndexes, i)
+		}
+	}
+
+	if len(succeededIndexes) != len(createdOrders) {
+		log.Warnf("the number of the created orders %d does not match the successful submit orders %d, order tags are not copied",
+			len(createdOrders), len(succeededIndexes))
+	}
+
+	for i := range createdOrders {
+		if len(succeededIndexes) == len(createdOrders) {
+			createdOrders[i].Tag = submitOrders[succeededIndexes[i]].Tag
+		}
+
+		if orderCallback != nil {
+			orderCallback(createdOrders[i])
+		}
+	}
+
+	return createdOrders, errIndexes, err
+}
+
+// BatchCancelOrder cancels the orders with the native batch API if the exchange implements types.ExchangeBatchOrderService,
+// otherwise, it calls the CancelOrders API. The orders that are failed to cancel are returned, when the exchange does not
+// implement the batch API, all the given orders are returned on error.
+func BatchCancelOrder(ctx context.Context, exchange types.Exchange, orders ...types.Order) ([]types.Order, error) {
+	if len(orders) == 0 {
+		return nil, nil
+	}
+
+	if service, ok := exchange.(types.ExchangeBatchOrderService); ok && len(orders) > 1 {
+		return service.BatchCancelOrders(ctx, orders...)
+	}
+
+	if err := exchange.CancelOrders(ctx, orders...); err != nil {
+		return orders, err
+	}
+
+	return nil, nil
+}
+
+// CancelAllOrdersOfSymbol cancels the orders of the symbol with the cancel-all API in one request if the exchange
+// implements types.ExchangeCancelAllOrdersService, otherwise, or if the cancel-all API fails, BatchCancelOrder is used.
+// The cancel-all API cancels every open order of the symbol, including the orders of the other strategies and the
+// manual orders, so the caller should use it only when the account is dedicated to the orders of the symbol.
+func CancelAllOrdersOfSymbol(ctx context.Context, exchange types.Exchange, symbol string, orders ...types.Order) ([]types.Order, error) {
+	service, ok := exchange.(types.ExchangeCancelAllOrdersService)
+	if !ok || len(orders) == 0 {
+		return BatchCancelOrder(ctx, exchange, orders...)
+	}
+
+	for _, o := range orders {
+		if o.Symbol != symbol {
+			return BatchCancelOrder(ctx, exchange, orders...)
+		}
+	}
+
+	canceledOrders, err := service.CancelOrdersBySymbol(ctx, symbol)
+	if err != nil {
+		log.WithError(err).Warnf("can not cancel %s orders by the cancel-all api, fallback to batch cancel", symbol)
+		return BatchCancelOrder(ctx, exchange, orders...)
+	}
+
+	orderMap := types.NewOrderMap(orders...)
+	for _, o := range canceledOrders {
+		if !orderMap.Exists(o.OrderID) {
+			log.Warnf("%s order #%d is canceled by the cancel-all api, but it is not in the canceling orders", symbol, o.OrderID)
+		}
+	}
+
+	return nil, nil
+}
+
 // BatchRetryPlaceOrder places the orders and retries the failed orders
 func BatchRetryPlaceOrder(ctx context.Context, exchange types.Exchange, errIdx []int, orderCallback OrderCallback, logger log.FieldLogger, submitOrders ...types.SubmitOrder) (types.OrderSlice, []int, error) {
 	if logger == nil {
@@ -0,0 +1,187 @@
+package bbgo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/c9s/bbgo/pkg/testing/testhelper"
+	"github.com/c9s/bbgo/pkg/types"
+	"github.com/c9s/bbgo/pkg/types/mocks"
+)
+
+// batchExchange fails the submit orders and the orders with zero price to simulate the partial success
+type batchExchange struct {
+	*mocks.MockExchange
+
+	nextOrderID uint64
+}
+
+func (e *batchExchange) BatchSubmitOrders(ctx context.Context, orders ...types.SubmitOrder) (types.OrderSlice, []int, error) {
+	var createdOrders types.OrderSlice
+	var errIndexes []int
+	for i, o := range orders {
+		if o.Price.IsZero() {
+			errIndexes = append(errIndexes, i)
+			continue
+		}
+
+		e.nextOrderID++
+		// the exchange does not know the order tag
+		o.Tag = ""
+		createdOrders = append(createdOrders, types.Order{SubmitOrder: o, OrderID: e.nextOrderID, Status: types.OrderStatusNew})
+	}
+
+	if len(errIndexes) > 0 {
+		return createdOrders, errIndexes, errors.New("invalid price")
+	}
+
+	return createdOrders, nil, nil
+}
+
+func (e *batchExchange) BatchCancelOrders(ctx context.Context, orders ...types.Order) ([]types.Order, error) {
+	var failedOrders []types.Order
+	for _, o := range orders {
+		if o.Price.IsZero() {
+			failedOrders = append(failedOrders, o)
+		}
+	}
+
+	if len(failedOrders) > 0 {
+		return failedOrders, errors.New("invalid price")
+	}
+
+	return nil, nil
+}
+
+func TestBatchPlaceOrder_BatchOrderService(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	ex := &batchExchange{MockExchange: mocks.NewMockExchange(mockCtrl)}
+
+	submitOrders := []types.SubmitOrder{
+		{Symbol: "BTCUSDT", Side: types.SideTypeBuy, Type: types.OrderTypeLimit, Price: Number(19000.0), Quantity: Number(0.01), Tag: "a"},
+		{Symbol: "BTCUSDT", Side: types.SideTypeBuy, Type: types.OrderTypeLimit, Price: Number(0), Quantity: Number(0.01), Tag: "b"},
+		{Symbol: "BTCUSDT", Side: types.SideTypeSell, Type: types.OrderTypeLimit, Price: Number(21000.0), Quantity: Number(0.01), Tag: "c"},
+	}
+
+	var callbackOrders []types.Order
+	createdOrders, errIndexes, err := BatchPlaceOrder(context.Background(), ex, func(order types.Order) {
+		callbackOrders = append(callbackOrders, order)
+	}, submitOrders...)
+	assert.Error(t, err)
+	assert.Equal(t, []int{1}, errIndexes)
+	if assert.Len(t, createdOrders, 2) {
+		assert.Equal(t, "a", createdOrders[0].Tag)
+		assert.Equal(t, "c", createdOrders[1].Tag)
+	}
+	assert.Equal(t, []types.Order(createdOrders), callbackOrders)
+}
+
+func TestBatchCancelOrder(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	orders := []types.Order{
+		{OrderID: 1, SubmitOrder: types.SubmitOrder{Symbol: "BTCUSDT", Price: Number(19000.0)}},
+		{OrderID: 2, SubmitOrder: types.SubmitOrder{Symbol: "BTCUSDT", Price: Number(0)}},
+	}
+
+	t.Run("batch order service", func(t *testing.T) {
+		ex := &batchExchange{MockExchange: mocks.NewMockExchange(mockCtrl)}
+		failedOrders, err := BatchCancelOrder(context.Background(), ex, orders...)
+		assert.Error(t, err)
+		if assert.Len(t, failedOrders, 1) {
+			assert.Equal(t, uint64(2), failedOrders[0].OrderID)
+		}
+	})
+
+	t.Run("fallback to cancel orders", func(t *testing.T) {
+		mockEx := mocks.NewMockExchange(mockCtrl)
+		mockEx.EXPECT().CancelOrders(gomock.Any(), orders).Return(errors.New("cancel error"))
+
+		failedOrders, err := BatchCancelOrder(context.Background(), mockEx, orders...)
+		assert.Error(t, err)
+		assert.Equal(t, orders, failedOrders)
+	})
+}
+
+// cancelAllExchange records the symbols canceled by the cancel-all api
+type cancelAllExchange struct {
+	*batchExchange
+
+	canceledSymbols []string
+}
+
+func (e *cancelAllExchange) CancelOrdersBySymbol(ctx context.Context, symbol string) ([]types.Order, error) {
+	e.canceledSymbols = append(e.canceledSymbols, symbol)
+	return nil, nil
+}
+
+func TestCancelAllOrdersOfSymbol(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	orders := []types.Order{
+		{OrderID: 1, SubmitOrder: types.SubmitOrder{Symbol: "BTCUSDT", Price: Number(19000.0)}},
+		{OrderID: 2, SubmitOrder: types.SubmitOrder{Symbol: "BTCUSDT", Price: Number(0)}},
+	}
+
+	t.Run("cancel-all api", func(t *testing.T) {
+		ex := &cancelAllExchange{batchExchange: &batchExchange{MockExchange: mocks.NewMockExchange(mockCtrl)}}
+		failedOrders, err := CancelAllOrdersOfSymbol(context.Background(), ex, "BTCUSDT", orders...)
+		assert.NoError(t, err)
+		assert.Empty(t, failedOrders)
+		assert.Equal(t, []string{"BTCUSDT"}, ex.canceledSymbols)
+	})
+
+	t.Run("the orders of the other symbol", func(t *testing.T) {
+		ex := &cancelAllExchange{batchExchange: &batchExchange{MockExchange: mocks.NewMockExchange(mockCtrl)}}
+		failedOrders, err := CancelAllOrdersOfSymbol(context.Background(), ex, "ETHUSDT", orders...)
+		assert.Error(t, err)
+		if assert.Len(t, failedOrders, 1) {
+			assert.Equal(t, uint64(2), failedOrders[0].OrderID)
+		}
+		assert.Empty(t, ex.canceledSymbols)
+	})
+}
+
+func TestActiveOrderBook_CancelAllOrdersApi(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	order := types.Order{
+		OrderID: 1,
+		SubmitOrder: types.SubmitOrder{
+			Symbol: "BTCUSDT",
+			Price:  Number(19000.0),
+		},
+		Status: types.OrderStatusNew,
+	}
+
+	t.Run("batch cancel by default", func(t *testing.T) {
+		mockEx := mocks.NewMockExchange(mockCtrl)
+		mockEx.EXPECT().CancelOrders(gomock.Any(), order).Return(nil)
+
+		ex := &cancelAllExchange{batchExchange: &batchExchange{MockExchange: mockEx}}
+		ob := NewActiveOrderBook("BTCUSDT")
+		ob.Add(order)
+
+		assert.NoError(t, ob.FastCancel(context.Background(), ex))
+		assert.Empty(t, ex.canceledSymbols)
+	})
+
+	t.Run("cancel-all api is enabled", func(t *testing.T) {
+		ex := &cancelAllExchange{batchExchange: &batchExchange{MockExchange: mocks.NewMockExchange(mockCtrl)}}
+		ob := NewActiveOrderBook("BTCUSDT")
+		ob.SetUseCancelAllOrdersApi(true)
+		ob.Add(order)
+
+		assert.NoError(t, ob.FastCancel(context.Background(), ex))
+		assert.Equal(t, []string{"BTCUSDT"}, ex.canceledSymbols)
+	})
+}
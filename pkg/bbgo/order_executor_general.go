@@ -13,6 +13,7 @@ import (
 	"github.com/c9s/bbgo/pkg/core"
 	"github.com/c9s/bbgo/pkg/exchange/retry"
 	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/service"
 	"github.com/c9s/bbgo/pkg/types"
 	"github.com/c9s/bbgo/pkg/util"
 	"github.com/c9s/bbgo/pkg/util/backoff"
@@ -58,7 +59,9 @@ type GeneralOrderExecutor struct {
 	strategy           string
 	strategyInstanceID string
 	position           *types.Position
+	profitStats        *types.ProfitStats
 	tradeCollector     *core.TradeCollector
+	environment        *Environment
 
 	logger log.FieldLogger
 
@@ -110,6 +113,12 @@ func (e *GeneralOrderExecutor) SetMaxRetries(maxRetries uint) {
 	e.maxRetries = maxRetries
 }
 
+// UseCancelAllOrdersApi lets the active maker orders be canceled by the cancel-all API of the exchange
+// when all of them are canceled, see CancelAllOrdersOfSymbol.
+func (e *GeneralOrderExecutor) UseCancelAllOrdersApi(enabled bool) {
+	e.activeMakerOrders.SetUseCancelAllOrdersApi(enabled)
+}
+
 func (e *GeneralOrderExecutor) startMarginAssetUpdater(ctx context.Context) {
 	marginService, ok := e.session.Exchange.(types.MarginBorrowRepayService)
 	if !ok {
@@ -159,11 +168,29 @@ func (e *GeneralOrderExecutor) marginAssetMaxBorrowableUpdater(
 }
 
 func (e *GeneralOrderExecutor) BindEnvironment(environ *Environment) {
+	e.environment = environ
+	if tracker := environ.StrategyInstanceNAVTracker; tracker != nil {
+		tracker.TrackPosition(e.session, e.strategy, e.strategyInstanceID, e.position, e.profitStats)
+	}
+
+	if hub := environ.StrategyEventHub; hub != nil {
+		hub.BindOrderExecutor(e)
+	}
+
 	e.tradeCollector.OnProfit(func(trade types.Trade, profit *types.Profit) {
 		environ.RecordPosition(e.position, trade, profit)
 	})
 }
 
+// PublishEvent publishes the strategy event of the order executor, it does nothing if the strategy event hub is not enabled
+func (e *GeneralOrderExecutor) PublishEvent(eventType StrategyEventType, data interface{}) {
+	if e.environment == nil || e.environment.StrategyEventHub == nil {
+		return
+	}
+
+	e.environment.StrategyEventHub.PublishData(eventType, e.session.Name, e.strategy, e.strategyInstanceID, data)
+}
+
 func (e *GeneralOrderExecutor) BindTradeStats(tradeStats *types.TradeStats) {
 	e.tradeCollector.OnProfit(func(trade types.Trade, profit *types.Profit) {
 		if profit == nil {
@@ -175,6 +202,11 @@ func (e *GeneralOrderExecutor) BindTradeStats(tradeStats *types.TradeStats) {
 }
 
 func (e *GeneralOrderExecutor) BindProfitStats(profitStats *types.ProfitStats) {
+	e.profitStats = profitStats
+	if e.environment != nil && e.environment.StrategyInstanceNAVTracker != nil {
+		e.environment.StrategyInstanceNAVTracker.TrackPosition(e.session, e.strategy, e.strategyInstanceID, nil, profitStats)
+	}
+
 	e.tradeCollector.OnProfit(func(trade types.Trade, profit *types.Profit) {
 		profitStats.AddTrade(trade)
 		if profit == nil {
@@ -194,6 +226,10 @@ func (e *GeneralOrderExecutor) Bind() {
 	e.activeMakerOrders.BindStream(e.session.UserDataStream)
 	e.orderStore.BindStream(e.session.UserDataStream)
 
+	if resyncer := e.session.UserDataResyncer(); resyncer != nil {
+		resyncer.AddActiveOrderBook(e.activeMakerOrders)
+	}
+
 	if !e.disableNotify {
 		// trade notify
 		e.tradeCollector.OnTrade(func(trade types.Trade, profit, netProfit fixedpoint.Value) {
@@ -211,9 +247,9 @@ func (e *GeneralOrderExecutor) Bind() {
 
 // CancelOrders cancels the given order objects directly
 func (e *GeneralOrderExecutor) CancelOrders(ctx context.Context, orders ...types.Order) error {
-	err := e.session.Exchange.CancelOrders(ctx, orders...)
-	if err != nil { // Retry once
-		err = e.session.Exchange.CancelOrders(ctx, orders...)
+	failedOrders, err := BatchCancelOrder(ctx, e.session.Exchange, orders...)
+	if err != nil { // Retry the failed orders once
+		_, err = BatchCancelOrder(ctx, e.session.Exchange, failedOrders...)
 	}
 	return err
 }
@@ -233,6 +269,10 @@ func (e *GeneralOrderExecutor) SubmitOrders(
 	orderCreateCallback := func(createdOrder types.Order) {
 		e.orderStore.Add(createdOrder)
 		e.activeMakerOrders.Add(createdOrder)
+
+		if e.environment != nil && e.environment.StrategyInstanceNAVTracker != nil {
+			e.environment.StrategyInstanceNAVTracker.AddOrder(e.session, e.strategy, e.strategyInstanceID, createdOrder)
+		}
 	}
 
 	defer e.tradeCollector.Process()
@@ -480,6 +520,59 @@ func (e *GeneralOrderExecutor) GracefulCancel(ctx context.Context, orders ...typ
 	return nil
 }
 
+// AmendOrder amends the price and the quantity of the active order, a zero price or quantity means the field is not changed,
+// the quantity of a partially filled order defaults to the remaining quantity.
+// If the exchange implements types.ExchangeOrderAmendService, the order is amended through the exchange API,
+// otherwise, the order is canceled and a new order is submitted.
+// The active order book and the order store are updated with the new order, since the order ID might be changed.
+func (e *GeneralOrderExecutor) AmendOrder(ctx context.Context, order types.Order, newPrice, newQuantity fixedpoint.Value) (*types.Order, error) {
+	if service, ok := e.session.Exchange.(types.ExchangeOrderAmendService); ok {
+		newOrder, err := service.AmendOrder(ctx, order, newPrice, newQuantity)
+		if err == nil {
+			if newOrder == nil {
+				return nil, fmt.Errorf("amended order #%d is not returned", order.OrderID)
+			}
+
+			newOrder.Market = order.Market
+			newOrder.Tag = order.Tag
+			newOrder.GroupID = order.GroupID
+
+			e.orderStore.Add(*newOrder)
+			e.activeMakerOrders.Replace(order, *newOrder)
+			return newOrder, nil
+		}
+
+		if !errors.Is(err, types.ErrOrderAmendNotSupported) {
+			return nil, err
+		}
+
+		log.WithError(err).Warnf("amend order is not supported, falling back to cancel and submit")
+	}
+
+	amendedOrder, err := order.Amend(newPrice, newQuantity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.GracefulCancel(ctx, order); err != nil {
+		return nil, err
+	}
+
+	submitOrder := amendedOrder.SubmitOrder
+	submitOrder.ClientOrderID = ""
+
+	createdOrders, err := e.SubmitOrders(ctx, submitOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(createdOrders) == 0 {
+		return nil, fmt.Errorf("replaced order of #%d is not created", order.OrderID)
+	}
+
+	return &createdOrders[0], nil
+}
+
 var ErrPositionAlreadyClosing = errors.New("position is already in closing process")
 
 // ClosePosition closes the current position by a percentage.
@@ -555,6 +648,16 @@ func (e *GeneralOrderExecutor) TradeCollector() *core.TradeCollector {
 	return e.tradeCollector
 }
 
+// PersistenceService returns the persistence service of the bound environment,
+// the default (in-memory) persistence service is returned if the persistence is not configured.
+func (e *GeneralOrderExecutor) PersistenceService() service.PersistenceService {
+	if e.environment != nil && e.environment.PersistentService != nil {
+		return e.environment.PersistentService.Get()
+	}
+
+	return defaultPersistenceServiceFacade.Get()
+}
+
 func (e *GeneralOrderExecutor) Session() *ExchangeSession {
 	return e.session
 }
@@ -0,0 +1,126 @@
+package bbgo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	. "github.com/c9s/bbgo/pkg/testing/testhelper"
+	"github.com/c9s/bbgo/pkg/types"
+	"github.com/c9s/bbgo/pkg/types/mocks"
+)
+
+// amendExchange replaces the amended order with a new order ID like the cancel-replace API
+type amendExchange struct {
+	*mocks.MockExchange
+}
+
+func (e *amendExchange) AmendOrder(ctx context.Context, order types.Order, newPrice, newQuantity fixedpoint.Value) (*types.Order, error) {
+	newOrder := order
+	newOrder.OrderID = order.OrderID + 1
+	newOrder.Price = newPrice
+	return &newOrder, nil
+}
+
+func TestGeneralOrderExecutor_AmendOrder(t *testing.T) {
+	market := getTestMarket()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockEx := mocks.NewMockExchange(mockCtrl)
+	mockEx.EXPECT().NewStream().Return(&types.StandardStream{}).Times(2)
+
+	session := NewExchangeSession("test", &amendExchange{MockExchange: mockEx})
+	position := types.NewPositionFromMarket(market)
+	orderExecutor := NewGeneralOrderExecutor(session, "BTCUSDT", "test", "test-01", position)
+
+	order := types.Order{
+		OrderID: 1,
+		SubmitOrder: types.SubmitOrder{
+			Symbol:   "BTCUSDT",
+			Side:     types.SideTypeBuy,
+			Type:     types.OrderTypeLimit,
+			Quantity: Number("0.01"),
+			Price:    Number(19000.0),
+			Market:   market,
+			Tag:      "test",
+		},
+		Status: types.OrderStatusNew,
+	}
+	orderExecutor.ActiveMakerOrders().Add(order)
+	orderExecutor.OrderStore().Add(order)
+
+	newOrder, err := orderExecutor.AmendOrder(context.Background(), order, Number(19100.0), fixedpoint.Zero)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(2), newOrder.OrderID)
+		assert.Equal(t, "test", newOrder.Tag)
+	}
+
+	assert.Equal(t, 1, orderExecutor.ActiveMakerOrders().NumOfOrders())
+	_, ok := orderExecutor.ActiveMakerOrders().Get(2)
+	assert.True(t, ok)
+	assert.True(t, orderExecutor.OrderStore().Exists(2))
+}
+
+func TestGeneralOrderExecutor_AmendOrder_PartiallyFilled(t *testing.T) {
+	IsBackTesting = true
+	defer func() {
+		IsBackTesting = false
+	}()
+
+	market := getTestMarket()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// the mock exchange does not support amending orders, so the order is canceled and submitted again
+	mockEx := mocks.NewMockExchange(mockCtrl)
+	mockEx.EXPECT().NewStream().Return(&types.StandardStream{}).Times(2)
+
+	session := NewExchangeSession("test", mockEx)
+	session.markets["BTCUSDT"] = market
+	position := types.NewPositionFromMarket(market)
+	orderExecutor := NewGeneralOrderExecutor(session, "BTCUSDT", "test", "test-01", position)
+
+	order := types.Order{
+		OrderID: 1,
+		SubmitOrder: types.SubmitOrder{
+			Symbol:   "BTCUSDT",
+			Side:     types.SideTypeBuy,
+			Type:     types.OrderTypeLimit,
+			Quantity: Number("0.01"),
+			Price:    Number(19000.0),
+			Market:   market,
+		},
+		Status:           types.OrderStatusPartiallyFilled,
+		ExecutedQuantity: Number("0.004"),
+	}
+	orderExecutor.ActiveMakerOrders().Add(order)
+
+	t.Run("remaining quantity", func(t *testing.T) {
+		mockEx.EXPECT().CancelOrders(gomock.Any(), order).Return(nil)
+		mockEx.EXPECT().SubmitOrder(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, submitOrder types.SubmitOrder) (*types.Order, error) {
+			assert.Equal(t, Number("0.006"), submitOrder.Quantity)
+			assert.Equal(t, Number(19100.0), submitOrder.Price)
+			return &types.Order{SubmitOrder: submitOrder, OrderID: 2, Status: types.OrderStatusNew}, nil
+		})
+
+		newOrder, err := orderExecutor.AmendOrder(context.Background(), order, Number(19100.0), fixedpoint.Zero)
+		if assert.NoError(t, err) {
+			assert.Equal(t, uint64(2), newOrder.OrderID)
+		}
+	})
+
+	t.Run("remaining quantity below the min quantity", func(t *testing.T) {
+		dustOrder := order
+		dustOrder.ExecutedQuantity = Number("0.0095")
+
+		// the order is not canceled
+		_, err := orderExecutor.AmendOrder(context.Background(), dustOrder, Number(19100.0), fixedpoint.Zero)
+		assert.Error(t, err)
+	})
+}
@@ -53,9 +53,9 @@ func (e *SimpleOrderExecutor) CancelOrders(ctx context.Context, orders ...types.
 		return nil
 	}
 
-	err := e.session.Exchange.CancelOrders(ctx, orders...)
-	if err != nil { // Retry once
-		err2 := e.session.Exchange.CancelOrders(ctx, orders...)
+	failedOrders, err := BatchCancelOrder(ctx, e.session.Exchange, orders...)
+	if err != nil { // Retry the failed orders once
+		_, err2 := BatchCancelOrder(ctx, e.session.Exchange, failedOrders...)
 		if err2 != nil {
 			return multierr.Append(err, err2)
 		}
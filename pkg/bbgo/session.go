@@ -15,6 +15,7 @@ import (
 
 	"github.com/c9s/bbgo/pkg/cache"
 	"github.com/c9s/bbgo/pkg/core"
+	"github.com/c9s/bbgo/pkg/exchange/ratelimit"
 	"github.com/c9s/bbgo/pkg/exchange/retry"
 	"github.com/c9s/bbgo/pkg/util/templateutil"
 
@@ -69,6 +70,17 @@ type ExchangeSession struct {
 	IsolatedFutures       bool   `json:"isolatedFutures,omitempty" yaml:"isolatedFutures,omitempty"`
 	IsolatedFuturesSymbol string `json:"isolatedFuturesSymbol,omitempty" yaml:"isolatedFuturesSymbol,omitempty"`
 
+	// RateLimits overrides the request weight limits of the exchange by the api scope, e.g., spot or futures.
+	// The limiters are shared by the sessions with the same api key.
+	RateLimits map[string]ratelimit.Config `json:"rateLimits,omitempty" yaml:"rateLimits,omitempty"`
+
+	// DisableUserDataResync disables the order and trade resync after the user data stream is reconnected
+	DisableUserDataResync bool `json:"disableUserDataResync,omitempty" yaml:"disableUserDataResync,omitempty"`
+
+	// OrderBookIntegrity enables the checksum, crossed book and staleness checks of the streaming order books.
+	// The broken book is reset and re-snapshotted.
+	OrderBookIntegrity *types.BookIntegrityConfig `json:"orderBookIntegrity,omitempty" yaml:"orderBookIntegrity,omitempty"`
+
 	// ---------------------------
 	// Runtime fields
 	// ---------------------------
@@ -122,6 +134,8 @@ type ExchangeSession struct {
 
 	orderStores map[string]*core.OrderStore
 
+	userDataResyncer *UserDataResyncer
+
 	usedSymbols        map[string]struct{}
 	initializedSymbols map[string]struct{}
 
@@ -296,6 +310,13 @@ func (session *ExchangeSession) Init(ctx context.Context, environ *Environment)
 
 		session.bindConnectionStatusNotification(session.UserDataStream, "user data")
 
+		if !session.DisableUserDataResync {
+			if emitter, ok := session.UserDataStream.(types.StandardStreamEmitter); ok {
+				session.userDataResyncer = NewUserDataResyncer(session, emitter)
+				session.userDataResyncer.Bind()
+			}
+		}
+
 		// if metrics mode is enabled, we bind the callbacks to update metrics
 		if viper.GetBool("metrics") {
 			session.bindUserDataStreamMetrics(session.UserDataStream)
@@ -468,6 +489,10 @@ func (session *ExchangeSession) initSymbol(ctx context.Context, environ *Environ
 			book.BindStream(session.MarketDataStream)
 			session.orderBooks[sub.Symbol] = book
 
+			if session.OrderBookIntegrity != nil {
+				session.enableOrderBookIntegrityCheck(ctx, book)
+			}
+
 		case types.KLineChannel:
 			if sub.Options.Interval == "" {
 				continue
@@ -664,6 +689,11 @@ func (session *ExchangeSession) OrderStore(symbol string) (store *core.OrderStor
 	return store, ok
 }
 
+// UserDataResyncer returns the resyncer of the user data stream, it's nil if the resync is disabled
+func (session *ExchangeSession) UserDataResyncer() *UserDataResyncer {
+	return session.userDataResyncer
+}
+
 func (session *ExchangeSession) OrderStores() map[string]*core.OrderStore {
 	return session.orderStores
 }
@@ -805,6 +835,31 @@ func (session *ExchangeSession) newBasicPrivateExchange(exchangeName types.Excha
 	return nil, fmt.Errorf("exchange %T does not implement types.Exchange", exMinimal)
 }
 
+// configureRateLimits applies the rate limit configs to the shared rate limiters of the exchange
+func (session *ExchangeSession) configureRateLimits(ex types.Exchange) error {
+	if len(session.RateLimits) == 0 {
+		return nil
+	}
+
+	provider, ok := ex.(ratelimit.Provider)
+	if !ok {
+		return fmt.Errorf("exchange %s does not support rate limit config", session.ExchangeName)
+	}
+
+	for _, limiter := range provider.RateLimiters() {
+		config, ok := session.RateLimits[limiter.Key().Scope]
+		if !ok {
+			continue
+		}
+
+		if err := limiter.Configure(config); err != nil {
+			return fmt.Errorf("invalid %s rate limit config: %w", limiter.Key().Scope, err)
+		}
+	}
+
+	return nil
+}
+
 // InitExchange initialize the exchange instance and allocate memory for fields
 // In this stage, the session var could be loaded from the JSON config, so the pointer fields are still nil
 // The Init method will be called after this stage, environment.Init will call the session.Init method later.
@@ -851,6 +906,10 @@ func (session *ExchangeSession) InitExchange(name string, ex types.Exchange) err
 		}
 	}
 
+	if err := session.configureRateLimits(ex); err != nil {
+		return err
+	}
+
 	session.Name = name
 	session.Exchange = ex
 	session.UserDataStream = ex.NewStream()
@@ -994,6 +1053,44 @@ func (session *ExchangeSession) bindUserDataStreamMetrics(stream types.Stream) {
 	})
 }
 
+// CaptureStreams records the raw websocket messages of the user data stream and the market data stream
+// to a compressed capture file of the session in the given directory, the returned writer should be closed on exit.
+func (session *ExchangeSession) CaptureStreams(dir string) (*types.CaptureWriter, error) {
+	writer, filePath, err := types.CreateCaptureFile(dir, session.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	writer.Record(session.Name, "user", session.UserDataStream)
+	writer.Record(session.Name, "market", session.MarketDataStream)
+
+	session.logger.Infof("capturing the websocket messages to %s", filePath)
+	return writer, nil
+}
+
+// enableOrderBookIntegrityCheck enables the integrity check of the stream book, the integrity issues are
+// counted in the metrics and notified.
+func (session *ExchangeSession) enableOrderBookIntegrityCheck(ctx context.Context, book *types.StreamOrderBook) {
+	market, ok := session.markets[book.Symbol]
+	if !ok {
+		log.Warnf("market %s is not defined, the order book checksum can not be verified", book.Symbol)
+	}
+
+	book.OnIntegrityIssue(func(issue types.BookIntegrityIssue) {
+		log.Warnf("session %s %s", session.Name, issue.String())
+
+		metricsOrderBookIntegrityIssues.With(prometheus.Labels{
+			"exchange": session.ExchangeName.String(),
+			"symbol":   issue.Symbol,
+			"issue":    string(issue.Type),
+		}).Inc()
+
+		Notify("session %s %s, re-snapshotting the order book", session.Name, issue.String())
+	})
+
+	book.EnableIntegrityCheck(ctx, session.MarketDataStream, market, *session.OrderBookIntegrity)
+}
+
 func (session *ExchangeSession) bindConnectionStatusNotification(stream types.Stream, streamName string) {
 	stream.OnDisconnect(func() {
 		Notify("session %s %s stream disconnected", session.Name, streamName)
@@ -0,0 +1,296 @@
+package bbgo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+
+	"github.com/c9s/bbgo/pkg/exchange/retry"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+const (
+	// userDataResyncTimeout is the timeout of a resync after the user data stream is reconnected
+	userDataResyncTimeout = 3 * time.Minute
+
+	// userDataResyncLookBack is subtracted from the disconnect time, since the connection could be broken
+	// before the disconnect event is emitted
+	userDataResyncLookBack = time.Minute
+
+	// seenTradeTTL is how long the trade keys received from the stream are kept for the de-duplication
+	seenTradeTTL = 6 * time.Hour
+
+	maxSeenTrades = 5000
+)
+
+// UserDataResyncer detects the disconnect windows of the user data stream. When the stream is authenticated again,
+// it queries the open orders, the trades since the disconnection and the balances from the exchange, and emits the
+// missed order updates, trade updates and the balance snapshot to the stream.
+// Therefore, the order stores, the active order books and the trade collectors bound to the stream are synced
+// without the strategy specific recovery.
+type UserDataResyncer struct {
+	session *ExchangeSession
+	stream  types.StandardStreamEmitter
+
+	mu             sync.Mutex
+	disconnectedAt time.Time
+	resyncing      bool
+	seenTrades     map[types.TradeKey]time.Time
+	orderBooks     []*ActiveOrderBook
+
+	// now is the clock of the resyncer, it's replaced in the tests
+	now func() time.Time
+}
+
+func NewUserDataResyncer(session *ExchangeSession, stream types.StandardStreamEmitter) *UserDataResyncer {
+	return &UserDataResyncer{
+		session:    session,
+		stream:     stream,
+		seenTrades: make(map[types.TradeKey]time.Time),
+		now:        time.Now,
+	}
+}
+
+// AddActiveOrderBook adds the active order book, the working orders of the book are checked in the resync
+func (r *UserDataResyncer) AddActiveOrderBook(book *ActiveOrderBook) {
+	r.mu.Lock()
+	r.orderBooks = append(r.orderBooks, book)
+	r.mu.Unlock()
+}
+
+func (r *UserDataResyncer) Bind() {
+	r.stream.OnTradeUpdate(r.recordTrade)
+
+	r.stream.OnDisconnect(func() {
+		r.mu.Lock()
+		if r.disconnectedAt.IsZero() {
+			r.disconnectedAt = r.now()
+		}
+		r.mu.Unlock()
+	})
+
+	r.stream.OnAuth(func() {
+		r.mu.Lock()
+		if r.disconnectedAt.IsZero() || r.resyncing {
+			r.mu.Unlock()
+			return
+		}
+
+		since := r.disconnectedAt.Add(-userDataResyncLookBack)
+		r.disconnectedAt = time.Time{}
+		r.resyncing = true
+		r.mu.Unlock()
+
+		go func() {
+			defer func() {
+				r.mu.Lock()
+				r.resyncing = false
+				r.mu.Unlock()
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), userDataResyncTimeout)
+			defer cancel()
+
+			r.session.logger.Infof("user data stream reconnected, resyncing the orders and the trades since %s...", since)
+			if err := r.Resync(ctx, since); err != nil {
+				r.session.logger.WithError(err).Errorf("user data resync error")
+			}
+		}()
+	})
+}
+
+// Resync queries the orders, the trades since the given time and the balances, and emits the missed updates
+func (r *UserDataResyncer) Resync(ctx context.Context, since time.Time) error {
+	var errs error
+	for _, symbol := range r.symbols() {
+		if err := r.resyncSymbol(ctx, symbol, since); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("%s resync error: %w", symbol, err))
+		}
+	}
+
+	balances, err := r.session.Exchange.QueryAccountBalances(ctx)
+	if err != nil {
+		return multierr.Append(errs, fmt.Errorf("balances resync error: %w", err))
+	}
+
+	r.stream.EmitBalanceSnapshot(balances)
+	return errs
+}
+
+func (r *UserDataResyncer) resyncSymbol(ctx context.Context, symbol string, since time.Time) error {
+	// trades are emitted before the order updates, so that the trade collectors could still match the trades
+	// with the orders before the orders are removed from the order stores.
+	numOfTrades := 0
+	if service, ok := r.session.Exchange.(types.ExchangeTradeHistoryService); ok {
+		trades, err := service.QueryTrades(ctx, symbol, &types.TradeQueryOptions{StartTime: &since})
+		if err != nil {
+			return err
+		}
+
+		sort.Slice(trades, func(i, j int) bool {
+			return trades[i].Time.Before(trades[j].Time.Time())
+		})
+
+		for _, trade := range trades {
+			if r.isSeenTrade(trade) {
+				continue
+			}
+
+			numOfTrades++
+			r.stream.EmitTradeUpdate(trade)
+		}
+	} else {
+		r.session.logger.Warnf("exchange %s does not support the trade query, the missed trades are not resynced", r.session.ExchangeName)
+	}
+
+	openOrders, err := retry.QueryOpenOrdersUntilSuccessfulLite(ctx, r.session.Exchange, symbol)
+	if err != nil {
+		return err
+	}
+
+	updatedOrders, err := r.diffOrders(ctx, r.workingOrders(symbol), openOrders)
+	for _, order := range updatedOrders {
+		r.stream.EmitOrderUpdate(order)
+	}
+
+	r.session.logger.Infof("%s resynced %d missed trades and %d order updates", symbol, numOfTrades, len(updatedOrders))
+	return err
+}
+
+// diffOrders returns the order updates of the open orders that are new or changed, and the final states of
+// the working orders that are closed while the stream was disconnected.
+func (r *UserDataResyncer) diffOrders(ctx context.Context, workingOrders map[uint64]types.Order, openOrders []types.Order) ([]types.Order, error) {
+	var updatedOrders []types.Order
+	var errs error
+
+	openOrderIDs := make(map[uint64]struct{}, len(openOrders))
+	for _, openOrder := range openOrders {
+		openOrderIDs[openOrder.OrderID] = struct{}{}
+
+		order, ok := workingOrders[openOrder.OrderID]
+		if !ok || order.Status != openOrder.Status || order.ExecutedQuantity.Compare(openOrder.ExecutedQuantity) != 0 {
+			updatedOrders = append(updatedOrders, openOrder)
+		}
+	}
+
+	for orderID, order := range workingOrders {
+		if _, ok := openOrderIDs[orderID]; ok {
+			continue
+		}
+
+		closedOrder, err := r.queryOrder(ctx, order)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+
+		if types.IsActiveOrder(*closedOrder) {
+			// the order could be created after the open orders query
+			continue
+		}
+
+		closedOrder.Tag = order.Tag
+		updatedOrders = append(updatedOrders, *closedOrder)
+	}
+
+	sort.Slice(updatedOrders, func(i, j int) bool {
+		return updatedOrders[i].UpdateTime.Before(updatedOrders[j].UpdateTime.Time())
+	})
+
+	return updatedOrders, errs
+}
+
+func (r *UserDataResyncer) queryOrder(ctx context.Context, order types.Order) (*types.Order, error) {
+	service, ok := r.session.Exchange.(types.ExchangeOrderQueryService)
+	if !ok {
+		return nil, fmt.Errorf("exchange %s does not support the order query, order %d is not resynced", r.session.ExchangeName, order.OrderID)
+	}
+
+	return retry.QueryOrderUntilSuccessful(ctx, service, types.OrderQuery{
+		Symbol:        order.Symbol,
+		OrderID:       strconv.FormatUint(order.OrderID, 10),
+		ClientOrderID: order.ClientOrderID,
+	})
+}
+
+// workingOrders collects the working orders of the symbol from the order stores and the active order books
+func (r *UserDataResyncer) workingOrders(symbol string) map[uint64]types.Order {
+	orders := make(map[uint64]types.Order)
+	if store, ok := r.session.OrderStore(symbol); ok {
+		for _, order := range store.Orders() {
+			if types.IsActiveOrder(order) {
+				orders[order.OrderID] = order
+			}
+		}
+	}
+
+	r.mu.Lock()
+	books := r.orderBooks
+	r.mu.Unlock()
+
+	for _, book := range books {
+		if book.Symbol != symbol {
+			continue
+		}
+
+		for _, order := range book.Orders() {
+			if types.IsActiveOrder(order) {
+				orders[order.OrderID] = order
+			}
+		}
+	}
+
+	return orders
+}
+
+func (r *UserDataResyncer) symbols() []string {
+	symbolSet := make(map[string]struct{})
+	for symbol := range r.session.OrderStores() {
+		symbolSet[symbol] = struct{}{}
+	}
+
+	r.mu.Lock()
+	for _, book := range r.orderBooks {
+		if len(book.Symbol) > 0 {
+			symbolSet[book.Symbol] = struct{}{}
+		}
+	}
+	r.mu.Unlock()
+
+	symbols := make([]string, 0, len(symbolSet))
+	for symbol := range symbolSet {
+		symbols = append(symbols, symbol)
+	}
+
+	sort.Strings(symbols)
+	return symbols
+}
+
+func (r *UserDataResyncer) recordTrade(trade types.Trade) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.seenTrades[trade.Key()] = now
+
+	if len(r.seenTrades) > maxSeenTrades {
+		for key, t := range r.seenTrades {
+			if now.Sub(t) > seenTradeTTL {
+				delete(r.seenTrades, key)
+			}
+		}
+	}
+}
+
+func (r *UserDataResyncer) isSeenTrade(trade types.Trade) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.seenTrades[trade.Key()]
+	return ok
+}
@@ -0,0 +1,168 @@
+package bbgo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/core"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	. "github.com/c9s/bbgo/pkg/testing/testhelper"
+	"github.com/c9s/bbgo/pkg/types"
+	"github.com/c9s/bbgo/pkg/types/mocks"
+)
+
+// resyncExchange returns the closed orders and the trades that are missed while the stream was disconnected
+type resyncExchange struct {
+	*mocks.MockExchange
+
+	closedOrders map[string]types.Order
+	trades       []types.Trade
+}
+
+func (e *resyncExchange) QueryOrder(ctx context.Context, q types.OrderQuery) (*types.Order, error) {
+	order := e.closedOrders[q.OrderID]
+	return &order, nil
+}
+
+func (e *resyncExchange) QueryOrderTrades(ctx context.Context, q types.OrderQuery) ([]types.Trade, error) {
+	return nil, nil
+}
+
+func (e *resyncExchange) QueryTrades(ctx context.Context, symbol string, options *types.TradeQueryOptions) ([]types.Trade, error) {
+	return e.trades, nil
+}
+
+func (e *resyncExchange) QueryClosedOrders(ctx context.Context, symbol string, since, until time.Time, lastOrderID uint64) ([]types.Order, error) {
+	return nil, nil
+}
+
+func newResyncTestOrder(orderID uint64, status types.OrderStatus, executedQuantity fixedpoint.Value) types.Order {
+	return types.Order{
+		OrderID: orderID,
+		SubmitOrder: types.SubmitOrder{
+			Symbol:   "BTCUSDT",
+			Side:     types.SideTypeBuy,
+			Type:     types.OrderTypeLimit,
+			Quantity: Number(1.0),
+			Price:    Number(19000.0),
+		},
+		Status:           status,
+		ExecutedQuantity: executedQuantity,
+		Exchange:         types.ExchangeBinance,
+	}
+}
+
+func TestUserDataResyncer_Resync(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockEx := mocks.NewMockExchange(mockCtrl)
+	mockEx.EXPECT().NewStream().Return(&types.StandardStream{}).Times(2)
+
+	filledOrder := newResyncTestOrder(1, types.OrderStatusFilled, Number(1.0))
+	partiallyFilledOrder := newResyncTestOrder(2, types.OrderStatusPartiallyFilled, Number(0.5))
+	newOrder := newResyncTestOrder(3, types.OrderStatusNew, fixedpoint.Zero)
+	unchangedOrder := newResyncTestOrder(4, types.OrderStatusNew, fixedpoint.Zero)
+
+	seenTrade := types.Trade{ID: 1, OrderID: 2, Exchange: types.ExchangeBinance, Side: types.SideTypeBuy, Symbol: "BTCUSDT", Time: types.Time(time.Now())}
+	missedTrade := types.Trade{ID: 2, OrderID: 1, Exchange: types.ExchangeBinance, Side: types.SideTypeBuy, Symbol: "BTCUSDT", Time: types.Time(time.Now())}
+
+	ex := &resyncExchange{
+		MockExchange: mockEx,
+		closedOrders: map[string]types.Order{"1": filledOrder},
+		trades:       []types.Trade{seenTrade, missedTrade},
+	}
+
+	mockEx.EXPECT().QueryOpenOrders(gomock.Any(), "BTCUSDT").Return([]types.Order{partiallyFilledOrder, newOrder, unchangedOrder}, nil)
+	mockEx.EXPECT().QueryAccountBalances(gomock.Any()).Return(types.BalanceMap{
+		"USDT": {Currency: "USDT", Available: Number(1000.0)},
+	}, nil)
+
+	session := NewExchangeSession("test", ex)
+	stream := session.UserDataStream.(*types.StandardStream)
+
+	store := core.NewOrderStore("BTCUSDT")
+	store.Add(
+		newResyncTestOrder(1, types.OrderStatusNew, fixedpoint.Zero),
+		newResyncTestOrder(2, types.OrderStatusNew, fixedpoint.Zero),
+	)
+	session.orderStores["BTCUSDT"] = store
+
+	book := NewActiveOrderBook("BTCUSDT")
+	book.Add(unchangedOrder)
+
+	resyncer := NewUserDataResyncer(session, stream)
+	resyncer.AddActiveOrderBook(book)
+	resyncer.Bind()
+
+	// the trade received before the disconnection
+	stream.EmitTradeUpdate(seenTrade)
+
+	var trades []types.Trade
+	var orders []types.Order
+	var balances types.BalanceMap
+	stream.OnTradeUpdate(func(trade types.Trade) {
+		trades = append(trades, trade)
+	})
+	stream.OnOrderUpdate(func(order types.Order) {
+		orders = append(orders, order)
+	})
+	stream.OnBalanceSnapshot(func(snapshot types.BalanceMap) {
+		balances = snapshot
+	})
+
+	err := resyncer.Resync(context.Background(), time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []types.Trade{missedTrade}, trades)
+
+	orderStatus := map[uint64]types.OrderStatus{}
+	for _, order := range orders {
+		orderStatus[order.OrderID] = order.Status
+	}
+	assert.Equal(t, map[uint64]types.OrderStatus{
+		1: types.OrderStatusFilled,
+		2: types.OrderStatusPartiallyFilled,
+		3: types.OrderStatusNew,
+	}, orderStatus)
+
+	if assert.Contains(t, balances, "USDT") {
+		assert.Equal(t, Number(1000.0), balances["USDT"].Available)
+	}
+}
+
+func TestUserDataResyncer_Bind(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockEx := mocks.NewMockExchange(mockCtrl)
+	mockEx.EXPECT().NewStream().Return(&types.StandardStream{}).Times(2)
+	mockEx.EXPECT().QueryAccountBalances(gomock.Any()).Return(types.BalanceMap{}, nil).Times(1)
+
+	session := NewExchangeSession("test", mockEx)
+	stream := session.UserDataStream.(*types.StandardStream)
+
+	resyncer := NewUserDataResyncer(session, stream)
+	resyncer.Bind()
+
+	resynced := make(chan struct{}, 1)
+	stream.OnBalanceSnapshot(func(balances types.BalanceMap) {
+		resynced <- struct{}{}
+	})
+
+	// the first authentication is not a reconnection
+	stream.EmitAuth()
+
+	stream.EmitDisconnect()
+	stream.EmitAuth()
+
+	select {
+	case <-resynced:
+	case <-time.After(time.Second):
+		t.Fatal("the user data is not resynced after the reconnection")
+	}
+}
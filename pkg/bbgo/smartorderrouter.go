@@ -0,0 +1,256 @@
+package bbgo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.uber.org/multierr"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// RoutedOrder is a child order of the parent order on a venue
+type RoutedOrder struct {
+	Session     string
+	SubmitOrder types.SubmitOrder
+
+	// EffectiveAmount is the quote amount in the quote currency of the consolidated book after the taker fee,
+	// it's the cost for the buy orders and the proceeds for the sell orders.
+	EffectiveAmount fixedpoint.Value
+}
+
+// OrderRoute is the routing plan of a parent order
+type OrderRoute struct {
+	Side types.SideType
+
+	// Quantity is the routed base quantity
+	Quantity fixedpoint.Value
+
+	// Remaining is the quantity that can not be routed because of the book depth, the balances,
+	// the limit price or the minimal order size of the venues.
+	Remaining fixedpoint.Value
+
+	// EffectiveAmount is the total effective quote amount of the child orders
+	EffectiveAmount fixedpoint.Value
+
+	Orders []RoutedOrder
+}
+
+// AveragePrice returns the average effective price of the route
+func (r *OrderRoute) AveragePrice() fixedpoint.Value {
+	if r.Quantity.IsZero() {
+		return fixedpoint.Zero
+	}
+
+	return r.EffectiveAmount.Div(r.Quantity)
+}
+
+// SmartOrderRouter splits a parent order across the venues of the consolidated order book.
+// The price levels are taken from the best effective price until the parent quantity is filled,
+// the quantity of each venue is limited by the available balance of the venue session.
+type SmartOrderRouter struct {
+	Book *ConsolidatedOrderBook
+}
+
+func NewSmartOrderRouter(book *ConsolidatedOrderBook) *SmartOrderRouter {
+	return &SmartOrderRouter{Book: book}
+}
+
+type routeAllocation struct {
+	venue *consolidatedBookVenue
+
+	quantity        fixedpoint.Value
+	price           fixedpoint.Value
+	effectiveAmount fixedpoint.Value
+
+	// budget is the available balance of the session, the quote balance for buy and the base balance for sell,
+	// it's shared by the allocations of the same session and currency, nil means no limit.
+	budget *routeBudget
+}
+
+// routeBudget is the remaining available balance of a currency in a session
+type routeBudget struct {
+	amount fixedpoint.Value
+}
+
+// Route plans the child orders of the parent order. The limit price is the worst effective price in the quote
+// currency of the consolidated book, the levels beyond the limit price are not used. Zero limit price means no limit.
+// The venues that are added without the session are not limited by the balances.
+func (r *SmartOrderRouter) Route(side types.SideType, quantity, limitPrice fixedpoint.Value) (*OrderRoute, error) {
+	if quantity.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid parent order quantity: %s", quantity.String())
+	}
+
+	var levels []ConsolidatedPriceLevel
+	switch side {
+	case types.SideTypeBuy:
+		levels = r.Book.SideBook(types.SideTypeSell)
+	case types.SideTypeSell:
+		levels = r.Book.SideBook(types.SideTypeBuy)
+	default:
+		return nil, fmt.Errorf("unsupported side: %s", side)
+	}
+
+	remaining := quantity
+	allocations := make(map[string]*routeAllocation)
+	budgets := make(map[string]*routeBudget)
+	for _, level := range levels {
+		if remaining.Sign() <= 0 {
+			break
+		}
+
+		if !limitPrice.IsZero() {
+			if side == types.SideTypeBuy && level.EffectivePrice.Compare(limitPrice) > 0 {
+				break
+			} else if side == types.SideTypeSell && level.EffectivePrice.Compare(limitPrice) < 0 {
+				break
+			}
+		}
+
+		key := level.Session + "." + level.Symbol
+		allocation, ok := allocations[key]
+		if !ok {
+			venue, ok := r.Book.venue(level.Session, level.Symbol)
+			if !ok {
+				continue
+			}
+
+			allocation = newRouteAllocation(venue, side, budgets)
+			allocations[key] = allocation
+		}
+
+		q := fixedpoint.Min(level.Volume, remaining)
+		if budget := allocation.budget; budget != nil {
+			if side == types.SideTypeBuy {
+				unitCost := level.Price.Mul(fixedpoint.One.Add(allocation.venue.takerFeeRate))
+				q = fixedpoint.Min(q, budget.amount.Div(unitCost))
+				budget.amount = budget.amount.Sub(q.Mul(unitCost))
+			} else {
+				q = fixedpoint.Min(q, budget.amount)
+				budget.amount = budget.amount.Sub(q)
+			}
+		}
+
+		if q.Sign() <= 0 {
+			continue
+		}
+
+		allocation.quantity = allocation.quantity.Add(q)
+		allocation.price = level.Price
+		allocation.effectiveAmount = allocation.effectiveAmount.Add(q.Mul(level.EffectivePrice))
+		remaining = remaining.Sub(q)
+	}
+
+	route := &OrderRoute{
+		Side:      side,
+		Remaining: remaining,
+	}
+
+	for _, allocation := range allocations {
+		market := allocation.venue.market
+
+		orderQuantity := allocation.quantity
+		if market.StepSize.Sign() > 0 {
+			orderQuantity = market.TruncateQuantity(orderQuantity)
+		}
+
+		if orderQuantity.Compare(market.MinQuantity) < 0 || orderQuantity.Mul(allocation.price).Compare(market.MinNotional) < 0 {
+			route.Remaining = route.Remaining.Add(allocation.quantity)
+			continue
+		}
+
+		route.Remaining = route.Remaining.Add(allocation.quantity.Sub(orderQuantity))
+
+		effectiveAmount := allocation.effectiveAmount.Mul(orderQuantity).Div(allocation.quantity)
+		route.Quantity = route.Quantity.Add(orderQuantity)
+		route.EffectiveAmount = route.EffectiveAmount.Add(effectiveAmount)
+		route.Orders = append(route.Orders, RoutedOrder{
+			Session: allocation.venue.sessionName,
+			SubmitOrder: types.SubmitOrder{
+				Symbol:      market.Symbol,
+				Side:        side,
+				Type:        types.OrderTypeLimit,
+				Price:       allocation.price,
+				Quantity:    orderQuantity,
+				TimeInForce: types.TimeInForceIOC,
+				Market:      market,
+			},
+			EffectiveAmount: effectiveAmount,
+		})
+	}
+
+	sort.Slice(route.Orders, func(i, j int) bool {
+		return route.Orders[i].Session < route.Orders[j].Session
+	})
+
+	return route, nil
+}
+
+// newRouteAllocation creates the allocation of the venue, the budget is looked up from the budgets by the session and the currency,
+// so the venues of the same session share the base balance when selling to the markets of the different quote currencies.
+func newRouteAllocation(venue *consolidatedBookVenue, side types.SideType, budgets map[string]*routeBudget) *routeAllocation {
+	allocation := &routeAllocation{venue: venue}
+	if venue.session == nil {
+		return allocation
+	}
+
+	currency := venue.market.BaseCurrency
+	if side == types.SideTypeBuy {
+		currency = venue.market.QuoteCurrency
+	}
+
+	key := venue.sessionName + "." + currency
+	budget, ok := budgets[key]
+	if !ok {
+		budget = &routeBudget{}
+		if balance, ok := venue.session.GetAccount().Balance(currency); ok {
+			budget.amount = balance.Available
+		}
+
+		budgets[key] = budget
+	}
+
+	allocation.budget = budget
+	return allocation
+}
+
+// Execute submits the child orders of the route to the venue sessions concurrently
+func (r *SmartOrderRouter) Execute(ctx context.Context, route *OrderRoute) (types.OrderSlice, error) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var createdOrders types.OrderSlice
+	var errs error
+
+	for _, routedOrder := range route.Orders {
+		venue, ok := r.Book.venue(routedOrder.Session, routedOrder.SubmitOrder.Symbol)
+		if !ok || venue.session == nil {
+			errs = multierr.Append(errs, fmt.Errorf("session %s is not found for the routed order %s", routedOrder.Session, routedOrder.SubmitOrder.String()))
+			continue
+		}
+
+		wg.Add(1)
+		go func(session *ExchangeSession, submitOrder types.SubmitOrder) {
+			defer wg.Done()
+
+			createdOrder, err := session.Exchange.SubmitOrder(ctx, submitOrder)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("session %s order submit error: %w", session.Name, err))
+				return
+			}
+
+			if createdOrder != nil {
+				createdOrders = append(createdOrders, *createdOrder)
+			}
+		}(venue.session, routedOrder.SubmitOrder)
+	}
+
+	wg.Wait()
+	return createdOrders, errs
+}
@@ -0,0 +1,200 @@
+package bbgo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/c9s/bbgo/pkg/testing/testhelper"
+	"github.com/c9s/bbgo/pkg/types"
+	"github.com/c9s/bbgo/pkg/types/mocks"
+)
+
+func newRouterTestMarket(symbol, quoteCurrency string) types.Market {
+	return types.Market{
+		Symbol:        symbol,
+		BaseCurrency:  "BTC",
+		QuoteCurrency: quoteCurrency,
+		StepSize:      Number(0.0001),
+		TickSize:      Number(0.01),
+		MinQuantity:   Number(0.001),
+		MinNotional:   Number(10.0),
+	}
+}
+
+// priceVolumes creates the price volume slice from the price and volume pairs
+func priceVolumes(pairs ...float64) (slice types.PriceVolumeSlice) {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		slice = append(slice, types.PriceVolume{Price: Number(pairs[i]), Volume: Number(pairs[i+1])})
+	}
+	return slice
+}
+
+func newRouterTestBook(symbol string, bids, asks types.PriceVolumeSlice) *types.SliceOrderBook {
+	return &types.SliceOrderBook{Symbol: symbol, Bids: bids, Asks: asks}
+}
+
+func TestConsolidatedOrderBook_SideBook(t *testing.T) {
+	book := NewConsolidatedOrderBook("BTC", "USDT")
+	book.SetConversionRate("USDC", Number(0.99))
+
+	err := book.AddBook("binance", newRouterTestMarket("BTCUSDT", "USDT"), newRouterTestBook("BTCUSDT",
+		priceVolumes(19000, 1),
+		priceVolumes(19100, 1)), Number(0.001))
+	assert.NoError(t, err)
+
+	err = book.AddBook("okex", newRouterTestMarket("BTCUSDC", "USDC"), newRouterTestBook("BTCUSDC",
+		priceVolumes(19200, 1),
+		priceVolumes(19250, 1)), Number(0.002))
+	assert.NoError(t, err)
+
+	err = book.AddBook("max", newRouterTestMarket("BTCTWD", "TWD"), newRouterTestBook("BTCTWD", nil, nil), Number(0.001))
+	assert.Error(t, err, "the conversion rate of TWD is not defined")
+
+	asks := book.SideBook(types.SideTypeSell)
+	if assert.Len(t, asks, 2) {
+		// 19250 * 0.99 * 1.002 = 19095.615
+		assert.Equal(t, "okex", asks[0].Session)
+		assert.Equal(t, Number(19057.5), asks[0].NormalizedPrice)
+		assert.Equal(t, Number(19095.615), asks[0].EffectivePrice)
+
+		assert.Equal(t, "binance", asks[1].Session)
+		assert.Equal(t, Number(19119.1), asks[1].EffectivePrice)
+	}
+
+	bid, ask, ok := book.BestBidAndAsk()
+	if assert.True(t, ok) {
+		assert.Equal(t, "binance", bid.Session)
+		assert.Equal(t, "okex", ask.Session)
+	}
+}
+
+func TestSmartOrderRouter_Route(t *testing.T) {
+	book := NewConsolidatedOrderBook("BTC", "USDT")
+	_ = book.AddBook("binance", newRouterTestMarket("BTCUSDT", "USDT"), newRouterTestBook("BTCUSDT",
+		priceVolumes(19000, 1),
+		priceVolumes(19100, 0.5, 19110, 1)), Number(0.001))
+	_ = book.AddBook("okex", newRouterTestMarket("BTCUSDT", "USDT"), newRouterTestBook("BTCUSDT",
+		priceVolumes(19010, 0.2),
+		priceVolumes(19105, 0.3, 19200, 1)), Number(0.0))
+
+	router := NewSmartOrderRouter(book)
+
+	t.Run("buy", func(t *testing.T) {
+		route, err := router.Route(types.SideTypeBuy, Number(1.0), Number(0))
+		if assert.NoError(t, err) {
+			assert.Equal(t, Number(1.0), route.Quantity)
+			assert.Equal(t, Number(0), route.Remaining)
+			if assert.Len(t, route.Orders, 2) {
+				assert.Equal(t, "binance", route.Orders[0].Session)
+				assert.Equal(t, Number(0.7), route.Orders[0].SubmitOrder.Quantity)
+				assert.Equal(t, Number(19110), route.Orders[0].SubmitOrder.Price)
+				assert.Equal(t, types.TimeInForceIOC, route.Orders[0].SubmitOrder.TimeInForce)
+
+				assert.Equal(t, "okex", route.Orders[1].Session)
+				assert.Equal(t, Number(0.3), route.Orders[1].SubmitOrder.Quantity)
+				assert.Equal(t, Number(19105), route.Orders[1].SubmitOrder.Price)
+			}
+		}
+	})
+
+	t.Run("sell with limit price", func(t *testing.T) {
+		// binance bid 19000 * 0.999 = 18981, which is below the limit price
+		route, err := router.Route(types.SideTypeSell, Number(1.0), Number(19000))
+		if assert.NoError(t, err) {
+			assert.Equal(t, Number(0.2), route.Quantity)
+			assert.Equal(t, Number(0.8), route.Remaining)
+			if assert.Len(t, route.Orders, 1) {
+				assert.Equal(t, "okex", route.Orders[0].Session)
+			}
+		}
+	})
+}
+
+func TestSmartOrderRouter_Execute(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockEx := mocks.NewMockExchange(mockCtrl)
+	mockEx.EXPECT().NewStream().Return(&types.StandardStream{}).Times(2)
+
+	session := NewExchangeSession("binance", mockEx)
+	session.markets["BTCUSDT"] = newRouterTestMarket("BTCUSDT", "USDT")
+	session.TakerFeeRate = Number(0.001)
+	session.Account.UpdateBalances(types.BalanceMap{
+		"USDT": {Currency: "USDT", Available: Number(1001.0 * 10)},
+	})
+
+	streamBook := types.NewStreamBook("BTCUSDT")
+	streamBook.Load(types.SliceOrderBook{
+		Symbol: "BTCUSDT",
+		Bids:   priceVolumes(19000, 1),
+		Asks:   priceVolumes(10000, 1, 10100, 1),
+	})
+	session.orderBooks["BTCUSDT"] = streamBook
+
+	book := NewConsolidatedOrderBook("BTC", "USDT")
+	assert.NoError(t, book.AddSession(session, "BTCUSDT"))
+
+	router := NewSmartOrderRouter(book)
+
+	// the quote balance can only buy 1 BTC at 10000 with the fee
+	route, err := router.Route(types.SideTypeBuy, Number(2.0), Number(0))
+	if !assert.NoError(t, err) || !assert.Len(t, route.Orders, 1) {
+		return
+	}
+
+	assert.Equal(t, Number(1.0), route.Quantity)
+	assert.Equal(t, Number(1.0), route.Remaining)
+
+	submitOrder := route.Orders[0].SubmitOrder
+	mockEx.EXPECT().SubmitOrder(gomock.Any(), submitOrder).Return(&types.Order{
+		SubmitOrder: submitOrder,
+		OrderID:     1,
+		Status:      types.OrderStatusNew,
+	}, nil)
+
+	orders, err := router.Execute(context.Background(), route)
+	assert.NoError(t, err)
+	assert.Len(t, orders, 1)
+}
+
+func TestSmartOrderRouter_SharedBudget(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockEx := mocks.NewMockExchange(mockCtrl)
+	mockEx.EXPECT().NewStream().Return(&types.StandardStream{}).Times(2)
+
+	session := NewExchangeSession("binance", mockEx)
+	session.markets["BTCUSDT"] = newRouterTestMarket("BTCUSDT", "USDT")
+	session.markets["BTCUSDC"] = newRouterTestMarket("BTCUSDC", "USDC")
+	session.Account.UpdateBalances(types.BalanceMap{
+		"BTC": {Currency: "BTC", Available: Number(1.0)},
+	})
+
+	for _, symbol := range []string{"BTCUSDT", "BTCUSDC"} {
+		streamBook := types.NewStreamBook(symbol)
+		streamBook.Load(types.SliceOrderBook{
+			Symbol: symbol,
+			Bids:   priceVolumes(19000, 1),
+			Asks:   priceVolumes(19100, 1),
+		})
+		session.orderBooks[symbol] = streamBook
+	}
+
+	book := NewConsolidatedOrderBook("BTC", "USDT")
+	book.SetConversionRate("USDC", Number(1.0))
+	assert.NoError(t, book.AddSession(session, "BTCUSDT"))
+	assert.NoError(t, book.AddSession(session, "BTCUSDC"))
+
+	// the BTC balance is shared by both markets of the session
+	route, err := NewSmartOrderRouter(book).Route(types.SideTypeSell, Number(2.0), Number(0))
+	if assert.NoError(t, err) {
+		assert.Equal(t, Number(1.0), route.Quantity)
+		assert.Equal(t, Number(1.0), route.Remaining)
+		assert.Len(t, route.Orders, 1)
+	}
+}
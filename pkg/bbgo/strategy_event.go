@@ -0,0 +1,306 @@
+package bbgo
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+type StrategyEventType string
+
+const (
+	StrategyEventTypeOrder           StrategyEventType = "order"
+	StrategyEventTypeTrade           StrategyEventType = "trade"
+	StrategyEventTypePosition        StrategyEventType = "position"
+	StrategyEventTypeProfit          StrategyEventType = "profit"
+	StrategyEventTypeActiveOrderBook StrategyEventType = "activeOrderBook"
+	StrategyEventTypeRisk            StrategyEventType = "risk"
+	StrategyEventTypeLog             StrategyEventType = "log"
+)
+
+// StrategyEvent is the event of a strategy instance, the data is marshaled when the event is published,
+// so that the subscribers do not read the objects that are still updated by the strategy
+type StrategyEvent struct {
+	Type               StrategyEventType `json:"type"`
+	Session            string            `json:"session,omitempty"`
+	Strategy           string            `json:"strategy,omitempty"`
+	StrategyInstanceID string            `json:"strategyInstanceID,omitempty"`
+	Time               time.Time         `json:"time"`
+	Data               json.RawMessage   `json:"data,omitempty"`
+}
+
+// StrategyTradeEvent is the data of the trade event
+type StrategyTradeEvent struct {
+	Trade     types.Trade      `json:"trade"`
+	Profit    fixedpoint.Value `json:"profit"`
+	NetProfit fixedpoint.Value `json:"netProfit"`
+}
+
+// StrategyActiveOrderBookEvent is the data of the active order book event
+type StrategyActiveOrderBookEvent struct {
+	// Action is one of new, filled and canceled
+	Action      string      `json:"action"`
+	Order       types.Order `json:"order"`
+	NumOfOrders int         `json:"numOfOrders"`
+}
+
+// StrategyRiskEvent is the data of the risk control event
+type StrategyRiskEvent struct {
+	// RiskControl is the name of the risk control, e.g. circuitBreak
+	RiskControl string      `json:"riskControl"`
+	Message     string      `json:"message"`
+	Data        interface{} `json:"data,omitempty"`
+}
+
+// StrategyLogEvent is the data of the log event
+type StrategyLogEvent struct {
+	Level   string                 `json:"level"`
+	Message string                 `json:"message"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+// StrategyEventSubscriber receives the events of the subscribed topics from C.
+//
+// A topic is "<type>" or "<type>:<strategy ID or strategy instance ID>", "*" matches any type or any strategy,
+// e.g. "trade", "position:grid2-BTCUSDT-size-150", "*:grid2" and "*".
+type StrategyEventSubscriber struct {
+	C chan StrategyEvent
+
+	mu      sync.Mutex
+	topics  map[string]struct{}
+	dropped int64
+}
+
+// Subscribe adds the topics
+func (s *StrategyEventSubscriber) Subscribe(topics ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, topic := range topics {
+		s.topics[normalizeStrategyEventTopic(topic)] = struct{}{}
+	}
+}
+
+// Unsubscribe removes the topics
+func (s *StrategyEventSubscriber) Unsubscribe(topics ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, topic := range topics {
+		delete(s.topics, normalizeStrategyEventTopic(topic))
+	}
+}
+
+// Topics returns the subscribed topics
+func (s *StrategyEventSubscriber) Topics() (topics []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for topic := range s.topics {
+		topics = append(topics, topic)
+	}
+	return topics
+}
+
+// Dropped returns the number of the events dropped because the channel is full
+func (s *StrategyEventSubscriber) Dropped() int64 {
+	return atomic.LoadInt64(&s.dropped)
+}
+
+func (s *StrategyEventSubscriber) Match(event StrategyEvent) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, eventType := range []string{string(event.Type), "*"} {
+		for _, key := range []string{event.StrategyInstanceID, event.Strategy, "*"} {
+			if key == "" {
+				continue
+			}
+
+			if _, ok := s.topics[eventType+":"+key]; ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func normalizeStrategyEventTopic(topic string) string {
+	topic = strings.TrimSpace(topic)
+	if !strings.Contains(topic, ":") {
+		return topic + ":*"
+	}
+	return topic
+}
+
+// StrategyEventHub broadcasts the strategy events to the subscribers.
+// The events are dropped for the subscriber if its channel is full, so a slow subscriber never blocks the strategies.
+type StrategyEventHub struct {
+	mu          sync.Mutex
+	subscribers map[*StrategyEventSubscriber]struct{}
+}
+
+func NewStrategyEventHub() *StrategyEventHub {
+	return &StrategyEventHub{
+		subscribers: make(map[*StrategyEventSubscriber]struct{}),
+	}
+}
+
+// NewSubscriber registers a subscriber with the channel buffer size
+func (h *StrategyEventHub) NewSubscriber(bufferSize int) *StrategyEventSubscriber {
+	sub := &StrategyEventSubscriber{
+		C:      make(chan StrategyEvent, bufferSize),
+		topics: make(map[string]struct{}),
+	}
+
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+	return sub
+}
+
+// RemoveSubscriber unregisters the subscriber, the channel of the subscriber is not closed
+func (h *StrategyEventHub) RemoveSubscriber(sub *StrategyEventSubscriber) {
+	h.mu.Lock()
+	delete(h.subscribers, sub)
+	h.mu.Unlock()
+}
+
+func (h *StrategyEventHub) Publish(event StrategyEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers {
+		if !sub.Match(event) {
+			continue
+		}
+
+		select {
+		case sub.C <- event:
+		default:
+			atomic.AddInt64(&sub.dropped, 1)
+		}
+	}
+}
+
+// PublishData marshals the data and publishes the event, the data is not marshaled if there is no subscriber
+func (h *StrategyEventHub) PublishData(eventType StrategyEventType, session, strategy, instanceID string, data interface{}) {
+	h.mu.Lock()
+	numOfSubscribers := len(h.subscribers)
+	h.mu.Unlock()
+
+	if numOfSubscribers == 0 {
+		return
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		// do not log it with logrus, the log hook publishes the log events
+		return
+	}
+
+	h.Publish(StrategyEvent{
+		Type:               eventType,
+		Session:            session,
+		Strategy:           strategy,
+		StrategyInstanceID: instanceID,
+		Time:               time.Now(),
+		Data:               raw,
+	})
+}
+
+// BindOrderExecutor publishes the order, trade, position, profit and active order book events of the order executor
+func (h *StrategyEventHub) BindOrderExecutor(e *GeneralOrderExecutor) {
+	publish := func(eventType StrategyEventType, data interface{}) {
+		h.PublishData(eventType, e.session.Name, e.strategy, e.strategyInstanceID, data)
+	}
+
+	if e.session != nil && e.session.UserDataStream != nil {
+		e.session.UserDataStream.OnOrderUpdate(func(order types.Order) {
+			if e.orderStore.Exists(order.OrderID) || e.activeMakerOrders.Exists(order) {
+				publish(StrategyEventTypeOrder, order)
+			}
+		})
+	}
+
+	e.tradeCollector.OnTrade(func(trade types.Trade, profit, netProfit fixedpoint.Value) {
+		publish(StrategyEventTypeTrade, StrategyTradeEvent{Trade: trade, Profit: profit, NetProfit: netProfit})
+	})
+
+	e.tradeCollector.OnPositionUpdate(func(position *types.Position) {
+		publish(StrategyEventTypePosition, position)
+	})
+
+	e.tradeCollector.OnProfit(func(trade types.Trade, profit *types.Profit) {
+		if profit != nil {
+			publish(StrategyEventTypeProfit, profit)
+		}
+	})
+
+	activeOrderBookHandler := func(action string) func(order types.Order) {
+		return func(order types.Order) {
+			publish(StrategyEventTypeActiveOrderBook, StrategyActiveOrderBookEvent{
+				Action:      action,
+				Order:       order,
+				NumOfOrders: e.activeMakerOrders.NumOfOrders(),
+			})
+		}
+	}
+
+	e.activeMakerOrders.OnNew(activeOrderBookHandler("new"))
+	e.activeMakerOrders.OnFilled(activeOrderBookHandler("filled"))
+	e.activeMakerOrders.OnCanceled(activeOrderBookHandler("canceled"))
+}
+
+// StrategyEventLogHook publishes the log entries with the "strategy" field as the log events,
+// the strategy instance ID is read from the "strategy_instance" field if it's set
+type StrategyEventLogHook struct {
+	hub    *StrategyEventHub
+	levels []logrus.Level
+}
+
+// NewStrategyEventLogHook returns the log hook of the levels equal to or more severe than the given level
+func NewStrategyEventLogHook(hub *StrategyEventHub, level logrus.Level) *StrategyEventLogHook {
+	var levels []logrus.Level
+	for _, l := range logrus.AllLevels {
+		if l <= level {
+			levels = append(levels, l)
+		}
+	}
+
+	return &StrategyEventLogHook{hub: hub, levels: levels}
+}
+
+func (h *StrategyEventLogHook) Levels() []logrus.Level {
+	return h.levels
+}
+
+func (h *StrategyEventLogHook) Fire(e *logrus.Entry) error {
+	strategy, ok := e.Data["strategy"].(string)
+	if !ok {
+		return nil
+	}
+
+	instanceID, _ := e.Data["strategy_instance"].(string)
+	session, _ := e.Data["session"].(string)
+
+	fields := make(map[string]interface{}, len(e.Data))
+	for k, v := range e.Data {
+		if err, isErr := v.(error); isErr {
+			v = err.Error()
+		}
+		fields[k] = v
+	}
+
+	h.hub.PublishData(StrategyEventTypeLog, session, strategy, instanceID, StrategyLogEvent{
+		Level:   e.Level.String(),
+		Message: e.Message,
+		Fields:  fields,
+	})
+	return nil
+}
@@ -0,0 +1,154 @@
+package bbgo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/c9s/bbgo/pkg/testing/testhelper"
+	"github.com/c9s/bbgo/pkg/types"
+	"github.com/c9s/bbgo/pkg/types/mocks"
+)
+
+func TestStrategyEventSubscriber_Match(t *testing.T) {
+	hub := NewStrategyEventHub()
+	sub := hub.NewSubscriber(10)
+
+	trade := StrategyEvent{Type: StrategyEventTypeTrade, Strategy: "grid2", StrategyInstanceID: "grid2-BTCUSDT"}
+	position := StrategyEvent{Type: StrategyEventTypePosition, Strategy: "xmaker", StrategyInstanceID: "xmaker-ETHUSDT"}
+	assert.False(t, sub.Match(trade))
+
+	sub.Subscribe("trade")
+	assert.True(t, sub.Match(trade))
+	assert.False(t, sub.Match(position))
+
+	sub.Unsubscribe("trade")
+	sub.Subscribe("*:xmaker-ETHUSDT")
+	assert.False(t, sub.Match(trade))
+	assert.True(t, sub.Match(position))
+
+	sub.Unsubscribe("*:xmaker-ETHUSDT")
+	sub.Subscribe("position:grid2")
+	assert.False(t, sub.Match(trade))
+	assert.False(t, sub.Match(position))
+
+	sub.Subscribe("*")
+	assert.True(t, sub.Match(trade))
+	assert.ElementsMatch(t, []string{"position:grid2", "*:*"}, sub.Topics())
+}
+
+func TestStrategyEventHub_Publish(t *testing.T) {
+	hub := NewStrategyEventHub()
+	sub := hub.NewSubscriber(1)
+	sub.Subscribe("risk")
+
+	hub.PublishData(StrategyEventTypeRisk, "binance", "grid2", "grid2-BTCUSDT", StrategyRiskEvent{RiskControl: "circuitBreak"})
+	hub.PublishData(StrategyEventTypeRisk, "binance", "grid2", "grid2-BTCUSDT", StrategyRiskEvent{RiskControl: "circuitBreak"})
+	hub.PublishData(StrategyEventTypeLog, "binance", "grid2", "grid2-BTCUSDT", StrategyLogEvent{Message: "ignored"})
+	assert.Equal(t, int64(1), sub.Dropped())
+
+	event := <-sub.C
+	assert.Equal(t, StrategyEventTypeRisk, event.Type)
+	assert.Equal(t, "grid2-BTCUSDT", event.StrategyInstanceID)
+	assert.JSONEq(t, `{"riskControl":"circuitBreak","message":""}`, string(event.Data))
+
+	hub.RemoveSubscriber(sub)
+	hub.PublishData(StrategyEventTypeRisk, "binance", "grid2", "grid2-BTCUSDT", StrategyRiskEvent{RiskControl: "circuitBreak"})
+	assert.Len(t, sub.C, 0)
+}
+
+func TestStrategyEventHub_BindOrderExecutor(t *testing.T) {
+	market := getTestMarket()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockEx := mocks.NewMockExchange(mockCtrl)
+	mockEx.EXPECT().NewStream().Return(&types.StandardStream{}).Times(2)
+
+	session := NewExchangeSession("test", mockEx)
+	position := types.NewPositionFromMarket(market)
+	orderExecutor := NewGeneralOrderExecutor(session, "BTCUSDT", "test", "test-01", position)
+
+	hub := NewStrategyEventHub()
+	hub.BindOrderExecutor(orderExecutor)
+
+	sub := hub.NewSubscriber(10)
+	sub.Subscribe("trade:test-01", "position:test", "activeOrderBook")
+
+	order := types.Order{
+		OrderID: 1,
+		SubmitOrder: types.SubmitOrder{
+			Symbol:   "BTCUSDT",
+			Side:     types.SideTypeBuy,
+			Type:     types.OrderTypeLimit,
+			Quantity: Number("0.01"),
+			Price:    Number(19000.0),
+			Market:   market,
+		},
+		Status: types.OrderStatusNew,
+	}
+	orderExecutor.OrderStore().Add(order)
+	orderExecutor.ActiveMakerOrders().Add(order)
+
+	orderExecutor.TradeCollector().ProcessTrade(types.Trade{
+		ID:            1,
+		OrderID:       1,
+		Exchange:      types.ExchangeBinance,
+		Symbol:        "BTCUSDT",
+		Side:          types.SideTypeBuy,
+		Price:         Number(19000.0),
+		Quantity:      Number("0.01"),
+		QuoteQuantity: Number(190.0),
+		FeeCurrency:   "BNB",
+		Fee:           Number(0.0),
+	})
+
+	var eventTypes []StrategyEventType
+	for len(sub.C) > 0 {
+		event := <-sub.C
+		assert.Equal(t, "test", event.Session)
+		assert.Equal(t, "test-01", event.StrategyInstanceID)
+		eventTypes = append(eventTypes, event.Type)
+
+		if event.Type == StrategyEventTypePosition {
+			var p types.Position
+			if assert.NoError(t, json.Unmarshal(event.Data, &p)) {
+				assert.Equal(t, "0.01", p.Base.String())
+			}
+		}
+	}
+
+	assert.Equal(t, []StrategyEventType{
+		StrategyEventTypeActiveOrderBook,
+		StrategyEventTypeTrade,
+		StrategyEventTypePosition,
+	}, eventTypes)
+}
+
+func TestStrategyEventLogHook(t *testing.T) {
+	hub := NewStrategyEventHub()
+	sub := hub.NewSubscriber(10)
+	sub.Subscribe("log")
+
+	logger := logrus.New()
+	logger.AddHook(NewStrategyEventLogHook(hub, logrus.InfoLevel))
+
+	logger.Info("no strategy")
+	logger.WithField("strategy", "grid2").Debug("debug")
+	logger.WithFields(logrus.Fields{"strategy": "grid2", "strategy_instance": "grid2-BTCUSDT"}).Warn("warning")
+
+	if assert.Len(t, sub.C, 1) {
+		event := <-sub.C
+		assert.Equal(t, "grid2-BTCUSDT", event.StrategyInstanceID)
+
+		var data StrategyLogEvent
+		if assert.NoError(t, json.Unmarshal(event.Data, &data)) {
+			assert.Equal(t, "warning", data.Level)
+			assert.Equal(t, "warning", data.Message)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package bbgo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+var ErrStrategyInstanceNotFound = errors.New("strategy instance not found")
+
+var ErrStrategyControlNotSupported = errors.New("strategy control is not supported")
+
+// StrategyReloader reloads the running strategy, e.g. re-reads its parameters or re-creates its orders
+type StrategyReloader interface {
+	Reload(ctx context.Context) error
+}
+
+// StrategyInstance is a single exchange strategy attached on a session of the trader
+type StrategyInstance struct {
+	Session    string
+	InstanceID string
+	Strategy   SingleExchangeStrategy
+}
+
+// StrategyInstanceState is the snapshot of the running strategy instance
+type StrategyInstanceState struct {
+	Session      string               `json:"session"`
+	StrategyID   string               `json:"strategyID"`
+	InstanceID   string               `json:"instanceID"`
+	Status       types.StrategyStatus `json:"status,omitempty"`
+	Position     *types.Position      `json:"position,omitempty"`
+	ProfitStats  *types.ProfitStats   `json:"profitStats,omitempty"`
+	ActiveOrders []types.Order        `json:"activeOrders"`
+
+	// Controls are the supported controls: suspend, resume, emergencyStop, closePosition and reload
+	Controls []string `json:"controls"`
+}
+
+// StrategyInstances returns the single exchange strategies sorted by the session and the instance ID
+func (trader *Trader) StrategyInstances() []StrategyInstance {
+	var instances []StrategyInstance
+	for session, strategies := range trader.exchangeStrategies {
+		for _, strategy := range strategies {
+			signature, err := getStrategySignature(strategy)
+			if err != nil {
+				continue
+			}
+
+			instances = append(instances, StrategyInstance{
+				Session:    session,
+				InstanceID: signature,
+				Strategy:   strategy,
+			})
+		}
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		if instances[i].Session != instances[j].Session {
+			return instances[i].Session < instances[j].Session
+		}
+		return instances[i].InstanceID < instances[j].InstanceID
+	})
+	return instances
+}
+
+// FindStrategyInstance finds the strategy instance by the instance ID, the session is required
+// only if the instance ID is used on more than one session
+func (trader *Trader) FindStrategyInstance(session, instanceID string) (*StrategyInstance, error) {
+	var found []StrategyInstance
+	for _, instance := range trader.StrategyInstances() {
+		if instance.InstanceID == instanceID && (session == "" || instance.Session == session) {
+			found = append(found, instance)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, errors.Wrapf(ErrStrategyInstanceNotFound, "instance %s", instanceID)
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, fmt.Errorf("strategy instance %s is found on more than one session, the session is required", instanceID)
+	}
+}
+
+// State returns the status, the position, the profit stats and the active orders of the strategy instance
+func (i *StrategyInstance) State() StrategyInstanceState {
+	state := StrategyInstanceState{
+		Session:      i.Session,
+		StrategyID:   i.Strategy.ID(),
+		InstanceID:   i.InstanceID,
+		ActiveOrders: []types.Order{},
+		Controls:     []string{},
+	}
+
+	if reader, ok := i.Strategy.(StrategyStatusReader); ok {
+		state.Status = reader.GetStatus()
+	}
+
+	if reader, ok := i.Strategy.(PositionReader); ok {
+		state.Position = reader.CurrentPosition()
+	}
+
+	var orderIDs = make(map[uint64]struct{})
+	addOrders := func(orders []types.Order) {
+		for _, order := range orders {
+			if _, ok := orderIDs[order.OrderID]; !ok {
+				orderIDs[order.OrderID] = struct{}{}
+				state.ActiveOrders = append(state.ActiveOrders, order)
+			}
+		}
+	}
+
+	iterateStrategyFields(i.Strategy, func(fv reflect.Value) {
+		switch v := fv.Interface().(type) {
+		case *types.Position:
+			if state.Position == nil {
+				state.Position = v
+			}
+
+		case *types.ProfitStats:
+			if state.ProfitStats == nil {
+				state.ProfitStats = v
+			}
+
+		case *GeneralOrderExecutor:
+			if v.ActiveMakerOrders() != nil {
+				addOrders(v.ActiveMakerOrders().Orders())
+			}
+
+		case *ActiveOrderBook:
+			addOrders(v.Orders())
+		}
+	})
+
+	if _, ok := i.Strategy.(StrategyToggler); ok {
+		state.Controls = append(state.Controls, "suspend", "resume")
+	}
+
+	if _, ok := i.Strategy.(EmergencyStopper); ok {
+		state.Controls = append(state.Controls, "emergencyStop")
+	}
+
+	if _, ok := i.Strategy.(PositionCloser); ok {
+		state.Controls = append(state.Controls, "closePosition")
+	}
+
+	if _, ok := i.Strategy.(StrategyReloader); ok {
+		state.Controls = append(state.Controls, "reload")
+	}
+
+	return state
+}
+
+// Suspend suspends the running strategy, it does nothing if the strategy is not running
+func (i *StrategyInstance) Suspend() error {
+	toggler, ok := i.Strategy.(StrategyToggler)
+	if !ok {
+		return errors.Wrapf(ErrStrategyControlNotSupported, "strategy %s does not implement StrategyToggler", i.InstanceID)
+	}
+
+	if toggler.GetStatus() != types.StrategyStatusRunning {
+		return nil
+	}
+
+	return toggler.Suspend()
+}
+
+// Resume resumes the suspended strategy, it does nothing if the strategy is running
+func (i *StrategyInstance) Resume() error {
+	toggler, ok := i.Strategy.(StrategyToggler)
+	if !ok {
+		return errors.Wrapf(ErrStrategyControlNotSupported, "strategy %s does not implement StrategyToggler", i.InstanceID)
+	}
+
+	if toggler.GetStatus() != types.StrategyStatusStopped {
+		return nil
+	}
+
+	return toggler.Resume()
+}
+
+func (i *StrategyInstance) EmergencyStop() error {
+	stopper, ok := i.Strategy.(EmergencyStopper)
+	if !ok {
+		return errors.Wrapf(ErrStrategyControlNotSupported, "strategy %s does not implement EmergencyStopper", i.InstanceID)
+	}
+
+	return stopper.EmergencyStop()
+}
+
+func (i *StrategyInstance) ClosePosition(ctx context.Context, percentage fixedpoint.Value) error {
+	closer, ok := i.Strategy.(PositionCloser)
+	if !ok {
+		return errors.Wrapf(ErrStrategyControlNotSupported, "strategy %s does not implement PositionCloser", i.InstanceID)
+	}
+
+	if percentage.Sign() <= 0 || percentage.Compare(fixedpoint.One) > 0 {
+		return fmt.Errorf("invalid percentage %s, it should be in (0, 1]", percentage.String())
+	}
+
+	return closer.ClosePosition(ctx, percentage)
+}
+
+func (i *StrategyInstance) Reload(ctx context.Context) error {
+	reloader, ok := i.Strategy.(StrategyReloader)
+	if !ok {
+		return errors.Wrapf(ErrStrategyControlNotSupported, "strategy %s does not implement StrategyReloader", i.InstanceID)
+	}
+
+	return reloader.Reload(ctx)
+}
+
+// iterateStrategyFields iterates the exported non-nil pointer fields of the strategy, including the fields of
+// the exported embedded structs
+func iterateStrategyFields(obj interface{}, cb func(fv reflect.Value)) {
+	iterateStructFields(reflect.ValueOf(obj), cb)
+}
+
+func iterateStructFields(rv reflect.Value, cb func(fv reflect.Value)) {
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rv.NumField(); i++ {
+		ft := rt.Field(i)
+		fv := rv.Field(i)
+
+		if !fv.CanInterface() {
+			continue
+		}
+
+		if ft.Anonymous {
+			iterateStructFields(fv, cb)
+			continue
+		}
+
+		if fv.Kind() != reflect.Ptr || fv.IsNil() {
+			continue
+		}
+
+		cb(fv)
+	}
+}
@@ -0,0 +1,128 @@
+package bbgo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+type InstanceTestBase struct {
+	ProfitStats *types.ProfitStats
+	Orders      *ActiveOrderBook
+}
+
+type instanceTestStrategy struct {
+	*InstanceTestBase
+
+	Symbol   string `json:"symbol"`
+	Position *types.Position
+
+	status   types.StrategyStatus
+	suspends int
+	closed   fixedpoint.Value
+}
+
+func (s *instanceTestStrategy) ID() string { return "instancetest" }
+
+func (s *instanceTestStrategy) InstanceID() string { return s.ID() + ":" + s.Symbol }
+
+func (s *instanceTestStrategy) Run(ctx context.Context, orderExecutor OrderExecutor, session *ExchangeSession) error {
+	return nil
+}
+
+func (s *instanceTestStrategy) GetStatus() types.StrategyStatus { return s.status }
+
+func (s *instanceTestStrategy) Suspend() error {
+	s.suspends++
+	s.status = types.StrategyStatusStopped
+	return nil
+}
+
+func (s *instanceTestStrategy) Resume() error {
+	s.status = types.StrategyStatusRunning
+	return nil
+}
+
+func (s *instanceTestStrategy) ClosePosition(ctx context.Context, percentage fixedpoint.Value) error {
+	s.closed = percentage
+	return nil
+}
+
+func newInstanceTestTrader(strategies map[string][]SingleExchangeStrategy) *Trader {
+	trader := NewTrader(NewEnvironment())
+	trader.exchangeStrategies = strategies
+	return trader
+}
+
+func TestTrader_StrategyInstances(t *testing.T) {
+	market := getTestMarket()
+	orders := NewActiveOrderBook("BTCUSDT")
+	orders.Add(types.Order{OrderID: 1, SubmitOrder: types.SubmitOrder{Symbol: "BTCUSDT"}, Status: types.OrderStatusNew})
+
+	strategy := &instanceTestStrategy{
+		InstanceTestBase: &InstanceTestBase{
+			ProfitStats: types.NewProfitStats(market),
+			Orders:      orders,
+		},
+		Symbol:   "BTCUSDT",
+		Position: types.NewPositionFromMarket(market),
+		status:   types.StrategyStatusRunning,
+	}
+
+	trader := newInstanceTestTrader(map[string][]SingleExchangeStrategy{
+		"binance": {strategy},
+		"max":     {&myStrategy{Symbol: "ETHUSDT"}},
+	})
+
+	instances := trader.StrategyInstances()
+	if assert.Len(t, instances, 2) {
+		assert.Equal(t, "binance", instances[0].Session)
+		assert.Equal(t, "instancetest:BTCUSDT", instances[0].InstanceID)
+		assert.Equal(t, "max", instances[1].Session)
+		assert.Equal(t, "mystrategy:ETHUSDT", instances[1].InstanceID)
+	}
+
+	instance, err := trader.FindStrategyInstance("", "instancetest:BTCUSDT")
+	if assert.NoError(t, err) {
+		state := instance.State()
+		assert.Equal(t, types.StrategyStatusRunning, state.Status)
+		assert.Same(t, strategy.Position, state.Position)
+		assert.Same(t, strategy.ProfitStats, state.ProfitStats)
+		if assert.Len(t, state.ActiveOrders, 1) {
+			assert.Equal(t, uint64(1), state.ActiveOrders[0].OrderID)
+		}
+		assert.Equal(t, []string{"suspend", "resume", "closePosition"}, state.Controls)
+	}
+
+	_, err = trader.FindStrategyInstance("binance", "mystrategy:ETHUSDT")
+	assert.ErrorIs(t, err, ErrStrategyInstanceNotFound)
+}
+
+func TestStrategyInstance_Controls(t *testing.T) {
+	strategy := &instanceTestStrategy{Symbol: "BTCUSDT", status: types.StrategyStatusRunning}
+	instance := &StrategyInstance{Session: "binance", InstanceID: "instancetest:BTCUSDT", Strategy: strategy}
+
+	assert.NoError(t, instance.Suspend())
+	assert.NoError(t, instance.Suspend())
+	assert.Equal(t, 1, strategy.suspends)
+	assert.Equal(t, types.StrategyStatusStopped, strategy.status)
+
+	assert.NoError(t, instance.Resume())
+	assert.Equal(t, types.StrategyStatusRunning, strategy.status)
+
+	assert.NoError(t, instance.ClosePosition(context.Background(), fixedpoint.NewFromFloat(0.5)))
+	assert.Equal(t, fixedpoint.NewFromFloat(0.5), strategy.closed)
+	assert.Error(t, instance.ClosePosition(context.Background(), fixedpoint.NewFromFloat(1.5)))
+
+	assert.ErrorIs(t, instance.EmergencyStop(), ErrStrategyControlNotSupported)
+	assert.ErrorIs(t, instance.Reload(context.Background()), ErrStrategyControlNotSupported)
+
+	// the embedded struct is nil
+	state := instance.State()
+	assert.Nil(t, state.ProfitStats)
+	assert.Empty(t, state.ActiveOrders)
+}
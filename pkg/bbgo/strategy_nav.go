@@ -0,0 +1,485 @@
+package bbgo
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/service"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// maxPendingNAVTrades is the max number of the trades that are kept until their orders are attributed
+const maxPendingNAVTrades = 1000
+
+// maxNAVTradeKeys is the max number of the recent trade keys that are kept for ignoring the duplicated trades
+const maxNAVTradeKeys = 1000
+
+var defaultStrategyNAVInterval = types.Duration(time.Minute)
+
+type StrategyNAVConfig struct {
+	// Interval is the sampling interval of the net asset values, default 1m
+	Interval types.Duration `json:"interval,omitempty" yaml:"interval,omitempty"`
+}
+
+type navOrderKey struct {
+	session string
+	orderID uint64
+}
+
+type navPendingTrade struct {
+	session string
+	trade   types.Trade
+}
+
+type navPositionKey struct {
+	session string
+	symbol  string
+}
+
+type navPosition struct {
+	session  *ExchangeSession
+	position *types.Position
+}
+
+type strategyInstanceNAV struct {
+	strategy, instanceID string
+	session              *ExchangeSession
+
+	groupIDs map[uint32]struct{}
+	tags     map[string]struct{}
+
+	positions map[navPositionKey]*navPosition
+
+	// tradeKeys and tradeKeyQueue are the recent trade keys, the oldest key is dropped when the queue is full
+	tradeKeys     map[types.TradeKey]struct{}
+	tradeKeyQueue []types.TradeKey
+	numOfTrades   int
+
+	realizedProfit fixedpoint.Value
+	feeInUSD       fixedpoint.Value
+
+	// restored is true if the instance is restored from the stored net asset value
+	restored bool
+
+	last *types.StrategyInstanceNAV
+}
+
+// StrategyInstanceNAVTracker tracks the mark-to-market net asset value of each strategy instance.
+//
+// The trades are attributed to the strategy instance by the orders submitted by its order executor,
+// or the orders with the registered group IDs or tags. The realized and the unrealized profits are
+// gross profits, and the fees are converted to USD and deducted from the net asset value.
+type StrategyInstanceNAVTracker struct {
+	// Service is optional, the net asset values are not persisted if it's nil
+	Service *service.StrategyInstanceNAVService
+
+	Interval types.Duration
+
+	mu        sync.Mutex
+	instances map[string]*strategyInstanceNAV
+	orders    map[navOrderKey]*strategyInstanceNAV
+	pending   []navPendingTrade
+	sessions  map[string]*ExchangeSession
+}
+
+func NewStrategyInstanceNAVTracker(service *service.StrategyInstanceNAVService, interval types.Duration) *StrategyInstanceNAVTracker {
+	if interval == 0 {
+		interval = defaultStrategyNAVInterval
+	}
+
+	return &StrategyInstanceNAVTracker{
+		Service:   service,
+		Interval:  interval,
+		instances: make(map[string]*strategyInstanceNAV),
+		orders:    make(map[navOrderKey]*strategyInstanceNAV),
+		sessions:  make(map[string]*ExchangeSession),
+	}
+}
+
+// BindSession attributes the order updates and the trades of the session
+func (t *StrategyInstanceNAVTracker) BindSession(session *ExchangeSession) {
+	t.mu.Lock()
+	if _, ok := t.sessions[session.Name]; ok {
+		t.mu.Unlock()
+		return
+	}
+	t.sessions[session.Name] = session
+	t.mu.Unlock()
+
+	session.UserDataStream.OnOrderUpdate(func(order types.Order) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		key := navOrderKey{session: session.Name, orderID: order.OrderID}
+		if _, ok := t.orders[key]; ok {
+			return
+		}
+
+		for _, instance := range t.instances {
+			if instance.matchOrder(order) {
+				t.addOrder(session, order.OrderID, instance)
+				return
+			}
+		}
+	})
+
+	session.UserDataStream.OnTradeUpdate(func(trade types.Trade) {
+		t.addTrade(session, trade)
+	})
+}
+
+// Track registers the strategy instance, the first session is used as the session of the instance
+func (t *StrategyInstanceNAVTracker) Track(session *ExchangeSession, strategy, instanceID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.track(session, strategy, instanceID)
+}
+
+func (t *StrategyInstanceNAVTracker) track(session *ExchangeSession, strategy, instanceID string) *strategyInstanceNAV {
+	if instance, ok := t.instances[instanceID]; ok {
+		return instance
+	}
+
+	instance := &strategyInstanceNAV{
+		strategy:   strategy,
+		instanceID: instanceID,
+		session:    session,
+		groupIDs:   make(map[uint32]struct{}),
+		tags:       make(map[string]struct{}),
+		positions:  make(map[navPositionKey]*navPosition),
+		tradeKeys:  make(map[types.TradeKey]struct{}),
+	}
+	t.instances[instanceID] = instance
+
+	if t.Service != nil {
+		t.restore(instance)
+	}
+
+	return instance
+}
+
+// restore seeds the profit, the fee and the number of trades of the instance from its last stored net asset value,
+// so that the net asset value is not reset after the restart
+func (t *StrategyInstanceNAVTracker) restore(instance *strategyInstanceNAV) {
+	navs, err := t.Service.Query(service.QueryStrategyInstanceNAVOptions{
+		StrategyInstanceID: instance.instanceID,
+		Ordering:           "DESC",
+		Limit:              1,
+	})
+	if err != nil {
+		log.WithError(err).Errorf("can not query the last nav of the strategy instance %s", instance.instanceID)
+		return
+	}
+
+	if len(navs) == 0 {
+		return
+	}
+
+	nav := navs[0]
+	instance.realizedProfit = nav.RealizedProfit
+	instance.feeInUSD = nav.FeeInUSD
+	instance.numOfTrades = nav.NumOfTrades
+	instance.last = &nav
+	instance.restored = true
+}
+
+// TrackPosition registers the strategy instance with its persisted position and profit stats.
+//
+// The position is copied as the opening position of the instance, so that the trades closing the position
+// opened before the restart do not open a reverse position. The realized profit and the fee are seeded
+// from the profit stats if the instance is not restored from the stored net asset value.
+// Both the position and the profit stats can be nil.
+func (t *StrategyInstanceNAVTracker) TrackPosition(session *ExchangeSession, strategy, instanceID string, position *types.Position, profitStats *types.ProfitStats) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	instance := t.track(session, strategy, instanceID)
+	if position != nil {
+		instance.seedPosition(session, position)
+	}
+
+	if profitStats != nil {
+		instance.seedProfitStats(session, profitStats)
+	}
+}
+
+// AddGroupID attributes the orders of the group ID to the strategy instance
+func (t *StrategyInstanceNAVTracker) AddGroupID(session *ExchangeSession, strategy, instanceID string, groupID uint32) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.track(session, strategy, instanceID).groupIDs[groupID] = struct{}{}
+}
+
+// AddTag attributes the orders of the tag to the strategy instance
+func (t *StrategyInstanceNAVTracker) AddTag(session *ExchangeSession, strategy, instanceID string, tag string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.track(session, strategy, instanceID).tags[tag] = struct{}{}
+}
+
+// AddOrder attributes the created order to the strategy instance
+func (t *StrategyInstanceNAVTracker) AddOrder(session *ExchangeSession, strategy, instanceID string, order types.Order) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.addOrder(session, order.OrderID, t.track(session, strategy, instanceID))
+}
+
+// addOrder must be called with the lock held
+func (t *StrategyInstanceNAVTracker) addOrder(session *ExchangeSession, orderID uint64, instance *strategyInstanceNAV) {
+	t.orders[navOrderKey{session: session.Name, orderID: orderID}] = instance
+
+	// the trades could arrive before the order is attributed
+	var rest []navPendingTrade
+	for _, p := range t.pending {
+		if p.session == session.Name && p.trade.OrderID == orderID {
+			instance.addTrade(session, p.trade)
+		} else {
+			rest = append(rest, p)
+		}
+	}
+	t.pending = rest
+}
+
+func (t *StrategyInstanceNAVTracker) addTrade(session *ExchangeSession, trade types.Trade) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if instance, ok := t.orders[navOrderKey{session: session.Name, orderID: trade.OrderID}]; ok {
+		instance.addTrade(session, trade)
+		return
+	}
+
+	t.pending = append(t.pending, navPendingTrade{session: session.Name, trade: trade})
+	if len(t.pending) > maxPendingNAVTrades {
+		t.pending = t.pending[len(t.pending)-maxPendingNAVTrades:]
+	}
+}
+
+// Sample marks the strategy instances to the market at the given time, the instances are sorted by the instance ID
+func (t *StrategyInstanceNAVTracker) Sample(now time.Time) []types.StrategyInstanceNAV {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var navs []types.StrategyInstanceNAV
+	for _, instance := range t.instances {
+		nav := instance.sample(now)
+		instance.last = &nav
+		navs = append(navs, nav)
+	}
+
+	sort.Slice(navs, func(i, j int) bool {
+		return navs[i].StrategyInstanceID < navs[j].StrategyInstanceID
+	})
+	return navs
+}
+
+// Last returns the last sampled net asset values
+func (t *StrategyInstanceNAVTracker) Last() []types.StrategyInstanceNAV {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var navs []types.StrategyInstanceNAV
+	for _, instance := range t.instances {
+		if instance.last != nil {
+			navs = append(navs, *instance.last)
+		}
+	}
+
+	sort.Slice(navs, func(i, j int) bool {
+		return navs[i].StrategyInstanceID < navs[j].StrategyInstanceID
+	})
+	return navs
+}
+
+// Run samples the net asset values at every interval until the context is canceled
+func (t *StrategyInstanceNAVTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.Interval.Duration())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case now := <-ticker.C:
+			for _, nav := range t.Sample(now) {
+				updateStrategyNAVMetrics(nav)
+
+				if t.Service != nil {
+					if err := t.Service.Insert(nav); err != nil {
+						log.WithError(err).Errorf("can not insert strategy instance nav: %s", nav.String())
+					}
+				}
+			}
+		}
+	}
+}
+
+func (i *strategyInstanceNAV) matchOrder(order types.Order) bool {
+	if order.GroupID != 0 {
+		if _, ok := i.groupIDs[order.GroupID]; ok {
+			return true
+		}
+	}
+
+	if order.Tag != "" {
+		if _, ok := i.tags[order.Tag]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (i *strategyInstanceNAV) seedPosition(session *ExchangeSession, position *types.Position) {
+	key := navPositionKey{session: session.Name, symbol: position.Symbol}
+	if _, ok := i.positions[key]; ok {
+		return
+	}
+
+	market, ok := session.Market(position.Symbol)
+	if !ok {
+		log.Warnf("strategy instance nav: market %s not found in session %s", position.Symbol, session.Name)
+		return
+	}
+
+	position.Lock()
+	p := types.NewPositionFromMarket(market)
+	p.Base = position.Base
+	p.Quote = position.Quote
+	p.AverageCost = position.AverageCost
+	p.ApproximateAverageCost = position.ApproximateAverageCost
+	position.Unlock()
+
+	i.positions[key] = &navPosition{session: session, position: p}
+}
+
+func (i *strategyInstanceNAV) seedProfitStats(session *ExchangeSession, profitStats *types.ProfitStats) {
+	if i.restored || !i.realizedProfit.IsZero() || !i.feeInUSD.IsZero() {
+		return
+	}
+
+	// the accumulated net profit is the accumulated profit minus the fee in quote
+	fee := profitStats.AccumulatedPnL.Sub(profitStats.AccumulatedNetProfit)
+	profit, ok := usdValue(session, profitStats.QuoteCurrency, profitStats.AccumulatedPnL)
+	if !ok {
+		log.Warnf("strategy instance nav: can not convert the %s profit to USD", profitStats.QuoteCurrency)
+		return
+	}
+
+	feeInUSD, ok := usdValue(session, profitStats.QuoteCurrency, fee)
+	if !ok {
+		log.Warnf("strategy instance nav: can not convert the %s fee to USD", profitStats.QuoteCurrency)
+		return
+	}
+
+	i.realizedProfit = profit
+	i.feeInUSD = feeInUSD
+}
+
+// addTradeKey returns false if the trade is duplicated
+func (i *strategyInstanceNAV) addTradeKey(key types.TradeKey) bool {
+	if _, ok := i.tradeKeys[key]; ok {
+		return false
+	}
+
+	i.tradeKeys[key] = struct{}{}
+	i.tradeKeyQueue = append(i.tradeKeyQueue, key)
+	if len(i.tradeKeyQueue) > maxNAVTradeKeys {
+		delete(i.tradeKeys, i.tradeKeyQueue[0])
+		i.tradeKeyQueue = i.tradeKeyQueue[1:]
+	}
+
+	i.numOfTrades++
+	return true
+}
+
+func (i *strategyInstanceNAV) addTrade(session *ExchangeSession, trade types.Trade) {
+	if !i.addTradeKey(trade.Key()) {
+		return
+	}
+
+	key := navPositionKey{session: session.Name, symbol: trade.Symbol}
+	p, ok := i.positions[key]
+	if !ok {
+		market, ok := session.Market(trade.Symbol)
+		if !ok {
+			log.Warnf("strategy instance nav: market %s not found in session %s", trade.Symbol, session.Name)
+			return
+		}
+
+		p = &navPosition{session: session, position: types.NewPositionFromMarket(market)}
+		i.positions[key] = p
+	}
+
+	if fee, ok := usdValue(session, trade.FeeCurrency, trade.Fee); ok {
+		i.feeInUSD = i.feeInUSD.Add(fee)
+	} else if !trade.Fee.IsZero() {
+		log.Warnf("strategy instance nav: can not convert the %s fee to USD", trade.FeeCurrency)
+	}
+
+	// the fee is deducted separately, so the position is fed with the gross trade
+	grossTrade := trade
+	grossTrade.Fee = fixedpoint.Zero
+	grossTrade.FeeCurrency = ""
+
+	if profit, _, madeProfit := p.position.AddTrade(grossTrade); madeProfit {
+		if profitInUSD, ok := usdValue(session, p.position.QuoteCurrency, profit); ok {
+			i.realizedProfit = i.realizedProfit.Add(profitInUSD)
+		}
+	}
+}
+
+func (i *strategyInstanceNAV) sample(now time.Time) types.StrategyInstanceNAV {
+	nav := types.StrategyInstanceNAV{
+		Strategy:           i.strategy,
+		StrategyInstanceID: i.instanceID,
+		Session:            i.session.Name,
+		Time:               types.Time(now),
+		RealizedProfit:     i.realizedProfit,
+		FeeInUSD:           i.feeInUSD,
+		NumOfTrades:        i.numOfTrades,
+	}
+
+	for key, p := range i.positions {
+		if p.position.Base.IsZero() {
+			continue
+		}
+
+		price, ok := p.session.LastPrice(key.symbol)
+		if !ok {
+			continue
+		}
+
+		if unrealized, ok := usdValue(p.session, p.position.QuoteCurrency, p.position.UnrealizedProfit(price)); ok {
+			nav.UnrealizedProfit = nav.UnrealizedProfit.Add(unrealized)
+		}
+
+		if value, ok := usdValue(p.session, p.position.QuoteCurrency, p.position.Base.Mul(price)); ok {
+			nav.PositionValue = nav.PositionValue.Add(value)
+		}
+	}
+
+	nav.NetAssetValue = nav.RealizedProfit.Add(nav.UnrealizedProfit).Sub(nav.FeeInUSD)
+	return nav
+}
+
+// usdValue converts the amount of the currency to USD with the last price of the USD stable coin market
+func usdValue(session *ExchangeSession, currency string, amount fixedpoint.Value) (fixedpoint.Value, bool) {
+	if amount.IsZero() || types.IsUSDFiatCurrency(currency) {
+		return amount, true
+	}
+
+	for _, quote := range types.USDFiatCurrencies {
+		if price, ok := session.LastPrice(currency + quote); ok {
+			return amount.Mul(price), true
+		}
+	}
+
+	return fixedpoint.Zero, false
+}
@@ -0,0 +1,202 @@
+package bbgo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/c9s/rockhopper"
+	"github.com/golang/mock/gomock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/service"
+	"github.com/c9s/bbgo/pkg/types"
+	"github.com/c9s/bbgo/pkg/types/mocks"
+)
+
+func TestStrategyInstanceNAVTracker(t *testing.T) {
+	market := getTestMarket()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockEx := mocks.NewMockExchange(mockCtrl)
+	mockEx.EXPECT().NewStream().Return(&types.StandardStream{}).Times(2)
+
+	session := NewExchangeSession("test", mockEx)
+	session.markets[market.Symbol] = market
+
+	tracker := NewStrategyInstanceNAVTracker(nil, 0)
+	tracker.BindSession(session)
+	tracker.AddGroupID(session, "grid2", "grid2-a", 100)
+	tracker.Track(session, "bollmaker", "bollmaker-b")
+
+	stream := session.UserDataStream.(*types.StandardStream)
+
+	// the trade arrives before the order update
+	stream.EmitTradeUpdate(types.Trade{
+		ID: 1, OrderID: 1, Symbol: "BTCUSDT", Side: types.SideTypeBuy,
+		Price: fixedpoint.NewFromFloat(20000), Quantity: fixedpoint.One, QuoteQuantity: fixedpoint.NewFromFloat(20000),
+		Fee: fixedpoint.NewFromFloat(10), FeeCurrency: "USDT",
+	})
+	stream.EmitOrderUpdate(types.Order{SubmitOrder: types.SubmitOrder{Symbol: "BTCUSDT", GroupID: 100}, OrderID: 1})
+
+	// the order of the other strategy instance
+	tracker.AddOrder(session, "bollmaker", "bollmaker-b", types.Order{SubmitOrder: types.SubmitOrder{Symbol: "BTCUSDT"}, OrderID: 2})
+
+	sell := types.Trade{
+		ID: 2, OrderID: 1, Symbol: "BTCUSDT", Side: types.SideTypeSell,
+		Price: fixedpoint.NewFromFloat(21000), Quantity: fixedpoint.NewFromFloat(0.5), QuoteQuantity: fixedpoint.NewFromFloat(10500),
+		Fee: fixedpoint.NewFromFloat(0.01), FeeCurrency: "BNB",
+	}
+	session.lastPrices["BNBUSDT"] = fixedpoint.NewFromFloat(300)
+	stream.EmitTradeUpdate(sell)
+
+	// the duplicated trade is ignored
+	stream.EmitTradeUpdate(sell)
+
+	// the trade of the unknown order is not attributed
+	stream.EmitTradeUpdate(types.Trade{ID: 3, OrderID: 3, Symbol: "BTCUSDT", Side: types.SideTypeBuy,
+		Price: fixedpoint.NewFromFloat(20000), Quantity: fixedpoint.One})
+
+	session.lastPrices["BTCUSDT"] = fixedpoint.NewFromFloat(22000)
+
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	navs := tracker.Sample(now)
+	require.Len(t, navs, 2)
+
+	assert.Equal(t, "bollmaker-b", navs[0].StrategyInstanceID)
+	assert.Equal(t, "0", navs[0].NetAssetValue.String())
+	assert.Equal(t, 0, navs[0].NumOfTrades)
+
+	nav := navs[1]
+	assert.Equal(t, "grid2", nav.Strategy)
+	assert.Equal(t, "grid2-a", nav.StrategyInstanceID)
+	assert.Equal(t, "test", nav.Session)
+	assert.Equal(t, now, nav.Time.Time())
+	assert.Equal(t, 2, nav.NumOfTrades)
+	assert.Equal(t, "500", nav.RealizedProfit.String())
+	assert.Equal(t, "1000", nav.UnrealizedProfit.String())
+	assert.Equal(t, "13", nav.FeeInUSD.String())
+	assert.Equal(t, "11000", nav.PositionValue.String())
+	assert.Equal(t, "1487", nav.NetAssetValue.String())
+
+	assert.Equal(t, navs, tracker.Last())
+}
+
+func newTestNAVSession(t *testing.T) *ExchangeSession {
+	market := getTestMarket()
+
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockEx := mocks.NewMockExchange(mockCtrl)
+	mockEx.EXPECT().NewStream().Return(&types.StandardStream{}).Times(2)
+
+	session := NewExchangeSession("test", mockEx)
+	session.markets[market.Symbol] = market
+	return session
+}
+
+func TestStrategyInstanceNAVTracker_Restore(t *testing.T) {
+	dialect, err := rockhopper.LoadDialect("sqlite3")
+	require.NoError(t, err)
+
+	db, err := rockhopper.Open("sqlite3", dialect, ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.CurrentVersion()
+	require.NoError(t, err)
+
+	var loader rockhopper.SqlMigrationLoader
+	migrations, err := loader.Load("../../migrations/sqlite3")
+	require.NoError(t, err)
+	require.NoError(t, rockhopper.Up(context.Background(), db, migrations, 0, 0))
+
+	navService := &service.StrategyInstanceNAVService{DB: sqlx.NewDb(db.DB, "sqlite3")}
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, navService.Insert(types.StrategyInstanceNAV{
+			Strategy:           "grid2",
+			StrategyInstanceID: "grid2-a",
+			Session:            "test",
+			Time:               types.Time(now.Add(time.Duration(i) * time.Minute)),
+			RealizedProfit:     fixedpoint.NewFromInt(100 + int64(i)),
+			FeeInUSD:           fixedpoint.NewFromInt(5),
+			NumOfTrades:        10 + i,
+		}))
+	}
+
+	session := newTestNAVSession(t)
+	tracker := NewStrategyInstanceNAVTracker(navService, 0)
+	tracker.BindSession(session)
+
+	// the profit stats are ignored since the instance is restored from the stored nav
+	profitStats := &types.ProfitStats{QuoteCurrency: "USDT", AccumulatedPnL: fixedpoint.NewFromInt(1000)}
+	tracker.TrackPosition(session, "grid2", "grid2-a", nil, profitStats)
+
+	navs := tracker.Last()
+	require.Len(t, navs, 1)
+	assert.Equal(t, 11, navs[0].NumOfTrades)
+
+	navs = tracker.Sample(now.Add(time.Hour))
+	require.Len(t, navs, 1)
+	assert.Equal(t, "101", navs[0].RealizedProfit.String())
+	assert.Equal(t, "5", navs[0].FeeInUSD.String())
+	assert.Equal(t, "96", navs[0].NetAssetValue.String())
+	assert.Equal(t, 11, navs[0].NumOfTrades)
+}
+
+func TestStrategyInstanceNAVTracker_TrackPosition(t *testing.T) {
+	session := newTestNAVSession(t)
+	tracker := NewStrategyInstanceNAVTracker(nil, 0)
+	tracker.BindSession(session)
+
+	position := types.NewPositionFromMarket(getTestMarket())
+	position.Base = fixedpoint.One
+	position.Quote = fixedpoint.NewFromInt(-20000)
+	position.AverageCost = fixedpoint.NewFromInt(20000)
+
+	profitStats := &types.ProfitStats{
+		QuoteCurrency:        "USDT",
+		AccumulatedPnL:       fixedpoint.NewFromInt(300),
+		AccumulatedNetProfit: fixedpoint.NewFromInt(280),
+	}
+
+	tracker.TrackPosition(session, "bollmaker", "bollmaker-a", position, profitStats)
+	tracker.AddOrder(session, "bollmaker", "bollmaker-a", types.Order{SubmitOrder: types.SubmitOrder{Symbol: "BTCUSDT"}, OrderID: 1})
+
+	// the sell trade closes the position opened before the restart instead of opening a short position
+	stream := session.UserDataStream.(*types.StandardStream)
+	stream.EmitTradeUpdate(types.Trade{
+		ID: 1, OrderID: 1, Symbol: "BTCUSDT", Side: types.SideTypeSell,
+		Price: fixedpoint.NewFromFloat(21000), Quantity: fixedpoint.One, QuoteQuantity: fixedpoint.NewFromFloat(21000),
+	})
+
+	session.lastPrices["BTCUSDT"] = fixedpoint.NewFromFloat(22000)
+
+	navs := tracker.Sample(time.Now())
+	require.Len(t, navs, 1)
+	assert.Equal(t, "1300", navs[0].RealizedProfit.String())
+	assert.Equal(t, "20", navs[0].FeeInUSD.String())
+	assert.Equal(t, "0", navs[0].UnrealizedProfit.String())
+	assert.Equal(t, "0", navs[0].PositionValue.String())
+	assert.Equal(t, "1280", navs[0].NetAssetValue.String())
+	assert.Equal(t, 1, navs[0].NumOfTrades)
+}
+
+func TestStrategyInstanceNAV_AddTradeKey(t *testing.T) {
+	instance := &strategyInstanceNAV{tradeKeys: make(map[types.TradeKey]struct{})}
+	for i := 0; i < maxNAVTradeKeys+10; i++ {
+		assert.True(t, instance.addTradeKey(types.TradeKey{Exchange: "binance", ID: uint64(i)}))
+	}
+
+	assert.False(t, instance.addTradeKey(types.TradeKey{Exchange: "binance", ID: maxNAVTradeKeys + 9}), "the recent trade key should be kept")
+	assert.Len(t, instance.tradeKeys, maxNAVTradeKeys)
+	assert.Len(t, instance.tradeKeyQueue, maxNAVTradeKeys)
+	assert.Equal(t, maxNAVTradeKeys+10, instance.numOfTrades)
+}
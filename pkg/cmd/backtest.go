@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
@@ -47,6 +48,8 @@ func init() {
 	BacktestCmd.Flags().Bool("force", false, "force execution without confirm")
 	BacktestCmd.Flags().String("output", "", "the report output directory")
 	BacktestCmd.Flags().Bool("subdir", false, "generate report in the sub-directory of the output directory")
+	BacktestCmd.Flags().String("equity-curve-interval", "1h", "the sampling interval of the equity curve report")
+	BacktestCmd.Flags().Bool("html", false, "generate the self-contained html report, it's written to the report directory if --output is given, or the current directory")
 	RootCmd.AddCommand(BacktestCmd)
 }
 
@@ -110,6 +113,21 @@ var BacktestCmd = &cobra.Command{
 			return err
 		}
 
+		equityCurveIntervalStr, err := cmd.Flags().GetString("equity-curve-interval")
+		if err != nil {
+			return err
+		}
+
+		equityCurveInterval := types.Interval(equityCurveIntervalStr)
+		if _, ok := types.SupportedIntervals[equityCurveInterval]; !ok {
+			return fmt.Errorf("unsupported equity curve interval: %s", equityCurveIntervalStr)
+		}
+
+		generatingHTMLReport, err := cmd.Flags().GetBool("html")
+		if err != nil {
+			return err
+		}
+
 		syncOnly, err := cmd.Flags().GetBool("sync-only")
 		if err != nil {
 			return err
@@ -283,6 +301,22 @@ var BacktestCmd = &cobra.Command{
 			}
 		}
 
+		// in the portfolio back-test, each strategy instance runs on the session of its own sub-account
+		traderConfig := userConfig
+		var portfolioConfig bbgo.BacktestPortfolio
+		var portfolioInstances []*backtest.PortfolioInstance
+		if userConfig.Backtest.Portfolio != nil {
+			portfolioConfig = userConfig.Backtest.Portfolio.WithDefaults(len(userConfig.ExchangeStrategies))
+			if err := portfolioConfig.Validate(len(userConfig.ExchangeStrategies)); err != nil {
+				return err
+			}
+
+			traderConfig, portfolioInstances, err = setupPortfolioSessions(environ, userConfig, portfolioConfig)
+			if err != nil {
+				return err
+			}
+		}
+
 		if err := environ.Init(ctx); err != nil {
 			return err
 		}
@@ -292,6 +326,15 @@ var BacktestCmd = &cobra.Command{
 			backtestEx := session.Exchange.(*backtest.Exchange)
 			backtestEx.MarketDataStream = session.MarketDataStream.(types.StandardStreamEmitter)
 			backtestEx.BindUserData(userDataStream)
+
+			// environ.BindSync is skipped in back-testing, so the trades are recorded here
+			if userConfig.Backtest.RecordTrades {
+				userDataStream.OnTradeUpdate(func(trade types.Trade) {
+					if err := environ.TradeService.Insert(trade); err != nil {
+						log.WithError(err).Errorf("back-test trade insert error: %+v", trade)
+					}
+				})
+			}
 		}
 
 		trader := bbgo.NewTrader(environ)
@@ -299,7 +342,7 @@ var BacktestCmd = &cobra.Command{
 			trader.DisableLogging()
 		}
 
-		if err := trader.Configure(userConfig); err != nil {
+		if err := trader.Configure(traderConfig); err != nil {
 			return err
 		}
 
@@ -318,6 +361,8 @@ var BacktestCmd = &cobra.Command{
 		var runID = userConfig.GetSignature() + "_" + uuid.NewString()
 		var reportDir = outputDirectory
 		var sessionTradeStats = make(map[string]map[string]*types.TradeStats)
+		var sessionExcursionRecorders = make(map[string]map[string]*backtest.ExcursionRecorder)
+		var positions []*types.Position
 
 		// for each exchange session, iterate the positions and
 		// allocate trade collector to calculate the tradeStats
@@ -325,6 +370,7 @@ var BacktestCmd = &cobra.Command{
 		for _, exSource := range exchangeSources {
 			sessionName := exSource.Session.Name
 			tradeStatsMap := make(map[string]*types.TradeStats)
+			excursionRecorders := make(map[string]*backtest.ExcursionRecorder)
 			for usedSymbol := range exSource.Session.Positions() {
 				market, _ := exSource.Session.Market(usedSymbol)
 				position := types.NewPositionFromMarket(market)
@@ -342,11 +388,97 @@ var BacktestCmd = &cobra.Command{
 				})
 				tradeStatsMap[usedSymbol] = tradeStats
 
+				excursionRecorder := backtest.NewExcursionRecorder(position)
+				excursionRecorder.BindTradeCollector(tradeCollector)
+				excursionRecorders[usedSymbol] = excursionRecorder
+				positions = append(positions, position)
+
 				orderStore.BindStream(exSource.Session.UserDataStream)
 				tradeCollector.BindStream(exSource.Session.UserDataStream)
 				tradeCollectorList = append(tradeCollectorList, tradeCollector)
 			}
 			sessionTradeStats[sessionName] = tradeStatsMap
+			sessionExcursionRecorders[sessionName] = excursionRecorders
+		}
+
+		// mark the equity and the excursions to market at every kline close of the required interval
+		equityCurveRecorder := backtest.NewEquityCurveRecorder(equityCurveInterval)
+		kLineHandlers = append(kLineHandlers, func(k types.KLine, exSource *backtest.ExchangeDataSource) {
+			if k.Interval != requiredInterval {
+				return
+			}
+
+			if excursionRecorder, ok := sessionExcursionRecorders[exSource.Session.Name][k.Symbol]; ok {
+				excursionRecorder.HandleKLine(k)
+			}
+
+			// the sub-accounts of the portfolio back-test are fed by the source of their parent
+			for _, subAccount := range exSource.Exchange.SubAccounts() {
+				if excursionRecorder, ok := sessionExcursionRecorders[subAccount.Src.Session.Name][k.Symbol]; ok {
+					excursionRecorder.HandleKLine(k)
+				}
+			}
+
+			equity := fixedpoint.Zero
+			for _, src := range exchangeSources {
+				balances, err := src.Exchange.QueryAccountBalances(ctx)
+				if err != nil {
+					log.WithError(err).Errorf("query back-test account balance error")
+					return
+				}
+
+				equity = equity.Add(balances.Assets(src.Session.AllLastPrices(), k.EndTime.Time()).InUSD())
+			}
+
+			exposed := false
+			for _, position := range positions {
+				if !position.GetBase().IsZero() && !position.IsDust() {
+					exposed = true
+					break
+				}
+			}
+
+			equityCurveRecorder.Record(k.EndTime.Time(), equity, exposed)
+		})
+
+		// collect the klines of the chart interval for the price chart of the html report
+		var sessionChartKLines = make(map[string]map[string][]types.KLine)
+		if generatingHTMLReport {
+			chartInterval := equityCurveInterval
+			if _, ok := allKLineIntervals[chartInterval]; !ok {
+				chartInterval = types.Interval1h
+			}
+
+			kLineHandlers = append(kLineHandlers, func(k types.KLine, exSource *backtest.ExchangeDataSource) {
+				if k.Interval != chartInterval {
+					return
+				}
+
+				chartKLines, ok := sessionChartKLines[exSource.Session.Name]
+				if !ok {
+					chartKLines = make(map[string][]types.KLine)
+					sessionChartKLines[exSource.Session.Name] = chartKLines
+				}
+
+				chartKLines[k.Symbol] = append(chartKLines[k.Symbol], k)
+			})
+		}
+
+		var portfolioAllocator *backtest.PortfolioAllocator
+		if len(portfolioInstances) > 0 {
+			var reserves []*bbgo.ExchangeSession
+			for _, session := range environ.Sessions() {
+				if len(session.Exchange.(*backtest.Exchange).SubAccounts()) > 0 {
+					reserves = append(reserves, session)
+				}
+			}
+
+			portfolioAllocator = backtest.NewPortfolioAllocator(portfolioConfig, portfolioInstances, reserves)
+			kLineHandlers = append(kLineHandlers, func(k types.KLine, _ *backtest.ExchangeDataSource) {
+				if k.Interval == requiredInterval {
+					portfolioAllocator.Update(k.EndTime.Time())
+				}
+			})
 		}
 
 		kLineHandlers = append(kLineHandlers, func(k types.KLine, _ *backtest.ExchangeDataSource) {
@@ -357,6 +489,8 @@ var BacktestCmd = &cobra.Command{
 			}
 		})
 
+		// submittedOrders are the orders of each exchange source written to the report
+		var submittedOrders []*types.SyncOrderMap
 		if generatingReport {
 			if reportFileInSubDir {
 				// reportDir = filepath.Join(reportDir, backtestSessionName)
@@ -449,7 +583,13 @@ var BacktestCmd = &cobra.Command{
 			_ = ordersTsv.Write(types.Order{}.CsvHeader())
 
 			for _, exSource := range exchangeSources {
+				// keep the last update of every submitted order, including the canceled and the open orders
+				orderMap := types.NewSyncOrderMap()
+				submittedOrders = append(submittedOrders, orderMap)
+
 				exSource.Session.UserDataStream.OnOrderUpdate(func(order types.Order) {
+					orderMap.Add(order)
+
 					if order.Status == types.OrderStatusFilled {
 						for _, record := range order.CsvRecords() {
 							_ = ordersTsv.Write(record)
@@ -460,16 +600,21 @@ var BacktestCmd = &cobra.Command{
 		}
 
 		runCtx, cancelRun := context.WithCancel(ctx)
+		// the sub-accounts have no kline channel, they are fed by the sources of their parents
+		var feedSources []*backtest.ExchangeDataSource
 		for _, exK := range exchangeSources {
 			exK.Callbacks = kLineHandlers
+			if exK.C != nil {
+				feedSources = append(feedSources, exK)
+			}
 		}
 		go func() {
 			defer cancelRun()
 
 			// Optimize back-test speed for single exchange source
-			var numOfExchangeSources = len(exchangeSources)
+			var numOfExchangeSources = len(feedSources)
 			if numOfExchangeSources == 1 {
-				exSource := exchangeSources[0]
+				exSource := feedSources[0]
 				for k := range exSource.C {
 					exSource.Exchange.ConsumeKLine(k, requiredInterval)
 				}
@@ -482,7 +627,7 @@ var BacktestCmd = &cobra.Command{
 
 		RunMultiExchangeData:
 			for {
-				for _, exK := range exchangeSources {
+				for _, exK := range feedSources {
 					k, more := <-exK.C
 					if !more {
 						if err := exK.Exchange.CloseMarketData(); err != nil {
@@ -509,14 +654,16 @@ var BacktestCmd = &cobra.Command{
 		// put the logger back to print the pnl
 		log.SetLevel(log.InfoLevel)
 
+		equityCurveRecorder.Finish()
+		equityCurveStats := equityCurveRecorder.Stats()
+
 		// aggregate total balances
 		initTotalBalances := types.BalanceMap{}
 		finalTotalBalances := types.BalanceMap{}
 		var sessionNames []string
 		for _, session := range environ.Sessions() {
 			sessionNames = append(sessionNames, session.Name)
-			accountConfig := userConfig.Backtest.GetAccount(session.Name)
-			initBalances := accountConfig.Balances.BalanceMap()
+			initBalances := session.Exchange.(*backtest.Exchange).InitialBalances()
 			initTotalBalances = initTotalBalances.Add(initBalances)
 
 			finalBalances := session.GetAccount().Balances()
@@ -537,6 +684,7 @@ var BacktestCmd = &cobra.Command{
 			summaryReport.Intervals = append(summaryReport.Intervals, interval)
 		}
 
+		var htmlSymbolReports []backtest.HTMLSymbolReport
 		for _, session := range environ.Sessions() {
 			for symbol, trades := range session.Trades {
 				if len(trades.Trades) == 0 {
@@ -549,13 +697,27 @@ var BacktestCmd = &cobra.Command{
 				winningRatio := tradeState.WinningRatio
 				intervalProfits := tradeState.IntervalProfits[types.Interval1d]
 
-				symbolReport, err := createSymbolReport(userConfig, session, symbol, trades.Copy(), intervalProfits, profitFactor, winningRatio)
+				symbolReport, err := createSymbolReport(session, symbol, trades.Copy(), intervalProfits, profitFactor, winningRatio)
 				if err != nil {
 					return err
 				}
 
+				if excursionRecorder, ok := sessionExcursionRecorders[session.Name][symbol]; ok {
+					excursionRecorder.Finish(equityCurveStats.EndTime)
+					symbolReport.SetExcursions(excursionRecorder.Excursions(), equityCurveStats.StartTime, equityCurveStats.EndTime)
+				}
+
 				summaryReport.Symbols = append(summaryReport.Symbols, symbol)
 				summaryReport.SymbolReports = append(summaryReport.SymbolReports, *symbolReport)
+				if generatingHTMLReport {
+					htmlSymbolReports = append(htmlSymbolReports, backtest.HTMLSymbolReport{
+						Session: session.Name,
+						Report:  symbolReport,
+						KLines:  sessionChartKLines[session.Name][symbol],
+						Trades:  trades.Copy(),
+					})
+				}
+
 				summaryReport.TotalProfit = symbolReport.PnL.Profit
 				summaryReport.TotalUnrealizedProfit = symbolReport.PnL.UnrealizedProfit
 				summaryReport.InitialEquityValue = summaryReport.InitialEquityValue.Add(symbolReport.InitialEquityValue())
@@ -573,7 +735,85 @@ var BacktestCmd = &cobra.Command{
 			}
 		}
 
+		summaryReport.SetEquityCurveStats(equityCurveStats)
+
+		if userConfig.Backtest.MonteCarlo != nil {
+			var allTrades []types.Trade
+			var markets = types.MarketMap{}
+			for _, session := range environ.Sessions() {
+				for _, trades := range session.Trades {
+					allTrades = append(allTrades, trades.Copy()...)
+				}
+
+				for symbol, market := range session.Markets() {
+					markets[symbol] = market
+				}
+			}
+
+			samples, _ := backtest.NewMonteCarloSamples(allTrades, markets, equityCurveStats.InitialEquity)
+			summaryReport.MonteCarlo = backtest.RunMonteCarlo(samples, equityCurveStats.InitialEquity, *userConfig.Backtest.MonteCarlo)
+		}
+
+		if portfolioAllocator != nil {
+			summaryReport.Portfolio = portfolioAllocator.Report(endTime)
+		}
+
+		if generatingHTMLReport {
+			htmlReportFile := filepath.Join(reportDir, backtest.HTMLReportFileName)
+			if err := backtest.WriteHTMLReportFile(htmlReportFile, &backtest.HTMLReport{
+				Summary:     summaryReport,
+				EquityCurve: equityCurveRecorder.Curve(),
+				Symbols:     htmlSymbolReports,
+			}); err != nil {
+				return errors.Wrapf(err, "can not write html report file: %s", htmlReportFile)
+			}
+
+			log.Infof("html report is written to %s", htmlReportFile)
+		}
+
 		if generatingReport {
+			equityCurveFile := filepath.Join(reportDir, backtest.EquityCurveFileName)
+			if err := util.WriteJsonFile(equityCurveFile, equityCurveRecorder.Curve()); err != nil {
+				return errors.Wrapf(err, "can not write equity curve json file: %s", equityCurveFile)
+			}
+
+			var allTrades []types.Trade
+			for _, session := range environ.Sessions() {
+				for _, trades := range session.Trades {
+					allTrades = append(allTrades, trades.Copy()...)
+				}
+			}
+
+			sort.SliceStable(allTrades, func(i, j int) bool {
+				return allTrades[i].Time.Time().Before(allTrades[j].Time.Time())
+			})
+
+			tradesFile := filepath.Join(reportDir, backtest.TradesFileName)
+			if err := util.WriteJsonFile(tradesFile, allTrades); err != nil {
+				return errors.Wrapf(err, "can not write trades json file: %s", tradesFile)
+			}
+
+			var allOrders []types.Order
+			for _, orderMap := range submittedOrders {
+				allOrders = append(allOrders, orderMap.Orders()...)
+			}
+
+			sort.SliceStable(allOrders, func(i, j int) bool {
+				return allOrders[i].CreationTime.Time().Before(allOrders[j].CreationTime.Time())
+			})
+
+			ordersFile := filepath.Join(reportDir, backtest.OrdersFileName)
+			if err := util.WriteJsonFile(ordersFile, allOrders); err != nil {
+				return errors.Wrapf(err, "can not write orders json file: %s", ordersFile)
+			}
+
+			if summaryReport.Portfolio != nil {
+				portfolioFile := filepath.Join(reportDir, backtest.PortfolioReportFileName)
+				if err := util.WriteJsonFile(portfolioFile, summaryReport.Portfolio); err != nil {
+					return errors.Wrapf(err, "can not write portfolio report json file: %s", portfolioFile)
+				}
+			}
+
 			summaryReportFile := filepath.Join(reportDir, "summary.json")
 
 			// output summary report filepath to stdout, so that our optimizer can read from it
@@ -605,6 +845,13 @@ var BacktestCmd = &cobra.Command{
 			color.Green("END TIME: %s\n", endTime.Format(time.RFC1123))
 			color.Green("INITIAL TOTAL BALANCE: %v\n", initTotalBalances)
 			color.Green("FINAL TOTAL BALANCE: %v\n", finalTotalBalances)
+			summaryReport.PrintEquityCurveStats()
+			if summaryReport.MonteCarlo != nil {
+				summaryReport.MonteCarlo.Print()
+			}
+			if summaryReport.Portfolio != nil {
+				summaryReport.Portfolio.Print()
+			}
 			for _, symbolReport := range summaryReport.SymbolReports {
 				symbolReport.Print(wantBaseAssetBaseline)
 			}
@@ -614,8 +861,72 @@ var BacktestCmd = &cobra.Command{
 	},
 }
 
+// setupPortfolioSessions creates a sub-account session for each strategy instance, the initial balances of the
+// mounted session are split by the weights, and returns the config that mounts the strategies on the new sessions
+func setupPortfolioSessions(
+	environ *bbgo.Environment, userConfig *bbgo.Config, config bbgo.BacktestPortfolio,
+) (*bbgo.Config, []*backtest.PortfolioInstance, error) {
+	if len(userConfig.CrossExchangeStrategies) > 0 {
+		return nil, nil, errors.New("cross exchange strategies are not supported in the portfolio back-test")
+	}
+
+	// the weights are applied to the balances before any sub-account is created
+	initialBalances := make(map[string]types.BalanceMap)
+	for name, session := range environ.Sessions() {
+		initialBalances[name] = session.Exchange.(*backtest.Exchange).InitialBalances()
+	}
+
+	traderConfig := *userConfig
+	traderConfig.ExchangeStrategies = nil
+
+	var instances []*backtest.PortfolioInstance
+	for i, entry := range userConfig.ExchangeStrategies {
+		if len(entry.Mounts) != 1 {
+			return nil, nil, fmt.Errorf("strategy %s should be mounted on exactly one session in the portfolio back-test, got %v",
+				entry.Strategy.ID(), entry.Mounts)
+		}
+
+		mount := entry.Mounts[0]
+		session, ok := environ.Session(mount)
+		if !ok {
+			return nil, nil, fmt.Errorf("session %s is not found for strategy %s", mount, entry.Strategy.ID())
+		}
+
+		balances := types.BalanceMap{}
+		for currency, balance := range initialBalances[mount] {
+			balances[currency] = types.Balance{
+				Currency:  currency,
+				Available: balance.Available.Mul(config.Weights[i]),
+			}
+		}
+
+		subAccount, err := session.Exchange.(*backtest.Exchange).NewSubAccount(balances)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		subSessionName := fmt.Sprintf("%s-%s-%d", mount, entry.Strategy.ID(), i)
+		subSession := environ.AddExchange(subSessionName, subAccount)
+		subSession.UseHeikinAshi = session.UseHeikinAshi
+		subSession.Futures = session.Futures
+
+		instance, err := backtest.NewPortfolioInstance(entry.Strategy, subSession, config.Weights[i])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		instances = append(instances, instance)
+		traderConfig.ExchangeStrategies = append(traderConfig.ExchangeStrategies, bbgo.ExchangeStrategyMount{
+			Mounts:   []string{subSessionName},
+			Strategy: entry.Strategy,
+		})
+	}
+
+	return &traderConfig, instances, nil
+}
+
 func createSymbolReport(
-	userConfig *bbgo.Config, session *bbgo.ExchangeSession, symbol string, trades []types.Trade,
+	session *bbgo.ExchangeSession, symbol string, trades []types.Trade,
 	intervalProfit *types.IntervalProfitCollector,
 	profitFactor, winningRatio fixedpoint.Value,
 ) (
@@ -651,8 +962,13 @@ func createSymbolReport(
 	sortinoRatio := fixedpoint.NewFromFloat(intervalProfit.GetSortino())
 
 	report := calculator.Calculate(symbol, trades, lastPrice)
-	accountConfig := userConfig.Backtest.GetAccount(session.Exchange.Name().String())
-	initBalances := accountConfig.Balances.BalanceMap()
+
+	quoteVolume := fixedpoint.Zero
+	for _, trade := range trades {
+		quoteVolume = quoteVolume.Add(trade.QuoteQuantity)
+	}
+
+	initBalances := backtestExchange.InitialBalances()
 	finalBalances := session.GetAccount().Balances()
 	symbolReport := backtest.SessionSymbolReport{
 		Exchange:        session.Exchange.Name(),
@@ -668,6 +984,12 @@ func createSymbolReport(
 		Sortino:      sortinoRatio,
 		ProfitFactor: profitFactor,
 		WinningRatio: winningRatio,
+		QuoteVolume:  quoteVolume,
+	}
+
+	if initialEquity := symbolReport.InitialEquityValue(); initialEquity.Sign() > 0 {
+		symbolReport.Turnover = quoteVolume.Div(initialEquity)
+		symbolReport.FeeDrag = report.FeeInUSD.Div(initialEquity)
 	}
 
 	for _, s := range session.Subscriptions {
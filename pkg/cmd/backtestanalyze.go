@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/c9s/bbgo/pkg/backtest"
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/cache"
+	"github.com/c9s/bbgo/pkg/exchange"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/service"
+	"github.com/c9s/bbgo/pkg/types"
+	"github.com/c9s/bbgo/pkg/util"
+)
+
+func init() {
+	backtestAnalyzeCmd.Flags().String("config", "config/bbgo.yaml", "strategy config file")
+	backtestAnalyzeCmd.Flags().String("summary", "", "the summary.json of the back-test report, the initial equity is read from it")
+	backtestAnalyzeCmd.Flags().String("initial-equity", "", "the initial equity in the quote currency, overrides the --summary option")
+	backtestAnalyzeCmd.Flags().Int("iterations", 0, "the number of the simulations of each method (default 1000)")
+	backtestAnalyzeCmd.Flags().Int64("seed", 0, "the seed of the random source")
+	backtestAnalyzeCmd.Flags().String("slippage", "", "the max ratio of the random slippage, e.g. 0.001")
+	backtestAnalyzeCmd.Flags().String("fee-rate", "", "the max ratio of the random extra fee, e.g. 0.0005")
+	backtestAnalyzeCmd.Flags().String("ruin-threshold", "", "the ratio of the initial equity lost that is counted as ruin (default 0.5)")
+	backtestAnalyzeCmd.Flags().String("confidence", "", "the confidence level of the intervals (default 0.95)")
+	backtestAnalyzeCmd.Flags().String("output", "", "write the analysis report to the json file")
+	BacktestCmd.AddCommand(backtestAnalyzeCmd)
+}
+
+// backtestAnalyzeCmd analyzes the trades recorded by the back-test, the backtest.recordTrades option should be enabled
+var backtestAnalyzeCmd = &cobra.Command{
+	Use:          "analyze",
+	Short:        "run the monte carlo robustness analysis of the recorded back-test trades",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		configFile, err := cmd.Flags().GetString("config")
+		if err != nil {
+			return err
+		}
+
+		userConfig, err := bbgo.Load(configFile, true)
+		if err != nil {
+			return err
+		}
+
+		if userConfig.Backtest == nil {
+			return errors.New("backtest config is not defined")
+		}
+
+		config := bbgo.BacktestMonteCarlo{}
+		if userConfig.Backtest.MonteCarlo != nil {
+			config = *userConfig.Backtest.MonteCarlo
+		}
+
+		if err := parseMonteCarloFlags(cmd, &config); err != nil {
+			return err
+		}
+
+		initialEquity, err := getAnalyzeInitialEquity(cmd)
+		if err != nil {
+			return err
+		}
+
+		environ := bbgo.NewEnvironment()
+		if err := bbgo.BootstrapBacktestEnvironment(ctx, environ); err != nil {
+			return err
+		}
+
+		if environ.TradeService == nil {
+			return errors.New("database service is not enabled, please check your environment variables DB_DRIVER and DB_DSN")
+		}
+
+		startTime := userConfig.Backtest.StartTime.Time().Local()
+		endTime := time.Now().Local()
+		if userConfig.Backtest.EndTime != nil {
+			endTime = userConfig.Backtest.EndTime.Time().Local()
+		}
+
+		sessionNames := userConfig.Backtest.Sessions
+		if len(sessionNames) == 0 {
+			for _, exName := range types.SupportedExchanges {
+				sessionNames = append(sessionNames, exName.String())
+			}
+		}
+
+		var trades []types.Trade
+		var markets = types.MarketMap{}
+		for _, name := range sessionNames {
+			exName, err := types.ValidExchangeName(name)
+			if err != nil {
+				return err
+			}
+
+			publicExchange, err := exchange.NewPublic(exName)
+			if err != nil {
+				return err
+			}
+
+			exMarkets, err := cache.LoadExchangeMarketsWithCache(ctx, publicExchange)
+			if err != nil {
+				return err
+			}
+
+			for _, symbol := range userConfig.Backtest.Symbols {
+				if market, ok := exMarkets[symbol]; ok {
+					markets[symbol] = market
+				}
+
+				symbolTrades, err := environ.TradeService.Query(service.QueryTradesOptions{
+					Exchange: exName,
+					Symbol:   symbol,
+					Since:    &startTime,
+					Until:    &endTime,
+				})
+				if err != nil {
+					return err
+				}
+
+				trades = append(trades, symbolTrades...)
+			}
+		}
+
+		if len(trades) == 0 {
+			return errors.New("no recorded back-test trades found, please enable backtest.recordTrades and run the back-test first")
+		}
+
+		samples, tradeStats := backtest.NewMonteCarloSamples(trades, markets, initialEquity)
+		log.Infof("loaded %d trades, %d profits", len(trades), len(samples))
+
+		report := backtest.RunMonteCarlo(samples, initialEquity, config)
+
+		fmt.Println(tradeStats.BriefString())
+		report.Print()
+
+		outputFile, err := cmd.Flags().GetString("output")
+		if err != nil {
+			return err
+		}
+
+		if len(outputFile) > 0 {
+			if err := util.WriteJsonFile(outputFile, report); err != nil {
+				return errors.Wrapf(err, "can not write monte carlo report json file: %s", outputFile)
+			}
+		}
+
+		return nil
+	},
+}
+
+func getAnalyzeInitialEquity(cmd *cobra.Command) (fixedpoint.Value, error) {
+	initialEquityStr, err := cmd.Flags().GetString("initial-equity")
+	if err != nil {
+		return fixedpoint.Zero, err
+	}
+
+	if len(initialEquityStr) > 0 {
+		return fixedpoint.NewFromString(initialEquityStr)
+	}
+
+	summaryFile, err := cmd.Flags().GetString("summary")
+	if err != nil {
+		return fixedpoint.Zero, err
+	}
+
+	if len(summaryFile) == 0 {
+		return fixedpoint.Zero, errors.New("--initial-equity or --summary is required")
+	}
+
+	summaryReport, err := backtest.ReadSummaryReport(summaryFile)
+	if err != nil {
+		return fixedpoint.Zero, err
+	}
+
+	return summaryReport.InitialEquityValue, nil
+}
+
+// parseMonteCarloFlags overrides the config by the given flags
+func parseMonteCarloFlags(cmd *cobra.Command, config *bbgo.BacktestMonteCarlo) (err error) {
+	flags := cmd.Flags()
+	if flags.Changed("iterations") {
+		if config.Iterations, err = flags.GetInt("iterations"); err != nil {
+			return err
+		}
+	}
+
+	if flags.Changed("seed") {
+		if config.Seed, err = flags.GetInt64("seed"); err != nil {
+			return err
+		}
+	}
+
+	valueFlags := map[string]*fixedpoint.Value{
+		"slippage":       &config.Slippage,
+		"fee-rate":       &config.FeeRate,
+		"ruin-threshold": &config.RuinThreshold,
+		"confidence":     &config.ConfidenceLevel,
+	}
+
+	for name, value := range valueFlags {
+		if !flags.Changed(name) {
+			continue
+		}
+
+		str, err := flags.GetString(name)
+		if err != nil {
+			return err
+		}
+
+		if *value, err = fixedpoint.NewFromString(str); err != nil {
+			return errors.Wrapf(err, "invalid --%s value", name)
+		}
+	}
+
+	return nil
+}
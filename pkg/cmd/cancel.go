@@ -147,6 +147,17 @@ var cancelOrderCmd = &cobra.Command{
 						log.Info("CANCELED ", o.String())
 					}
 				}
+			} else if service, ok := session.Exchange.(types.ExchangeCancelAllOrdersService); ok && len(symbol) > 0 {
+				log.Infof("canceling orders by symbol: %s", symbol)
+
+				orders, err := service.CancelOrdersBySymbol(ctx, symbol)
+				if err != nil {
+					return err
+				}
+
+				for _, o := range orders {
+					log.Info("CANCELED ", o.String())
+				}
 			} else if len(symbol) > 0 {
 				openOrders, err := session.Exchange.QueryOpenOrders(ctx, symbol)
 				if err != nil {
@@ -0,0 +1,332 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/c9s/bbgo/pkg/backtest"
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/cache"
+	"github.com/c9s/bbgo/pkg/dynamic"
+	"github.com/c9s/bbgo/pkg/exchange"
+	"github.com/c9s/bbgo/pkg/service"
+	"github.com/c9s/bbgo/pkg/types"
+	"github.com/c9s/bbgo/pkg/util"
+)
+
+// DriftReportFileName is the file name of the drift report in the output directory
+const DriftReportFileName = "drift_report.json"
+
+func init() {
+	driftReportCmd.Flags().String("config", "config/bbgo.yaml", "strategy config file")
+	driftReportCmd.Flags().String("strategy-instance", "", "the instance ID of the live strategy, can be omitted if only one exchange strategy is configured")
+	driftReportCmd.Flags().String("since", "", "the start time of the period, e.g. 2024-01-01")
+	driftReportCmd.Flags().String("until", "", "the end time of the period, default now")
+	driftReportCmd.Flags().Duration("match-window", backtest.DefaultDriftMatchWindow, "the max time difference of the aligned live and back-test orders")
+	driftReportCmd.Flags().Bool("sync", false, "sync the back-test data before the back-test")
+	driftReportCmd.Flags().String("output", "", "the output directory of the back-test report and the drift report, a temporary directory is used if it's not set")
+	RootCmd.AddCommand(driftReportCmd)
+}
+
+// driftReportCmd back-tests the period of a running strategy instance with the same config,
+// and compares the back-test orders and profits with the live orders and trades stored in the database
+var driftReportCmd = &cobra.Command{
+	Use:   "drift-report",
+	Short: "compare the live trades of a strategy instance with the back-test of the same period",
+	Long: "compare the live trades of a strategy instance with the back-test of the same period.\n\n" +
+		"The trades of each run are merged into the submitted orders, and the orders of both runs are aligned " +
+		"by symbol, side and submit time. The back-test orders are all the submitted orders, including the " +
+		"unfilled ones, and the live orders are the stored orders of the live trades of the instance.",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		configFile, err := cmd.Flags().GetString("config")
+		if err != nil {
+			return err
+		}
+
+		instanceID, err := cmd.Flags().GetString("strategy-instance")
+		if err != nil {
+			return err
+		}
+
+		sinceStr, err := cmd.Flags().GetString("since")
+		if err != nil {
+			return err
+		}
+
+		untilStr, err := cmd.Flags().GetString("until")
+		if err != nil {
+			return err
+		}
+
+		matchWindow, err := cmd.Flags().GetDuration("match-window")
+		if err != nil {
+			return err
+		}
+
+		wantSync, err := cmd.Flags().GetBool("sync")
+		if err != nil {
+			return err
+		}
+
+		outputDirectory, err := cmd.Flags().GetString("output")
+		if err != nil {
+			return err
+		}
+
+		if sinceStr == "" {
+			return errors.New("--since is required")
+		}
+
+		since, err := types.ParseLooseFormatTime(sinceStr)
+		if err != nil {
+			return err
+		}
+
+		startTime := since.Time()
+		endTime := time.Now()
+		if untilStr != "" {
+			until, err := types.ParseLooseFormatTime(untilStr)
+			if err != nil {
+				return err
+			}
+			endTime = until.Time()
+		}
+
+		if !startTime.Before(endTime) {
+			return fmt.Errorf("the start time %s should be before the end time %s", startTime, endTime)
+		}
+
+		userConfig, err := bbgo.Load(configFile, true)
+		if err != nil {
+			return err
+		}
+
+		mount, err := findDriftStrategyMount(userConfig, instanceID)
+		if err != nil {
+			return err
+		}
+
+		instanceID = dynamic.CallID(mount.Strategy)
+		exName, err := driftSessionExchange(userConfig, mount)
+		if err != nil {
+			return err
+		}
+
+		environ := bbgo.NewEnvironment()
+		if err := bbgo.BootstrapBacktestEnvironment(ctx, environ); err != nil {
+			return err
+		}
+
+		if environ.TradeService == nil {
+			return errors.New("database service is not enabled, please check your environment variables DB_DRIVER and DB_DSN")
+		}
+
+		liveTrades, err := environ.TradeService.Query(service.QueryTradesOptions{
+			Exchange:           exName,
+			StrategyInstanceID: instanceID,
+			Since:              &startTime,
+			Until:              &endTime,
+		})
+		if err != nil {
+			return err
+		}
+
+		log.Infof("loaded %d live trades of %s", len(liveTrades), instanceID)
+
+		// the trades are tied to the strategy instance, so are the orders of the trades
+		var orderIDs []uint64
+		var orderIDSet = make(map[uint64]struct{})
+		for _, trade := range liveTrades {
+			if _, ok := orderIDSet[trade.OrderID]; !ok && trade.OrderID != 0 {
+				orderIDSet[trade.OrderID] = struct{}{}
+				orderIDs = append(orderIDs, trade.OrderID)
+			}
+		}
+
+		liveOrders, err := environ.OrderService.QueryByOrderIDs(exName, orderIDs)
+		if err != nil {
+			return err
+		}
+
+		log.Infof("loaded %d live orders of %s", len(liveOrders), instanceID)
+
+		var symbols []string
+		var symbolSet = make(map[string]struct{})
+		for _, trade := range liveTrades {
+			if _, ok := symbolSet[trade.Symbol]; !ok {
+				symbolSet[trade.Symbol] = struct{}{}
+				symbols = append(symbols, trade.Symbol)
+			}
+		}
+
+		if outputDirectory == "" {
+			outputDirectory, err = os.MkdirTemp("", "bbgo-drift-*")
+			if err != nil {
+				return err
+			}
+		} else if err := util.SafeMkdirAll(outputDirectory); err != nil {
+			return err
+		}
+
+		backtestConfig := newDriftBacktestConfig(userConfig, mount, exName, symbols, startTime, endTime)
+		backtestOrders, backtestTrades, err := runDriftBacktest(backtestConfig, outputDirectory, wantSync)
+		if err != nil {
+			return err
+		}
+
+		log.Infof("loaded %d back-test orders and %d back-test trades", len(backtestOrders), len(backtestTrades))
+
+		publicExchange, err := exchange.NewPublic(exName)
+		if err != nil {
+			return err
+		}
+
+		markets, err := cache.LoadExchangeMarketsWithCache(ctx, publicExchange)
+		if err != nil {
+			return err
+		}
+
+		report := backtest.NewDriftReport(instanceID, liveOrders, liveTrades, backtestOrders, backtestTrades, markets,
+			startTime, endTime, matchWindow)
+		report.Print()
+
+		reportFile := filepath.Join(outputDirectory, DriftReportFileName)
+		if err := util.WriteJsonFile(reportFile, report); err != nil {
+			return errors.Wrapf(err, "can not write drift report json file: %s", reportFile)
+		}
+
+		fmt.Println(reportFile)
+		return nil
+	},
+}
+
+// findDriftStrategyMount finds the exchange strategy of the instance ID, the instance ID can be omitted if there is
+// only one exchange strategy
+func findDriftStrategyMount(userConfig *bbgo.Config, instanceID string) (*bbgo.ExchangeStrategyMount, error) {
+	if instanceID == "" {
+		if len(userConfig.ExchangeStrategies) != 1 {
+			return nil, errors.New("--strategy-instance is required if there are more than one exchange strategies")
+		}
+
+		return &userConfig.ExchangeStrategies[0], nil
+	}
+
+	var ids []string
+	for i, mount := range userConfig.ExchangeStrategies {
+		id := dynamic.CallID(mount.Strategy)
+		if id == instanceID {
+			return &userConfig.ExchangeStrategies[i], nil
+		}
+		ids = append(ids, id)
+	}
+
+	return nil, fmt.Errorf("strategy instance %s is not found in the config, available instances: %v", instanceID, ids)
+}
+
+func driftSessionExchange(userConfig *bbgo.Config, mount *bbgo.ExchangeStrategyMount) (types.ExchangeName, error) {
+	if len(mount.Mounts) != 1 {
+		return "", fmt.Errorf("strategy %s should be mounted on exactly one session", mount.Strategy.ID())
+	}
+
+	sessionName := mount.Mounts[0]
+	if session, ok := userConfig.Sessions[sessionName]; ok && session.ExchangeName != "" {
+		return session.ExchangeName, nil
+	}
+
+	return types.ValidExchangeName(sessionName)
+}
+
+// newDriftBacktestConfig returns the config that back-tests only the strategy in the given period,
+// the back-test session is named by the exchange name
+func newDriftBacktestConfig(
+	userConfig *bbgo.Config, mount *bbgo.ExchangeStrategyMount, exName types.ExchangeName, symbols []string,
+	startTime, endTime time.Time,
+) *bbgo.Config {
+	config := *userConfig
+	config.CrossExchangeStrategies = nil
+	config.ExchangeStrategies = []bbgo.ExchangeStrategyMount{{
+		Mounts:   []string{exName.String()},
+		Strategy: mount.Strategy,
+	}}
+
+	var backtestConfig bbgo.Backtest
+	if userConfig.Backtest != nil {
+		backtestConfig = *userConfig.Backtest
+	}
+
+	end := types.LooseFormatTime(endTime)
+	backtestConfig.StartTime = types.LooseFormatTime(startTime)
+	backtestConfig.EndTime = &end
+	backtestConfig.Sessions = []string{exName.String()}
+	backtestConfig.RecordTrades = false
+	backtestConfig.MonteCarlo = nil
+	backtestConfig.Portfolio = nil
+	if len(backtestConfig.Symbols) == 0 {
+		backtestConfig.Symbols = symbols
+	}
+
+	config.Backtest = &backtestConfig
+	return &config
+}
+
+// runDriftBacktest runs the back-test command in a sub-process, and returns the back-test orders and trades
+func runDriftBacktest(config *bbgo.Config, outputDirectory string, wantSync bool) ([]types.Order, []types.Trade, error) {
+	configYaml, err := config.YAML()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	configFile := filepath.Join(outputDirectory, "backtest.yaml")
+	if err := os.WriteFile(configFile, configYaml, 0644); err != nil {
+		return nil, nil, err
+	}
+
+	bin, err := os.Executable()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	backtestArgs := []string{"backtest", "--config", configFile, "--output", outputDirectory, "--force"}
+	if wantSync {
+		backtestArgs = append(backtestArgs, "--sync")
+	}
+
+	c := exec.Command(bin, backtestArgs...)
+	c.Stderr = os.Stderr
+	if output, err := c.Output(); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to execute backtest: %s", string(output))
+	}
+
+	var orders []types.Order
+	if err := readDriftJsonFile(filepath.Join(outputDirectory, backtest.OrdersFileName), &orders); err != nil {
+		return nil, nil, err
+	}
+
+	var trades []types.Trade
+	if err := readDriftJsonFile(filepath.Join(outputDirectory, backtest.TradesFileName), &trades); err != nil {
+		return nil, nil, err
+	}
+
+	return orders, trades, nil
+}
+
+func readDriftJsonFile(file string, v interface{}) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
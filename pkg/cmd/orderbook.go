@@ -59,6 +59,13 @@ var orderbookCmd = &cobra.Command{
 		s := session.Exchange.NewStream()
 		s.SetPublicOnly()
 		s.Subscribe(types.BookChannel, symbol, types.SubscribeOptions{})
+
+		closeCapture, err := captureStream(cmd, sessionName, "market", s)
+		if err != nil {
+			return err
+		}
+		defer closeCapture()
+
 		s.OnBookSnapshot(func(book types.SliceOrderBook) {
 			if dumpDepthUpdate {
 				log.Infof("orderbook snapshot: %s", book.String())
@@ -165,6 +172,7 @@ func init() {
 	orderbookCmd.Flags().String("session", "", "session name")
 	orderbookCmd.Flags().String("symbol", "", "the trading pair. e.g, BTCUSDT, LTCUSDT...")
 	orderbookCmd.Flags().Bool("dump-update", false, "dump the depth update")
+	orderbookCmd.Flags().String("capture-dir", "", "capture the raw websocket messages to the directory")
 
 	orderUpdateCmd.Flags().String("session", "", "session name")
 	RootCmd.AddCommand(orderbookCmd)
@@ -190,6 +190,9 @@ func init() {
 	RootCmd.PersistentFlags().String("telegram-bot-token", "", "telegram bot token from bot father")
 	RootCmd.PersistentFlags().String("telegram-bot-auth-token", "", "telegram auth token")
 
+	RootCmd.PersistentFlags().String("discord-webhook-url", "", "discord webhook url")
+	RootCmd.PersistentFlags().String("smtp-password", "", "smtp password of the email notification")
+
 	RootCmd.PersistentFlags().String("binance-api-key", "", "binance api key")
 	RootCmd.PersistentFlags().String("binance-api-secret", "", "binance api secret")
 
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+func init() {
+	routeOrderCmd.Flags().StringSlice("sessions", nil, "the exchange sessions to route the order, default is all the sessions")
+	routeOrderCmd.Flags().String("base", "", "the base currency, like BTC")
+	routeOrderCmd.Flags().String("quote", "", "the quote currency of the consolidated book, like USDT")
+	routeOrderCmd.Flags().String("side", "", "the trading side: buy or sell")
+	routeOrderCmd.Flags().String("quantity", "", "the base quantity of the parent order")
+	routeOrderCmd.Flags().String("price", "", "the worst effective price after the fees in the quote currency")
+	routeOrderCmd.Flags().StringToString("rate", nil, "the conversion rates of the other quote currencies, like --rate USDC=1 --rate TWD=0.032")
+	routeOrderCmd.Flags().Duration("book-timeout", 10*time.Second, "the timeout of waiting for the order book snapshots")
+	routeOrderCmd.Flags().Bool("dry-run", false, "print the routed orders without submitting them")
+	RootCmd.AddCommand(routeOrderCmd)
+}
+
+// go run ./cmd/bbgo route-order --base BTC --quote USDT --rate USDC=1 --side buy --quantity 0.5 --dry-run
+var routeOrderCmd = &cobra.Command{
+	Use:          "route-order --base BASE --quote QUOTE --side SIDE --quantity QUANTITY [--price PRICE]",
+	Short:        "split an order across the sessions by the consolidated order book",
+	SilenceUsage: true,
+	PreRunE: cobraInitRequired([]string{
+		"base",
+		"quote",
+		"side",
+		"quantity",
+	}),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sessionNames, err := cmd.Flags().GetStringSlice("sessions")
+		if err != nil {
+			return err
+		}
+
+		baseCurrency, err := cmd.Flags().GetString("base")
+		if err != nil {
+			return err
+		}
+
+		quoteCurrency, err := cmd.Flags().GetString("quote")
+		if err != nil {
+			return err
+		}
+
+		sideStr, err := cmd.Flags().GetString("side")
+		if err != nil {
+			return err
+		}
+
+		side, err := types.StrToSideType(sideStr)
+		if err != nil {
+			return err
+		}
+
+		quantityStr, err := cmd.Flags().GetString("quantity")
+		if err != nil {
+			return err
+		}
+
+		quantity, err := fixedpoint.NewFromString(quantityStr)
+		if err != nil {
+			return fmt.Errorf("invalid quantity %s: %w", quantityStr, err)
+		}
+
+		limitPrice := fixedpoint.Zero
+		if priceStr, err := cmd.Flags().GetString("price"); err != nil {
+			return err
+		} else if len(priceStr) > 0 {
+			limitPrice, err = fixedpoint.NewFromString(priceStr)
+			if err != nil {
+				return fmt.Errorf("invalid price %s: %w", priceStr, err)
+			}
+		}
+
+		rates, err := cmd.Flags().GetStringToString("rate")
+		if err != nil {
+			return err
+		}
+
+		bookTimeout, err := cmd.Flags().GetDuration("book-timeout")
+		if err != nil {
+			return err
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return err
+		}
+
+		environ := bbgo.NewEnvironment()
+		if err := environ.ConfigureExchangeSessions(userConfig); err != nil {
+			return err
+		}
+
+		if err := environ.Init(ctx); err != nil {
+			return err
+		}
+
+		book := bbgo.NewConsolidatedOrderBook(strings.ToUpper(baseCurrency), strings.ToUpper(quoteCurrency))
+		for currency, rateStr := range rates {
+			rate, err := fixedpoint.NewFromString(rateStr)
+			if err != nil {
+				return fmt.Errorf("invalid conversion rate of %s: %w", currency, err)
+			}
+
+			book.SetConversionRate(strings.ToUpper(currency), rate)
+		}
+
+		var sessions []*bbgo.ExchangeSession
+		if len(sessionNames) > 0 {
+			for _, sessionName := range sessionNames {
+				session, ok := environ.Session(sessionName)
+				if !ok {
+					return fmt.Errorf("session %s not found", sessionName)
+				}
+
+				sessions = append(sessions, session)
+			}
+		} else {
+			for _, session := range environ.Sessions() {
+				sessions = append(sessions, session)
+			}
+		}
+
+		for _, session := range sessions {
+			var symbols []string
+			for symbol, market := range session.Markets() {
+				if market.BaseCurrency != book.BaseCurrency {
+					continue
+				}
+
+				if _, ok := book.ConversionRate(market.QuoteCurrency); ok {
+					symbols = append(symbols, symbol)
+				}
+			}
+
+			if len(symbols) == 0 {
+				log.Warnf("session %s has no %s market with the convertible quote currency, skipped", session.Name, book.BaseCurrency)
+				continue
+			}
+
+			stream := session.Exchange.NewStream()
+			stream.SetPublicOnly()
+
+			var books []*types.StreamOrderBook
+			for _, symbol := range symbols {
+				stream.Subscribe(types.BookChannel, symbol, types.SubscribeOptions{})
+
+				streamBook := types.NewStreamBook(symbol)
+				streamBook.BindStream(stream)
+				books = append(books, streamBook)
+			}
+
+			if err := stream.Connect(ctx); err != nil {
+				return fmt.Errorf("failed to connect to %s: %w", session.Name, err)
+			}
+
+			defer func(stream types.Stream) {
+				if err := stream.Close(); err != nil {
+					log.WithError(err).Errorf("connection close error")
+				}
+			}(stream)
+
+			for _, streamBook := range books {
+				if err := waitBookSnapshot(ctx, streamBook, bookTimeout); err != nil {
+					return fmt.Errorf("session %s: %w", session.Name, err)
+				}
+
+				if err := book.AddSessionBook(session, streamBook.Symbol, streamBook); err != nil {
+					return err
+				}
+			}
+		}
+
+		router := bbgo.NewSmartOrderRouter(book)
+		route, err := router.Route(side, quantity, limitPrice)
+		if err != nil {
+			return err
+		}
+
+		for _, routedOrder := range route.Orders {
+			log.Infof("ROUTED %s %s effective amount %s %s",
+				routedOrder.Session, routedOrder.SubmitOrder.String(), routedOrder.EffectiveAmount.String(), book.QuoteCurrency)
+		}
+
+		log.Infof("routed quantity %s %s, average effective price %s %s, remaining quantity %s",
+			route.Quantity.String(), book.BaseCurrency,
+			route.AveragePrice().String(), book.QuoteCurrency,
+			route.Remaining.String())
+
+		if dryRun {
+			return nil
+		}
+
+		orders, err := router.Execute(ctx, route)
+		for _, order := range orders {
+			log.Infof("SUBMITTED %s", order.String())
+		}
+
+		return err
+	},
+}
+
+// waitBookSnapshot waits for the first snapshot of the stream book
+func waitBookSnapshot(ctx context.Context, book *types.StreamOrderBook, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-timer.C:
+			return fmt.Errorf("timeout waiting for the %s order book snapshot", book.Symbol)
+
+		case signal := <-book.C:
+			if signal.Type == types.BookSignalSnapshot {
+				return nil
+			}
+		}
+	}
+}
@@ -10,13 +10,16 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/pquerna/otp"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
 
 	"github.com/c9s/bbgo/pkg/bbgo"
 	"github.com/c9s/bbgo/pkg/cmd/cmdutil"
 	"github.com/c9s/bbgo/pkg/grpc"
+	"github.com/c9s/bbgo/pkg/interact"
 	"github.com/c9s/bbgo/pkg/server"
 )
 
@@ -29,11 +32,18 @@ func init() {
 	RunCmd.Flags().Bool("enable-webserver", false, "enable webserver")
 	RunCmd.Flags().Bool("enable-web-server", false, "legacy option, this is renamed to --enable-webserver")
 	RunCmd.Flags().String("webserver-bind", ":8080", "webserver binding")
+	RunCmd.Flags().String("webserver-auth-token", "", "the bearer token of the webserver strategy api, env var WEBSERVER_AUTH_TOKEN is used if it's not set")
+	RunCmd.Flags().String("webserver-totp-key-url", "", "the time-based one-time password key URL of the webserver strategy api, env var WEBSERVER_TOTP_KEY_URL is used if it's not set")
 	RunCmd.Flags().Bool("lightweight", false, "lightweight mode")
 
 	RunCmd.Flags().Bool("enable-grpc", false, "enable grpc server")
 	RunCmd.Flags().String("grpc-bind", ":50051", "grpc server binding")
+	RunCmd.Flags().String("grpc-auth-token", "", "the bearer token of the grpc server, env var GRPC_AUTH_TOKEN is used if it's not set")
+	RunCmd.Flags().String("grpc-totp-key-url", "", "the time-based one-time password key URL of the grpc server, env var GRPC_TOTP_KEY_URL is used if it's not set")
+	RunCmd.Flags().String("grpc-tls-cert", "", "the TLS certificate file of the grpc server")
+	RunCmd.Flags().String("grpc-tls-key", "", "the TLS key file of the grpc server")
 
+	RunCmd.Flags().String("capture-dir", "", "capture the raw websocket messages of the sessions to the directory")
 	RunCmd.Flags().Bool("setup", false, "use setup mode")
 	RootCmd.AddCommand(RunCmd)
 }
@@ -102,6 +112,11 @@ func runConfig(basectx context.Context, cmd *cobra.Command, userConfig *bbgo.Con
 		return err
 	}
 
+	webServerAuth, err := newAPIAuth(cmd, "webserver")
+	if err != nil {
+		return err
+	}
+
 	enableWebServerLegacy, err := cmd.Flags().GetBool("enable-web-server")
 	if err != nil {
 		return err
@@ -121,8 +136,20 @@ func runConfig(basectx context.Context, cmd *cobra.Command, userConfig *bbgo.Con
 		return err
 	}
 
-	_ = grpcBind
-	_ = enableGrpc
+	grpcAuth, err := newAPIAuth(cmd, "grpc")
+	if err != nil {
+		return err
+	}
+
+	grpcTLSCert, err := cmd.Flags().GetString("grpc-tls-cert")
+	if err != nil {
+		return err
+	}
+
+	grpcTLSKey, err := cmd.Flags().GetString("grpc-tls-key")
+	if err != nil {
+		return err
+	}
 
 	tradingCtx, cancelTrading := context.WithCancel(basectx)
 	defer cancelTrading()
@@ -148,6 +175,27 @@ func runConfig(basectx context.Context, cmd *cobra.Command, userConfig *bbgo.Con
 		return err
 	}
 
+	captureDir, err := cmd.Flags().GetString("capture-dir")
+	if err != nil {
+		return err
+	}
+
+	if len(captureDir) > 0 {
+		for _, session := range environ.Sessions() {
+			writer, err := session.CaptureStreams(captureDir)
+			if err != nil {
+				return err
+			}
+
+			sessionName := session.Name
+			defer func() {
+				if err := writer.Close(); err != nil {
+					log.WithError(err).Errorf("[%s] websocket capture file close error", sessionName)
+				}
+			}()
+		}
+	}
+
 	if !noSync {
 		if err := environ.Sync(tradingCtx, userConfig); err != nil {
 			return err
@@ -158,6 +206,12 @@ func runConfig(basectx context.Context, cmd *cobra.Command, userConfig *bbgo.Con
 		}
 	}
 
+	if enableWebServer {
+		// the hub must be set before the order executors are bound to the environment
+		environ.StrategyEventHub = bbgo.NewStrategyEventHub()
+		log.AddHook(bbgo.NewStrategyEventLogHook(environ.StrategyEventHub, log.InfoLevel))
+	}
+
 	trader := bbgo.NewTrader(environ)
 	if err := trader.Configure(userConfig); err != nil {
 		return err
@@ -175,12 +229,17 @@ func runConfig(basectx context.Context, cmd *cobra.Command, userConfig *bbgo.Con
 		return err
 	}
 
+	if environ.StrategyInstanceNAVTracker != nil {
+		go environ.StrategyInstanceNAVTracker.Run(tradingCtx)
+	}
+
 	if enableWebServer {
 		go func() {
 			s := &server.Server{
 				Config:  userConfig,
 				Environ: environ,
 				Trader:  trader,
+				Auth:    webServerAuth,
 			}
 
 			if err := s.Run(tradingCtx, webServerBind); err != nil {
@@ -192,9 +251,12 @@ func runConfig(basectx context.Context, cmd *cobra.Command, userConfig *bbgo.Con
 	if enableGrpc {
 		go func() {
 			s := &grpc.Server{
-				Config:  userConfig,
-				Environ: environ,
-				Trader:  trader,
+				Config:   userConfig,
+				Environ:  environ,
+				Trader:   trader,
+				Auth:     grpcAuth,
+				CertFile: grpcTLSCert,
+				KeyFile:  grpcTLSKey,
 			}
 			if err := s.ListenAndServe(grpcBind); err != nil {
 				log.WithError(err).Errorf("grpc server bind error")
@@ -332,3 +394,35 @@ func buildAndRun(ctx context.Context, userConfig *bbgo.Config, args ...string) (
 	runCmd.Stderr = os.Stderr
 	return runCmd, runCmd.Start()
 }
+
+// newAPIAuth returns the auth of the api server from the flags "<prefix>-auth-token" and "<prefix>-totp-key-url",
+// the strategy control api is disabled if neither the token nor the totp key is given
+func newAPIAuth(cmd *cobra.Command, prefix string) (*interact.AuthInteract, error) {
+	token, err := cmd.Flags().GetString(prefix + "-auth-token")
+	if err != nil {
+		return nil, err
+	}
+
+	if token == "" {
+		token = viper.GetString(prefix + "-auth-token")
+	}
+
+	keyURL, err := cmd.Flags().GetString(prefix + "-totp-key-url")
+	if err != nil {
+		return nil, err
+	}
+
+	if keyURL == "" {
+		keyURL = viper.GetString(prefix + "-totp-key-url")
+	}
+
+	var key *otp.Key
+	if keyURL != "" {
+		key, err = otp.NewKeyFromURL(keyURL)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s totp key url", prefix)
+		}
+	}
+
+	return interact.NewAuthInteract(token, key), nil
+}
@@ -53,6 +53,12 @@ var userDataStreamCmd = &cobra.Command{
 			log.Infof("[balanceSnapshot] %+v", trade)
 		})
 
+		closeCapture, err := captureStream(cmd, sessionName, "user", s)
+		if err != nil {
+			return err
+		}
+		defer closeCapture()
+
 		log.Infof("connecting...")
 		if err := s.Connect(ctx); err != nil {
 			return fmt.Errorf("failed to connect to %s", sessionName)
@@ -74,5 +80,6 @@ var userDataStreamCmd = &cobra.Command{
 
 func init() {
 	userDataStreamCmd.Flags().String("session", "", "session name")
+	userDataStreamCmd.Flags().String("capture-dir", "", "capture the raw websocket messages to the directory")
 	RootCmd.AddCommand(userDataStreamCmd)
 }
@@ -31,3 +31,26 @@ func inBaseAsset(balances types.BalanceMap, market types.Market, price fixedpoin
 	base := balances[market.BaseCurrency]
 	return quote.Total().Div(price).Add(base.Total())
 }
+
+// captureStream records the raw messages of the stream to the capture directory given by the --capture-dir flag,
+// the returned function closes the capture file.
+func captureStream(cmd *cobra.Command, sessionName, streamName string, stream types.Stream) (func(), error) {
+	captureDir, err := cmd.Flags().GetString("capture-dir")
+	if err != nil || len(captureDir) == 0 {
+		return func() {}, err
+	}
+
+	writer, filePath, err := types.CreateCaptureFile(captureDir, sessionName)
+	if err != nil {
+		return nil, err
+	}
+
+	writer.Record(sessionName, streamName, stream)
+	log.Infof("capturing the websocket messages to %s", filePath)
+
+	return func() {
+		if err := writer.Close(); err != nil {
+			log.WithError(err).Errorf("websocket capture file close error")
+		}
+	}, nil
+}
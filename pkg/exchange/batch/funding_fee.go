@@ -39,3 +39,30 @@ func (e *BinanceFuturesIncomeBatchQuery) Query(ctx context.Context, symbol strin
 	errC = query.Query(ctx, c, startTime, endTime)
 	return c, errC
 }
+
+type FundingFeeBatchQuery struct {
+	types.FundingFeeHistoryService
+}
+
+func (e *FundingFeeBatchQuery) Query(ctx context.Context, symbol string, startTime, endTime time.Time) (c chan types.FundingFee, errC chan error) {
+	query := &AsyncTimeRangedBatchQuery{
+		Type:    types.FundingFee{},
+		Limiter: rate.NewLimiter(rate.Every(3*time.Second), 1),
+		// some exchanges limit the time range of the query to 7 days
+		JumpIfEmpty: time.Hour * 24 * 7,
+		Q: func(startTime, endTime time.Time) (interface{}, error) {
+			return e.QueryFundingFeeHistory(ctx, symbol, &startTime, &endTime)
+		},
+		T: func(obj interface{}) time.Time {
+			return time.Time(obj.(types.FundingFee).Time)
+		},
+		ID: func(obj interface{}) string {
+			fee := obj.(types.FundingFee)
+			return fee.Symbol + fee.TransactionID + fee.Time.String()
+		},
+	}
+
+	c = make(chan types.FundingFee, 100)
+	errC = query.Query(ctx, c, startTime, endTime)
+	return c, errC
+}
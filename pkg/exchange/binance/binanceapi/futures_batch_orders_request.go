@@ -0,0 +1,66 @@
+package binanceapi
+
+import (
+	"github.com/c9s/requestgen"
+)
+
+// FuturesBatchOrder is the order of the batch orders request, all the values are sent as strings
+type FuturesBatchOrder struct {
+	Symbol           string `json:"symbol"`
+	Side             string `json:"side"`
+	Type             string `json:"type"`
+	Quantity         string `json:"quantity,omitempty"`
+	Price            string `json:"price,omitempty"`
+	StopPrice        string `json:"stopPrice,omitempty"`
+	TimeInForce      string `json:"timeInForce,omitempty"`
+	ReduceOnly       string `json:"reduceOnly,omitempty"`
+	NewClientOrderID string `json:"newClientOrderId,omitempty"`
+	NewOrderRespType string `json:"newOrderRespType,omitempty"`
+}
+
+// FuturesBatchOrderResponse is the result of each order in the batch orders response,
+// the code and the msg fields are set when the order is failed.
+type FuturesBatchOrderResponse struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+
+	Symbol        string `json:"symbol"`
+	OrderID       int64  `json:"orderId"`
+	ClientOrderID string `json:"clientOrderId"`
+	Price         string `json:"price"`
+	OrigQuantity  string `json:"origQty"`
+	ExecutedQty   string `json:"executedQty"`
+	Status        string `json:"status"`
+	TimeInForce   string `json:"timeInForce"`
+	Type          string `json:"type"`
+	Side          string `json:"side"`
+	ReduceOnly    bool   `json:"reduceOnly"`
+	UpdateTime    int64  `json:"updateTime"`
+}
+
+// FuturesPlaceBatchOrdersRequest places up to 5 orders, batchOrders is the JSON encoded list of FuturesBatchOrder
+//
+//go:generate requestgen -method POST -url "/fapi/v1/batchOrders" -type FuturesPlaceBatchOrdersRequest -responseType []FuturesBatchOrderResponse
+type FuturesPlaceBatchOrdersRequest struct {
+	client requestgen.AuthenticatedAPIClient
+
+	batchOrders string `param:"batchOrders"`
+}
+
+func (c *FuturesRestClient) NewFuturesPlaceBatchOrdersRequest() *FuturesPlaceBatchOrdersRequest {
+	return &FuturesPlaceBatchOrdersRequest{client: c}
+}
+
+// FuturesCancelBatchOrdersRequest cancels up to 10 orders, orderIdList is the JSON encoded list of the order ids
+//
+//go:generate requestgen -method DELETE -url "/fapi/v1/batchOrders" -type FuturesCancelBatchOrdersRequest -responseType []FuturesBatchOrderResponse
+type FuturesCancelBatchOrdersRequest struct {
+	client requestgen.AuthenticatedAPIClient
+
+	symbol      string `param:"symbol"`
+	orderIdList string `param:"orderIdList"`
+}
+
+func (c *FuturesRestClient) NewFuturesCancelBatchOrdersRequest() *FuturesCancelBatchOrdersRequest {
+	return &FuturesCancelBatchOrdersRequest{client: c}
+}
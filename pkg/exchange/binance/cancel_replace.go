@@ -6,9 +6,26 @@ import (
 
 	"github.com/adshao/go-binance/v2"
 	"github.com/c9s/bbgo/pkg/exchange/binance/binanceapi"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
 	"github.com/c9s/bbgo/pkg/types"
 )
 
+// AmendOrder replaces the open order with the new price and quantity through the cancel-replace API,
+// the new order has a new order ID. Only the spot orders are supported.
+// The quantity of a partially filled order defaults to the remaining quantity.
+func (e *Exchange) AmendOrder(ctx context.Context, order types.Order, newPrice, newQuantity fixedpoint.Value) (*types.Order, error) {
+	if e.IsFutures || e.IsMargin {
+		return nil, fmt.Errorf("binance margin and futures orders: %w", types.ErrOrderAmendNotSupported)
+	}
+
+	newOrder, err := order.Amend(newPrice, newQuantity)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.CancelReplace(ctx, types.StopOnFailure, newOrder)
+}
+
 func (e *Exchange) CancelReplace(ctx context.Context, cancelReplaceMode types.CancelReplaceModeType, o types.Order) (*types.Order, error) {
 	if err := orderLimiter.Wait(ctx); err != nil {
 		log.WithError(err).Errorf("order rate limiter wait error")
@@ -0,0 +1,75 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/testing/httptesting"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+func TestExchange_AmendOrder_PartiallyFilled(t *testing.T) {
+	market := types.Market{
+		Symbol:          "BTCUSDT",
+		BaseCurrency:    "BTC",
+		QuoteCurrency:   "USDT",
+		PricePrecision:  2,
+		VolumePrecision: 4,
+		StepSize:        fixedpoint.MustNewFromString("0.0001"),
+		TickSize:        fixedpoint.MustNewFromString("0.01"),
+		MinQuantity:     fixedpoint.MustNewFromString("0.001"),
+		MinNotional:     fixedpoint.MustNewFromString("10"),
+	}
+
+	order := types.Order{
+		OrderID: 1,
+		SubmitOrder: types.SubmitOrder{
+			Symbol:   "BTCUSDT",
+			Side:     types.SideTypeBuy,
+			Type:     types.OrderTypeLimit,
+			Quantity: fixedpoint.MustNewFromString("0.01"),
+			Price:    fixedpoint.MustNewFromString("19000"),
+			Market:   market,
+		},
+		Status:           types.OrderStatusPartiallyFilled,
+		ExecutedQuantity: fixedpoint.MustNewFromString("0.004"),
+	}
+
+	var quantities []string
+	transport := &httptesting.MockTransport{}
+	transport.POST("/api/v3/order/cancelReplace", func(req *http.Request) (*http.Response, error) {
+		if err := req.ParseForm(); err != nil {
+			return nil, err
+		}
+
+		quantities = append(quantities, req.PostForm.Get("quantity"))
+		return httptesting.BuildResponseString(http.StatusOK, `{"data":{"cancelResult":"SUCCESS","newOrderResult":"SUCCESS",
+"newOrderResponse":{"symbol":"BTCUSDT","orderId":2,"price":"19100.00","origQty":"0.0060","executedQty":"0",
+"status":"NEW","timeInForce":"GTC","type":"LIMIT","side":"BUY","transactTime":1700000000000}}}`), nil
+	})
+
+	ex := New("key", "secret")
+	ex.client2.HttpClient = &http.Client{Transport: transport}
+
+	t.Run("remaining quantity", func(t *testing.T) {
+		newOrder, err := ex.AmendOrder(context.Background(), order, fixedpoint.MustNewFromString("19100"), fixedpoint.Zero)
+		if assert.NoError(t, err) {
+			assert.Equal(t, uint64(2), newOrder.OrderID)
+		}
+
+		assert.Equal(t, []string{"0.0060"}, quantities)
+	})
+
+	t.Run("remaining quantity below the min quantity", func(t *testing.T) {
+		dustOrder := order
+		dustOrder.ExecutedQuantity = fixedpoint.MustNewFromString("0.0095")
+
+		_, err := ex.AmendOrder(context.Background(), dustOrder, fixedpoint.MustNewFromString("19100"), fixedpoint.Zero)
+		assert.Error(t, err)
+		assert.Len(t, quantities, 1)
+	})
+}
@@ -288,3 +288,75 @@ func convertPositionRisk(risk *futures.PositionRisk) (*types.PositionRisk, error
 		LiquidationPrice: liquidationPrice,
 	}, nil
 }
+
+// toLocalFuturesBatchOrder converts the submit order to the order of the futures batch orders request
+func toLocalFuturesBatchOrder(order types.SubmitOrder) (*binanceapi.FuturesBatchOrder, error) {
+	if order.ClosePosition {
+		return nil, fmt.Errorf("close position order is not supported by the batch orders api")
+	}
+
+	orderType, err := toLocalFuturesOrderType(order.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	batchOrder := &binanceapi.FuturesBatchOrder{
+		Symbol:           order.Symbol,
+		Side:             string(order.Side),
+		Type:             string(orderType),
+		NewClientOrderID: newFuturesClientOrderID(order.ClientOrderID),
+		NewOrderRespType: string(futures.NewOrderRespTypeRESULT),
+	}
+
+	if order.ReduceOnly {
+		batchOrder.ReduceOnly = "true"
+	}
+
+	formatQuantity := func(v fixedpoint.Value) string { return v.FormatString(8) }
+	formatPrice := formatQuantity
+	if order.Market.Symbol != "" {
+		formatQuantity = order.Market.FormatQuantity
+		formatPrice = order.Market.FormatPrice
+	}
+
+	batchOrder.Quantity = formatQuantity(order.Quantity)
+
+	switch order.Type {
+	case types.OrderTypeStopLimit, types.OrderTypeLimit, types.OrderTypeLimitMaker:
+		batchOrder.Price = formatPrice(order.Price)
+	}
+
+	switch order.Type {
+	case types.OrderTypeStopLimit, types.OrderTypeStopMarket:
+		batchOrder.StopPrice = formatPrice(order.StopPrice)
+	}
+
+	if len(order.TimeInForce) > 0 {
+		batchOrder.TimeInForce = string(order.TimeInForce)
+	} else {
+		switch order.Type {
+		case types.OrderTypeLimit, types.OrderTypeLimitMaker, types.OrderTypeStopLimit:
+			batchOrder.TimeInForce = string(futures.TimeInForceTypeGTC)
+		}
+	}
+
+	return batchOrder, nil
+}
+
+// toFuturesOrderFromBatchResponse converts the order result of the batch orders response to the futures order
+func toFuturesOrderFromBatchResponse(res binanceapi.FuturesBatchOrderResponse) *futures.Order {
+	return &futures.Order{
+		Symbol:           res.Symbol,
+		OrderID:          res.OrderID,
+		ClientOrderID:    res.ClientOrderID,
+		Price:            res.Price,
+		OrigQuantity:     res.OrigQuantity,
+		ExecutedQuantity: res.ExecutedQty,
+		Status:           futures.OrderStatusType(res.Status),
+		TimeInForce:      futures.TimeInForceType(res.TimeInForce),
+		Type:             futures.OrderType(res.Type),
+		Side:             futures.SideType(res.Side),
+		ReduceOnly:       res.ReduceOnly,
+		UpdateTime:       res.UpdateTime,
+	}
+}
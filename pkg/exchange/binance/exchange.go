@@ -23,6 +23,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/c9s/bbgo/pkg/exchange/binance/binanceapi"
+	"github.com/c9s/bbgo/pkg/exchange/ratelimit"
 	"github.com/c9s/bbgo/pkg/fixedpoint"
 	"github.com/c9s/bbgo/pkg/types"
 	"github.com/c9s/bbgo/pkg/util"
@@ -54,6 +55,9 @@ func init() {
 	_ = types.Exchange(&Exchange{})
 	_ = types.MarginExchange(&Exchange{})
 	_ = types.FuturesExchange(&Exchange{})
+	_ = types.ExchangeBatchOrderService(&Exchange{})
+	_ = types.ExchangeCancelAllOrdersService(&Exchange{})
+	_ = ratelimit.Provider(&Exchange{})
 
 	if n, ok := util.GetEnvVarInt("BINANCE_ORDER_RATE_LIMITER"); ok {
 		orderLimiter = rate.NewLimiter(rate.Every(time.Duration(n)*time.Minute), 2)
@@ -90,17 +94,18 @@ type Exchange struct {
 	client2 *binanceapi.RestClient
 
 	futuresClient2 *binanceapi.FuturesRestClient
+
+	// spotLimiter and futuresLimiter are the request weight limiters shared by the instances with the same api host
+	spotLimiter, futuresLimiter *ratelimit.Limiter
 }
 
 var timeSetterOnce sync.Once
 
 func New(key, secret string) *Exchange {
 	var client = binance.NewClient(key, secret)
-	client.HTTPClient = binanceapi.DefaultHttpClient
 	client.Debug = viper.GetBool("debug-binance-client")
 
 	var futuresClient = binance.NewFuturesClient(key, secret)
-	futuresClient.HTTPClient = binanceapi.DefaultHttpClient
 	futuresClient.Debug = viper.GetBool("debug-binance-futures-client")
 
 	if isBinanceUs() {
@@ -112,8 +117,20 @@ func New(key, secret string) *Exchange {
 		futuresClient.BaseURL = FutureTestBaseURL
 	}
 
+	// binance counts the request weight by IP, so the limiters are shared by all the API keys that send the requests
+	// to the same host from this process
+	spotLimiter := ratelimit.Get(ratelimit.NewHostKey(types.ExchangeBinance, rateLimitScopeSpot, client.BaseURL), defaultSpotRateLimitConfig)
+	futuresLimiter := ratelimit.Get(ratelimit.NewHostKey(types.ExchangeBinance, rateLimitScopeFutures, futuresClient.BaseURL), defaultFuturesRateLimitConfig)
+	spotHttpClient := newRateLimitedHttpClient(spotLimiter)
+	futuresHttpClient := newRateLimitedHttpClient(futuresLimiter)
+	client.HTTPClient = spotHttpClient
+	futuresClient.HTTPClient = futuresHttpClient
+
 	client2 := binanceapi.NewClient(client.BaseURL)
+	client2.HttpClient = spotHttpClient
+
 	futuresClient2 := binanceapi.NewFuturesRestClient(futuresClient.BaseURL)
+	futuresClient2.HttpClient = futuresHttpClient
 
 	ex := &Exchange{
 		key:            key,
@@ -122,6 +139,8 @@ func New(key, secret string) *Exchange {
 		futuresClient:  futuresClient,
 		client2:        client2,
 		futuresClient2: futuresClient2,
+		spotLimiter:    spotLimiter,
+		futuresLimiter: futuresLimiter,
 	}
 
 	if len(key) > 0 && len(secret) > 0 {
@@ -706,6 +725,11 @@ func (e *Exchange) QueryAccount(ctx context.Context) (*types.Account, error) {
 }
 
 func (e *Exchange) QueryOpenOrders(ctx context.Context, symbol string) (orders []types.Order, err error) {
+	endpoint := e.rateLimitEndpoint("/api/v3/openOrders", "/sapi/v1/margin/openOrders", "/fapi/v1/openOrders")
+	if err := e.waitRateLimit(ctx, endpoint, ratelimit.PriorityNormal); err != nil {
+		return nil, err
+	}
+
 	if e.IsMargin {
 		req := e.client.NewListMarginOpenOrdersService().Symbol(symbol)
 		req.IsIsolated(e.IsIsolatedMargin)
@@ -855,12 +879,18 @@ func (e *Exchange) QueryClosedOrders(ctx context.Context, symbol string, since,
 	return toGlobalOrders(binanceOrders, e.IsMargin)
 }
 
+// CancelOrders cancels the orders with the high priority of the request weight
 func (e *Exchange) CancelOrders(ctx context.Context, orders ...types.Order) (err error) {
 	if err = orderLimiter.Wait(ctx); err != nil {
 		log.WithError(err).Errorf("order rate limiter wait error")
 		return err
 	}
 
+	endpoint := e.rateLimitEndpoint("/api/v3/order", "/sapi/v1/margin/order", "/fapi/v1/order")
+	if err = e.waitRateLimitN(ctx, endpoint, len(orders), ratelimit.PriorityHigh); err != nil {
+		return err
+	}
+
 	if e.IsFutures {
 		return e.cancelFuturesOrders(ctx, orders...)
 	}
@@ -1126,6 +1156,11 @@ func (e *Exchange) SubmitOrder(ctx context.Context, order types.SubmitOrder) (cr
 		return nil, err
 	}
 
+	endpoint := e.rateLimitEndpoint("/api/v3/order", "/sapi/v1/margin/order", "/fapi/v1/order")
+	if err = e.waitRateLimit(ctx, endpoint, ratelimit.PriorityNormal); err != nil {
+		return nil, err
+	}
+
 	if e.IsMargin {
 		createdOrder, err = e.submitMarginOrder(ctx, order)
 	} else if e.IsFutures {
@@ -1137,6 +1172,104 @@ func (e *Exchange) SubmitOrder(ctx context.Context, order types.SubmitOrder) (cr
 	return createdOrder, err
 }
 
+// BatchSubmitOrders submits the orders with the batch orders API in the futures mode,
+// the orders are submitted one by one in the spot and the margin mode since there is no batch API for them.
+func (e *Exchange) BatchSubmitOrders(ctx context.Context, orders ...types.SubmitOrder) (createdOrders types.OrderSlice, errIndexes []int, err error) {
+	if e.IsFutures {
+		return e.batchSubmitFuturesOrders(ctx, orders...)
+	}
+
+	for i, order := range orders {
+		createdOrder, err2 := e.SubmitOrder(ctx, order)
+		if err2 != nil {
+			err = multierr.Append(err, err2)
+			errIndexes = append(errIndexes, i)
+			continue
+		}
+
+		createdOrders = append(createdOrders, *createdOrder)
+	}
+
+	return createdOrders, errIndexes, err
+}
+
+// BatchCancelOrders cancels the orders with the batch orders API in the futures mode,
+// the orders are canceled one by one in the spot and the margin mode.
+func (e *Exchange) BatchCancelOrders(ctx context.Context, orders ...types.Order) (failedOrders []types.Order, err error) {
+	if e.IsFutures {
+		return e.batchCancelFuturesOrders(ctx, orders...)
+	}
+
+	for _, order := range orders {
+		if err2 := e.CancelOrders(ctx, order); err2 != nil {
+			err = multierr.Append(err, err2)
+			failedOrders = append(failedOrders, order)
+		}
+	}
+
+	return failedOrders, err
+}
+
+// CancelOrdersBySymbol cancels all the open orders of the symbol, including the orders not submitted by bbgo.
+// The returned orders are the open orders queried before the cancellation.
+func (e *Exchange) CancelOrdersBySymbol(ctx context.Context, symbol string) ([]types.Order, error) {
+	openOrders, err := e.QueryOpenOrders(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(openOrders) == 0 {
+		return nil, nil
+	}
+
+	if e.IsMargin {
+		failedOrders, err := e.BatchCancelOrders(ctx, openOrders...)
+		return excludeOrders(openOrders, failedOrders), err
+	}
+
+	if err := orderLimiter.Wait(ctx); err != nil {
+		log.WithError(err).Errorf("order rate limiter wait error")
+		return nil, err
+	}
+
+	endpoint := e.rateLimitEndpoint("/api/v3/openOrders", "/sapi/v1/margin/openOrders", "/fapi/v1/allOpenOrders")
+	if err := e.waitRateLimit(ctx, endpoint, ratelimit.PriorityHigh); err != nil {
+		return nil, err
+	}
+
+	if e.IsFutures {
+		err = e.futuresClient.NewCancelAllOpenOrdersService().Symbol(symbol).Do(ctx)
+	} else {
+		_, err = e.client.NewCancelOpenOrdersService().Symbol(symbol).Do(ctx)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range openOrders {
+		openOrders[i].Status = types.OrderStatusCanceled
+	}
+
+	return openOrders, nil
+}
+
+// excludeOrders returns the orders which are not in the excluded orders
+func excludeOrders(orders, excluded []types.Order) (result []types.Order) {
+	excludedIDs := make(map[uint64]struct{}, len(excluded))
+	for _, o := range excluded {
+		excludedIDs[o.OrderID] = struct{}{}
+	}
+
+	for _, o := range orders {
+		if _, ok := excludedIDs[o.OrderID]; !ok {
+			result = append(result, o)
+		}
+	}
+
+	return result
+}
+
 // QueryKLines queries the Kline/candlestick bars for a symbol. Klines are uniquely identified by their open time.
 // Binance uses inclusive start time query range, eg:
 // https://api.binance.com/api/v3/klines?symbol=BTCUSDT&interval=1m&startTime=1620172860000
@@ -1305,6 +1438,11 @@ func (e *Exchange) QueryTrades(ctx context.Context, symbol string, options *type
 		return nil, err
 	}
 
+	endpoint := e.rateLimitEndpoint("/api/v3/myTrades", "/sapi/v1/margin/myTrades", "/fapi/v1/userTrades")
+	if err := e.waitRateLimit(ctx, endpoint, ratelimit.PriorityNormal); err != nil {
+		return nil, err
+	}
+
 	if e.IsMargin {
 		return e.queryMarginTrades(ctx, symbol, options)
 	} else if e.IsFutures {
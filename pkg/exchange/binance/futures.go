@@ -2,7 +2,9 @@ package binance
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/adshao/go-binance/v2"
@@ -11,6 +13,7 @@ import (
 	"go.uber.org/multierr"
 
 	"github.com/c9s/bbgo/pkg/exchange/binance/binanceapi"
+	"github.com/c9s/bbgo/pkg/exchange/ratelimit"
 	"github.com/c9s/bbgo/pkg/fixedpoint"
 	"github.com/c9s/bbgo/pkg/types"
 )
@@ -126,6 +129,176 @@ func (e *Exchange) cancelFuturesOrders(ctx context.Context, orders ...types.Orde
 	return err
 }
 
+const (
+	// maxFuturesBatchSubmitSize is the max number of orders of the futures batch orders API
+	maxFuturesBatchSubmitSize = 5
+
+	// maxFuturesBatchCancelSize is the max number of orders of the futures batch cancel orders API
+	maxFuturesBatchCancelSize = 10
+)
+
+func (e *Exchange) batchSubmitFuturesOrders(ctx context.Context, orders ...types.SubmitOrder) (createdOrders types.OrderSlice, errIndexes []int, err error) {
+	for begin := 0; begin < len(orders); begin += maxFuturesBatchSubmitSize {
+		end := begin + maxFuturesBatchSubmitSize
+		if end > len(orders) {
+			end = len(orders)
+		}
+
+		var batchOrders []binanceapi.FuturesBatchOrder
+		var batchIndexes []int
+		for i := begin; i < end; i++ {
+			batchOrder, err2 := toLocalFuturesBatchOrder(orders[i])
+			if err2 != nil {
+				err = multierr.Append(err, types.NewOrderError(err2, types.Order{SubmitOrder: orders[i]}))
+				errIndexes = append(errIndexes, i)
+				continue
+			}
+
+			batchOrders = append(batchOrders, *batchOrder)
+			batchIndexes = append(batchIndexes, i)
+		}
+
+		if len(batchOrders) == 0 {
+			continue
+		}
+
+		responses, err2 := e.doFuturesPlaceBatchOrders(ctx, batchOrders)
+		if err2 != nil {
+			err = multierr.Append(err, fmt.Errorf("failed to place futures batch orders: %w", err2))
+			errIndexes = append(errIndexes, batchIndexes...)
+			continue
+		}
+
+		for i, res := range responses {
+			idx := batchIndexes[i]
+			if res.Code != 0 || res.OrderID == 0 {
+				err = multierr.Append(err, types.NewOrderError(
+					fmt.Errorf("failed to place futures order, code: %d, msg: %s", res.Code, res.Msg),
+					types.Order{SubmitOrder: orders[idx]}))
+				errIndexes = append(errIndexes, idx)
+				continue
+			}
+
+			createdOrder, err2 := toGlobalFuturesOrder(toFuturesOrderFromBatchResponse(res), false)
+			if err2 != nil {
+				err = multierr.Append(err, err2)
+				errIndexes = append(errIndexes, idx)
+				continue
+			}
+
+			createdOrders = append(createdOrders, *createdOrder)
+		}
+	}
+
+	sort.Ints(errIndexes)
+	return createdOrders, errIndexes, err
+}
+
+func (e *Exchange) doFuturesPlaceBatchOrders(ctx context.Context, batchOrders []binanceapi.FuturesBatchOrder) ([]binanceapi.FuturesBatchOrderResponse, error) {
+	payload, err := json.Marshal(batchOrders)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := orderLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("order rate limiter wait error: %w", err)
+	}
+
+	if err := e.waitRateLimit(ctx, "/fapi/v1/batchOrders", ratelimit.PriorityNormal); err != nil {
+		return nil, err
+	}
+
+	responses, err := e.futuresClient2.NewFuturesPlaceBatchOrdersRequest().BatchOrders(string(payload)).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(responses) != len(batchOrders) {
+		return nil, fmt.Errorf("unexpected length of batch orders response: %d, exp: %d", len(responses), len(batchOrders))
+	}
+
+	return responses, nil
+}
+
+// batchCancelFuturesOrders cancels the orders with the batch cancel API, the orders are grouped by the symbol
+func (e *Exchange) batchCancelFuturesOrders(ctx context.Context, orders ...types.Order) (failedOrders []types.Order, err error) {
+	var symbols []string
+	ordersBySymbol := map[string][]types.Order{}
+	for _, o := range orders {
+		if o.OrderID == 0 {
+			err = multierr.Append(err, types.NewOrderError(
+				fmt.Errorf("can not cancel %s order, order does not contain orderID", o.Symbol),
+				o))
+			failedOrders = append(failedOrders, o)
+			continue
+		}
+
+		if _, ok := ordersBySymbol[o.Symbol]; !ok {
+			symbols = append(symbols, o.Symbol)
+		}
+		ordersBySymbol[o.Symbol] = append(ordersBySymbol[o.Symbol], o)
+	}
+
+	for _, symbol := range symbols {
+		symbolOrders := ordersBySymbol[symbol]
+		for begin := 0; begin < len(symbolOrders); begin += maxFuturesBatchCancelSize {
+			end := begin + maxFuturesBatchCancelSize
+			if end > len(symbolOrders) {
+				end = len(symbolOrders)
+			}
+
+			batchOrders := symbolOrders[begin:end]
+			orderIDs := make([]uint64, len(batchOrders))
+			for i, o := range batchOrders {
+				orderIDs[i] = o.OrderID
+			}
+
+			responses, err2 := e.doFuturesCancelBatchOrders(ctx, symbol, orderIDs)
+			if err2 != nil {
+				err = multierr.Append(err, fmt.Errorf("failed to cancel futures batch orders: %w", err2))
+				failedOrders = append(failedOrders, batchOrders...)
+				continue
+			}
+
+			for i, res := range responses {
+				if res.Code != 0 {
+					err = multierr.Append(err, types.NewOrderError(
+						fmt.Errorf("failed to cancel futures order, code: %d, msg: %s", res.Code, res.Msg),
+						batchOrders[i]))
+					failedOrders = append(failedOrders, batchOrders[i])
+				}
+			}
+		}
+	}
+
+	return failedOrders, err
+}
+
+func (e *Exchange) doFuturesCancelBatchOrders(ctx context.Context, symbol string, orderIDs []uint64) ([]binanceapi.FuturesBatchOrderResponse, error) {
+	payload, err := json.Marshal(orderIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.waitRateLimit(ctx, "/fapi/v1/batchOrders", ratelimit.PriorityHigh); err != nil {
+		return nil, err
+	}
+
+	responses, err := e.futuresClient2.NewFuturesCancelBatchOrdersRequest().
+		Symbol(symbol).
+		OrderIdList(string(payload)).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(responses) != len(orderIDs) {
+		return nil, fmt.Errorf("unexpected length of batch cancel response: %d, exp: %d", len(responses), len(orderIDs))
+	}
+
+	return responses, nil
+}
+
 func (e *Exchange) submitFuturesOrder(ctx context.Context, order types.SubmitOrder) (*types.Order, error) {
 	orderType, err := toLocalFuturesOrderType(order.Type)
 	if err != nil {
@@ -0,0 +1,100 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/c9s/bbgo/pkg/exchange/binance/binanceapi"
+	"github.com/c9s/bbgo/pkg/exchange/ratelimit"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+const (
+	rateLimitScopeSpot    = "spot"
+	rateLimitScopeFutures = "futures"
+
+	// usedWeightHeader is the used request weight of the current minute, it's counted by IP
+	usedWeightHeader = "X-Mbx-Used-Weight-1m"
+)
+
+// https://binance-docs.github.io/apidocs/spot/en/#limits
+// the margin api (/sapi) shares the request weight with the spot api in the same scope.
+var defaultSpotRateLimitConfig = ratelimit.Config{
+	Limit:    6000,
+	Interval: types.Duration(time.Minute),
+	Reserved: 600,
+	Weights: map[string]int{
+		"/api/v3/order":              1,
+		"/api/v3/openOrders":         6,
+		"/api/v3/allOrders":          20,
+		"/api/v3/myTrades":           20,
+		"/api/v3/account":            20,
+		"/sapi/v1/margin/order":      6,
+		"/sapi/v1/margin/openOrders": 10,
+		"/sapi/v1/margin/allOrders":  200,
+		"/sapi/v1/margin/myTrades":   10,
+	},
+}
+
+// https://binance-docs.github.io/apidocs/futures/en/#limits
+var defaultFuturesRateLimitConfig = ratelimit.Config{
+	Limit:    2400,
+	Interval: types.Duration(time.Minute),
+	Reserved: 240,
+	Weights: map[string]int{
+		"/fapi/v1/order":         1,
+		"/fapi/v1/batchOrders":   5,
+		"/fapi/v1/openOrders":    1,
+		"/fapi/v1/allOpenOrders": 1,
+		"/fapi/v1/allOrders":     5,
+		"/fapi/v1/userTrades":    5,
+		"/fapi/v2/account":       5,
+	},
+}
+
+// newRateLimitedHttpClient creates the http client that updates the limiter by the used weight header
+func newRateLimitedHttpClient(limiter *ratelimit.Limiter) *http.Client {
+	return &http.Client{
+		Timeout:   binanceapi.DefaultHttpClient.Timeout,
+		Transport: ratelimit.NewTransport(binanceapi.DefaultHttpClient.Transport, limiter, ratelimit.UsedWeightHeaderParser(usedWeightHeader)),
+	}
+}
+
+// RateLimiters returns the shared rate limiters of the spot and the futures api
+func (e *Exchange) RateLimiters() []*ratelimit.Limiter {
+	return []*ratelimit.Limiter{e.spotLimiter, e.futuresLimiter}
+}
+
+// rateLimitEndpoint returns the endpoint of the current trading mode for looking up the request weight
+func (e *Exchange) rateLimitEndpoint(spot, margin, futures string) string {
+	if e.IsFutures {
+		return futures
+	} else if e.IsMargin {
+		return margin
+	}
+
+	return spot
+}
+
+// waitRateLimit waits for the request weight of the endpoint, the cancel requests should use the high priority
+// so that they could use the reserved weight when the limit is almost reached.
+func (e *Exchange) waitRateLimit(ctx context.Context, endpoint string, priority ratelimit.Priority) error {
+	return e.waitRateLimitN(ctx, endpoint, 1, priority)
+}
+
+// waitRateLimitN waits for the request weight of n requests to the endpoint
+func (e *Exchange) waitRateLimitN(ctx context.Context, endpoint string, n int, priority ratelimit.Priority) error {
+	limiter := e.spotLimiter
+	if e.IsFutures {
+		limiter = e.futuresLimiter
+	}
+
+	weight := limiter.Config().Weight(endpoint) * n
+	if err := limiter.WaitN(ctx, weight, priority); err != nil {
+		return fmt.Errorf("rate limiter wait error, endpoint: %s, err: %w", endpoint, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package bitget
+
+import (
+	"hash/crc32"
+	"strings"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// checksumDepth is the number of the price levels used in the order book checksum
+const checksumDepth = 25
+
+var _ types.BookChecksumCalculator = &Stream{}
+
+// BookChecksum calculates the order book checksum in the way of Bitget: the top 25 bids and asks are interleaved
+// as "bidPrice:bidSize:askPrice:askSize:...", and the checksum is the crc32 of the string as a signed 32-bit integer.
+// Bitget formats the price and the size with the precisions of the market, e.g. "28350.70".
+// see https://www.bitget.com/api-doc/spot/websocket/public/Depth-Channel
+func (s *Stream) BookChecksum(market types.Market, bids, asks types.PriceVolumeSlice) int64 {
+	fields := make([]string, 0, checksumDepth*4)
+	appendLevel := func(pv types.PriceVolume) {
+		fields = append(fields, pv.Price.FormatString(market.PricePrecision), pv.Volume.FormatString(market.VolumePrecision))
+	}
+
+	for i := 0; i < checksumDepth; i++ {
+		if i < len(bids) {
+			appendLevel(bids[i])
+		}
+
+		if i < len(asks) {
+			appendLevel(asks[i])
+		}
+	}
+
+	return int64(int32(crc32.ChecksumIEEE([]byte(strings.Join(fields, ":")))))
+}
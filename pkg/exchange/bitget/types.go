@@ -146,10 +146,11 @@ func (e *BookEvent) ToGlobalOrderBooks() []types.SliceOrderBook {
 	books := make([]types.SliceOrderBook, len(e.Events))
 	for i, event := range e.Events {
 		books[i] = types.SliceOrderBook{
-			Symbol: e.instId,
-			Bids:   event.Bids,
-			Asks:   event.Asks,
-			Time:   event.Ts.Time(),
+			Symbol:   e.instId,
+			Bids:     event.Bids,
+			Asks:     event.Asks,
+			Time:     event.Ts.Time(),
+			Checksum: int64(event.Checksum),
 		}
 	}
 
@@ -0,0 +1,37 @@
+package bybitapi
+
+import (
+	"github.com/c9s/requestgen"
+)
+
+//go:generate -command GetRequest requestgen -method GET -responseType .APIResponse -responseDataField Result
+//go:generate -command PostRequest requestgen -method POST -responseType .APIResponse -responseDataField Result
+
+type AmendOrderResponse struct {
+	OrderId     string `json:"orderId"`
+	OrderLinkId string `json:"orderLinkId"`
+}
+
+//go:generate PostRequest -url "/v5/order/amend" -type AmendOrderRequest -responseDataType .AmendOrderResponse
+type AmendOrderRequest struct {
+	client requestgen.AuthenticatedAPIClient
+
+	category Category `param:"category" validValues:"spot,linear"`
+	symbol   string   `param:"symbol"`
+
+	// Either orderId or orderLinkId is required
+	orderId     *string `param:"orderId"`
+	orderLinkId *string `param:"orderLinkId"`
+
+	// qty is the order quantity after modification
+	qty *string `param:"qty"`
+	// price is the order price after modification
+	price *string `param:"price"`
+}
+
+func (c *RestClient) NewAmendOrderRequest() *AmendOrderRequest {
+	return &AmendOrderRequest{
+		client:   c,
+		category: CategorySpot,
+	}
+}
@@ -0,0 +1,29 @@
+package bybitapi
+
+import (
+	"github.com/c9s/requestgen"
+)
+
+//go:generate -command PostRequest requestgen -method POST -responseType .APIResponse
+
+// BatchCancelOrderItem is the order of the batch cancel order request. Either orderId or orderLinkId is required
+type BatchCancelOrderItem struct {
+	Symbol      string `json:"symbol"`
+	OrderId     string `json:"orderId,omitempty"`
+	OrderLinkId string `json:"orderLinkId,omitempty"`
+}
+
+//go:generate PostRequest -url "/v5/order/cancel-batch" -type BatchCancelOrderRequest
+type BatchCancelOrderRequest struct {
+	client requestgen.AuthenticatedAPIClient
+
+	category Category               `param:"category" validValues:"spot,linear"`
+	request  []BatchCancelOrderItem `param:"request"`
+}
+
+func (c *RestClient) NewBatchCancelOrderRequest() *BatchCancelOrderRequest {
+	return &BatchCancelOrderRequest{
+		client:   c,
+		category: CategorySpot,
+	}
+}
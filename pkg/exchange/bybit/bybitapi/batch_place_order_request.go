@@ -0,0 +1,89 @@
+package bybitapi
+
+import (
+	"encoding/json"
+
+	"github.com/c9s/requestgen"
+)
+
+//go:generate -command PostRequest requestgen -method POST -responseType .APIResponse
+
+// BatchPlaceOrderItem is the order of the batch place order request
+type BatchPlaceOrderItem struct {
+	Symbol      string      `json:"symbol"`
+	Side        Side        `json:"side"`
+	OrderType   OrderType   `json:"orderType"`
+	Qty         string      `json:"qty"`
+	Price       string      `json:"price,omitempty"`
+	TimeInForce TimeInForce `json:"timeInForce,omitempty"`
+	OrderLinkId string      `json:"orderLinkId,omitempty"`
+	ReduceOnly  bool        `json:"reduceOnly,omitempty"`
+}
+
+type BatchOrderResult struct {
+	List []BatchOrderResultItem `json:"list"`
+}
+
+type BatchOrderResultItem struct {
+	Category    Category `json:"category"`
+	Symbol      string   `json:"symbol"`
+	OrderId     string   `json:"orderId"`
+	OrderLinkId string   `json:"orderLinkId"`
+}
+
+type BatchOrderExtInfo struct {
+	List []BatchOrderExtInfoItem `json:"list"`
+}
+
+// BatchOrderExtInfoItem is the result of each order in the batch request, the code is 0 if the order is successful.
+type BatchOrderExtInfoItem struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// BatchOrderItemResponse is the result of each order of the batch order request
+type BatchOrderItemResponse struct {
+	BatchOrderResultItem
+	BatchOrderExtInfoItem
+}
+
+// ParseBatchOrderResponse parses the result list and the ext info list of the batch order api response, and
+// merges them by the index.
+func ParseBatchOrderResponse(resp *APIResponse) ([]BatchOrderItemResponse, error) {
+	var result BatchOrderResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return nil, err
+	}
+
+	var extInfo BatchOrderExtInfo
+	if len(resp.RetExtInfo) > 0 {
+		if err := json.Unmarshal(resp.RetExtInfo, &extInfo); err != nil {
+			return nil, err
+		}
+	}
+
+	items := make([]BatchOrderItemResponse, len(result.List))
+	for i, r := range result.List {
+		items[i].BatchOrderResultItem = r
+		if i < len(extInfo.List) {
+			items[i].BatchOrderExtInfoItem = extInfo.List[i]
+		}
+	}
+
+	return items, nil
+}
+
+//go:generate PostRequest -url "/v5/order/create-batch" -type BatchPlaceOrderRequest
+type BatchPlaceOrderRequest struct {
+	client requestgen.AuthenticatedAPIClient
+
+	category Category              `param:"category" validValues:"spot,linear"`
+	request  []BatchPlaceOrderItem `param:"request"`
+}
+
+func (c *RestClient) NewBatchPlaceOrderRequest() *BatchPlaceOrderRequest {
+	return &BatchPlaceOrderRequest{
+		client:   c,
+		category: CategorySpot,
+	}
+}
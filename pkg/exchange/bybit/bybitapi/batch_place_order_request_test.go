@@ -0,0 +1,75 @@
+package bybitapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBatchOrderResponse(t *testing.T) {
+	t.Run("partial success", func(t *testing.T) {
+		data := `{
+    "retCode": 0,
+    "retMsg": "OK",
+    "result": {
+        "list": [
+            {
+                "category": "linear",
+                "symbol": "BTCUSDT",
+                "orderId": "b4c9a8e4-ab2a-4a4e-9a4e-7c8b7e1b4f1a",
+                "orderLinkId": "order-1",
+                "createAt": "1713434102752"
+            },
+            {
+                "category": "linear",
+                "symbol": "BTCUSDT",
+                "orderId": "",
+                "orderLinkId": "order-2",
+                "createAt": ""
+            }
+        ]
+    },
+    "retExtInfo": {
+        "list": [
+            {
+                "code": 0,
+                "msg": "OK"
+            },
+            {
+                "code": 10001,
+                "msg": "params error"
+            }
+        ]
+    },
+    "time": 1713434102753
+}`
+		var resp APIResponse
+		err := json.Unmarshal([]byte(data), &resp)
+		assert.NoError(t, err)
+
+		items, err := ParseBatchOrderResponse(&resp)
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+
+		assert.Equal(t, "b4c9a8e4-ab2a-4a4e-9a4e-7c8b7e1b4f1a", items[0].OrderId)
+		assert.Equal(t, "order-1", items[0].OrderLinkId)
+		assert.Equal(t, 0, items[0].Code)
+
+		assert.Equal(t, "order-2", items[1].OrderLinkId)
+		assert.Equal(t, 10001, items[1].Code)
+		assert.Equal(t, "params error", items[1].Msg)
+	})
+
+	t.Run("empty ext info", func(t *testing.T) {
+		resp := APIResponse{
+			Result: []byte(`{"list":[{"symbol":"BTCUSDT","orderId":"1","orderLinkId":""}]}`),
+		}
+
+		items, err := ParseBatchOrderResponse(&resp)
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Equal(t, "1", items[0].OrderId)
+		assert.Equal(t, 0, items[0].Code)
+	})
+}
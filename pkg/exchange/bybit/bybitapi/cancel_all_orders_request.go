@@ -0,0 +1,26 @@
+package bybitapi
+
+import (
+	"github.com/c9s/requestgen"
+)
+
+//go:generate -command PostRequest requestgen -method POST -responseType .APIResponse -responseDataField Result
+
+type CancelAllOrdersResponse struct {
+	List []CancelOrderResponse `json:"list"`
+}
+
+//go:generate PostRequest -url "/v5/order/cancel-all" -type CancelAllOrdersRequest -responseDataType .CancelAllOrdersResponse
+type CancelAllOrdersRequest struct {
+	client requestgen.AuthenticatedAPIClient
+
+	category Category `param:"category" validValues:"spot,linear"`
+	symbol   string   `param:"symbol"`
+}
+
+func (c *RestClient) NewCancelAllOrdersRequest() *CancelAllOrdersRequest {
+	return &CancelAllOrdersRequest{
+		client:   c,
+		category: CategorySpot,
+	}
+}
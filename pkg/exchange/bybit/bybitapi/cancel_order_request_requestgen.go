@@ -56,7 +56,7 @@ func (p *CancelOrderRequest) GetParameters() (map[string]interface{}, error) {
 
 	// TEMPLATE check-valid-values
 	switch category {
-	case "spot":
+	case "spot", "linear":
 		params["category"] = category
 
 	default:
@@ -24,6 +24,7 @@ const (
 
 	RestBaseURL         = "https://api.bybit.com"
 	WsSpotPublicSpotUrl = "wss://stream.bybit.com/v5/public/spot"
+	WsLinearPublicUrl   = "wss://stream.bybit.com/v5/public/linear"
 	WsSpotPrivateUrl    = "wss://stream.bybit.com/v5/private"
 )
 
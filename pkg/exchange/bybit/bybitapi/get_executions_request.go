@@ -0,0 +1,77 @@
+package bybitapi
+
+import (
+	"time"
+
+	"github.com/c9s/requestgen"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+//go:generate -command GetRequest requestgen -method GET -responseType .APIResponse -responseDataField Result
+//go:generate -command PostRequest requestgen -method POST -responseType .APIResponse -responseDataField Result
+
+type ExecType string
+
+const (
+	ExecTypeTrade   ExecType = "Trade"
+	ExecTypeFunding ExecType = "Funding"
+)
+
+type ExecutionsResponse struct {
+	Category       Category    `json:"category"`
+	List           []Execution `json:"list"`
+	NextPageCursor string      `json:"nextPageCursor"`
+}
+
+type Execution struct {
+	Symbol      string           `json:"symbol"`
+	OrderId     string           `json:"orderId"`
+	OrderLinkId string           `json:"orderLinkId"`
+	Side        Side             `json:"side"`
+	OrderPrice  fixedpoint.Value `json:"orderPrice"`
+	OrderQty    fixedpoint.Value `json:"orderQty"`
+	LeavesQty   fixedpoint.Value `json:"leavesQty"`
+	OrderType   OrderType        `json:"orderType"`
+	ExecId      string           `json:"execId"`
+	ExecPrice   fixedpoint.Value `json:"execPrice"`
+	ExecQty     fixedpoint.Value `json:"execQty"`
+	ExecType    ExecType         `json:"execType"`
+	ExecValue   fixedpoint.Value `json:"execValue"`
+	// ExecFee is charged in the settle coin for the linear category, a negative fee means the fee is received.
+	// For the funding execution, a positive fee means the funding fee is paid.
+	ExecFee    fixedpoint.Value           `json:"execFee"`
+	FeeRate    fixedpoint.Value           `json:"feeRate"`
+	ExecTime   types.MillisecondTimestamp `json:"execTime"`
+	IsMaker    bool                       `json:"isMaker"`
+	MarkPrice  fixedpoint.Value           `json:"markPrice"`
+	IndexPrice fixedpoint.Value           `json:"indexPrice"`
+	ClosedSize fixedpoint.Value           `json:"closedSize"`
+}
+
+// GetExecutionsRequest queries the executions of the unified account, the time range between startTime and endTime
+// can not exceed 7 days.
+//
+//go:generate GetRequest -url "/v5/execution/list" -type GetExecutionsRequest -responseDataType .ExecutionsResponse
+type GetExecutionsRequest struct {
+	client requestgen.AuthenticatedAPIClient
+
+	category    Category   `param:"category,query" validValues:"linear"`
+	symbol      *string    `param:"symbol,query"`
+	orderId     *string    `param:"orderId,query"`
+	orderLinkId *string    `param:"orderLinkId,query"`
+	startTime   *time.Time `param:"startTime,query,milliseconds"`
+	endTime     *time.Time `param:"endTime,query,milliseconds"`
+	execType    *ExecType  `param:"execType,query"`
+	// limit is the number of results per page, the maximum is 100, the default is 50
+	limit  *uint64 `param:"limit,query"`
+	cursor *string `param:"cursor,query"`
+}
+
+func (c *RestClient) NewGetExecutionsRequest() *GetExecutionsRequest {
+	return &GetExecutionsRequest{
+		client:   c,
+		category: CategoryLinear,
+	}
+}
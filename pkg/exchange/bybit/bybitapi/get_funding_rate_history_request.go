@@ -0,0 +1,43 @@
+package bybitapi
+
+import (
+	"time"
+
+	"github.com/c9s/requestgen"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+//go:generate -command GetRequest requestgen -method GET -responseType .APIResponse -responseDataField Result
+//go:generate -command PostRequest requestgen -method POST -responseType .APIResponse -responseDataField Result
+
+type FundingRateHistoryResponse struct {
+	Category Category             `json:"category"`
+	List     []FundingRateHistory `json:"list"`
+}
+
+type FundingRateHistory struct {
+	Symbol               string                     `json:"symbol"`
+	FundingRate          fixedpoint.Value           `json:"fundingRate"`
+	FundingRateTimestamp types.MillisecondTimestamp `json:"fundingRateTimestamp"`
+}
+
+//go:generate GetRequest -url "/v5/market/funding/history" -type GetFundingRateHistoryRequest -responseDataType .FundingRateHistoryResponse
+type GetFundingRateHistoryRequest struct {
+	client requestgen.APIClient
+
+	category  Category   `param:"category,query" validValues:"linear"`
+	symbol    string     `param:"symbol,query"`
+	startTime *time.Time `param:"startTime,query,milliseconds"`
+	endTime   *time.Time `param:"endTime,query,milliseconds"`
+	// limit is the number of results per page, the maximum is 200, the default is 200
+	limit *uint64 `param:"limit,query"`
+}
+
+func (c *RestClient) NewGetFundingRateHistoryRequest() *GetFundingRateHistoryRequest {
+	return &GetFundingRateHistoryRequest{
+		client:   c,
+		category: CategoryLinear,
+	}
+}
@@ -12,6 +12,8 @@ import (
 type InstrumentsInfo struct {
 	Category Category     `json:"category"`
 	List     []Instrument `json:"list"`
+	// NextPageCursor is only returned for the `linear` category.
+	NextPageCursor string `json:"nextPageCursor"`
 }
 
 type Instrument struct {
@@ -21,6 +23,11 @@ type Instrument struct {
 	Innovation    string `json:"innovation"`
 	Status        Status `json:"status"`
 	MarginTrading string `json:"marginTrading"`
+
+	// The following fields are only returned for the `linear` category.
+	ContractType ContractType `json:"contractType"`
+	SettleCoin   string       `json:"settleCoin"`
+
 	LotSizeFilter struct {
 		BasePrecision  fixedpoint.Value `json:"basePrecision"`
 		QuotePrecision fixedpoint.Value `json:"quotePrecision"`
@@ -28,10 +35,18 @@ type Instrument struct {
 		MaxOrderQty    fixedpoint.Value `json:"maxOrderQty"`
 		MinOrderAmt    fixedpoint.Value `json:"minOrderAmt"`
 		MaxOrderAmt    fixedpoint.Value `json:"maxOrderAmt"`
+
+		// QtyStep and MinNotionalValue are only returned for the `linear` category.
+		QtyStep          fixedpoint.Value `json:"qtyStep"`
+		MinNotionalValue fixedpoint.Value `json:"minNotionalValue"`
 	} `json:"lotSizeFilter"`
 
 	PriceFilter struct {
 		TickSize fixedpoint.Value `json:"tickSize"`
+
+		// MinPrice and MaxPrice are only returned for the `linear` category.
+		MinPrice fixedpoint.Value `json:"minPrice"`
+		MaxPrice fixedpoint.Value `json:"maxPrice"`
 	} `json:"priceFilter"`
 }
 
@@ -39,7 +54,7 @@ type Instrument struct {
 type GetInstrumentsInfoRequest struct {
 	client requestgen.APIClient
 
-	category Category `param:"category,query" validValues:"spot"`
+	category Category `param:"category,query" validValues:"spot,linear"`
 	symbol   *string  `param:"symbol,query"`
 
 	// limit is invalid if category spot.
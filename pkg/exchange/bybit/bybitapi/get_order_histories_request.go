@@ -13,7 +13,7 @@ import (
 type GetOrderHistoriesRequest struct {
 	client requestgen.AuthenticatedAPIClient
 
-	category Category `param:"category,query" validValues:"spot"`
+	category Category `param:"category,query" validValues:"spot,linear"`
 
 	symbol      *string `param:"symbol,query"`
 	orderId     *string `param:"orderId,query"`
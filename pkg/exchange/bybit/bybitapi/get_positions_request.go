@@ -0,0 +1,76 @@
+package bybitapi
+
+import (
+	"github.com/c9s/requestgen"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+//go:generate -command GetRequest requestgen -method GET -responseType .APIResponse -responseDataField Result
+//go:generate -command PostRequest requestgen -method POST -responseType .APIResponse -responseDataField Result
+
+type PositionTradeMode int
+
+const (
+	PositionTradeModeCross    PositionTradeMode = 0
+	PositionTradeModeIsolated PositionTradeMode = 1
+)
+
+type PositionsResponse struct {
+	Category       Category   `json:"category"`
+	List           []Position `json:"list"`
+	NextPageCursor string     `json:"nextPageCursor"`
+}
+
+type Position struct {
+	// PositionIdx is used to identify positions in different position modes.
+	// 0: one-way mode, 1: hedge-mode Buy side, 2: hedge-mode Sell side
+	PositionIdx int    `json:"positionIdx"`
+	Symbol      string `json:"symbol"`
+	// Side is Buy for the long position, Sell for the short position, and empty for no position.
+	Side           Side              `json:"side"`
+	Size           fixedpoint.Value  `json:"size"`
+	AvgPrice       fixedpoint.Value  `json:"avgPrice"`
+	PositionValue  fixedpoint.Value  `json:"positionValue"`
+	TradeMode      PositionTradeMode `json:"tradeMode"`
+	PositionStatus string            `json:"positionStatus"`
+	Leverage       fixedpoint.Value  `json:"leverage"`
+	MarkPrice      fixedpoint.Value  `json:"markPrice"`
+	LiqPrice       fixedpoint.Value  `json:"liqPrice"`
+	BustPrice      fixedpoint.Value  `json:"bustPrice"`
+	PositionIM     fixedpoint.Value  `json:"positionIM"`
+	PositionMM     fixedpoint.Value  `json:"positionMM"`
+	TakeProfit     fixedpoint.Value  `json:"takeProfit"`
+	StopLoss       fixedpoint.Value  `json:"stopLoss"`
+	UnrealisedPnl  fixedpoint.Value  `json:"unrealisedPnl"`
+	CumRealisedPnl fixedpoint.Value  `json:"cumRealisedPnl"`
+
+	CreatedTime types.MillisecondTimestamp `json:"createdTime"`
+	UpdatedTime types.MillisecondTimestamp `json:"updatedTime"`
+
+	// EntryPrice is only supported in the websocket, it's the same as AvgPrice.
+	EntryPrice fixedpoint.Value `json:"entryPrice"`
+	// Category is only supported in the websocket.
+	Category Category `json:"category"`
+}
+
+//go:generate GetRequest -url "/v5/position/list" -type GetPositionsRequest -responseDataType .PositionsResponse
+type GetPositionsRequest struct {
+	client requestgen.AuthenticatedAPIClient
+
+	category Category `param:"category,query" validValues:"linear"`
+	// symbol or settleCoin is required
+	symbol     *string `param:"symbol,query"`
+	settleCoin *string `param:"settleCoin,query"`
+	// limit is the number of results per page, the maximum is 200, the default is 20
+	limit  *uint64 `param:"limit,query"`
+	cursor *string `param:"cursor,query"`
+}
+
+func (c *RestClient) NewGetPositionsRequest() *GetPositionsRequest {
+	return &GetPositionsRequest{
+		client:   c,
+		category: CategoryLinear,
+	}
+}
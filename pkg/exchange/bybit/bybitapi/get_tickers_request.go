@@ -34,6 +34,12 @@ type Ticker struct {
 	Turnover24H   fixedpoint.Value `json:"turnover24h"`
 	Volume24H     fixedpoint.Value `json:"volume24h"`
 	UsdIndexPrice fixedpoint.Value `json:"usdIndexPrice"`
+
+	// The following fields are only returned for the `linear` category.
+	MarkPrice       fixedpoint.Value           `json:"markPrice"`
+	IndexPrice      fixedpoint.Value           `json:"indexPrice"`
+	FundingRate     fixedpoint.Value           `json:"fundingRate"`
+	NextFundingTime types.MillisecondTimestamp `json:"nextFundingTime"`
 }
 
 // GetTickersRequest without **-responseDataType .InstrumentsInfo** in generation command, because the caller
@@ -43,7 +49,7 @@ type Ticker struct {
 type GetTickersRequest struct {
 	client requestgen.APIClient
 
-	category Category `param:"category,query" validValues:"spot"`
+	category Category `param:"category,query" validValues:"spot,linear"`
 	symbol   *string  `param:"symbol,query"`
 }
 
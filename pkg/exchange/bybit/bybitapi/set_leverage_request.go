@@ -0,0 +1,31 @@
+package bybitapi
+
+import (
+	"github.com/c9s/requestgen"
+)
+
+//go:generate -command GetRequest requestgen -method GET -responseType .APIResponse -responseDataField Result
+//go:generate -command PostRequest requestgen -method POST -responseType .APIResponse -responseDataField Result
+
+// ErrCodeLeverageNotModified is returned if the leverage is the same as the current leverage.
+const ErrCodeLeverageNotModified = 110043
+
+// SetLeverageRequest sets the leverage of the position, the buyLeverage must be the same as the sellLeverage
+// in the one-way mode.
+//
+//go:generate PostRequest -url "/v5/position/set-leverage" -type SetLeverageRequest
+type SetLeverageRequest struct {
+	client requestgen.AuthenticatedAPIClient
+
+	category     Category `param:"category" validValues:"linear"`
+	symbol       string   `param:"symbol"`
+	buyLeverage  string   `param:"buyLeverage"`
+	sellLeverage string   `param:"sellLeverage"`
+}
+
+func (c *RestClient) NewSetLeverageRequest() *SetLeverageRequest {
+	return &SetLeverageRequest{
+		client:   c,
+		category: CategoryLinear,
+	}
+}
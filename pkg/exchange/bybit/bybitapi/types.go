@@ -39,7 +39,14 @@ var (
 type Category string
 
 const (
-	CategorySpot Category = "spot"
+	CategorySpot   Category = "spot"
+	CategoryLinear Category = "linear"
+)
+
+type ContractType string
+
+const (
+	ContractTypeLinearPerpetual ContractType = "LinearPerpetual"
 )
 
 type Status string
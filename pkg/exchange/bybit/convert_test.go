@@ -48,6 +48,9 @@ func TestToGlobalMarket(t *testing.T) {
 			MaxOrderQty    fixedpoint.Value `json:"maxOrderQty"`
 			MinOrderAmt    fixedpoint.Value `json:"minOrderAmt"`
 			MaxOrderAmt    fixedpoint.Value `json:"maxOrderAmt"`
+
+			QtyStep          fixedpoint.Value `json:"qtyStep"`
+			MinNotionalValue fixedpoint.Value `json:"minNotionalValue"`
 		}{
 			BasePrecision:  fixedpoint.NewFromFloat(0.000001),
 			QuotePrecision: fixedpoint.NewFromFloat(0.00000001),
@@ -58,6 +61,9 @@ func TestToGlobalMarket(t *testing.T) {
 		},
 		PriceFilter: struct {
 			TickSize fixedpoint.Value `json:"tickSize"`
+
+			MinPrice fixedpoint.Value `json:"minPrice"`
+			MaxPrice fixedpoint.Value `json:"maxPrice"`
 		}{
 			TickSize: fixedpoint.NewFromFloat(0.01),
 		},
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
@@ -19,6 +20,8 @@ import (
 
 const (
 	maxOrderIdLen     = 36
+	// maxBatchOrderSize is the max number of orders of the batch place order and the batch cancel order API
+	maxBatchOrderSize = 10
 	defaultQueryLimit = 50
 	defaultKLineLimit = 1000
 
@@ -47,12 +50,20 @@ var (
 	_ types.ExchangeTradeService      = &Exchange{}
 	_ types.Exchange                  = &Exchange{}
 	_ types.ExchangeOrderQueryService = &Exchange{}
+	_ types.ExchangeOrderAmendService = &Exchange{}
+	_ types.FuturesExchange           = &Exchange{}
+	_ types.FundingFeeHistoryService  = &Exchange{}
+	_ types.ExchangeBatchOrderService = &Exchange{}
+
+	_ types.ExchangeCancelAllOrdersService = &Exchange{}
 )
 
 type Exchange struct {
 	key, secret string
 	client      *bybitapi.RestClient
 	v3client    *v3.Client
+
+	types.FuturesSettings
 }
 
 func New(key, secret string) (*Exchange, error) {
@@ -85,6 +96,10 @@ func (e *Exchange) PlatformFeeCurrency() string {
 }
 
 func (e *Exchange) QueryMarkets(ctx context.Context) (types.MarketMap, error) {
+	if e.IsFutures {
+		return e.queryFuturesMarkets(ctx)
+	}
+
 	if err := sharedRateLimiter.Wait(ctx); err != nil {
 		return nil, fmt.Errorf("markets rate limiter wait error: %w", err)
 	}
@@ -107,7 +122,7 @@ func (e *Exchange) QueryTicker(ctx context.Context, symbol string) (*types.Ticke
 		return nil, fmt.Errorf("ticker order rate limiter wait error: %w", err)
 	}
 
-	s, err := e.client.NewGetTickersRequest().Symbol(symbol).DoWithResponseTime(ctx)
+	s, err := e.client.NewGetTickersRequest().Category(e.category()).Symbol(symbol).DoWithResponseTime(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call ticker, symbol: %s, err: %w", symbol, err)
 	}
@@ -138,7 +153,7 @@ func (e *Exchange) QueryTickers(ctx context.Context, symbols ...string) (map[str
 	if err := sharedRateLimiter.Wait(ctx); err != nil {
 		return nil, fmt.Errorf("tickers rate limiter wait error: %w", err)
 	}
-	allTickers, err := e.client.NewGetTickersRequest().DoWithResponseTime(ctx)
+	allTickers, err := e.client.NewGetTickersRequest().Category(e.category()).DoWithResponseTime(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call ticker, err: %w", err)
 	}
@@ -153,7 +168,7 @@ func (e *Exchange) QueryTickers(ctx context.Context, symbols ...string) (map[str
 func (e *Exchange) QueryOpenOrders(ctx context.Context, symbol string) (orders []types.Order, err error) {
 	cursor := ""
 	for {
-		req := e.client.NewGetOpenOrderRequest().Symbol(symbol)
+		req := e.client.NewGetOpenOrderRequest().Category(e.category()).Symbol(symbol)
 		if len(cursor) != 0 {
 			// the default limit is 20.
 			req = req.Cursor(cursor)
@@ -168,7 +183,7 @@ func (e *Exchange) QueryOpenOrders(ctx context.Context, symbol string) (orders [
 		}
 
 		for _, order := range res.List {
-			order, err := toGlobalOrder(order)
+			order, err := e.toGlobalOrder(order)
 			if err != nil {
 				return nil, fmt.Errorf("failed to convert order, err: %v", err)
 			}
@@ -194,7 +209,7 @@ func (e *Exchange) QueryOrder(ctx context.Context, q types.OrderQuery) (*types.O
 		return nil, errors.New("only accept one parameter of OrderID/ClientOrderID")
 	}
 
-	req := e.client.NewGetOrderHistoriesRequest()
+	req := e.client.NewGetOrderHistoriesRequest().Category(e.category())
 	if len(q.Symbol) != 0 {
 		req.Symbol(q.Symbol)
 	}
@@ -215,7 +230,7 @@ func (e *Exchange) QueryOrder(ctx context.Context, q types.OrderQuery) (*types.O
 		return nil, fmt.Errorf("unexpected order length, queryConfig: %+v", q)
 	}
 
-	return toGlobalOrder(res.List[0])
+	return e.toGlobalOrder(res.List[0])
 }
 
 func (e *Exchange) QueryOrderTrades(ctx context.Context, q types.OrderQuery) (trades []types.Trade, err error) {
@@ -226,6 +241,11 @@ func (e *Exchange) QueryOrderTrades(ctx context.Context, q types.OrderQuery) (tr
 	if len(q.OrderID) == 0 {
 		return nil, errors.New("orderID is required parameter")
 	}
+
+	if e.IsFutures {
+		return e.queryFuturesTrades(ctx, q.Symbol, q.OrderID, nil, nil)
+	}
+
 	req := e.v3client.NewGetTradesRequest().OrderId(q.OrderID)
 
 	if len(q.Symbol) != 0 {
@@ -262,7 +282,7 @@ func (e *Exchange) SubmitOrder(ctx context.Context, order types.SubmitOrder) (*t
 		return nil, fmt.Errorf("order.Market.Symbol is required: %+v", order)
 	}
 
-	req := e.client.NewPlaceOrderRequest()
+	req := e.client.NewPlaceOrderRequest().Category(e.category())
 	req.Symbol(order.Market.Symbol)
 
 	// set order type
@@ -281,8 +301,8 @@ func (e *Exchange) SubmitOrder(ctx context.Context, order types.SubmitOrder) (*t
 
 	// set quantity
 	orderQty := order.Quantity
-	// if the order is market buy, the quantity is quote coin, instead of base coin. so we need to convert it.
-	if order.Type == types.OrderTypeMarket && order.Side == types.SideTypeBuy {
+	// if the spot order is market buy, the quantity is quote coin, instead of base coin. so we need to convert it.
+	if !e.IsFutures && order.Type == types.OrderTypeMarket && order.Side == types.SideTypeBuy {
 		ticker, err := e.QueryTicker(ctx, order.Market.Symbol)
 		if err != nil {
 			return nil, err
@@ -291,6 +311,10 @@ func (e *Exchange) SubmitOrder(ctx context.Context, order types.SubmitOrder) (*t
 	}
 	req.Qty(order.Market.FormatQuantity(orderQty))
 
+	if e.IsFutures && order.ReduceOnly {
+		req.ReduceOnly(true)
+	}
+
 	// set price
 	switch order.Type {
 	case types.OrderTypeLimit:
@@ -327,7 +351,7 @@ func (e *Exchange) SubmitOrder(ctx context.Context, order types.SubmitOrder) (*t
 		return nil, fmt.Errorf("unexpected order id, resp: %#v, order: %#v", res, order)
 	}
 
-	ordersResp, err := e.client.NewGetOpenOrderRequest().OrderId(res.OrderId).Do(ctx)
+	ordersResp, err := e.client.NewGetOpenOrderRequest().Category(e.category()).OrderId(res.OrderId).Do(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query order by client order id: %s, err: %w", res.OrderLinkId, err)
 	}
@@ -336,7 +360,7 @@ func (e *Exchange) SubmitOrder(ctx context.Context, order types.SubmitOrder) (*t
 		return nil, fmt.Errorf("unexpected order length, client order id: %s", res.OrderLinkId)
 	}
 
-	return toGlobalOrder(ordersResp.List[0])
+	return e.toGlobalOrder(ordersResp.List[0])
 }
 
 func (e *Exchange) CancelOrders(ctx context.Context, orders ...types.Order) (errs error) {
@@ -345,7 +369,7 @@ func (e *Exchange) CancelOrders(ctx context.Context, orders ...types.Order) (err
 	}
 
 	for _, order := range orders {
-		req := e.client.NewCancelOrderRequest()
+		req := e.client.NewCancelOrderRequest().Category(e.category())
 
 		reqId := ""
 		switch {
@@ -388,6 +412,323 @@ func (e *Exchange) CancelOrders(ctx context.Context, orders ...types.Order) (err
 	return errs
 }
 
+// BatchSubmitOrders submits the orders with the batch place order API, each request includes up to 10 orders.
+// The created orders are converted from the submit orders with the new order status.
+func (e *Exchange) BatchSubmitOrders(ctx context.Context, orders ...types.SubmitOrder) (types.OrderSlice, []int, error) {
+	var createdOrders types.OrderSlice
+	var errIndexes []int
+	var errs error
+
+	for begin := 0; begin < len(orders); begin += maxBatchOrderSize {
+		end := begin + maxBatchOrderSize
+		if end > len(orders) {
+			end = len(orders)
+		}
+
+		var items []bybitapi.BatchPlaceOrderItem
+		var itemIndexes []int
+		for i := begin; i < end; i++ {
+			item, err := e.newBatchPlaceOrderItem(ctx, orders[i])
+			if err != nil {
+				errs = multierr.Append(errs, types.NewOrderError(err, types.Order{SubmitOrder: orders[i]}))
+				errIndexes = append(errIndexes, i)
+				continue
+			}
+
+			items = append(items, *item)
+			itemIndexes = append(itemIndexes, i)
+		}
+
+		if len(items) == 0 {
+			continue
+		}
+
+		if err := orderRateLimiter.Wait(ctx); err != nil {
+			errIndexes = append(errIndexes, itemIndexes...)
+			for i := end; i < len(orders); i++ {
+				errIndexes = append(errIndexes, i)
+			}
+			sort.Ints(errIndexes)
+			return createdOrders, errIndexes, multierr.Append(errs, fmt.Errorf("place order rate limiter wait error: %w", err))
+		}
+
+		responses, err := e.doBatchOrderRequest(ctx, e.client.NewBatchPlaceOrderRequest().Category(e.category()).Request(items), len(items))
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to place batch orders: %w", err))
+			errIndexes = append(errIndexes, itemIndexes...)
+			continue
+		}
+
+		for i, res := range responses {
+			idx := itemIndexes[i]
+			if res.Code != 0 || len(res.OrderId) == 0 {
+				errs = multierr.Append(errs, types.NewOrderError(
+					fmt.Errorf("failed to place order, code: %d, msg: %s", res.Code, res.Msg),
+					types.Order{SubmitOrder: orders[idx]}))
+				errIndexes = append(errIndexes, idx)
+				continue
+			}
+
+			createdOrder, err := e.toGlobalCreatedOrder(orders[idx], res.BatchOrderResultItem)
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				errIndexes = append(errIndexes, idx)
+				continue
+			}
+
+			createdOrders = append(createdOrders, *createdOrder)
+		}
+	}
+
+	sort.Ints(errIndexes)
+	return createdOrders, errIndexes, errs
+}
+
+// BatchCancelOrders cancels the orders with the batch cancel order API, each request includes up to 10 orders.
+func (e *Exchange) BatchCancelOrders(ctx context.Context, orders ...types.Order) ([]types.Order, error) {
+	var failedOrders []types.Order
+	var errs error
+
+	for begin := 0; begin < len(orders); begin += maxBatchOrderSize {
+		end := begin + maxBatchOrderSize
+		if end > len(orders) {
+			end = len(orders)
+		}
+
+		var items []bybitapi.BatchCancelOrderItem
+		var itemOrders []types.Order
+		for _, order := range orders[begin:end] {
+			item := bybitapi.BatchCancelOrderItem{Symbol: order.Symbol}
+			switch {
+			// use the OrderID first, then the ClientOrderID
+			case order.OrderID > 0:
+				item.OrderId = order.UUID
+
+			case len(order.ClientOrderID) != 0:
+				item.OrderLinkId = order.ClientOrderID
+
+			default:
+				errs = multierr.Append(errs, fmt.Errorf("the order uuid and client order id are empty, order: %#v", order))
+				failedOrders = append(failedOrders, order)
+				continue
+			}
+
+			items = append(items, item)
+			itemOrders = append(itemOrders, order)
+		}
+
+		if len(items) == 0 {
+			continue
+		}
+
+		if err := orderRateLimiter.Wait(ctx); err != nil {
+			failedOrders = append(failedOrders, itemOrders...)
+			failedOrders = append(failedOrders, orders[end:]...)
+			return failedOrders, multierr.Append(errs, fmt.Errorf("cancel order rate limiter wait error: %w", err))
+		}
+
+		responses, err := e.doBatchOrderRequest(ctx, e.client.NewBatchCancelOrderRequest().Category(e.category()).Request(items), len(items))
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to cancel batch orders: %w", err))
+			failedOrders = append(failedOrders, itemOrders...)
+			continue
+		}
+
+		for i, res := range responses {
+			if res.Code != 0 {
+				errs = multierr.Append(errs, types.NewOrderError(
+					fmt.Errorf("failed to cancel order, code: %d, msg: %s", res.Code, res.Msg), itemOrders[i]))
+				failedOrders = append(failedOrders, itemOrders[i])
+			}
+		}
+	}
+
+	return failedOrders, errs
+}
+
+// CancelOrdersBySymbol cancels all the open orders of the symbol, including the orders not submitted by bbgo.
+func (e *Exchange) CancelOrdersBySymbol(ctx context.Context, symbol string) ([]types.Order, error) {
+	if len(symbol) == 0 {
+		return nil, errors.New("symbol is required")
+	}
+
+	openOrders, err := e.QueryOpenOrders(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := orderRateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("cancel order rate limiter wait error: %w", err)
+	}
+
+	res, err := e.client.NewCancelAllOrdersRequest().Category(e.category()).Symbol(symbol).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel orders of symbol: %s, err: %w", symbol, err)
+	}
+
+	canceled := make(map[string]struct{}, len(res.List))
+	for _, o := range res.List {
+		canceled[o.OrderId] = struct{}{}
+	}
+
+	var canceledOrders []types.Order
+	for _, o := range openOrders {
+		if _, ok := canceled[o.UUID]; ok {
+			o.Status = types.OrderStatusCanceled
+			canceledOrders = append(canceledOrders, o)
+		}
+	}
+
+	return canceledOrders, nil
+}
+
+type batchOrderRequest interface {
+	Do(ctx context.Context) (*bybitapi.APIResponse, error)
+}
+
+// doBatchOrderRequest sends the batch order request and checks the number of the order results
+func (e *Exchange) doBatchOrderRequest(ctx context.Context, req batchOrderRequest, numOfOrders int) ([]bybitapi.BatchOrderItemResponse, error) {
+	resp, err := req.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := bybitapi.ParseBatchOrderResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(responses) != numOfOrders {
+		return nil, fmt.Errorf("unexpected length of batch order response: %d, exp: %d", len(responses), numOfOrders)
+	}
+
+	return responses, nil
+}
+
+// newBatchPlaceOrderItem converts the submit order to the order item of the batch place order request
+func (e *Exchange) newBatchPlaceOrderItem(ctx context.Context, order types.SubmitOrder) (*bybitapi.BatchPlaceOrderItem, error) {
+	if len(order.Market.Symbol) == 0 {
+		return nil, fmt.Errorf("order.Market.Symbol is required: %+v", order)
+	}
+
+	orderType, err := toLocalOrderType(order.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	side, err := toLocalSide(order.Side)
+	if err != nil {
+		return nil, err
+	}
+
+	// if the spot order is market buy, the quantity is quote coin, instead of base coin. so we need to convert it.
+	orderQty := order.Quantity
+	if !e.IsFutures && order.Type == types.OrderTypeMarket && order.Side == types.SideTypeBuy {
+		ticker, err := e.QueryTicker(ctx, order.Market.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		orderQty = order.Quantity.Mul(ticker.Buy)
+	}
+
+	if len(order.ClientOrderID) > maxOrderIdLen {
+		return nil, fmt.Errorf("unexpected length of order id, got: %d", len(order.ClientOrderID))
+	}
+
+	item := &bybitapi.BatchPlaceOrderItem{
+		Symbol:      order.Market.Symbol,
+		Side:        side,
+		OrderType:   orderType,
+		Qty:         order.Market.FormatQuantity(orderQty),
+		TimeInForce: bybitapi.TimeInForceGTC,
+		OrderLinkId: order.ClientOrderID,
+		ReduceOnly:  e.IsFutures && order.ReduceOnly,
+	}
+
+	if order.Type == types.OrderTypeLimit {
+		item.Price = order.Market.FormatPrice(order.Price)
+	}
+
+	switch order.TimeInForce {
+	case types.TimeInForceFOK:
+		item.TimeInForce = bybitapi.TimeInForceFOK
+	case types.TimeInForceIOC:
+		item.TimeInForce = bybitapi.TimeInForceIOC
+	}
+
+	return item, nil
+}
+
+// toGlobalCreatedOrder converts the submit order and its batch order result to the created order
+func (e *Exchange) toGlobalCreatedOrder(order types.SubmitOrder, res bybitapi.BatchOrderResultItem) (*types.Order, error) {
+	orderID := hashStringID(res.OrderId)
+	if !e.IsFutures {
+		id, err := strconv.ParseUint(res.OrderId, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected order id: %s, err: %w", res.OrderId, err)
+		}
+		orderID = id
+	}
+
+	if len(res.OrderLinkId) > 0 {
+		order.ClientOrderID = res.OrderLinkId
+	}
+
+	now := time.Now()
+	return &types.Order{
+		SubmitOrder:      order,
+		Exchange:         types.ExchangeBybit,
+		OrderID:          orderID,
+		UUID:             res.OrderId,
+		Status:           types.OrderStatusNew,
+		ExecutedQuantity: fixedpoint.Zero,
+		IsWorking:        true,
+		IsFutures:        e.IsFutures,
+		CreationTime:     types.Time(now),
+		UpdateTime:       types.Time(now),
+	}, nil
+}
+
+// AmendOrder amends the price and the quantity of the open order, the order ID is not changed after the amendment.
+func (e *Exchange) AmendOrder(ctx context.Context, order types.Order, newPrice, newQuantity fixedpoint.Value) (*types.Order, error) {
+	req := e.client.NewAmendOrderRequest().Category(e.category())
+	req.Symbol(order.Symbol)
+
+	switch {
+	// use the OrderID first, then the ClientOrderID
+	case order.OrderID > 0:
+		req.OrderId(order.UUID)
+
+	case len(order.ClientOrderID) != 0:
+		req.OrderLinkId(order.ClientOrderID)
+
+	default:
+		return nil, fmt.Errorf("the order uuid and client order id are empty, order: %#v", order)
+	}
+
+	if !newPrice.IsZero() {
+		req.Price(order.Market.FormatPrice(newPrice))
+	}
+
+	if !newQuantity.IsZero() {
+		req.Qty(order.Market.FormatQuantity(newQuantity))
+	}
+
+	if err := orderRateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("amend order rate limiter wait error: %w", err)
+	}
+
+	res, err := req.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to amend order, order: %#v, err: %w", order, err)
+	}
+
+	return e.QueryOrder(ctx, types.OrderQuery{
+		Symbol:  order.Symbol,
+		OrderID: res.OrderId,
+	})
+}
+
 func (e *Exchange) QueryClosedOrders(ctx context.Context, symbol string, since, util time.Time, lastOrderID uint64) (orders []types.Order, err error) {
 	if !since.IsZero() || !util.IsZero() {
 		log.Warn("!!!BYBIT EXCHANGE API NOTICE!!! the since/until conditions will not be effected on SPOT account, bybit exchange does not support time-range-based query currently")
@@ -397,6 +738,7 @@ func (e *Exchange) QueryClosedOrders(ctx context.Context, symbol string, since,
 		return nil, fmt.Errorf("query closed order rate limiter wait error: %w", err)
 	}
 	res, err := e.client.NewGetOrderHistoriesRequest().
+		Category(e.category()).
 		Symbol(symbol).
 		Cursor(strconv.FormatUint(lastOrderID, 10)).
 		Limit(defaultQueryLimit).
@@ -406,7 +748,7 @@ func (e *Exchange) QueryClosedOrders(ctx context.Context, symbol string, since,
 	}
 
 	for _, order := range res.List {
-		o, err2 := toGlobalOrder(order)
+		o, err2 := e.toGlobalOrder(order)
 		if err2 != nil {
 			err = multierr.Append(err, err2)
 			continue
@@ -437,6 +779,11 @@ It supports to query records up to 180 days.
 Otherwise, the result is sorted by tradeId in `descend`. **
 */
 func (e *Exchange) QueryTrades(ctx context.Context, symbol string, options *types.TradeQueryOptions) (trades []types.Trade, err error) {
+	if e.IsFutures {
+		// the linear trades are queried by the time range, since the execution ids are UUIDs.
+		return e.queryFuturesTrades(ctx, symbol, "", options.StartTime, options.EndTime)
+	}
+
 	// using v3 client, since the v5 API does not support feeCurrency.
 	req := e.v3client.NewGetTradesRequest()
 	req.Symbol(symbol)
@@ -502,6 +849,19 @@ func (e *Exchange) QueryAccount(ctx context.Context) (*types.Account, error) {
 	}
 	acct.UpdateBalances(balanceMap)
 
+	if e.IsFutures {
+		positions, err := e.QueryFuturesPositions(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		acct.AccountType = types.AccountTypeFutures
+		acct.FuturesInfo = &types.FuturesAccountInfo{
+			Positions:  positions,
+			UpdateTime: time.Now().UnixMilli(),
+		}
+	}
+
 	return acct, nil
 }
 
@@ -527,7 +887,7 @@ A k-line's start time is inclusive, but end time is not(startTime + interval - 1
 e.q. 15m interval k line can be represented as 00:00:00.000 ~ 00:14:59.999
 */
 func (e *Exchange) QueryKLines(ctx context.Context, symbol string, interval types.Interval, options types.KLineQueryOptions) ([]types.KLine, error) {
-	req := e.client.NewGetKLinesRequest().Symbol(symbol)
+	req := e.client.NewGetKLinesRequest().Category(e.category()).Symbol(symbol)
 	intervalStr, err := toLocalInterval(interval)
 	if err != nil {
 		return nil, err
@@ -558,7 +918,7 @@ func (e *Exchange) QueryKLines(ctx context.Context, symbol string, interval type
 		return nil, fmt.Errorf("failed to call k line, err: %w", err)
 	}
 
-	if resp.Category != bybitapi.CategorySpot {
+	if resp.Category != e.category() {
 		return nil, fmt.Errorf("unexpected category: %s", resp.Category)
 	}
 
@@ -593,5 +953,7 @@ func (e *Exchange) GetAllFeeRates(ctx context.Context) (bybitapi.FeeRates, error
 }
 
 func (e *Exchange) NewStream() types.Stream {
-	return NewStream(e.key, e.secret, e)
+	stream := NewStream(e.key, e.secret, e)
+	stream.FuturesSettings = e.FuturesSettings
+	return stream
 }
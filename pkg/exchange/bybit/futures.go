@@ -0,0 +1,454 @@
+package bybit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/c9s/bbgo/pkg/exchange/bybit/bybitapi"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+const (
+	// linearSettleCoin is the settle coin of the linear perpetual contracts, only the USDT-margined contracts are supported.
+	linearSettleCoin = "USDT"
+
+	defaultPositionLimit   = 200
+	defaultExecutionLimit  = 100
+	defaultInstrumentLimit = 1000
+
+	// maxExecutionQueryDuration is the maximum time range of the execution query
+	maxExecutionQueryDuration = 7 * 24 * time.Hour
+)
+
+// hashStringID converts the UUID order id of the linear category to uint64
+func hashStringID(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+func toGlobalFuturesMarket(m bybitapi.Instrument) types.Market {
+	return types.Market{
+		Symbol:          m.Symbol,
+		LocalSymbol:     m.Symbol,
+		PricePrecision:  m.PriceFilter.TickSize.NumFractionalDigits(),
+		VolumePrecision: m.LotSizeFilter.QtyStep.NumFractionalDigits(),
+		QuoteCurrency:   m.QuoteCoin,
+		BaseCurrency:    m.BaseCoin,
+		MinNotional:     m.LotSizeFilter.MinNotionalValue,
+		MinAmount:       m.LotSizeFilter.MinNotionalValue,
+
+		// quantity
+		MinQuantity: m.LotSizeFilter.MinOrderQty,
+		MaxQuantity: m.LotSizeFilter.MaxOrderQty,
+		StepSize:    m.LotSizeFilter.QtyStep,
+
+		// price
+		MinPrice: m.PriceFilter.MinPrice,
+		MaxPrice: m.PriceFilter.MaxPrice,
+		TickSize: m.PriceFilter.TickSize,
+	}
+}
+
+// toGlobalFuturesOrder converts the linear order, the quantity is always in the base coin and the order id is a UUID.
+func toGlobalFuturesOrder(order bybitapi.Order) (*types.Order, error) {
+	side, err := toGlobalSideType(order.Side)
+	if err != nil {
+		return nil, err
+	}
+
+	orderType, err := toGlobalOrderType(order.OrderType)
+	if err != nil {
+		return nil, err
+	}
+
+	timeInForce, err := toGlobalTimeInForce(order.TimeInForce)
+	if err != nil {
+		return nil, err
+	}
+
+	var status types.OrderStatus
+	if order.OrderStatus == bybitapi.OrderStatusPartiallyFilledCanceled {
+		status = types.OrderStatusCanceled
+	} else if status, err = processOtherOrderStatus(order.OrderStatus); err != nil {
+		return nil, err
+	}
+
+	return &types.Order{
+		SubmitOrder: types.SubmitOrder{
+			ClientOrderID: order.OrderLinkId,
+			Symbol:        order.Symbol,
+			Side:          side,
+			Type:          orderType,
+			Quantity:      order.Qty,
+			Price:         order.Price,
+			TimeInForce:   timeInForce,
+			ReduceOnly:    order.ReduceOnly,
+		},
+		Exchange:         types.ExchangeBybit,
+		OrderID:          hashStringID(order.OrderId),
+		UUID:             order.OrderId,
+		Status:           status,
+		ExecutedQuantity: order.CumExecQty,
+		IsWorking:        status == types.OrderStatusNew || status == types.OrderStatusPartiallyFilled,
+		IsFutures:        true,
+		CreationTime:     types.Time(order.CreatedTime.Time()),
+		UpdateTime:       types.Time(order.UpdatedTime.Time()),
+	}, nil
+}
+
+func toGlobalFuturesPosition(p bybitapi.Position) types.FuturesPosition {
+	base := p.Size
+	if p.Side == bybitapi.SideSell {
+		base = base.Neg()
+	}
+
+	avgPrice := p.AvgPrice
+	if avgPrice.IsZero() {
+		// the position event of the websocket only provides the entry price
+		avgPrice = p.EntryPrice
+	}
+
+	return types.FuturesPosition{
+		Symbol:                 p.Symbol,
+		BaseCurrency:           strings.TrimSuffix(p.Symbol, linearSettleCoin),
+		QuoteCurrency:          linearSettleCoin,
+		Base:                   base,
+		Quote:                  base.Mul(avgPrice).Neg(),
+		AverageCost:            avgPrice,
+		ApproximateAverageCost: avgPrice,
+		Isolated:               p.TradeMode == bybitapi.PositionTradeModeIsolated,
+		UpdateTime:             p.UpdatedTime.Time().UnixMilli(),
+		PositionRisk: &types.PositionRisk{
+			Leverage:         p.Leverage,
+			LiquidationPrice: p.LiqPrice,
+		},
+	}
+}
+
+func toGlobalFuturesPositions(positions []bybitapi.Position) types.FuturesPositionMap {
+	positionMap := make(types.FuturesPositionMap)
+	for _, position := range positions {
+		if len(position.Category) != 0 && position.Category != bybitapi.CategoryLinear {
+			continue
+		}
+
+		futuresPosition := toGlobalFuturesPosition(position)
+		positionMap[futuresPosition.Symbol] = futuresPosition
+	}
+
+	return positionMap
+}
+
+func toGlobalExecutionTrade(exec bybitapi.Execution) (*types.Trade, error) {
+	side, err := toGlobalSideType(exec.Side)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Trade{
+		ID:            hashStringID(exec.ExecId),
+		OrderID:       hashStringID(exec.OrderId),
+		Exchange:      types.ExchangeBybit,
+		Price:         exec.ExecPrice,
+		Quantity:      exec.ExecQty,
+		QuoteQuantity: exec.ExecPrice.Mul(exec.ExecQty),
+		Symbol:        exec.Symbol,
+		Side:          side,
+		IsBuyer:       side == types.SideTypeBuy,
+		IsMaker:       exec.IsMaker,
+		Time:          types.Time(exec.ExecTime),
+		Fee:           exec.ExecFee,
+		FeeCurrency:   linearSettleCoin,
+		IsFutures:     true,
+	}, nil
+}
+
+func toGlobalFundingFee(exec bybitapi.Execution) types.FundingFee {
+	return types.FundingFee{
+		Exchange: types.ExchangeBybit,
+		Symbol:   exec.Symbol,
+		Asset:    linearSettleCoin,
+		// the positive execFee means the funding fee is paid
+		Amount:        exec.ExecFee.Neg(),
+		TransactionID: exec.ExecId,
+		Time:          types.Time(exec.ExecTime),
+	}
+}
+
+func (e *Exchange) category() bybitapi.Category {
+	if e.IsFutures {
+		return bybitapi.CategoryLinear
+	}
+
+	return bybitapi.CategorySpot
+}
+
+func (e *Exchange) toGlobalOrder(order bybitapi.Order) (*types.Order, error) {
+	if e.IsFutures {
+		return toGlobalFuturesOrder(order)
+	}
+
+	return toGlobalOrder(order)
+}
+
+func (e *Exchange) queryFuturesMarkets(ctx context.Context) (types.MarketMap, error) {
+	marketMap := types.MarketMap{}
+	cursor := ""
+	for {
+		req := e.client.NewGetInstrumentsInfoRequest().
+			Category(bybitapi.CategoryLinear).
+			Limit(defaultInstrumentLimit)
+		if len(cursor) != 0 {
+			req.Cursor(cursor)
+		}
+
+		if err := sharedRateLimiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("markets rate limiter wait error: %w", err)
+		}
+
+		instruments, err := req.Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get linear instruments, err: %v", err)
+		}
+
+		for _, s := range instruments.List {
+			if s.ContractType != bybitapi.ContractTypeLinearPerpetual ||
+				s.SettleCoin != linearSettleCoin ||
+				s.Status != bybitapi.StatusTrading {
+				continue
+			}
+
+			marketMap.Add(toGlobalFuturesMarket(s))
+		}
+
+		if len(instruments.NextPageCursor) == 0 {
+			break
+		}
+		cursor = instruments.NextPageCursor
+	}
+
+	return marketMap, nil
+}
+
+// queryExecutions queries the executions of the linear category, the time range is truncated to 7 days from the start time.
+func (e *Exchange) queryExecutions(ctx context.Context, symbol, orderID string, execType bybitapi.ExecType, startTime, endTime *time.Time) ([]bybitapi.Execution, error) {
+	if startTime != nil {
+		maxEndTime := startTime.Add(maxExecutionQueryDuration)
+		if endTime == nil || endTime.After(maxEndTime) {
+			endTime = &maxEndTime
+		}
+	}
+
+	var executions []bybitapi.Execution
+	cursor := ""
+	for {
+		req := e.client.NewGetExecutionsRequest().
+			ExecType(execType).
+			Limit(defaultExecutionLimit)
+		if len(symbol) != 0 {
+			req.Symbol(symbol)
+		}
+		if len(orderID) != 0 {
+			req.OrderId(orderID)
+		}
+		if startTime != nil {
+			req.StartTime(startTime.UTC())
+		}
+		if endTime != nil {
+			req.EndTime(endTime.UTC())
+		}
+		if len(cursor) != 0 {
+			req.Cursor(cursor)
+		}
+
+		if err := queryOrderTradeRateLimiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("trade rate limiter wait error: %w", err)
+		}
+
+		res, err := req.Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query executions, err: %w", err)
+		}
+
+		executions = append(executions, res.List...)
+
+		if len(res.NextPageCursor) == 0 || len(res.List) == 0 {
+			break
+		}
+		cursor = res.NextPageCursor
+	}
+
+	return executions, nil
+}
+
+func (e *Exchange) queryFuturesTrades(ctx context.Context, symbol, orderID string, startTime, endTime *time.Time) ([]types.Trade, error) {
+	executions, err := e.queryExecutions(ctx, symbol, orderID, bybitapi.ExecTypeTrade, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	var trades []types.Trade
+	for _, exec := range executions {
+		trade, err := toGlobalExecutionTrade(exec)
+		if err != nil {
+			return nil, err
+		}
+		trades = append(trades, *trade)
+	}
+
+	return types.SortTradesAscending(trades), nil
+}
+
+// QueryFuturesPositions queries the USDT-margined linear positions
+func (e *Exchange) QueryFuturesPositions(ctx context.Context) (types.FuturesPositionMap, error) {
+	var positions []bybitapi.Position
+	cursor := ""
+	for {
+		req := e.client.NewGetPositionsRequest().
+			SettleCoin(linearSettleCoin).
+			Limit(defaultPositionLimit)
+		if len(cursor) != 0 {
+			req.Cursor(cursor)
+		}
+
+		if err := sharedRateLimiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("query positions rate limiter wait error: %w", err)
+		}
+
+		res, err := req.Do(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query positions, err: %w", err)
+		}
+
+		positions = append(positions, res.List...)
+
+		if len(res.NextPageCursor) == 0 || len(res.List) == 0 {
+			break
+		}
+		cursor = res.NextPageCursor
+	}
+
+	return toGlobalFuturesPositions(positions), nil
+}
+
+func (e *Exchange) QueryPositionRisk(ctx context.Context, symbol string) (*types.PositionRisk, error) {
+	if err := sharedRateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("query position rate limiter wait error: %w", err)
+	}
+
+	res, err := e.client.NewGetPositionsRequest().Symbol(symbol).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s position, err: %w", symbol, err)
+	}
+
+	if len(res.List) == 0 {
+		return nil, fmt.Errorf("%s position not found", symbol)
+	}
+
+	return &types.PositionRisk{
+		Leverage:         res.List[0].Leverage,
+		LiquidationPrice: res.List[0].LiqPrice,
+	}, nil
+}
+
+// SetLeverage sets the leverage of both sides of the linear position, it's not treated as an error
+// if the leverage is not modified.
+func (e *Exchange) SetLeverage(ctx context.Context, symbol string, leverage int) error {
+	if err := orderRateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("set leverage rate limiter wait error: %w", err)
+	}
+
+	lever := strconv.Itoa(leverage)
+	_, err := e.client.NewSetLeverageRequest().
+		Symbol(symbol).
+		BuyLeverage(lever).
+		SellLeverage(lever).
+		Do(ctx)
+	if err != nil {
+		if strings.Contains(err.Error(), fmt.Sprintf("retCode: %d", bybitapi.ErrCodeLeverageNotModified)) {
+			return nil
+		}
+		return fmt.Errorf("failed to set %s leverage to %d, err: %w", symbol, leverage, err)
+	}
+
+	return nil
+}
+
+// QueryPremiumIndex queries the mark price and the predicted funding rate of the linear contract
+func (e *Exchange) QueryPremiumIndex(ctx context.Context, symbol string) (*types.PremiumIndex, error) {
+	if err := sharedRateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("ticker rate limiter wait error: %w", err)
+	}
+
+	s, err := e.client.NewGetTickersRequest().
+		Category(bybitapi.CategoryLinear).
+		Symbol(symbol).
+		DoWithResponseTime(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s ticker, err: %w", symbol, err)
+	}
+
+	if len(s.List) != 1 {
+		return nil, fmt.Errorf("unexpected ticker length, exp:1, got:%d", len(s.List))
+	}
+
+	ticker := s.List[0]
+	return &types.PremiumIndex{
+		Symbol:          symbol,
+		MarkPrice:       ticker.MarkPrice,
+		LastFundingRate: ticker.FundingRate,
+		NextFundingTime: ticker.NextFundingTime.Time(),
+		Time:            s.ClosedTime.Time(),
+	}, nil
+}
+
+// QueryFundingRateHistory queries the last settled funding rate of the linear contract
+func (e *Exchange) QueryFundingRateHistory(ctx context.Context, symbol string) (*types.FundingRate, error) {
+	if err := sharedRateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("funding rate limiter wait error: %w", err)
+	}
+
+	res, err := e.client.NewGetFundingRateHistoryRequest().
+		Symbol(symbol).
+		Limit(1).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s funding rate, err: %w", symbol, err)
+	}
+
+	if len(res.List) == 0 {
+		return nil, fmt.Errorf("empty %s funding rate data", symbol)
+	}
+
+	return &types.FundingRate{
+		FundingRate: res.List[0].FundingRate,
+		FundingTime: res.List[0].FundingRateTimestamp.Time(),
+		Time:        time.Now(),
+	}, nil
+}
+
+// QueryFundingFeeHistory queries the settled funding fees of the linear positions, the time range
+// is truncated to 7 days from the start time. It's used by the batch.FundingFeeBatchQuery.
+func (e *Exchange) QueryFundingFeeHistory(ctx context.Context, symbol string, startTime, endTime *time.Time) ([]types.FundingFee, error) {
+	if startTime == nil {
+		return nil, errors.New("startTime is required for querying the funding fee history")
+	}
+
+	executions, err := e.queryExecutions(ctx, symbol, "", bybitapi.ExecTypeFunding, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	var fees []types.FundingFee
+	for _, exec := range executions {
+		fees = append(fees, toGlobalFundingFee(exec))
+	}
+
+	return fees, nil
+}
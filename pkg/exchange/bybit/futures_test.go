@@ -0,0 +1,169 @@
+package bybit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/exchange/bybit/bybitapi"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+func Test_parseWebSocketEvent_positionEvent(t *testing.T) {
+	s := Stream{}
+	msg := `{
+    "id": "59232430b58efe-5fc5-4470-9337-4ce293b68edd",
+    "topic": "position",
+    "creationTime": 1672364174455,
+    "data": [
+        {
+            "positionIdx": 0,
+            "tradeMode": 0,
+            "riskId": 41,
+            "riskLimitValue": "200000",
+            "symbol": "XRPUSDT",
+            "side": "Sell",
+            "size": "75",
+            "entryPrice": "0.3615",
+            "leverage": "10",
+            "positionValue": "27.1125",
+            "positionBalance": "0",
+            "markPrice": "0.3374",
+            "positionIM": "2.72589075",
+            "positionMM": "0.28576575",
+            "takeProfit": "0",
+            "stopLoss": "0",
+            "trailingStop": "0",
+            "unrealisedPnl": "1.8075",
+            "cumRealisedPnl": "0.64782276",
+            "createdTime": "1672121182216",
+            "updatedTime": "1672364174449",
+            "tpslMode": "Full",
+            "liqPrice": "0.6512",
+            "bustPrice": "",
+            "category": "linear",
+            "positionStatus": "Normal",
+            "adlRankIndicator": 2
+        }
+    ]
+}`
+
+	res, err := s.parseWebSocketEvent([]byte(msg))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	positions, ok := res.([]bybitapi.Position)
+	if !assert.True(t, ok) || !assert.Len(t, positions, 1) {
+		return
+	}
+
+	positionMap := toGlobalFuturesPositions(positions)
+	assert.Equal(t, types.FuturesPosition{
+		Symbol:                 "XRPUSDT",
+		BaseCurrency:           "XRP",
+		QuoteCurrency:          "USDT",
+		Base:                   fixedpoint.NewFromFloat(-75),
+		Quote:                  fixedpoint.NewFromFloat(27.1125),
+		AverageCost:            fixedpoint.NewFromFloat(0.3615),
+		ApproximateAverageCost: fixedpoint.NewFromFloat(0.3615),
+		Isolated:               false,
+		UpdateTime:             1672364174449,
+		PositionRisk: &types.PositionRisk{
+			Leverage:         fixedpoint.NewFromFloat(10),
+			LiquidationPrice: fixedpoint.NewFromFloat(0.6512),
+		},
+	}, positionMap["XRPUSDT"])
+}
+
+func Test_toGlobalFuturesOrder(t *testing.T) {
+	order := bybitapi.Order{
+		OrderId:     "42f4f364-82e1-49d3-ad1d-cd8cf9aa308d",
+		OrderLinkId: "test-order",
+		Symbol:      "BTCUSDT",
+		Side:        bybitapi.SideBuy,
+		OrderStatus: bybitapi.OrderStatusPartiallyFilledCanceled,
+		OrderType:   bybitapi.OrderTypeMarket,
+		TimeInForce: bybitapi.TimeInForceIOC,
+		Qty:         fixedpoint.NewFromFloat(0.5),
+		CumExecQty:  fixedpoint.NewFromFloat(0.2),
+		ReduceOnly:  true,
+	}
+
+	res, err := toGlobalFuturesOrder(order)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, hashStringID(order.OrderId), res.OrderID)
+	assert.Equal(t, order.OrderId, res.UUID)
+	// the quantity of the linear market buy order is in the base coin
+	assert.Equal(t, order.Qty, res.Quantity)
+	assert.Equal(t, types.OrderStatusCanceled, res.Status)
+	assert.True(t, res.ReduceOnly)
+	assert.True(t, res.IsFutures)
+}
+
+func TestTradeEvent_toGlobalFuturesTrade(t *testing.T) {
+	timeNow := time.Now()
+	event := TradeEvent{
+		OrderId:   "42f4f364-82e1-49d3-ad1d-cd8cf9aa308d",
+		Category:  bybitapi.CategoryLinear,
+		Symbol:    "BTCUSDT",
+		ExecId:    "7e2ae69c-4edf-5800-a352-893d52b446aa",
+		ExecPrice: fixedpoint.NewFromFloat(28000),
+		ExecQty:   fixedpoint.NewFromFloat(0.01),
+		IsMaker:   false,
+		Side:      bybitapi.SideSell,
+		ExecTime:  types.MillisecondTimestamp(timeNow),
+		ExecFee:   fixedpoint.NewFromFloat(0.154),
+	}
+
+	trade, err := event.toGlobalFuturesTrade()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, &types.Trade{
+		ID:            hashStringID(event.ExecId),
+		OrderID:       hashStringID(event.OrderId),
+		Exchange:      types.ExchangeBybit,
+		Price:         event.ExecPrice,
+		Quantity:      event.ExecQty,
+		QuoteQuantity: fixedpoint.NewFromFloat(280),
+		Symbol:        "BTCUSDT",
+		Side:          types.SideTypeSell,
+		IsBuyer:       false,
+		IsMaker:       false,
+		Time:          types.Time(timeNow),
+		Fee:           event.ExecFee,
+		FeeCurrency:   "USDT",
+		IsFutures:     true,
+	}, trade)
+
+	event.Category = bybitapi.CategorySpot
+	_, err = event.toGlobalFuturesTrade()
+	assert.Error(t, err)
+}
+
+func Test_toGlobalFundingFee(t *testing.T) {
+	timeNow := time.Now()
+	fee := toGlobalFundingFee(bybitapi.Execution{
+		Symbol:   "BTCUSDT",
+		ExecId:   "7e2ae69c-4edf-5800-a352-893d52b446aa",
+		ExecType: bybitapi.ExecTypeFunding,
+		ExecFee:  fixedpoint.NewFromFloat(0.05),
+		ExecTime: types.MillisecondTimestamp(timeNow),
+	})
+
+	assert.Equal(t, types.FundingFee{
+		Exchange:      types.ExchangeBybit,
+		Symbol:        "BTCUSDT",
+		Asset:         "USDT",
+		Amount:        fixedpoint.NewFromFloat(-0.05),
+		TransactionID: "7e2ae69c-4edf-5800-a352-893d52b446aa",
+		Time:          types.Time(timeNow),
+	}, fee)
+}
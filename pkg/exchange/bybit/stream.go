@@ -47,6 +47,12 @@ type AccountBalanceProvider interface {
 	QueryAccountBalances(ctx context.Context) (types.BalanceMap, error)
 }
 
+// FuturesPositionProvider provides a function to query all positions at streaming connected and emit position snapshot.
+// It's optional for the StreamDataProvider, and it's only used in the futures mode.
+type FuturesPositionProvider interface {
+	QueryFuturesPositions(ctx context.Context) (types.FuturesPositionMap, error)
+}
+
 //go:generate mockgen -destination=mocks/stream.go -package=mocks . StreamDataProvider
 type StreamDataProvider interface {
 	MarketInfoProvider
@@ -56,6 +62,7 @@ type StreamDataProvider interface {
 //go:generate callbackgen -type Stream
 type Stream struct {
 	types.StandardStream
+	types.FuturesSettings
 
 	key, secret        string
 	streamDataProvider StreamDataProvider
@@ -68,6 +75,7 @@ type Stream struct {
 	kLineEventCallbacks       []func(e KLineEvent)
 	orderEventCallbacks       []func(e []OrderEvent)
 	tradeEventCallbacks       []func(e []TradeEvent)
+	positionEventCallbacks    []func(e []bybitapi.Position)
 }
 
 func NewStream(key, secret string, userDataProvider StreamDataProvider) *Stream {
@@ -90,8 +98,10 @@ func NewStream(key, secret string, userDataProvider StreamDataProvider) *Stream
 			return
 		}
 
-		// get account fee rate
-		go stream.feeRateProvider.Start(ctx)
+		// get account fee rate, the linear trade events contain the fee, so we don't need the fee rate in the futures mode.
+		if !stream.IsFutures {
+			go stream.feeRateProvider.Start(ctx)
+		}
 
 		stream.marketsInfo, err = stream.streamDataProvider.QueryMarkets(ctx)
 		if err != nil {
@@ -109,6 +119,7 @@ func NewStream(key, secret string, userDataProvider StreamDataProvider) *Stream
 	stream.OnWalletEvent(stream.handleWalletEvent)
 	stream.OnOrderEvent(stream.handleOrderEvent)
 	stream.OnTradeEvent(stream.handleTradeEvent)
+	stream.OnPositionEvent(stream.handlePositionEvent)
 	return stream
 }
 
@@ -162,6 +173,9 @@ func (s *Stream) createEndpoint(_ context.Context) (string, error) {
 	var url string
 	if s.PublicOnly {
 		url = bybitapi.WsSpotPublicSpotUrl
+		if s.IsFutures {
+			url = bybitapi.WsLinearPublicUrl
+		}
 	} else {
 		url = bybitapi.WsSpotPrivateUrl
 	}
@@ -193,6 +207,9 @@ func (s *Stream) dispatchEvent(event interface{}) {
 	case []TradeEvent:
 		s.EmitTradeEvent(e)
 
+	case []bybitapi.Position:
+		s.EmitPositionEvent(e)
+
 	}
 }
 
@@ -267,6 +284,10 @@ func (s *Stream) parseWebSocketEvent(in []byte) (interface{}, error) {
 			var trades []TradeEvent
 			return trades, json.Unmarshal(e.WebSocketTopicEvent.Data, &trades)
 
+		case TopicTypePosition:
+			var positions []bybitapi.Position
+			return positions, json.Unmarshal(e.WebSocketTopicEvent.Data, &positions)
+
 		}
 	}
 
@@ -307,13 +328,18 @@ func (s *Stream) handlerConnect() {
 			return
 		}
 
+		topics := []string{
+			string(TopicTypeWallet),
+			string(TopicTypeOrder),
+			string(TopicTypeTrade),
+		}
+		if s.IsFutures {
+			topics = append(topics, string(TopicTypePosition))
+		}
+
 		if err := s.Conn.WriteJSON(WebsocketOp{
-			Op: WsOpTypeSubscribe,
-			Args: []string{
-				string(TopicTypeWallet),
-				string(TopicTypeOrder),
-				string(TopicTypeTrade),
-			},
+			Op:   WsOpTypeSubscribe,
+			Args: topics,
 		}); err != nil {
 			log.WithError(err).Error("failed to send subscription request")
 			return
@@ -367,6 +393,31 @@ func (s *Stream) handleAuthEvent() {
 	}
 
 	s.EmitBalanceSnapshot(balnacesMap)
+
+	if s.IsFutures {
+		s.emitFuturesPositionSnapshot(ctx)
+	}
+}
+
+func (s *Stream) emitFuturesPositionSnapshot(ctx context.Context) {
+	provider, ok := s.streamDataProvider.(FuturesPositionProvider)
+	if !ok {
+		log.Warnf("the stream data provider %T does not support querying futures positions", s.streamDataProvider)
+		return
+	}
+
+	var positions types.FuturesPositionMap
+	var err error
+	err = retry.GeneralBackoff(ctx, func() error {
+		positions, err = provider.QueryFuturesPositions(ctx)
+		return err
+	})
+	if err != nil {
+		log.WithError(err).Error("no more attempts to retrieve futures positions")
+		return
+	}
+
+	s.EmitFuturesPositionSnapshot(positions)
 }
 
 func (s *Stream) handleBookEvent(e BookEvent) {
@@ -400,13 +451,27 @@ func (s *Stream) handleWalletEvent(events []bybitapi.WalletBalances) {
 	s.StandardStream.EmitBalanceUpdate(toGlobalBalanceMap(events))
 }
 
+func (s *Stream) category() bybitapi.Category {
+	if s.IsFutures {
+		return bybitapi.CategoryLinear
+	}
+
+	return bybitapi.CategorySpot
+}
+
 func (s *Stream) handleOrderEvent(events []OrderEvent) {
 	for _, event := range events {
-		if event.Category != bybitapi.CategorySpot {
-			return
+		if event.Category != s.category() {
+			continue
 		}
 
-		gOrder, err := toGlobalOrder(event.Order)
+		var gOrder *types.Order
+		var err error
+		if s.IsFutures {
+			gOrder, err = toGlobalFuturesOrder(event.Order)
+		} else {
+			gOrder, err = toGlobalOrder(event.Order)
+		}
 		if err != nil {
 			if orderLogLimiter.Allow() {
 				log.WithError(err).Error("failed to convert to global order")
@@ -441,6 +506,22 @@ func (s *Stream) handleKLineEvent(klineEvent KLineEvent) {
 
 func (s *Stream) handleTradeEvent(events []TradeEvent) {
 	for _, event := range events {
+		if s.IsFutures {
+			if event.Category != bybitapi.CategoryLinear {
+				continue
+			}
+
+			gTrade, err := event.toGlobalFuturesTrade()
+			if err != nil {
+				if tradeLogLimiter.Allow() {
+					log.WithError(err).Errorf("unable to convert: %+v", event)
+				}
+				continue
+			}
+			s.StandardStream.EmitTradeUpdate(*gTrade)
+			continue
+		}
+
 		feeRate, found := s.feeRateProvider.Get(event.Symbol)
 		if !found {
 			feeRate = symbolFeeDetail{
@@ -480,3 +561,7 @@ func (s *Stream) handleTradeEvent(events []TradeEvent) {
 		s.StandardStream.EmitTradeUpdate(*gTrade)
 	}
 }
+
+func (s *Stream) handlePositionEvent(events []bybitapi.Position) {
+	s.StandardStream.EmitFuturesPositionUpdate(toGlobalFuturesPositions(events))
+}
@@ -65,3 +65,13 @@ func (s *Stream) EmitTradeEvent(e []TradeEvent) {
 		cb(e)
 	}
 }
+
+func (s *Stream) OnPositionEvent(cb func(e []bybitapi.Position)) {
+	s.positionEventCallbacks = append(s.positionEventCallbacks, cb)
+}
+
+func (s *Stream) EmitPositionEvent(e []bybitapi.Position) {
+	for _, cb := range s.positionEventCallbacks {
+		cb(e)
+	}
+}
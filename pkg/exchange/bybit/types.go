@@ -108,6 +108,7 @@ const (
 	TopicTypeOrder       TopicType = "order"
 	TopicTypeKLine       TopicType = "kline"
 	TopicTypeTrade       TopicType = "execution"
+	TopicTypePosition    TopicType = "position"
 )
 
 type DataType string
@@ -381,6 +382,31 @@ func (t *TradeEvent) toGlobalTrade(symbolFee symbolFeeDetail) (*types.Trade, err
 	return trade, nil
 }
 
+// toGlobalFuturesTrade converts the linear execution, the fee is charged in the settle coin.
+func (t *TradeEvent) toGlobalFuturesTrade() (*types.Trade, error) {
+	if t.Category != bybitapi.CategoryLinear {
+		return nil, fmt.Errorf("unexected category: %s", t.Category)
+	}
+
+	return toGlobalExecutionTrade(bybitapi.Execution{
+		Symbol:      t.Symbol,
+		OrderId:     t.OrderId,
+		OrderLinkId: t.OrderLinkId,
+		Side:        t.Side,
+		OrderType:   t.OrderType,
+		ExecId:      t.ExecId,
+		ExecPrice:   t.ExecPrice,
+		ExecQty:     t.ExecQty,
+		ExecType:    bybitapi.ExecType(t.ExecType),
+		ExecValue:   t.ExecValue,
+		ExecFee:     t.ExecFee,
+		FeeRate:     t.FeeRate,
+		ExecTime:    t.ExecTime,
+		IsMaker:     t.IsMaker,
+		ClosedSize:  t.ClosedSize,
+	})
+}
+
 // CalculateFee given isMaker to get the fee currency and fee.
 // https://bybit-exchange.github.io/docs/v5/enum#spot-fee-currency-instruction
 //
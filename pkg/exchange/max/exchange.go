@@ -24,7 +24,7 @@ var log = logrus.WithField("exchange", "max")
 
 func init() {
 	_ = types.ExchangeTradeHistoryService(&Exchange{})
-
+	_ = types.ExchangeCancelAllOrdersService(&Exchange{})
 }
 
 type Exchange struct {
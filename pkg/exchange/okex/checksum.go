@@ -0,0 +1,108 @@
+package okex
+
+import (
+	"hash/crc32"
+	"strings"
+	"sync"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// checksumDepth is the number of the price levels used in the order book checksum
+const checksumDepth = 25
+
+var _ types.BookChecksumCalculator = &Stream{}
+
+// rawLevel is the price and the size strings of a price level in the depth payload
+type rawLevel struct {
+	price, size string
+}
+
+// rawBookLevels keeps the raw strings of the book levels, since OKX calculates the checksum from the strings
+// in the payload, e.g. "0.10" can not be recovered from the fixedpoint value.
+type rawBookLevels struct {
+	mu    sync.Mutex
+	books map[string]map[types.SideType]map[fixedpoint.Value]rawLevel
+}
+
+func newRawBookLevels() *rawBookLevels {
+	return &rawBookLevels{
+		books: make(map[string]map[types.SideType]map[fixedpoint.Value]rawLevel),
+	}
+}
+
+// update applies the levels of the book event, the levels of the symbol are reset if it's a snapshot
+func (l *rawBookLevels) update(event BookEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	book, ok := l.books[event.Symbol]
+	if !ok || event.Action == ActionTypeSnapshot {
+		book = map[types.SideType]map[fixedpoint.Value]rawLevel{
+			types.SideTypeBuy:  make(map[fixedpoint.Value]rawLevel),
+			types.SideTypeSell: make(map[fixedpoint.Value]rawLevel),
+		}
+		l.books[event.Symbol] = book
+	}
+
+	apply := func(levels map[fixedpoint.Value]rawLevel, pvs PriceVolumeOrderSlice) {
+		for _, pv := range pvs {
+			if pv.Volume.IsZero() {
+				delete(levels, pv.Price)
+				continue
+			}
+
+			levels[pv.Price] = rawLevel{price: pv.RawPrice, size: pv.RawVolume}
+		}
+	}
+
+	for _, data := range event.Data {
+		apply(book[types.SideTypeBuy], data.Bids)
+		apply(book[types.SideTypeSell], data.Asks)
+	}
+}
+
+// get returns the raw strings of the level, the formatted values are returned if the level is not found
+func (l *rawBookLevels) get(symbol string, side types.SideType, pv types.PriceVolume) (price, size string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if book, ok := l.books[symbol]; ok {
+		if level, ok := book[side][pv.Price]; ok && level.price != "" {
+			return level.price, level.size
+		}
+	}
+
+	return pv.Price.String(), pv.Volume.String()
+}
+
+// BookChecksum calculates the order book checksum in the way of OKX: the top 25 bids and asks are interleaved
+// as "bidPrice:bidSize:askPrice:askSize:...", and the checksum is the crc32 of the string as a signed 32-bit integer.
+// The price and the size strings are the raw strings of the depth payload.
+// see https://www.okx.com/docs-v5/en/#order-book-trading-market-data-ws-order-book-channel
+func (s *Stream) BookChecksum(market types.Market, bids, asks types.PriceVolumeSlice) int64 {
+	fields := make([]string, 0, checksumDepth*4)
+	appendLevel := func(side types.SideType, pv types.PriceVolume) {
+		var price, size string
+		if s.rawBookLevels != nil {
+			price, size = s.rawBookLevels.get(market.Symbol, side, pv)
+		} else {
+			price, size = pv.Price.String(), pv.Volume.String()
+		}
+
+		fields = append(fields, price, size)
+	}
+
+	for i := 0; i < checksumDepth; i++ {
+		if i < len(bids) {
+			appendLevel(types.SideTypeBuy, bids[i])
+		}
+
+		if i < len(asks) {
+			appendLevel(types.SideTypeSell, asks[i])
+		}
+	}
+
+	return int64(int32(crc32.ChecksumIEEE([]byte(strings.Join(fields, ":")))))
+}
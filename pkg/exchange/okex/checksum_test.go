@@ -0,0 +1,79 @@
+package okex
+
+import (
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+func TestStream_BookChecksum(t *testing.T) {
+	// the example of the OKX document, the checksum string is "3366.1:7:3366.8:9:3366:6:3368:8"
+	bids := types.PriceVolumeSlice{
+		{Price: fixedpoint.NewFromFloat(3366.1), Volume: fixedpoint.NewFromFloat(7)},
+		{Price: fixedpoint.NewFromFloat(3366), Volume: fixedpoint.NewFromFloat(6)},
+	}
+	asks := types.PriceVolumeSlice{
+		{Price: fixedpoint.NewFromFloat(3366.8), Volume: fixedpoint.NewFromFloat(9)},
+		{Price: fixedpoint.NewFromFloat(3368), Volume: fixedpoint.NewFromFloat(8)},
+	}
+
+	s := &Stream{}
+	assert.Equal(t, int64(-1881014294), s.BookChecksum(types.Market{Symbol: "ETHUSDT"}, bids, asks))
+}
+
+func TestStream_BookChecksumRawStrings(t *testing.T) {
+	// the trailing zeros of the raw strings are kept in the checksum string "0.10:1.50:0.11:2.000"
+	s := &Stream{rawBookLevels: newRawBookLevels()}
+	s.rawBookLevels.update(BookEvent{
+		Symbol: "DOGEUSDT",
+		Action: ActionTypeSnapshot,
+		Data: []struct {
+			Bids                 PriceVolumeOrderSlice      `json:"bids"`
+			Asks                 PriceVolumeOrderSlice      `json:"asks"`
+			MillisecondTimestamp types.MillisecondTimestamp `json:"ts"`
+			Checksum             int                        `json:"checksum"`
+		}{{
+			Bids: PriceVolumeOrderSlice{{
+				PriceVolume: types.PriceVolume{Price: fixedpoint.NewFromFloat(0.1), Volume: fixedpoint.NewFromFloat(1.5)},
+				RawPrice:    "0.10",
+				RawVolume:   "1.50",
+			}},
+			Asks: PriceVolumeOrderSlice{{
+				PriceVolume: types.PriceVolume{Price: fixedpoint.NewFromFloat(0.11), Volume: fixedpoint.NewFromFloat(2)},
+				RawPrice:    "0.11",
+				RawVolume:   "2.000",
+			}},
+		}},
+	})
+
+	bids := types.PriceVolumeSlice{{Price: fixedpoint.NewFromFloat(0.1), Volume: fixedpoint.NewFromFloat(1.5)}}
+	asks := types.PriceVolumeSlice{{Price: fixedpoint.NewFromFloat(0.11), Volume: fixedpoint.NewFromFloat(2)}}
+
+	expected := int64(int32(crc32.ChecksumIEEE([]byte("0.10:1.50:0.11:2.000"))))
+	assert.Equal(t, expected, s.BookChecksum(types.Market{Symbol: "DOGEUSDT"}, bids, asks))
+
+	// the level is removed by the zero size
+	s.rawBookLevels.update(BookEvent{
+		Symbol: "DOGEUSDT",
+		Action: ActionTypeUpdate,
+		Data: []struct {
+			Bids                 PriceVolumeOrderSlice      `json:"bids"`
+			Asks                 PriceVolumeOrderSlice      `json:"asks"`
+			MillisecondTimestamp types.MillisecondTimestamp `json:"ts"`
+			Checksum             int                        `json:"checksum"`
+		}{{
+			Asks: PriceVolumeOrderSlice{{
+				PriceVolume: types.PriceVolume{Price: fixedpoint.NewFromFloat(0.11), Volume: fixedpoint.Zero},
+				RawPrice:    "0.11",
+				RawVolume:   "0",
+			}},
+		}},
+	})
+
+	expected = int64(int32(crc32.ChecksumIEEE([]byte("0.10:1.50"))))
+	assert.Equal(t, expected, s.BookChecksum(types.Market{Symbol: "DOGEUSDT"}, bids, nil))
+}
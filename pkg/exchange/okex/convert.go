@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
@@ -14,7 +15,7 @@ import (
 )
 
 func toGlobalSymbol(symbol string) string {
-	return strings.ReplaceAll(symbol, "-", "")
+	return strings.ReplaceAll(strings.TrimSuffix(symbol, swapSymbolSuffix), "-", "")
 }
 
 // //go:generate sh -c "echo \"package okex\nvar spotSymbolMap = map[string]string{\n\" $(curl -s -L 'https://okex.com/api/v5/public/instruments?instType=SPOT' | jq -r '.data[] | \"\\(.instId | sub(\"-\" ; \"\") | tojson ): \\( .instId | tojson),\n\"') \"\n}\" > symbols.go"
@@ -170,6 +171,13 @@ func orderDetailToGlobal(order *okexapi.OrderDetail) (*types.Order, error) {
 		return nil, err
 	}
 
+	quantity, executedQuantity := order.Size, order.AccumulatedFillSize
+	isFutures := order.InstrumentType == okexapi.InstrumentTypeSwap
+	if isFutures {
+		quantity = toGlobalSwapQuantity(order.InstrumentID, quantity)
+		executedQuantity = toGlobalSwapQuantity(order.InstrumentID, executedQuantity)
+	}
+
 	return &types.Order{
 		SubmitOrder: types.SubmitOrder{
 			ClientOrderID: order.ClientOrderId,
@@ -177,7 +185,7 @@ func orderDetailToGlobal(order *okexapi.OrderDetail) (*types.Order, error) {
 			Side:          side,
 			Type:          orderType,
 			Price:         order.Price,
-			Quantity:      order.Size,
+			Quantity:      quantity,
 			TimeInForce:   timeInForce,
 		},
 		Exchange:         types.ExchangeOKEx,
@@ -185,10 +193,11 @@ func orderDetailToGlobal(order *okexapi.OrderDetail) (*types.Order, error) {
 		UUID:             strconv.FormatInt(int64(order.OrderId), 10),
 		Status:           orderStatus,
 		OriginalStatus:   string(order.State),
-		ExecutedQuantity: order.AccumulatedFillSize,
+		ExecutedQuantity: executedQuantity,
 		IsWorking:        order.State.IsWorking(),
 		CreationTime:     types.Time(order.CreatedTime),
 		UpdateTime:       types.Time(order.UpdatedTime),
+		IsFutures:        isFutures,
 	}, nil
 }
 
@@ -208,6 +217,32 @@ func toGlobalOrders(orderDetails []okexapi.OrderDetails) ([]types.Order, error)
 	return orders, err
 }
 
+// toGlobalCreatedOrder converts the submit order and its batch order response to the created order
+func toGlobalCreatedOrder(order types.SubmitOrder, res okexapi.OrderResponse, isFutures bool) (*types.Order, error) {
+	orderID, err := strconv.ParseUint(res.OrderID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse order id: %s, err: %w", res.OrderID, err)
+	}
+
+	if len(res.ClientOrderID) > 0 {
+		order.ClientOrderID = res.ClientOrderID
+	}
+
+	now := time.Now()
+	return &types.Order{
+		SubmitOrder:      order,
+		Exchange:         types.ExchangeOKEx,
+		OrderID:          orderID,
+		UUID:             res.OrderID,
+		Status:           types.OrderStatusNew,
+		ExecutedQuantity: fixedpoint.Zero,
+		IsWorking:        true,
+		IsFutures:        isFutures,
+		CreationTime:     types.Time(now),
+		UpdateTime:       types.Time(now),
+	}, nil
+}
+
 func toGlobalOrderStatus(state okexapi.OrderState) (types.OrderStatus, error) {
 	switch state {
 	case okexapi.OrderStateCanceled:
@@ -319,6 +354,13 @@ func toGlobalOrder(okexOrder *okexapi.OrderDetails) (*types.Order, error) {
 		isMargin = true
 	}
 
+	quantity, executedQuantity := okexOrder.Quantity, okexOrder.FilledQuantity
+	isFutures := okexOrder.InstrumentType == okexapi.InstrumentTypeSwap
+	if isFutures {
+		quantity = toGlobalSwapQuantity(okexOrder.InstrumentID, quantity)
+		executedQuantity = toGlobalSwapQuantity(okexOrder.InstrumentID, executedQuantity)
+	}
+
 	return &types.Order{
 		SubmitOrder: types.SubmitOrder{
 			ClientOrderID: okexOrder.ClientOrderID,
@@ -326,18 +368,19 @@ func toGlobalOrder(okexOrder *okexapi.OrderDetails) (*types.Order, error) {
 			Side:          side,
 			Type:          orderType,
 			Price:         okexOrder.Price,
-			Quantity:      okexOrder.Quantity,
+			Quantity:      quantity,
 			StopPrice:     fixedpoint.Zero, // not supported yet
 			TimeInForce:   timeInForce,
 		},
 		Exchange:         types.ExchangeOKEx,
 		OrderID:          uint64(orderID),
 		Status:           orderStatus,
-		ExecutedQuantity: okexOrder.FilledQuantity,
+		ExecutedQuantity: executedQuantity,
 		IsWorking:        isWorking,
 		CreationTime:     types.Time(okexOrder.CreationTime),
 		UpdateTime:       types.Time(okexOrder.UpdateTime),
 		IsMargin:         isMargin,
+		IsFutures:        isFutures,
 		IsIsolated:       false,
 	}, nil
 }
@@ -359,8 +402,13 @@ func toGlobalTrade(orderDetail *okexapi.OrderDetails) (*types.Trade, error) {
 	}
 
 	isFuture := false
-	if orderDetail.InstrumentType == okexapi.InstrumentTypeFutures {
+	quantity := orderDetail.LastFilledQuantity
+	switch orderDetail.InstrumentType {
+	case okexapi.InstrumentTypeFutures:
+		isFuture = true
+	case okexapi.InstrumentTypeSwap:
 		isFuture = true
+		quantity = toGlobalSwapQuantity(orderDetail.InstrumentID, quantity)
 	}
 
 	return &types.Trade{
@@ -368,8 +416,8 @@ func toGlobalTrade(orderDetail *okexapi.OrderDetails) (*types.Trade, error) {
 		OrderID:       uint64(orderID),
 		Exchange:      types.ExchangeOKEx,
 		Price:         orderDetail.LastFilledPrice,
-		Quantity:      orderDetail.LastFilledQuantity,
-		QuoteQuantity: orderDetail.LastFilledPrice.Mul(orderDetail.LastFilledQuantity),
+		Quantity:      quantity,
+		QuoteQuantity: orderDetail.LastFilledPrice.Mul(quantity),
 		Symbol:        toGlobalSymbol(orderDetail.InstrumentID),
 		Side:          side,
 		IsBuyer:       side == types.SideTypeBuy,
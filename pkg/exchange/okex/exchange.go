@@ -3,6 +3,7 @@ package okex
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
@@ -30,6 +31,7 @@ var (
 	queryAccountLimiter         = rate.NewLimiter(rate.Every(200*time.Millisecond), 5)
 	placeOrderLimiter           = rate.NewLimiter(rate.Every(30*time.Millisecond), 30)
 	batchCancelOrderLimiter     = rate.NewLimiter(rate.Every(5*time.Millisecond), 200)
+	amendOrderLimiter           = rate.NewLimiter(rate.Every(35*time.Millisecond), 60)
 	queryOpenOrderLimiter       = rate.NewLimiter(rate.Every(30*time.Millisecond), 30)
 	queryClosedOrderRateLimiter = rate.NewLimiter(rate.Every(100*time.Millisecond), 10)
 )
@@ -42,6 +44,9 @@ const (
 
 	defaultQueryLimit = 100
 
+	// maxBatchOrderSize is the max number of orders of the batch place order and the batch cancel order API
+	maxBatchOrderSize = 20
+
 	maxHistoricalDataQueryPeriod = 90 * 24 * time.Hour
 )
 
@@ -51,7 +56,12 @@ var log = logrus.WithFields(logrus.Fields{
 
 var ErrSymbolRequired = errors.New("symbol is a required parameter")
 
+var _ types.FuturesExchange = &Exchange{}
+var _ types.ExchangeBatchOrderService = &Exchange{}
+
 type Exchange struct {
+	types.FuturesSettings
+
 	key, secret, passphrase string
 
 	client *okexapi.RestClient
@@ -77,6 +87,10 @@ func (e *Exchange) Name() types.ExchangeName {
 }
 
 func (e *Exchange) QueryMarkets(ctx context.Context) (types.MarketMap, error) {
+	if e.IsFutures {
+		return e.querySwapMarkets(ctx)
+	}
+
 	if err := queryMarketLimiter.Wait(ctx); err != nil {
 		return nil, fmt.Errorf("markets rate limiter wait error: %w", err)
 	}
@@ -119,12 +133,95 @@ func (e *Exchange) QueryMarkets(ctx context.Context) (types.MarketMap, error) {
 	return markets, nil
 }
 
+// querySwapMarkets queries the USDT-margined perpetual swap markets.
+// The sizes of the swap instruments are the number of contracts, they are converted to the base currency quantity
+// with the contract value (ctVal), so that the strategies can use the same quantity unit as the spot markets.
+func (e *Exchange) querySwapMarkets(ctx context.Context) (types.MarketMap, error) {
+	if err := queryMarketLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("markets rate limiter wait error: %w", err)
+	}
+
+	instruments, err := e.client.NewGetInstrumentsInfoRequest().InstType(okexapi.InstrumentTypeSwap).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	swapContractValues.Update(instruments)
+
+	markets := types.MarketMap{}
+	for _, instrument := range instruments {
+		// only the linear (USDT-margined) swaps are supported
+		if instrument.SettleCurrency != "USDT" || instrument.State != "live" {
+			continue
+		}
+
+		ctVal, err := fixedpoint.NewFromString(instrument.ContractValue)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s contract value %q: %w", instrument.InstrumentID, instrument.ContractValue, err)
+		}
+
+		// for BTC-USDT-SWAP, the contract value currency is BTC
+		baseCurrency := instrument.ContractValueCurrency
+		stepSize := instrument.LotSize.Mul(ctVal)
+
+		symbol := toGlobalSymbol(instrument.InstrumentID)
+		markets[symbol] = types.Market{
+			Symbol:      symbol,
+			LocalSymbol: instrument.InstrumentID,
+
+			QuoteCurrency: instrument.SettleCurrency,
+			BaseCurrency:  baseCurrency,
+
+			PricePrecision:  instrument.TickSize.NumFractionalDigits(),
+			VolumePrecision: stepSize.NumFractionalDigits(),
+
+			TickSize: instrument.TickSize,
+
+			// for BTC-USDT-SWAP, the lot size is 0.01 contract and the contract value is 0.01 BTC, so the step size is 0.0001 BTC
+			StepSize:    stepSize,
+			MinQuantity: instrument.MinSize.Mul(ctVal),
+
+			// OKEx does not offer minimal notional, use 1 USD here.
+			MinNotional: fixedpoint.One,
+			MinAmount:   fixedpoint.One,
+		}
+	}
+
+	return markets, nil
+}
+
+// localSymbol converts the global symbol to the instrument ID, the swap instrument ID is used in the futures mode.
+func (e *Exchange) localSymbol(symbol string) string {
+	if e.IsFutures {
+		return toLocalSwapSymbol(symbol)
+	}
+
+	return toLocalSymbol(symbol)
+}
+
+// loadContractValues loads the contract values for converting the swap order sizes in the futures mode
+func (e *Exchange) loadContractValues(ctx context.Context) error {
+	if !e.IsFutures {
+		return nil
+	}
+
+	return loadSwapContractValues(ctx, e.client)
+}
+
+func (e *Exchange) instrumentType() okexapi.InstrumentType {
+	if e.IsFutures {
+		return okexapi.InstrumentTypeSwap
+	}
+
+	return okexapi.InstrumentTypeSpot
+}
+
 func (e *Exchange) QueryTicker(ctx context.Context, symbol string) (*types.Ticker, error) {
 	if err := queryTickerLimiter.Wait(ctx); err != nil {
 		return nil, fmt.Errorf("ticker rate limiter wait error: %w", err)
 	}
 
-	symbol = toLocalSymbol(symbol)
+	symbol = e.localSymbol(symbol)
 	marketTicker, err := e.client.NewGetTickerRequest().InstId(symbol).Do(ctx)
 	if err != nil {
 		return nil, err
@@ -142,7 +239,7 @@ func (e *Exchange) QueryTickers(ctx context.Context, symbols ...string) (map[str
 		return nil, fmt.Errorf("tickers rate limiter wait error: %w", err)
 	}
 
-	marketTickers, err := e.client.NewGetTickersRequest().Do(ctx)
+	marketTickers, err := e.client.NewGetTickersRequest().InstType(e.instrumentType()).Do(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -180,6 +277,20 @@ func (e *Exchange) QueryAccount(ctx context.Context) (*types.Account, error) {
 
 	account := types.NewAccount()
 	account.UpdateBalances(bals)
+
+	if e.IsFutures {
+		positions, err := e.QueryFuturesPositions(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		account.AccountType = types.AccountTypeFutures
+		account.FuturesInfo = &types.FuturesAccountInfo{
+			Positions:  positions,
+			UpdateTime: time.Now().UnixMilli(),
+		}
+	}
+
 	return account, nil
 }
 
@@ -201,9 +312,24 @@ func (e *Exchange) QueryAccountBalances(ctx context.Context) (types.BalanceMap,
 }
 
 func (e *Exchange) SubmitOrder(ctx context.Context, order types.SubmitOrder) (*types.Order, error) {
+	orderReq, err := e.newPlaceOrderRequest(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.placeOrder(ctx, orderReq, order)
+}
+
+// newPlaceOrderRequest builds the place order request of the spot or the swap instrument.
+func (e *Exchange) newPlaceOrderRequest(ctx context.Context, order types.SubmitOrder) (*okexapi.PlaceOrderRequest, error) {
+	if e.IsFutures {
+		return e.newSwapPlaceOrderRequest(ctx, order)
+	}
+
 	orderReq := e.client.NewPlaceOrderRequest()
 
 	orderReq.InstrumentID(toLocalSymbol(order.Symbol))
+	orderReq.TradeMode(okexapi.TradeModeCash)
 	orderReq.Side(toLocalSideType(order.Side))
 	orderReq.Size(order.Market.FormatQuantity(order.Quantity))
 
@@ -222,9 +348,39 @@ func (e *Exchange) SubmitOrder(ctx context.Context, order types.SubmitOrder) (*t
 		}
 	}
 
+	return orderReq, setPlaceOrderType(orderReq, order)
+}
+
+// newSwapPlaceOrderRequest builds the place order request of the swap instrument, the quantity is converted to the number of contracts.
+func (e *Exchange) newSwapPlaceOrderRequest(ctx context.Context, order types.SubmitOrder) (*okexapi.PlaceOrderRequest, error) {
+	instrumentID := toLocalSwapSymbol(order.Symbol)
+	if err := loadSwapContractValues(ctx, e.client); err != nil {
+		return nil, err
+	}
+
+	orderReq := e.client.NewPlaceOrderRequest()
+	orderReq.InstrumentID(instrumentID)
+	orderReq.TradeMode(e.marginMode(order.Symbol))
+	orderReq.Side(toLocalSideType(order.Side))
+	orderReq.Size(toLocalSwapSize(instrumentID, order.Quantity).String())
+
+	switch order.Type {
+	case types.OrderTypeStopLimit, types.OrderTypeLimit, types.OrderTypeLimitMaker:
+		orderReq.Price(order.Market.FormatPrice(order.Price))
+	}
+
+	if order.ReduceOnly || order.ClosePosition {
+		orderReq.ReduceOnly(true)
+	}
+
+	return orderReq, setPlaceOrderType(orderReq, order)
+}
+
+// setPlaceOrderType sets the order type and the client order id of the place order request
+func setPlaceOrderType(orderReq *okexapi.PlaceOrderRequest, order types.SubmitOrder) error {
 	orderType, err := toLocalOrderType(order.Type)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	switch order.TimeInForce {
@@ -236,15 +392,18 @@ func (e *Exchange) SubmitOrder(ctx context.Context, order types.SubmitOrder) (*t
 		orderReq.OrderType(orderType)
 	}
 
-	if err := placeOrderLimiter.Wait(ctx); err != nil {
-		return nil, fmt.Errorf("place order rate limiter wait error: %w", err)
-	}
-
 	_, err = strconv.ParseInt(order.ClientOrderID, 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("client order id should be numberic: %s, err: %w", order.ClientOrderID, err)
+		return fmt.Errorf("client order id should be numberic: %s, err: %w", order.ClientOrderID, err)
 	}
 	orderReq.ClientOrderID(order.ClientOrderID)
+	return nil
+}
+
+func (e *Exchange) placeOrder(ctx context.Context, orderReq *okexapi.PlaceOrderRequest, order types.SubmitOrder) (*types.Order, error) {
+	if err := placeOrderLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("place order rate limiter wait error: %w", err)
+	}
 
 	orders, err := orderReq.Do(ctx)
 	if err != nil {
@@ -265,43 +424,16 @@ func (e *Exchange) SubmitOrder(ctx context.Context, order types.SubmitOrder) (*t
 	}
 
 	return orderRes, nil
-
-	// TODO: move this to batch place orders interface
-	/*
-		batchReq := e.client.TradeService.NewBatchPlaceOrderRequest()
-		batchReq.Add(reqs...)
-		orderHeads, err := batchReq.Do(ctx)
-		if err != nil {
-			return nil, err
-		}
-
-		for idx, orderHead := range orderHeads {
-			orderID, err := strconv.ParseInt(orderHead.OrderID, 10, 64)
-			if err != nil {
-				return createdOrder, err
-			}
-
-			submitOrder := order[idx]
-			createdOrder = append(createdOrder, types.Order{
-				SubmitOrder:      submitOrder,
-				Exchange:         types.ExchangeOKEx,
-				OrderID:          uint64(orderID),
-				Status:           types.OrderStatusNew,
-				ExecutedQuantity: fixedpoint.Zero,
-				IsWorking:        true,
-				CreationTime:     types.Time(time.Now()),
-				UpdateTime:       types.Time(time.Now()),
-				IsMargin:         false,
-				IsIsolated:       false,
-			})
-		}
-	*/
 }
 
 // QueryOpenOrders retrieves the pending orders. The data returned is ordered by createdTime, and we utilized the
 // `After` parameter to acquire all orders.
 func (e *Exchange) QueryOpenOrders(ctx context.Context, symbol string) (orders []types.Order, err error) {
-	instrumentID := toLocalSymbol(symbol)
+	if err := e.loadContractValues(ctx); err != nil {
+		return nil, err
+	}
+
+	instrumentID := e.localSymbol(symbol)
 
 	nextCursor := int64(0)
 	for {
@@ -310,6 +442,7 @@ func (e *Exchange) QueryOpenOrders(ctx context.Context, symbol string) (orders [
 		}
 
 		req := e.client.NewGetOpenOrdersRequest().
+			InstrumentType(e.instrumentType()).
 			InstrumentID(instrumentID).
 			After(strconv.FormatInt(nextCursor, 10))
 		openOrders, err := req.Do(ctx)
@@ -342,40 +475,221 @@ func (e *Exchange) QueryOpenOrders(ctx context.Context, symbol string) (orders [
 }
 
 func (e *Exchange) CancelOrders(ctx context.Context, orders ...types.Order) error {
+	_, err := e.BatchCancelOrders(ctx, orders...)
+	return err
+}
+
+// BatchSubmitOrders submits the orders with the batch order API, each request includes up to 20 orders.
+// The created orders are not queried again, they are converted from the submit orders with the new order status.
+func (e *Exchange) BatchSubmitOrders(ctx context.Context, orders ...types.SubmitOrder) (types.OrderSlice, []int, error) {
+	var createdOrders types.OrderSlice
+	var errIndexes []int
+	var errs error
+
+	for begin := 0; begin < len(orders); begin += maxBatchOrderSize {
+		end := begin + maxBatchOrderSize
+		if end > len(orders) {
+			end = len(orders)
+		}
+
+		var reqs []*okexapi.PlaceOrderRequest
+		var reqIndexes []int
+		for i := begin; i < end; i++ {
+			req, err := e.newPlaceOrderRequest(ctx, orders[i])
+			if err != nil {
+				errs = multierr.Append(errs, types.NewOrderError(err, types.Order{SubmitOrder: orders[i]}))
+				errIndexes = append(errIndexes, i)
+				continue
+			}
+
+			reqs = append(reqs, req)
+			reqIndexes = append(reqIndexes, i)
+		}
+
+		if len(reqs) == 0 {
+			continue
+		}
+
+		if err := placeOrderLimiter.Wait(ctx); err != nil {
+			errIndexes = append(errIndexes, reqIndexes...)
+			for i := end; i < len(orders); i++ {
+				errIndexes = append(errIndexes, i)
+			}
+			sort.Ints(errIndexes)
+			return createdOrders, errIndexes, multierr.Append(errs, fmt.Errorf("place order rate limiter wait error: %w", err))
+		}
+
+		responses, err := e.client.NewBatchPlaceOrderRequest().Add(reqs...).Do(ctx)
+		if err == nil && len(responses) != len(reqs) {
+			err = fmt.Errorf("unexpected length of batch order response: %d, exp: %d", len(responses), len(reqs))
+		}
+
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to place batch orders: %w", err))
+			errIndexes = append(errIndexes, reqIndexes...)
+			continue
+		}
+
+		for i, res := range responses {
+			idx := reqIndexes[i]
+			if res.Code != okexapi.OrderResponseCodeSuccess {
+				errs = multierr.Append(errs, types.NewOrderError(
+					fmt.Errorf("failed to place order, code: %s, msg: %s", res.Code, res.Message),
+					types.Order{SubmitOrder: orders[idx]}))
+				errIndexes = append(errIndexes, idx)
+				continue
+			}
+
+			createdOrder, err := toGlobalCreatedOrder(orders[idx], res, e.IsFutures)
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				errIndexes = append(errIndexes, idx)
+				continue
+			}
+
+			createdOrders = append(createdOrders, *createdOrder)
+		}
+	}
+
+	sort.Ints(errIndexes)
+	return createdOrders, errIndexes, errs
+}
+
+// BatchCancelOrders cancels the orders with the batch cancel API, each request includes up to 20 orders.
+func (e *Exchange) BatchCancelOrders(ctx context.Context, orders ...types.Order) ([]types.Order, error) {
 	if len(orders) == 0 {
-		return nil
+		return nil, nil
+	}
+
+	if err := e.loadContractValues(ctx); err != nil {
+		return orders, err
 	}
 
-	var reqs []*okexapi.CancelOrderRequest
-	for _, order := range orders {
-		if len(order.Symbol) == 0 {
-			return ErrSymbolRequired
+	var failedOrders []types.Order
+	var errs error
+	for begin := 0; begin < len(orders); begin += maxBatchOrderSize {
+		end := begin + maxBatchOrderSize
+		if end > len(orders) {
+			end = len(orders)
 		}
 
-		req := e.client.NewCancelOrderRequest()
-		req.InstrumentID(toLocalSymbol(order.Symbol))
-		req.OrderID(strconv.FormatUint(order.OrderID, 10))
-		if len(order.ClientOrderID) > 0 {
-			_, err := strconv.ParseInt(order.ClientOrderID, 10, 64)
-			if err != nil {
-				return fmt.Errorf("client order id should be numberic: %s, err: %w", order.ClientOrderID, err)
+		var reqs []*okexapi.CancelOrderRequest
+		var reqOrders []types.Order
+		for _, order := range orders[begin:end] {
+			if len(order.Symbol) == 0 {
+				errs = multierr.Append(errs, types.NewOrderError(ErrSymbolRequired, order))
+				failedOrders = append(failedOrders, order)
+				continue
+			}
+
+			req := e.client.NewCancelOrderRequest()
+			req.InstrumentID(e.localSymbol(order.Symbol))
+			req.OrderID(strconv.FormatUint(order.OrderID, 10))
+			if len(order.ClientOrderID) > 0 {
+				_, err := strconv.ParseInt(order.ClientOrderID, 10, 64)
+				if err != nil {
+					errs = multierr.Append(errs, types.NewOrderError(
+						fmt.Errorf("client order id should be numberic: %s, err: %w", order.ClientOrderID, err), order))
+					failedOrders = append(failedOrders, order)
+					continue
+				}
+				req.ClientOrderID(order.ClientOrderID)
+			}
+
+			reqs = append(reqs, req)
+			reqOrders = append(reqOrders, order)
+		}
+
+		if len(reqs) == 0 {
+			continue
+		}
+
+		if err := batchCancelOrderLimiter.Wait(ctx); err != nil {
+			return append(failedOrders, orders[begin:]...), multierr.Append(errs, fmt.Errorf("batch cancel order rate limiter wait error: %w", err))
+		}
+
+		responses, err := e.client.NewBatchCancelOrderRequest().Add(reqs...).Do(ctx)
+		if err == nil && len(responses) != len(reqs) {
+			err = fmt.Errorf("unexpected length of batch cancel response: %d, exp: %d", len(responses), len(reqs))
+		}
+
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to cancel batch orders: %w", err))
+			failedOrders = append(failedOrders, reqOrders...)
+			continue
+		}
+
+		for i, res := range responses {
+			if res.Code != okexapi.OrderResponseCodeSuccess {
+				errs = multierr.Append(errs, types.NewOrderError(
+					fmt.Errorf("failed to cancel order, code: %s, msg: %s", res.Code, res.Message), reqOrders[i]))
+				failedOrders = append(failedOrders, reqOrders[i])
 			}
-			req.ClientOrderID(order.ClientOrderID)
 		}
-		reqs = append(reqs, req)
 	}
 
-	if err := batchCancelOrderLimiter.Wait(ctx); err != nil {
-		return fmt.Errorf("batch cancel order rate limiter wait error: %w", err)
+	return failedOrders, errs
+}
+
+// AmendOrder amends the price and the quantity of the open order, the order ID is not changed after the amendment.
+func (e *Exchange) AmendOrder(ctx context.Context, order types.Order, newPrice, newQuantity fixedpoint.Value) (*types.Order, error) {
+	if len(order.Symbol) == 0 {
+		return nil, ErrSymbolRequired
+	}
+
+	req := e.client.NewAmendOrderRequest()
+	req.InstrumentID(e.localSymbol(order.Symbol))
+	req.OrderID(strconv.FormatUint(order.OrderID, 10))
+
+	if !newPrice.IsZero() {
+		if len(order.Market.Symbol) > 0 {
+			req.NewPrice(order.Market.FormatPrice(newPrice))
+		} else {
+			req.NewPrice(newPrice.String())
+		}
+	}
+
+	if !newQuantity.IsZero() {
+		if e.IsFutures {
+			if err := e.loadContractValues(ctx); err != nil {
+				return nil, err
+			}
+
+			req.NewSize(toLocalSwapSize(toLocalSwapSymbol(order.Symbol), newQuantity).String())
+		} else if len(order.Market.Symbol) > 0 {
+			req.NewSize(order.Market.FormatQuantity(newQuantity))
+		} else {
+			req.NewSize(newQuantity.String())
+		}
 	}
-	batchReq := e.client.NewBatchCancelOrderRequest()
-	batchReq.Add(reqs...)
-	_, err := batchReq.Do(ctx)
-	return err
+
+	if err := amendOrderLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("amend order rate limiter wait error: %w", err)
+	}
+
+	orders, err := req.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(orders) != 1 {
+		return nil, fmt.Errorf("unexpected length of amend order response: %v", orders)
+	}
+
+	if orders[0].Code != "0" {
+		return nil, fmt.Errorf("amend order error, code: %s, message: %s", orders[0].Code, orders[0].Message)
+	}
+
+	return e.QueryOrder(ctx, types.OrderQuery{
+		Symbol:  order.Symbol,
+		OrderID: orders[0].OrderID,
+	})
 }
 
 func (e *Exchange) NewStream() types.Stream {
-	return NewStream(e.client)
+	stream := NewStream(e.client)
+	stream.FuturesSettings = e.FuturesSettings
+	return stream
 }
 
 func (e *Exchange) QueryKLines(ctx context.Context, symbol string, interval types.Interval, options types.KLineQueryOptions) ([]types.KLine, error) {
@@ -388,7 +702,7 @@ func (e *Exchange) QueryKLines(ctx context.Context, symbol string, interval type
 		return nil, fmt.Errorf("fail to get interval: %w", err)
 	}
 
-	req := e.client.NewCandlesticksRequest(toLocalSymbol(symbol))
+	req := e.client.NewCandlesticksRequest(e.localSymbol(symbol))
 	req.Bar(intervalParam)
 
 	if options.StartTime != nil {
@@ -433,8 +747,12 @@ func (e *Exchange) QueryOrder(ctx context.Context, q types.OrderQuery) (*types.O
 	if len(q.OrderID) == 0 && len(q.ClientOrderID) == 0 {
 		return nil, errors.New("okex.QueryOrder: OrderId or ClientOrderId is required parameter")
 	}
+	if err := e.loadContractValues(ctx); err != nil {
+		return nil, err
+	}
+
 	req := e.client.NewGetOrderDetailsRequest()
-	req.InstrumentID(toLocalSymbol(q.Symbol)).
+	req.InstrumentID(e.localSymbol(q.Symbol)).
 		OrderID(q.OrderID).
 		ClientOrderID(q.ClientOrderID)
 
@@ -454,9 +772,13 @@ func (e *Exchange) QueryOrderTrades(ctx context.Context, q types.OrderQuery) ([]
 		log.Warn("!!!OKEX EXCHANGE API NOTICE!!! Okex does not support searching for trades using OrderClientId.")
 	}
 
-	req := e.client.NewGetTransactionHistoryRequest()
+	if err := e.loadContractValues(ctx); err != nil {
+		return nil, err
+	}
+
+	req := e.client.NewGetTransactionHistoryRequest().InstrumentType(e.instrumentType())
 	if len(q.Symbol) != 0 {
-		req.InstrumentID(toLocalSymbol(q.Symbol))
+		req.InstrumentID(e.localSymbol(q.Symbol))
 	}
 
 	if len(q.OrderID) != 0 {
@@ -515,12 +837,17 @@ func (e *Exchange) QueryClosedOrders(ctx context.Context, symbol string, since,
 		return nil, fmt.Errorf("the start time %s and end time %s cannot exceed 90 days", newSince, until)
 	}
 
+	if err := e.loadContractValues(ctx); err != nil {
+		return nil, err
+	}
+
 	if err := queryClosedOrderRateLimiter.Wait(ctx); err != nil {
 		return nil, fmt.Errorf("query closed order rate limiter wait error: %w", err)
 	}
 
 	res, err := e.client.NewGetOrderHistoryRequest().
-		InstrumentID(toLocalSymbol(symbol)).
+		InstrumentType(e.instrumentType()).
+		InstrumentID(e.localSymbol(symbol)).
 		StartTime(since).
 		EndTime(until).
 		Limit(defaultQueryLimit).
@@ -565,7 +892,13 @@ func (e *Exchange) QueryTrades(ctx context.Context, symbol string, options *type
 		log.Warn("!!!OKEX EXCHANGE API NOTICE!!! Okex does not support searching for trades using TradeId.")
 	}
 
-	req := e.client.NewGetTransactionHistoryRequest().InstrumentID(toLocalSymbol(symbol))
+	if err := e.loadContractValues(ctx); err != nil {
+		return nil, err
+	}
+
+	req := e.client.NewGetTransactionHistoryRequest().
+		InstrumentType(e.instrumentType()).
+		InstrumentID(e.localSymbol(symbol))
 
 	limit := uint64(options.Limit)
 	if limit > defaultQueryLimit || limit <= 0 {
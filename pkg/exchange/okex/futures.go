@@ -0,0 +1,289 @@
+package okex
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/c9s/bbgo/pkg/exchange/okex/okexapi"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+const swapSymbolSuffix = "-SWAP"
+
+// swapContract is the contract value (ctVal) and the lot size (lotSz) of a SWAP instrument
+type swapContract struct {
+	value   fixedpoint.Value
+	lotSize fixedpoint.Value
+}
+
+// contractValueMap stores the contract values (ctVal) and the lot sizes (lotSz) of the SWAP instruments.
+// The order size of the SWAP instruments is the number of contracts, for example, 1 contract of BTC-USDT-SWAP is 0.01 BTC,
+// so the sizes are converted from/to the base currency quantity with the contract value.
+type contractValueMap struct {
+	mu     sync.RWMutex
+	values map[string]swapContract
+}
+
+func (m *contractValueMap) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.values)
+}
+
+func (m *contractValueMap) Get(instrumentID string) (fixedpoint.Value, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	c, ok := m.values[instrumentID]
+	return c.value, ok
+}
+
+// LotSize returns the lot size of the instrument in contracts, zero if it's not loaded
+func (m *contractValueMap) LotSize(instrumentID string) fixedpoint.Value {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.values[instrumentID].lotSize
+}
+
+func (m *contractValueMap) Update(instruments []okexapi.InstrumentInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, instrument := range instruments {
+		if instrument.InstrumentType != string(okexapi.InstrumentTypeSwap) {
+			continue
+		}
+
+		ctVal, err := fixedpoint.NewFromString(instrument.ContractValue)
+		if err != nil || ctVal.Sign() <= 0 {
+			continue
+		}
+
+		m.values[instrument.InstrumentID] = swapContract{value: ctVal, lotSize: instrument.LotSize}
+	}
+}
+
+var swapContractValues = &contractValueMap{values: make(map[string]swapContract)}
+
+// loadSwapContractValues loads the contract values of the SWAP instruments if they are not loaded yet,
+// the markets could be loaded from the cache, so we can not rely on QueryMarkets to load them.
+func loadSwapContractValues(ctx context.Context, client *okexapi.RestClient) error {
+	if swapContractValues.Len() > 0 {
+		return nil
+	}
+
+	if err := queryMarketLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("markets rate limiter wait error: %w", err)
+	}
+
+	instruments, err := client.NewGetInstrumentsInfoRequest().InstType(okexapi.InstrumentTypeSwap).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query swap instruments: %w", err)
+	}
+
+	swapContractValues.Update(instruments)
+	return nil
+}
+
+// toGlobalSwapQuantity converts the number of contracts to the base currency quantity
+func toGlobalSwapQuantity(instrumentID string, size fixedpoint.Value) fixedpoint.Value {
+	ctVal, ok := swapContractValues.Get(instrumentID)
+	if !ok {
+		log.Errorf("contract value of %s is not loaded, the size is not converted", instrumentID)
+		return size
+	}
+
+	return size.Mul(ctVal)
+}
+
+// toLocalSwapSize converts the base currency quantity to the number of contracts,
+// the size is truncated to the lot size of the instrument since OKX rejects the size that is not a multiple of it.
+func toLocalSwapSize(instrumentID string, quantity fixedpoint.Value) fixedpoint.Value {
+	ctVal, ok := swapContractValues.Get(instrumentID)
+	if !ok {
+		log.Errorf("contract value of %s is not loaded, the quantity is not converted", instrumentID)
+		return quantity
+	}
+
+	size := quantity.Div(ctVal)
+	if lotSize := swapContractValues.LotSize(instrumentID); lotSize.Sign() > 0 {
+		size = size.Div(lotSize).Trunc().Mul(lotSize)
+	}
+
+	return size
+}
+
+func toLocalSwapSymbol(symbol string) string {
+	return toLocalSymbol(symbol) + swapSymbolSuffix
+}
+
+func toGlobalFuturesPosition(position okexapi.Position) types.FuturesPosition {
+	base := position.Position
+	if position.PositionSide == okexapi.PositionSideShort {
+		base = base.Neg()
+	}
+
+	base = toGlobalSwapQuantity(position.InstrumentID, base)
+
+	var baseCurrency, quoteCurrency string
+	if currencies := strings.Split(position.InstrumentID, "-"); len(currencies) >= 2 {
+		baseCurrency, quoteCurrency = currencies[0], currencies[1]
+	}
+
+	return types.FuturesPosition{
+		Symbol:                 toGlobalSymbol(position.InstrumentID),
+		BaseCurrency:           baseCurrency,
+		QuoteCurrency:          quoteCurrency,
+		Base:                   base,
+		Quote:                  base.Mul(position.AveragePrice).Neg(),
+		AverageCost:            position.AveragePrice,
+		ApproximateAverageCost: position.AveragePrice,
+		Isolated:               position.MarginMode == okexapi.TradeModeIsolated,
+		UpdateTime:             position.UpdateTime.Time().UnixMilli(),
+		PositionRisk: &types.PositionRisk{
+			Leverage:         position.Leverage,
+			LiquidationPrice: position.LiquidationPrice,
+		},
+	}
+}
+
+func toGlobalFuturesPositions(positions []okexapi.Position) types.FuturesPositionMap {
+	positionMap := make(types.FuturesPositionMap)
+	for _, position := range positions {
+		if position.InstrumentType != okexapi.InstrumentTypeSwap {
+			continue
+		}
+
+		futuresPosition := toGlobalFuturesPosition(position)
+		positionMap[futuresPosition.Symbol] = futuresPosition
+	}
+
+	return positionMap
+}
+
+// marginMode returns the margin mode of the symbol, the isolated margin mode is only used for the isolated futures symbol
+func (e *Exchange) marginMode(symbol string) okexapi.TradeMode {
+	if e.IsIsolatedFutures && e.IsolatedFuturesSymbol == symbol {
+		return okexapi.TradeModeIsolated
+	}
+
+	return okexapi.TradeModeCross
+}
+
+// QueryFuturesPositions queries the SWAP positions, the position quantities are converted to the base currency quantity.
+func (e *Exchange) QueryFuturesPositions(ctx context.Context) (types.FuturesPositionMap, error) {
+	if err := loadSwapContractValues(ctx, e.client); err != nil {
+		return nil, err
+	}
+
+	if err := queryAccountLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("account rate limiter wait error: %w", err)
+	}
+
+	positions, err := e.client.NewGetPositionsRequest().InstrumentType(okexapi.InstrumentTypeSwap).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query positions: %w", err)
+	}
+
+	return toGlobalFuturesPositions(positions), nil
+}
+
+func (e *Exchange) QueryPositionRisk(ctx context.Context, symbol string) (*types.PositionRisk, error) {
+	if err := queryAccountLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("account rate limiter wait error: %w", err)
+	}
+
+	positions, err := e.client.NewGetPositionsRequest().
+		InstrumentType(okexapi.InstrumentTypeSwap).
+		InstrumentID(toLocalSwapSymbol(symbol)).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s position: %w", symbol, err)
+	}
+
+	if len(positions) == 0 {
+		return nil, fmt.Errorf("%s position not found", symbol)
+	}
+
+	return &types.PositionRisk{
+		Leverage:         positions[0].Leverage,
+		LiquidationPrice: positions[0].LiquidationPrice,
+	}, nil
+}
+
+// SetLeverage sets the leverage of the SWAP instrument. OKX sets the margin mode of the instrument with the leverage,
+// the margin mode is isolated for the isolated futures symbol, otherwise it's cross.
+func (e *Exchange) SetLeverage(ctx context.Context, symbol string, leverage int) error {
+	if err := queryAccountLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("account rate limiter wait error: %w", err)
+	}
+
+	_, err := e.client.NewSetLeverageRequest().
+		InstrumentID(toLocalSwapSymbol(symbol)).
+		Leverage(strconv.Itoa(leverage)).
+		MarginMode(e.marginMode(symbol)).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to set %s leverage to %d: %w", symbol, leverage, err)
+	}
+
+	return nil
+}
+
+// QueryPremiumIndex queries the mark price and the current funding rate of the SWAP instrument
+func (e *Exchange) QueryPremiumIndex(ctx context.Context, symbol string) (*types.PremiumIndex, error) {
+	if err := marketDataLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	instrumentID := toLocalSwapSymbol(symbol)
+	fundingRate, err := e.client.NewGetFundingRate().InstrumentID(instrumentID).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s funding rate: %w", symbol, err)
+	}
+
+	markPrices, err := e.client.NewGetMarkPriceRequest().InstrumentID(instrumentID).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s mark price: %w", symbol, err)
+	}
+
+	if len(markPrices) == 0 {
+		return nil, fmt.Errorf("empty %s mark price data", symbol)
+	}
+
+	return &types.PremiumIndex{
+		Symbol:          symbol,
+		MarkPrice:       markPrices[0].MarkPrice,
+		LastFundingRate: fundingRate.FundingRate,
+		NextFundingTime: fundingRate.FundingTime.Time(),
+		Time:            markPrices[0].Timestamp.Time(),
+	}, nil
+}
+
+// QueryFundingRateHistory queries the last settled funding rate of the SWAP instrument
+func (e *Exchange) QueryFundingRateHistory(ctx context.Context, symbol string) (*types.FundingRate, error) {
+	if err := marketDataLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	rates, err := e.client.NewGetFundingRateHistoryRequest().
+		InstrumentID(toLocalSwapSymbol(symbol)).
+		Limit(1).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("empty %s funding rate data", symbol)
+	}
+
+	return &types.FundingRate{
+		FundingRate: rates[0].RealizedRate,
+		FundingTime: rates[0].FundingTime.Time(),
+		Time:        time.Now(),
+	}, nil
+}
@@ -0,0 +1,112 @@
+package okex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/exchange/okex/okexapi"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+func Test_toGlobalSymbol_swap(t *testing.T) {
+	assert.Equal(t, "BTCUSDT", toGlobalSymbol("BTC-USDT-SWAP"))
+	assert.Equal(t, "BTC-USDT-SWAP", toLocalSwapSymbol("BTCUSDT"))
+}
+
+func Test_swapContractValues(t *testing.T) {
+	swapContractValues.Update([]okexapi.InstrumentInfo{
+		{InstrumentType: "SWAP", InstrumentID: "BTC-USDT-SWAP", ContractValue: "0.01"},
+		{InstrumentType: "SPOT", InstrumentID: "BTC-USDT", ContractValue: ""},
+	})
+
+	_, ok := swapContractValues.Get("BTC-USDT")
+	assert.False(t, ok)
+
+	assert.Equal(t, fixedpoint.NewFromFloat(0.05), toGlobalSwapQuantity("BTC-USDT-SWAP", fixedpoint.NewFromFloat(5)))
+	assert.Equal(t, fixedpoint.NewFromFloat(5), toLocalSwapSize("BTC-USDT-SWAP", fixedpoint.NewFromFloat(0.05)))
+}
+
+func Test_toLocalSwapSize_lotSize(t *testing.T) {
+	swapContractValues.Update([]okexapi.InstrumentInfo{
+		{InstrumentType: "SWAP", InstrumentID: "ETH-USDT-SWAP", ContractValue: "0.1", LotSize: fixedpoint.NewFromFloat(1)},
+		{InstrumentType: "SWAP", InstrumentID: "SOL-USDT-SWAP", ContractValue: "1", LotSize: fixedpoint.NewFromFloat(0.01)},
+	})
+
+	// 0.123 ETH = 1.23 contracts, truncated to 1 contract
+	assert.Equal(t, fixedpoint.NewFromFloat(1), toLocalSwapSize("ETH-USDT-SWAP", fixedpoint.NewFromFloat(0.123)))
+	assert.Equal(t, fixedpoint.NewFromFloat(2), toLocalSwapSize("ETH-USDT-SWAP", fixedpoint.NewFromFloat(0.2)))
+	assert.Equal(t, fixedpoint.NewFromFloat(1.23), toLocalSwapSize("SOL-USDT-SWAP", fixedpoint.NewFromFloat(1.2345)))
+}
+
+func Test_parseWebSocketEvent_positionsEvent(t *testing.T) {
+	swapContractValues.Update([]okexapi.InstrumentInfo{
+		{InstrumentType: "SWAP", InstrumentID: "BTC-USDT-SWAP", ContractValue: "0.01"},
+	})
+
+	in := `
+{
+  "arg": {
+    "channel": "positions",
+    "uid": "77982378738415879",
+    "instType": "SWAP"
+  },
+  "data": [
+    {
+      "adl": "1",
+      "availPos": "",
+      "avgPx": "30000",
+      "cTime": "1619507758793",
+      "ccy": "USDT",
+      "instId": "BTC-USDT-SWAP",
+      "instType": "SWAP",
+      "lever": "10",
+      "liqPx": "27500.5",
+      "markPx": "30100",
+      "margin": "",
+      "mgnMode": "cross",
+      "mgnRatio": "11.731726509588816",
+      "notionalUsd": "301",
+      "pos": "-10",
+      "posId": "307173036051017730",
+      "posSide": "net",
+      "uTime": "1619507761462",
+      "upl": "-10",
+      "uplRatio": "-0.033"
+    }
+  ]
+}`
+
+	res, err := parseWebSocketEvent([]byte(in))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	positions, ok := res.([]okexapi.Position)
+	if !assert.True(t, ok) || !assert.Len(t, positions, 1) {
+		return
+	}
+
+	positionMap := toGlobalFuturesPositions(positions)
+	position, ok := positionMap["BTCUSDT"]
+	if !assert.True(t, ok) {
+		return
+	}
+
+	assert.Equal(t, types.FuturesPosition{
+		Symbol:                 "BTCUSDT",
+		BaseCurrency:           "BTC",
+		QuoteCurrency:          "USDT",
+		Base:                   fixedpoint.NewFromFloat(-0.1),
+		Quote:                  fixedpoint.NewFromFloat(3000),
+		AverageCost:            fixedpoint.NewFromFloat(30000),
+		ApproximateAverageCost: fixedpoint.NewFromFloat(30000),
+		Isolated:               false,
+		UpdateTime:             1619507761462,
+		PositionRisk: &types.PositionRisk{
+			Leverage:         fixedpoint.NewFromFloat(10),
+			LiquidationPrice: fixedpoint.NewFromFloat(27500.5),
+		},
+	}, position)
+}
@@ -0,0 +1,27 @@
+package okexapi
+
+import "github.com/c9s/requestgen"
+
+//go:generate -command GetRequest requestgen -method GET -responseType .APIResponse -responseDataField Data
+//go:generate -command PostRequest requestgen -method POST -responseType .APIResponse -responseDataField Data
+
+//go:generate PostRequest -url "/api/v5/trade/amend-order" -type AmendOrderRequest -responseDataType []OrderResponse
+type AmendOrderRequest struct {
+	client requestgen.AuthenticatedAPIClient
+
+	instrumentID  string  `param:"instId"`
+	orderID       *string `param:"ordId"`
+	clientOrderID *string `param:"clOrdId"`
+
+	// cancelOnFail cancels the order if the amendment fails
+	cancelOnFail *bool `param:"cxlOnFail"`
+
+	newSize  *string `param:"newSz"`
+	newPrice *string `param:"newPx"`
+}
+
+func (c *RestClient) NewAmendOrderRequest() *AmendOrderRequest {
+	return &AmendOrderRequest{
+		client: c,
+	}
+}
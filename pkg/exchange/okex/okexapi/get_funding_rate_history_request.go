@@ -0,0 +1,41 @@
+package okexapi
+
+import (
+	"time"
+
+	"github.com/c9s/requestgen"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+//go:generate -command GetRequest requestgen -method GET -responseType .APIResponse -responseDataField Data
+//go:generate -command PostRequest requestgen -method POST -responseType .APIResponse -responseDataField Data
+
+type FundingRateHistory struct {
+	InstrumentType InstrumentType             `json:"instType"`
+	InstrumentID   string                     `json:"instId"`
+	FundingRate    fixedpoint.Value           `json:"fundingRate"`
+	RealizedRate   fixedpoint.Value           `json:"realizedRate"`
+	FundingTime    types.MillisecondTimestamp `json:"fundingTime"`
+}
+
+//go:generate GetRequest -url "/api/v5/public/funding-rate-history" -type GetFundingRateHistoryRequest -responseDataType []FundingRateHistory
+type GetFundingRateHistoryRequest struct {
+	client requestgen.APIClient
+
+	instrumentID string `param:"instId,query"`
+
+	// before and after are the funding time for pagination
+	before *time.Time `param:"before,query,milliseconds"`
+	after  *time.Time `param:"after,query,milliseconds"`
+
+	// limit is the number of results per request, the maximum is 100, the default is 100
+	limit *uint64 `param:"limit,query"`
+}
+
+func (c *RestClient) NewGetFundingRateHistoryRequest() *GetFundingRateHistoryRequest {
+	return &GetFundingRateHistoryRequest{
+		client: c,
+	}
+}
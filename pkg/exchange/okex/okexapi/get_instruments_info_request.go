@@ -34,7 +34,7 @@ type InstrumentInfo struct {
 type GetInstrumentsInfoRequest struct {
 	client requestgen.APIClient
 
-	instType InstrumentType `param:"instType,query" validValues:"SPOT"`
+	instType InstrumentType `param:"instType,query" validValues:"SPOT,SWAP"`
 
 	instId *string `param:"instId,query"`
 }
@@ -0,0 +1,34 @@
+package okexapi
+
+import (
+	"github.com/c9s/requestgen"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+//go:generate -command GetRequest requestgen -method GET -responseType .APIResponse -responseDataField Data
+//go:generate -command PostRequest requestgen -method POST -responseType .APIResponse -responseDataField Data
+
+type MarkPriceInfo struct {
+	InstrumentType InstrumentType             `json:"instType"`
+	InstrumentID   string                     `json:"instId"`
+	MarkPrice      fixedpoint.Value           `json:"markPx"`
+	Timestamp      types.MillisecondTimestamp `json:"ts"`
+}
+
+//go:generate GetRequest -url "/api/v5/public/mark-price" -type GetMarkPriceRequest -responseDataType []MarkPriceInfo
+type GetMarkPriceRequest struct {
+	client requestgen.APIClient
+
+	instrumentType InstrumentType `param:"instType,query" validValues:"MARGIN,SWAP,FUTURES,OPTION"`
+
+	instrumentID *string `param:"instId,query"`
+}
+
+func (c *RestClient) NewGetMarkPriceRequest() *GetMarkPriceRequest {
+	return &GetMarkPriceRequest{
+		client:         c,
+		instrumentType: InstrumentTypeSwap,
+	}
+}
@@ -0,0 +1,66 @@
+package okexapi
+
+import (
+	"github.com/c9s/requestgen"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+//go:generate -command GetRequest requestgen -method GET -responseType .APIResponse -responseDataField Data
+//go:generate -command PostRequest requestgen -method POST -responseType .APIResponse -responseDataField Data
+
+type PositionSide string
+
+const (
+	PositionSideLong  PositionSide = "long"
+	PositionSideShort PositionSide = "short"
+	PositionSideNet   PositionSide = "net"
+)
+
+type Position struct {
+	InstrumentType InstrumentType `json:"instType"`
+	InstrumentID   string         `json:"instId"`
+	MarginMode     TradeMode      `json:"mgnMode"`
+	PositionID     string         `json:"posId"`
+
+	// PositionSide is "net" in the net mode, and "long" or "short" in the long/short mode
+	PositionSide PositionSide `json:"posSide"`
+
+	// Position is the quantity of the position in contracts,
+	// in the net mode, the position is positive for long position and negative for short position
+	Position          fixedpoint.Value `json:"pos"`
+	AvailablePosition fixedpoint.Value `json:"availPos"`
+	AveragePrice      fixedpoint.Value `json:"avgPx"`
+
+	UnrealizedPnL      fixedpoint.Value `json:"upl"`
+	UnrealizedPnLRatio fixedpoint.Value `json:"uplRatio"`
+	RealizedPnL        fixedpoint.Value `json:"realizedPnl"`
+
+	Leverage         fixedpoint.Value `json:"lever"`
+	LiquidationPrice fixedpoint.Value `json:"liqPx"`
+	MarkPrice        fixedpoint.Value `json:"markPx"`
+	Margin           fixedpoint.Value `json:"margin"`
+	MarginRatio      fixedpoint.Value `json:"mgnRatio"`
+	NotionalUsd      fixedpoint.Value `json:"notionalUsd"`
+
+	// Currency is the margin currency
+	Currency string `json:"ccy"`
+
+	CreationTime types.MillisecondTimestamp `json:"cTime"`
+	UpdateTime   types.MillisecondTimestamp `json:"uTime"`
+}
+
+//go:generate GetRequest -url "/api/v5/account/positions" -type GetPositionsRequest -responseDataType []Position
+type GetPositionsRequest struct {
+	client requestgen.AuthenticatedAPIClient
+
+	instrumentType *InstrumentType `param:"instType,query" validValues:"MARGIN,SWAP,FUTURES,OPTION"`
+	instrumentID   *string         `param:"instId,query"`
+}
+
+func (c *RestClient) NewGetPositionsRequest() *GetPositionsRequest {
+	return &GetPositionsRequest{
+		client: c,
+	}
+}
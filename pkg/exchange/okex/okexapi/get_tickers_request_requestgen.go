@@ -24,7 +24,7 @@ func (g *GetTickersRequest) GetQueryParameters() (url.Values, error) {
 
 	// TEMPLATE check-valid-values
 	switch instType {
-	case "SPOT":
+	case "SPOT", "SWAP":
 		params["instType"] = instType
 
 	default:
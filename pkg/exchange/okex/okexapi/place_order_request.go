@@ -28,6 +28,9 @@ type OrderResponse struct {
 	Message       string `json:"sMsg"`
 }
 
+// OrderResponseCodeSuccess is the sCode of the successful order response
+const OrderResponseCodeSuccess = "0"
+
 //go:generate PostRequest -url "/api/v5/trade/order" -type PlaceOrderRequest -responseDataType []OrderResponse
 type PlaceOrderRequest struct {
 	client requestgen.AuthenticatedAPIClient
@@ -60,6 +63,10 @@ type PlaceOrderRequest struct {
 	// Only applicable to SPOT Market Orders
 	// Default is quote_ccy for buy, base_ccy for sell
 	targetCurrency *TargetCurrency `param:"tgtCcy" validValues:"quote_ccy,base_ccy"`
+
+	// Whether orders can only reduce in position size.
+	// Only applicable to MARGIN orders, and FUTURES/SWAP orders in net mode
+	reduceOnly *bool `param:"reduceOnly"`
 }
 
 func (c *RestClient) NewPlaceOrderRequest() *PlaceOrderRequest {
@@ -56,6 +56,11 @@ func (r *PlaceOrderRequest) TargetCurrency(targetCurrency TargetCurrency) *Place
 	return r
 }
 
+func (r *PlaceOrderRequest) ReduceOnly(reduceOnly bool) *PlaceOrderRequest {
+	r.reduceOnly = &reduceOnly
+	return r
+}
+
 // GetQueryParameters builds and checks the query parameters and returns url.Values
 func (r *PlaceOrderRequest) GetQueryParameters() (url.Values, error) {
 	var params = map[string]interface{}{}
@@ -172,6 +177,14 @@ func (r *PlaceOrderRequest) GetParameters() (map[string]interface{}, error) {
 		params["tgtCcy"] = targetCurrency
 	} else {
 	}
+	// check reduceOnly field -> json key reduceOnly
+	if r.reduceOnly != nil {
+		reduceOnly := *r.reduceOnly
+
+		// assign parameter of reduceOnly
+		params["reduceOnly"] = reduceOnly
+	} else {
+	}
 
 	return params, nil
 }
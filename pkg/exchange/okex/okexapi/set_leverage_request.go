@@ -0,0 +1,41 @@
+package okexapi
+
+import (
+	"github.com/c9s/requestgen"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+)
+
+//go:generate -command GetRequest requestgen -method GET -responseType .APIResponse -responseDataField Data
+//go:generate -command PostRequest requestgen -method POST -responseType .APIResponse -responseDataField Data
+
+type LeverageResponse struct {
+	InstrumentID string           `json:"instId"`
+	Leverage     fixedpoint.Value `json:"lever"`
+	MarginMode   TradeMode        `json:"mgnMode"`
+	PositionSide PositionSide     `json:"posSide"`
+}
+
+// SetLeverageRequest sets the leverage of the instrument, the margin mode of the instrument is set together,
+// the margin mode of the order is still decided by the tdMode of the order.
+//
+//go:generate PostRequest -url "/api/v5/account/set-leverage" -type SetLeverageRequest -responseDataType []LeverageResponse
+type SetLeverageRequest struct {
+	client requestgen.AuthenticatedAPIClient
+
+	instrumentID string `param:"instId"`
+
+	leverage string `param:"lever"`
+
+	marginMode TradeMode `param:"mgnMode" validValues:"cross,isolated"`
+
+	// positionSide is only required for the isolated margin mode in the long/short mode
+	positionSide *PositionSide `param:"posSide" validValues:"long,short"`
+}
+
+func (c *RestClient) NewSetLeverageRequest() *SetLeverageRequest {
+	return &SetLeverageRequest{
+		client:     c,
+		marginMode: TradeModeCross,
+	}
+}
@@ -3,6 +3,7 @@ package okexapi
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/url"
 
 	"github.com/pkg/errors"
@@ -76,12 +77,7 @@ func (r *BatchCancelOrderRequest) Do(ctx context.Context) ([]OrderResponse, erro
 	if err := response.DecodeJSON(&apiResponse); err != nil {
 		return nil, err
 	}
-	var data []OrderResponse
-	if err := json.Unmarshal(apiResponse.Data, &data); err != nil {
-		return nil, err
-	}
-
-	return data, nil
+	return parseBatchOrderResponse(apiResponse)
 }
 
 type BatchPlaceOrderRequest struct {
@@ -117,11 +113,21 @@ func (r *BatchPlaceOrderRequest) Do(ctx context.Context) ([]OrderResponse, error
 	if err := response.DecodeJSON(&apiResponse); err != nil {
 		return nil, err
 	}
+	return parseBatchOrderResponse(apiResponse)
+}
+
+// parseBatchOrderResponse parses the order responses of the batch request. The batch request could be partially
+// successful, the result of each order is in the sCode and the sMsg fields of the order response.
+func parseBatchOrderResponse(apiResponse APIResponse) ([]OrderResponse, error) {
 	var data []OrderResponse
 	if err := json.Unmarshal(apiResponse.Data, &data); err != nil {
 		return nil, err
 	}
 
+	if len(data) == 0 && apiResponse.Code != "0" {
+		return nil, fmt.Errorf("batch request error, code: %s, msg: %s", apiResponse.Code, apiResponse.Message)
+	}
+
 	return data, nil
 }
 
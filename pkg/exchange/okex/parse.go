@@ -23,6 +23,7 @@ const (
 	ChannelAccount      Channel = "account"
 	ChannelMarketTrades Channel = "trades"
 	ChannelOrders       Channel = "orders"
+	ChannelPositions    Channel = "positions"
 )
 
 type ActionType string
@@ -77,6 +78,14 @@ func parseWebSocketEvent(in []byte) (interface{}, error) {
 		// TODO: remove fastjson
 		return parseOrder(v)
 
+	case ChannelPositions:
+		var positions []okexapi.Position
+		err = json.Unmarshal(event.Data, &positions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal data into positions: %+v, err: %w", string(event.Data), err)
+		}
+		return positions, nil
+
 	default:
 		if strings.HasPrefix(string(event.Arg.Channel), string(ChannelCandlePrefix)) {
 			// TODO: Support kline subscription. The kline requires another URL to subscribe, which is why we cannot
@@ -185,6 +194,7 @@ func (event *BookEvent) Book() types.SliceOrderBook {
 
 	if len(event.Data) > 0 {
 		book.Time = event.Data[0].MillisecondTimestamp.Time()
+		book.Checksum = int64(event.Data[len(event.Data)-1].Checksum)
 	}
 
 	for _, data := range event.Data {
@@ -206,6 +216,11 @@ type PriceVolumeOrder struct {
 	NumLiquidated int
 	// NumOrders is the number of orders at the price.
 	NumOrders int
+
+	// RawPrice and RawVolume are the strings in the payload, they are used for the order book checksum,
+	// since the formatted fixedpoint values do not keep the trailing zeros
+	RawPrice  string
+	RawVolume string
 }
 
 type PriceVolumeOrderSlice []PriceVolumeOrder
@@ -224,7 +239,7 @@ func (slice *PriceVolumeOrderSlice) UnmarshalJSON(b []byte) error {
 //
 //	[["8476.98", "415", "0", "13"], ["8477", "7", "0", "2"], ... ]
 func ParsePriceVolumeOrderSliceJSON(b []byte) (slice PriceVolumeOrderSlice, err error) {
-	var as [][]fixedpoint.Value
+	var as [][]json.RawMessage
 
 	err = json.Unmarshal(b, &as)
 	if err != nil {
@@ -232,11 +247,24 @@ func ParsePriceVolumeOrderSliceJSON(b []byte) (slice PriceVolumeOrderSlice, err
 	}
 
 	for _, a := range as {
+		if len(a) < 4 {
+			return slice, fmt.Errorf("failed to unmarshal price volume order slice: unexpected level %s", a)
+		}
+
+		var values [4]fixedpoint.Value
+		for i := range values {
+			if err := json.Unmarshal(a[i], &values[i]); err != nil {
+				return slice, fmt.Errorf("failed to unmarshal price volume order slice: %w", err)
+			}
+		}
+
 		var pv PriceVolumeOrder
-		pv.Price = a[0]
-		pv.Volume = a[1]
-		pv.NumLiquidated = a[2].Int()
-		pv.NumOrders = a[3].Int()
+		pv.Price = values[0]
+		pv.Volume = values[1]
+		pv.NumLiquidated = values[2].Int()
+		pv.NumOrders = values[3].Int()
+		pv.RawPrice = strings.Trim(string(a[0]), `"`)
+		pv.RawVolume = strings.Trim(string(a[1]), `"`)
 
 		slice = append(slice, pv)
 	}
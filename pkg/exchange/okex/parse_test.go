@@ -166,6 +166,8 @@ func TestParsePriceVolumeOrderSliceJSON(t *testing.T) {
 				},
 				NumLiquidated: fixedpoint.Zero.Int(),
 				NumOrders:     fixedpoint.NewFromFloat(13).Int(),
+				RawPrice:      "8476.98",
+				RawVolume:     "415",
 			},
 			{
 				PriceVolume: types.PriceVolume{
@@ -174,6 +176,8 @@ func TestParsePriceVolumeOrderSliceJSON(t *testing.T) {
 				},
 				NumLiquidated: fixedpoint.Zero.Int(),
 				NumOrders:     fixedpoint.NewFromFloat(2).Int(),
+				RawPrice:      "8477",
+				RawVolume:     "7",
 			},
 		}
 		bids := PriceVolumeOrderSlice{
@@ -184,6 +188,8 @@ func TestParsePriceVolumeOrderSliceJSON(t *testing.T) {
 				},
 				NumLiquidated: fixedpoint.Zero.Int(),
 				NumOrders:     fixedpoint.NewFromFloat(12).Int(),
+				RawPrice:      "8476",
+				RawVolume:     "256",
 			},
 		}
 
@@ -321,10 +327,11 @@ func TestBookEvent_Book(t *testing.T) {
 
 	book := event.Book()
 	assert.Equal(t, types.SliceOrderBook{
-		Symbol: "BTCUSDT",
-		Time:   types.NewMillisecondTimestampFromInt(1597026383085).Time(),
-		Bids:   bids,
-		Asks:   asks,
+		Symbol:   "BTCUSDT",
+		Time:     types.NewMillisecondTimestampFromInt(1597026383085).Time(),
+		Bids:     bids,
+		Asks:     asks,
+		Checksum: -855196043,
 	}, book)
 }
 
@@ -30,6 +30,7 @@ type WebsocketLogin struct {
 //go:generate callbackgen -type Stream -interface
 type Stream struct {
 	types.StandardStream
+	types.FuturesSettings
 
 	client *okexapi.RestClient
 
@@ -39,12 +40,16 @@ type Stream struct {
 	accountEventCallbacks      []func(account okexapi.Account)
 	orderDetailsEventCallbacks []func(orderDetails []okexapi.OrderDetails)
 	marketTradeEventCallbacks  []func(tradeDetail []MarketTradeEvent)
+	positionEventCallbacks     []func(positions []okexapi.Position)
+
+	rawBookLevels *rawBookLevels
 }
 
 func NewStream(client *okexapi.RestClient) *Stream {
 	stream := &Stream{
 		client:         client,
 		StandardStream: types.NewStandardStream(),
+		rawBookLevels:  newRawBookLevels(),
 	}
 
 	stream.SetParser(parseWebSocketEvent)
@@ -56,6 +61,7 @@ func NewStream(client *okexapi.RestClient) *Stream {
 	stream.OnAccountEvent(stream.handleAccountEvent)
 	stream.OnMarketTradeEvent(stream.handleMarketTradeEvent)
 	stream.OnOrderDetailsEvent(stream.handleOrderDetailsEvent)
+	stream.OnPositionEvent(stream.handlePositionEvent)
 	stream.OnConnect(stream.handleConnect)
 	stream.OnAuth(stream.handleAuth)
 	return stream
@@ -69,7 +75,7 @@ func (s *Stream) syncSubscriptions(opType WsEventType) error {
 	logger := log.WithField("opType", opType)
 	var topics []WebsocketSubscription
 	for _, subscription := range s.Subscriptions {
-		topic, err := convertSubscription(subscription)
+		topic, err := s.convertSubscription(subscription)
 		if err != nil {
 			logger.WithError(err).Errorf("convert error, subscription: %+v", subscription)
 			return err
@@ -103,7 +109,7 @@ func (s *Stream) handleConnect() {
 	if s.PublicOnly {
 		var subs []WebsocketSubscription
 		for _, subscription := range s.Subscriptions {
-			sub, err := convertSubscription(subscription)
+			sub, err := s.convertSubscription(subscription)
 			if err != nil {
 				log.WithError(err).Errorf("subscription convert error")
 				continue
@@ -151,10 +157,36 @@ func (s *Stream) handleConnect() {
 	}
 }
 
+// convertSubscription converts the subscription with the swap instrument ID in the futures mode
+func (s *Stream) convertSubscription(sub types.Subscription) (WebsocketSubscription, error) {
+	topic, err := convertSubscription(sub)
+	if err != nil {
+		return topic, err
+	}
+
+	if s.IsFutures {
+		topic.InstrumentID = toLocalSwapSymbol(sub.Symbol)
+	}
+
+	return topic, nil
+}
+
 func (s *Stream) handleAuth() {
 	var subs = []WebsocketSubscription{
 		{Channel: ChannelAccount},
-		{Channel: "orders", InstrumentType: string(okexapi.InstrumentTypeSpot)},
+	}
+
+	if s.IsFutures {
+		if err := loadSwapContractValues(context.Background(), s.client); err != nil {
+			log.WithError(err).Error("unable to load the contract values of the swap instruments")
+		}
+
+		subs = append(subs,
+			WebsocketSubscription{Channel: ChannelOrders, InstrumentType: string(okexapi.InstrumentTypeSwap)},
+			WebsocketSubscription{Channel: ChannelPositions, InstrumentType: string(okexapi.InstrumentTypeSwap)},
+		)
+	} else {
+		subs = append(subs, WebsocketSubscription{Channel: ChannelOrders, InstrumentType: string(okexapi.InstrumentTypeSpot)})
 	}
 
 	log.Infof("subscribing private channels: %+v", subs)
@@ -189,12 +221,18 @@ func (s *Stream) handleOrderDetailsEvent(orderDetails []okexapi.OrderDetails) {
 	}
 }
 
+func (s *Stream) handlePositionEvent(positions []okexapi.Position) {
+	s.EmitFuturesPositionUpdate(toGlobalFuturesPositions(positions))
+}
+
 func (s *Stream) handleAccountEvent(account okexapi.Account) {
 	balances := toGlobalBalance(&account)
 	s.EmitBalanceUpdate(balances)
 }
 
 func (s *Stream) handleBookEvent(data BookEvent) {
+	s.rawBookLevels.update(data)
+
 	book := data.Book()
 	switch data.Action {
 	case ActionTypeSnapshot:
@@ -268,5 +306,8 @@ func (s *Stream) dispatchEvent(e interface{}) {
 	case []MarketTradeEvent:
 		s.EmitMarketTradeEvent(et)
 
+	case []okexapi.Position:
+		s.EmitPositionEvent(et)
+
 	}
 }
@@ -56,6 +56,16 @@ func (s *Stream) EmitMarketTradeEvent(tradeDetail []MarketTradeEvent) {
 	}
 }
 
+func (s *Stream) OnPositionEvent(cb func(positions []okexapi.Position)) {
+	s.positionEventCallbacks = append(s.positionEventCallbacks, cb)
+}
+
+func (s *Stream) EmitPositionEvent(positions []okexapi.Position) {
+	for _, cb := range s.positionEventCallbacks {
+		cb(positions)
+	}
+}
+
 type StreamEventHub interface {
 	OnKLineEvent(cb func(candle KLineEvent))
 
@@ -66,4 +76,6 @@ type StreamEventHub interface {
 	OnOrderDetailsEvent(cb func(orderDetails []okexapi.OrderDetails))
 
 	OnMarketTradeEvent(cb func(tradeDetail []MarketTradeEvent))
+
+	OnPositionEvent(cb func(positions []okexapi.Position))
 }
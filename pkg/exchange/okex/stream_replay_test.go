@@ -0,0 +1,49 @@
+package okex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+func TestStream_ReplayBooks(t *testing.T) {
+	reader, err := types.OpenCaptureFile("testdata/books.jsonl.gz")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	stream := NewStream(nil)
+	replay, err := types.NewReplayStream(stream, reader)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer replay.Close()
+
+	book := types.NewMutexOrderBook("BTCUSDT")
+	var checksum int64
+	replay.OnBookSnapshot(func(snapshot types.SliceOrderBook) {
+		book.Load(snapshot)
+		checksum = snapshot.Checksum
+	})
+	replay.OnBookUpdate(func(update types.SliceOrderBook) {
+		book.Update(update)
+		checksum = update.Checksum
+	})
+
+	assert.NoError(t, replay.Replay(context.Background()))
+
+	assert.Equal(t, types.PriceVolumeSlice{
+		{Price: fixedpoint.NewFromFloat(8476), Volume: fixedpoint.NewFromFloat(256)},
+		{Price: fixedpoint.NewFromFloat(8475.5), Volume: fixedpoint.NewFromFloat(10)},
+	}, book.SideBook(types.SideTypeBuy))
+	assert.Equal(t, types.PriceVolumeSlice{
+		{Price: fixedpoint.NewFromFloat(8477), Volume: fixedpoint.NewFromFloat(7)},
+	}, book.SideBook(types.SideTypeSell))
+
+	market := types.Market{Symbol: "BTCUSDT"}
+	assert.Equal(t, checksum, stream.BookChecksum(market, book.SideBook(types.SideTypeBuy), book.SideBook(types.SideTypeSell)))
+}
@@ -0,0 +1,351 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/c9s/bbgo/pkg/types"
+	"github.com/c9s/bbgo/pkg/util"
+)
+
+// Priority is the priority of the request, the high priority requests like order cancellations
+// are allowed to use the reserved weight.
+type Priority int
+
+const (
+	PriorityNormal Priority = iota
+	PriorityHigh
+)
+
+func (p Priority) String() string {
+	if p == PriorityHigh {
+		return "high"
+	}
+
+	return "normal"
+}
+
+// Config is the weight limit of an exchange account in the interval
+type Config struct {
+	// Limit is the max request weight in the interval
+	Limit int `json:"limit" yaml:"limit"`
+
+	// Interval is the window size of the weight limit, the window is aligned to the interval like the exchange does
+	Interval types.Duration `json:"interval" yaml:"interval"`
+
+	// Reserved is the weight that is only available to the high priority requests
+	Reserved int `json:"reserved,omitempty" yaml:"reserved,omitempty"`
+
+	// DefaultWeight is the weight of the endpoints that are not defined in the weights
+	DefaultWeight int `json:"defaultWeight,omitempty" yaml:"defaultWeight,omitempty"`
+
+	// Weights is the request weight of each endpoint
+	Weights map[string]int `json:"weights,omitempty" yaml:"weights,omitempty"`
+}
+
+// UnmarshalYAML decodes the config with the JSON decoder, so that the interval could be written like "1m"
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw map[string]interface{}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, c)
+}
+
+// Merge overrides the config fields with the non-zero fields of the given config
+func (c Config) Merge(o Config) Config {
+	if o.Limit > 0 {
+		c.Limit = o.Limit
+	}
+
+	if o.Interval > 0 {
+		c.Interval = o.Interval
+	}
+
+	if o.Reserved > 0 {
+		c.Reserved = o.Reserved
+	}
+
+	if o.DefaultWeight > 0 {
+		c.DefaultWeight = o.DefaultWeight
+	}
+
+	if len(o.Weights) > 0 {
+		weights := make(map[string]int, len(c.Weights)+len(o.Weights))
+		for endpoint, w := range c.Weights {
+			weights[endpoint] = w
+		}
+
+		for endpoint, w := range o.Weights {
+			weights[endpoint] = w
+		}
+
+		c.Weights = weights
+	}
+
+	return c
+}
+
+func (c Config) Validate() error {
+	if c.Limit <= 0 {
+		return fmt.Errorf("rate limit should be positive, got %d", c.Limit)
+	}
+
+	if c.Interval <= 0 {
+		return fmt.Errorf("rate limit interval should be positive, got %s", c.Interval.Duration())
+	}
+
+	if c.Reserved < 0 || c.Reserved >= c.Limit {
+		return fmt.Errorf("reserved weight %d should be less than the limit %d", c.Reserved, c.Limit)
+	}
+
+	return nil
+}
+
+// Weight returns the weight of the endpoint
+func (c Config) Weight(endpoint string) int {
+	if w, ok := c.Weights[endpoint]; ok {
+		return w
+	}
+
+	if c.DefaultWeight > 0 {
+		return c.DefaultWeight
+	}
+
+	return 1
+}
+
+// Key is the identity of the rate limiter, the limiter is shared by all the exchange instances
+// that use the same credential, or send the requests to the same host, in the same scope.
+type Key struct {
+	Exchange types.ExchangeName
+
+	// Scope is the API group that has its own limit, e.g., spot and futures
+	Scope string
+
+	// Account is what the exchange counts the weight by, the hash of the API key, or the API host if the
+	// exchange counts the weight by IP. The API key itself is not kept here.
+	Account string
+}
+
+func NewKey(exchange types.ExchangeName, scope, apiKey string) Key {
+	account := "public"
+	if len(apiKey) > 0 {
+		account = strconv.FormatUint(uint64(util.FNV32(apiKey)), 16)
+	}
+
+	return Key{Exchange: exchange, Scope: scope, Account: account}
+}
+
+// NewHostKey returns the key for the exchanges that count the request weight by IP, e.g., binance.
+// All the requests of the process are assumed to be sent from the same IP, so the limiter is shared by all the
+// API keys that send the requests to the host of the base URL.
+func NewHostKey(exchange types.ExchangeName, scope, baseURL string) Key {
+	host := baseURL
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	return Key{Exchange: exchange, Scope: scope, Account: host}
+}
+
+func (k Key) String() string {
+	return string(k.Exchange) + ":" + k.Scope + ":" + k.Account
+}
+
+// Limiter is a weight based rate limiter with a fixed window. The used weight could be updated by the
+// used weight reported by the exchange, so that the requests from the other processes that use the same
+// credential are counted as well.
+type Limiter struct {
+	key Key
+
+	mu           sync.Mutex
+	config       Config
+	windowStart  time.Time
+	used         int
+	blockedUntil time.Time
+
+	// now is the clock of the limiter, it's replaced in the tests
+	now func() time.Time
+}
+
+func newLimiter(key Key, config Config) *Limiter {
+	return &Limiter{
+		key:    key,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+func (l *Limiter) Key() Key {
+	return l.key
+}
+
+// Config returns a copy of the current config
+func (l *Limiter) Config() Config {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.config
+}
+
+// Configure overrides the current config with the non-zero fields of the given config
+func (l *Limiter) Configure(config Config) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	merged := l.config.Merge(config)
+	if err := merged.Validate(); err != nil {
+		return err
+	}
+
+	l.config = merged
+	return nil
+}
+
+// Wait blocks until the weight of the endpoint is available
+func (l *Limiter) Wait(ctx context.Context, endpoint string, priority Priority) error {
+	return l.WaitN(ctx, l.Config().Weight(endpoint), priority)
+}
+
+// WaitN blocks until the given weight is available
+func (l *Limiter) WaitN(ctx context.Context, weight int, priority Priority) error {
+	startTime := time.Now()
+	defer func() {
+		if d := time.Since(startTime); d > 0 {
+			labels := l.labels()
+			labels["priority"] = priority.String()
+			metricsWaitSeconds.With(labels).Add(d.Seconds())
+		}
+	}()
+
+	for {
+		delay := l.reserve(weight, priority)
+		if delay == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes the weight if it's available, otherwise it returns the delay to the next window
+func (l *Limiter) reserve(weight int, priority Priority) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Before(l.blockedUntil) {
+		return l.blockedUntil.Sub(now)
+	}
+
+	l.rollWindow(now)
+
+	capacity := l.config.Limit
+	if priority != PriorityHigh {
+		capacity -= l.config.Reserved
+	}
+
+	// a request that is heavier than the capacity is allowed in an empty window, or it would wait forever
+	if l.used+weight > capacity && l.used > 0 {
+		return l.windowStart.Add(l.config.Interval.Duration()).Sub(now)
+	}
+
+	l.used += weight
+	l.updateMetrics()
+	return 0
+}
+
+// UpdateUsedWeight updates the used weight of the current window by the weight reported by the exchange
+func (l *Limiter) UpdateUsedWeight(used int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rollWindow(l.now())
+	if used > l.used {
+		l.used = used
+	}
+
+	l.updateMetrics()
+}
+
+// BlockUntil blocks all the requests until the given time, it's used when the exchange rejects the requests
+// for exceeding the rate limit.
+func (l *Limiter) BlockUntil(t time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if t.After(l.blockedUntil) {
+		l.blockedUntil = t
+	}
+
+	metricsBlockedTotal.With(l.labels()).Inc()
+}
+
+// Used returns the used weight of the current window
+func (l *Limiter) Used() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rollWindow(l.now())
+	return l.used
+}
+
+// Utilization returns the ratio of the used weight to the limit of the current window
+func (l *Limiter) Utilization() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rollWindow(l.now())
+	return l.utilization()
+}
+
+func (l *Limiter) utilization() float64 {
+	if l.config.Limit <= 0 {
+		return 0
+	}
+
+	return float64(l.used) / float64(l.config.Limit)
+}
+
+func (l *Limiter) rollWindow(now time.Time) {
+	windowStart := now.Truncate(l.config.Interval.Duration())
+	if windowStart.After(l.windowStart) {
+		l.windowStart = windowStart
+		l.used = 0
+		l.updateMetrics()
+	}
+}
+
+func (l *Limiter) updateMetrics() {
+	labels := l.labels()
+	metricsUsedWeight.With(labels).Set(float64(l.used))
+	metricsUtilization.With(labels).Set(l.utilization())
+}
+
+func (l *Limiter) labels() prometheus.Labels {
+	return prometheus.Labels{
+		"exchange": string(l.key.Exchange),
+		"scope":    l.key.Scope,
+		"account":  l.key.Account,
+	}
+}
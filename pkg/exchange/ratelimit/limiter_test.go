@@ -0,0 +1,159 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+func newTestLimiter(config Config, now time.Time) *Limiter {
+	l := newLimiter(NewKey("test", "spot", "key"), config)
+	l.now = func() time.Time { return now }
+	return l
+}
+
+func TestLimiter_Reserve(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 30, 0, time.UTC)
+	l := newTestLimiter(Config{
+		Limit:    10,
+		Interval: types.Duration(time.Minute),
+		Reserved: 3,
+		Weights:  map[string]int{"/order": 2},
+	}, now)
+
+	// normal priority requests could use up to 7 weight
+	assert.Equal(t, time.Duration(0), l.reserve(l.config.Weight("/order"), PriorityNormal))
+	assert.Equal(t, time.Duration(0), l.reserve(5, PriorityNormal))
+	assert.Equal(t, 30*time.Second, l.reserve(1, PriorityNormal))
+
+	// the reserved weight is available to the high priority requests
+	assert.Equal(t, time.Duration(0), l.reserve(3, PriorityHigh))
+	assert.Equal(t, 30*time.Second, l.reserve(1, PriorityHigh))
+	assert.Equal(t, 1.0, l.Utilization())
+
+	// the weight is released in the next window
+	l.now = func() time.Time { return now.Add(30 * time.Second) }
+	assert.Equal(t, 0, l.Used())
+	assert.Equal(t, time.Duration(0), l.reserve(1, PriorityNormal))
+}
+
+func TestLimiter_UpdateUsedWeight(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newTestLimiter(Config{Limit: 100, Interval: types.Duration(time.Minute)}, now)
+
+	assert.Equal(t, time.Duration(0), l.reserve(10, PriorityNormal))
+
+	// the used weight reported by the exchange includes the requests of the other processes
+	l.UpdateUsedWeight(95)
+	assert.Equal(t, 95, l.Used())
+	assert.Equal(t, time.Minute, l.reserve(10, PriorityNormal))
+
+	// the local counter is kept if it's larger than the reported weight
+	l.UpdateUsedWeight(20)
+	assert.Equal(t, 95, l.Used())
+}
+
+func TestLimiter_WaitN(t *testing.T) {
+	l := newLimiter(NewKey("test", "spot", "wait"), Config{Limit: 1, Interval: types.Duration(time.Hour)})
+
+	ctx := context.Background()
+	assert.NoError(t, l.WaitN(ctx, 1, PriorityNormal))
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.WaitN(ctx, 1, PriorityNormal), context.DeadlineExceeded)
+}
+
+func TestLimiter_Configure(t *testing.T) {
+	l := newLimiter(NewKey("test", "spot", "configure"), Config{
+		Limit:    10,
+		Interval: types.Duration(time.Minute),
+		Weights:  map[string]int{"/order": 2, "/trades": 5},
+	})
+
+	assert.NoError(t, l.Configure(Config{Limit: 20, Weights: map[string]int{"/trades": 10}}))
+
+	config := l.Config()
+	assert.Equal(t, 20, config.Limit)
+	assert.Equal(t, types.Duration(time.Minute), config.Interval)
+	assert.Equal(t, 2, config.Weight("/order"))
+	assert.Equal(t, 10, config.Weight("/trades"))
+	assert.Equal(t, 1, config.Weight("/ticker"))
+
+	assert.Error(t, l.Configure(Config{Reserved: 30}))
+	assert.Equal(t, 20, l.Config().Limit)
+}
+
+func TestGet(t *testing.T) {
+	key := NewKey("test", "futures", "shared")
+	l1 := Get(key, Config{Limit: 10, Interval: types.Duration(time.Minute)})
+	l2 := Get(NewKey("test", "futures", "shared"), Config{Limit: 20, Interval: types.Duration(time.Minute)})
+	assert.Same(t, l1, l2)
+	assert.Equal(t, 10, l2.Config().Limit)
+
+	assert.NotEqual(t, "shared", key.Account)
+	assert.Equal(t, "public", NewKey("test", "futures", "").Account)
+}
+
+func TestNewHostKey(t *testing.T) {
+	key := NewHostKey("test", "spot", "https://api.binance.com")
+	assert.Equal(t, "api.binance.com", key.Account)
+	assert.Equal(t, key, NewHostKey("test", "spot", "https://api.binance.com/"))
+	assert.NotEqual(t, key, NewHostKey("test", "spot", "https://api.binance.us"))
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Used-Weight", "42")
+		if r.URL.Path == "/banned" {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+	}))
+	defer server.Close()
+
+	l := newLimiter(NewKey("test", "spot", "transport"), Config{Limit: 100, Interval: types.Duration(time.Minute)})
+	client := &http.Client{Transport: NewTransport(nil, l, UsedWeightHeaderParser("X-Used-Weight"))}
+
+	resp, err := client.Get(server.URL + "/ok")
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+	assert.Equal(t, 42, l.Used())
+
+	resp, err = client.Get(server.URL + "/banned")
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+
+	delay := l.reserve(1, PriorityHigh)
+	assert.True(t, delay > time.Minute && delay <= 2*time.Minute, "delay: %s", delay)
+}
+
+func TestConfig_UnmarshalYAML(t *testing.T) {
+	var configs map[string]Config
+	err := yaml.Unmarshal([]byte(`
+spot:
+  limit: 3000
+  interval: 1m
+  reserved: 300
+  weights:
+    /api/v3/order: 2
+`), &configs)
+	if assert.NoError(t, err) {
+		assert.Equal(t, Config{
+			Limit:    3000,
+			Interval: types.Duration(time.Minute),
+			Reserved: 300,
+			Weights:  map[string]int{"/api/v3/order": 2},
+		}, configs["spot"])
+	}
+}
@@ -0,0 +1,63 @@
+package ratelimit
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	metricsUsedWeight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "bbgo_ratelimit_used_weight",
+			Help: "the used request weight of the current rate limit window",
+		},
+		[]string{
+			"exchange", // exchange name
+			"scope",    // api scope: spot or futures
+			"account",  // hash of the api key, or the api host
+		},
+	)
+
+	metricsUtilization = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "bbgo_ratelimit_utilization",
+			Help: "the ratio of the used request weight to the limit of the current rate limit window",
+		},
+		[]string{
+			"exchange", // exchange name
+			"scope",    // api scope: spot or futures
+			"account",  // hash of the api key, or the api host
+		},
+	)
+
+	metricsWaitSeconds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "bbgo_ratelimit_wait_seconds_total",
+			Help: "the total seconds of the requests waiting for the rate limiter",
+		},
+		[]string{
+			"exchange", // exchange name
+			"scope",    // api scope: spot or futures
+			"account",  // hash of the api key, or the api host
+			"priority", // request priority: normal or high
+		},
+	)
+
+	metricsBlockedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "bbgo_ratelimit_blocked_total",
+			Help: "the number of the rate limit responses from the exchange",
+		},
+		[]string{
+			"exchange", // exchange name
+			"scope",    // api scope: spot or futures
+			"account",  // hash of the api key, or the api host
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		metricsUsedWeight,
+		metricsUtilization,
+		metricsWaitSeconds,
+		metricsBlockedTotal,
+	)
+}
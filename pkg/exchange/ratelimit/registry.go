@@ -0,0 +1,30 @@
+package ratelimit
+
+import "sync"
+
+var registry = struct {
+	sync.Mutex
+	limiters map[Key]*Limiter
+}{
+	limiters: make(map[Key]*Limiter),
+}
+
+// Get returns the shared limiter of the key, the limiter is created with the default config if it does not exist
+func Get(key Key, defaultConfig Config) *Limiter {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if l, ok := registry.limiters[key]; ok {
+		return l
+	}
+
+	l := newLimiter(key, defaultConfig)
+	registry.limiters[key] = l
+	return l
+}
+
+// Provider is implemented by the exchanges that use the shared rate limiters, so that the
+// session could configure the limiters by the scope.
+type Provider interface {
+	RateLimiters() []*Limiter
+}
@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HeaderParser parses the used weight from the response header
+type HeaderParser func(header http.Header) (used int, ok bool)
+
+// UsedWeightHeaderParser returns a parser that reads the used weight from the given header
+func UsedWeightHeaderParser(name string) HeaderParser {
+	return func(header http.Header) (int, bool) {
+		v := header.Get(name)
+		if len(v) == 0 {
+			return 0, false
+		}
+
+		used, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, false
+		}
+
+		return used, true
+	}
+}
+
+// Transport updates the limiter by the rate limit headers and the rate limit status codes of the responses
+type Transport struct {
+	Base    http.RoundTripper
+	Limiter *Limiter
+	Parser  HeaderParser
+}
+
+func NewTransport(base http.RoundTripper, limiter *Limiter, parser HeaderParser) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &Transport{
+		Base:    base,
+		Limiter: limiter,
+		Parser:  parser,
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if t.Parser != nil {
+		if used, ok := t.Parser(resp.Header); ok {
+			t.Limiter.UpdateUsedWeight(used)
+		}
+	}
+
+	switch resp.StatusCode {
+	// 418 is returned by binance when the IP is banned for violating the rate limit
+	case http.StatusTooManyRequests, http.StatusTeapot:
+		t.Limiter.BlockUntil(time.Now().Add(retryAfter(resp.Header)))
+	}
+
+	return resp, nil
+}
+
+const defaultRetryAfter = time.Minute
+
+func retryAfter(header http.Header) time.Duration {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	return defaultRetryAfter
+}
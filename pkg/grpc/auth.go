@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/c9s/bbgo/pkg/interact"
+	"github.com/c9s/bbgo/pkg/pb"
+)
+
+const (
+	// AuthorizationMetadataKey is the metadata key of the bearer token
+	AuthorizationMetadataKey = "authorization"
+
+	// OTPMetadataKey is the metadata key of the time-based one-time password code
+	OTPMetadataKey = "x-otp"
+)
+
+// controlMethods are the full method names of the rpcs that change the strategies or the orders,
+// they are disabled if the auth is not configured
+var controlMethods = map[string]struct{}{
+	"/" + pb.StrategyService_ServiceDesc.ServiceName + "/SuspendStrategy": {},
+	"/" + pb.StrategyService_ServiceDesc.ServiceName + "/ResumeStrategy":  {},
+	"/" + pb.StrategyService_ServiceDesc.ServiceName + "/ClosePosition":   {},
+	"/" + pb.TradingService_ServiceDesc.ServiceName + "/SubmitOrder":      {},
+	"/" + pb.TradingService_ServiceDesc.ServiceName + "/CancelOrder":      {},
+}
+
+type authenticator struct {
+	auth *interact.AuthInteract
+}
+
+// authenticate verifies the bearer token and the one-time password code in the metadata.
+// All the rpcs are allowed except the control rpcs if the auth is not configured.
+func (a *authenticator) authenticate(ctx context.Context, fullMethod string) error {
+	if a.auth == nil {
+		if _, ok := controlMethods[fullMethod]; ok {
+			return status.Error(codes.PermissionDenied, "grpc authentication is not configured")
+		}
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var token, code string
+	if values := md.Get(AuthorizationMetadataKey); len(values) > 0 {
+		token = strings.TrimPrefix(values[0], "Bearer ")
+	}
+
+	if values := md.Get(OTPMetadataKey); len(values) > 0 {
+		code = values[0]
+	}
+
+	if err := a.auth.Verify(token, code); err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return nil
+}
+
+func (a *authenticator) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := a.authenticate(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (a *authenticator) streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := a.authenticate(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	return handler(srv, ss)
+}
@@ -72,8 +72,14 @@ func toOrderType(orderType pb.OrderType) types.OrderType {
 	switch orderType {
 	case pb.OrderType_MARKET:
 		return types.OrderTypeMarket
-	case pb.OrderType_LIMIT:
+	case pb.OrderType_LIMIT, pb.OrderType_IOC_LIMIT:
 		return types.OrderTypeLimit
+	case pb.OrderType_POST_ONLY:
+		return types.OrderTypeLimitMaker
+	case pb.OrderType_STOP_LIMIT:
+		return types.OrderTypeStopLimit
+	case pb.OrderType_STOP_MARKET:
+		return types.OrderTypeStopMarket
 
 	}
 
@@ -180,6 +186,8 @@ func transOrderType(orderType types.OrderType) pb.OrderType {
 		return pb.OrderType_LIMIT
 	case types.OrderTypeMarket:
 		return pb.OrderType_MARKET
+	case types.OrderTypeLimitMaker:
+		return pb.OrderType_POST_ONLY
 	case types.OrderTypeStopLimit:
 		return pb.OrderType_STOP_LIMIT
 	case types.OrderTypeStopMarket:
@@ -235,3 +243,93 @@ func transKLineResponse(session *bbgo.ExchangeSession, kline types.KLine) *pb.Ma
 		SubscribedAt: 0,
 	}
 }
+
+func transPosition(session *bbgo.ExchangeSession, position *types.Position) *pb.Position {
+	return &pb.Position{
+		Exchange:           session.ExchangeName.String(),
+		Symbol:             position.Symbol,
+		Base:               position.GetBase().String(),
+		Quote:              position.Quote.String(),
+		AverageCost:        position.AverageCost.String(),
+		Strategy:           position.Strategy,
+		StrategyInstanceId: position.StrategyInstanceID,
+	}
+}
+
+func transProfitStats(profitStats *types.ProfitStats) *pb.ProfitStats {
+	return &pb.ProfitStats{
+		Symbol:                 profitStats.Symbol,
+		QuoteCurrency:          profitStats.QuoteCurrency,
+		BaseCurrency:           profitStats.BaseCurrency,
+		AccumulatedPnl:         profitStats.AccumulatedPnL.String(),
+		AccumulatedNetProfit:   profitStats.AccumulatedNetProfit.String(),
+		AccumulatedGrossProfit: profitStats.AccumulatedGrossProfit.String(),
+		AccumulatedGrossLoss:   profitStats.AccumulatedGrossLoss.String(),
+		AccumulatedVolume:      profitStats.AccumulatedVolume.String(),
+		AccumulatedSince:       profitStats.AccumulatedSince,
+		TodayPnl:               profitStats.TodayPnL.String(),
+		TodayNetProfit:         profitStats.TodayNetProfit.String(),
+		TodayGrossProfit:       profitStats.TodayGrossProfit.String(),
+		TodayGrossLoss:         profitStats.TodayGrossLoss.String(),
+		TodaySince:             profitStats.TodaySince,
+	}
+}
+
+func transMarket(market types.Market) *pb.Market {
+	return &pb.Market{
+		Symbol:          market.Symbol,
+		BaseCurrency:    market.BaseCurrency,
+		QuoteCurrency:   market.QuoteCurrency,
+		TickSize:        market.TickSize.String(),
+		StepSize:        market.StepSize.String(),
+		MinNotional:     market.MinNotional.String(),
+		MinQuantity:     market.MinQuantity.String(),
+		PricePrecision:  int32(market.PricePrecision),
+		VolumePrecision: int32(market.VolumePrecision),
+	}
+}
+
+func transDepth(session *bbgo.ExchangeSession, book types.SliceOrderBook) *pb.Depth {
+	return &pb.Depth{
+		Exchange: session.ExchangeName.String(),
+		Symbol:   book.Symbol,
+		Asks:     transPriceVolume(book.Asks),
+		Bids:     transPriceVolume(book.Bids),
+	}
+}
+
+// toSubmitOrder converts the submit order from the external process,
+// unlike toSubmitOrders, it returns an error instead of panic when the numbers can not be parsed.
+func toSubmitOrder(pbOrder *pb.SubmitOrder) (*types.SubmitOrder, error) {
+	price, err := fixedpoint.NewFromString(pbOrder.Price)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price %q: %w", pbOrder.Price, err)
+	}
+
+	quantity, err := fixedpoint.NewFromString(pbOrder.Quantity)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quantity %q: %w", pbOrder.Quantity, err)
+	}
+
+	stopPrice, err := fixedpoint.NewFromString(pbOrder.StopPrice)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stop price %q: %w", pbOrder.StopPrice, err)
+	}
+
+	submitOrder := &types.SubmitOrder{
+		ClientOrderID: pbOrder.ClientOrderId,
+		Symbol:        pbOrder.Symbol,
+		Side:          toSide(pbOrder.Side),
+		Type:          toOrderType(pbOrder.OrderType),
+		Price:         price,
+		Quantity:      quantity,
+		StopPrice:     stopPrice,
+		GroupID:       uint32(pbOrder.GroupId),
+	}
+
+	if pbOrder.OrderType == pb.OrderType_IOC_LIMIT {
+		submitOrder.TimeInForce = types.TimeInForceIOC
+	}
+
+	return submitOrder, nil
+}
@@ -0,0 +1,310 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/pb"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+const defaultPluginReconnectDelay = 3 * time.Second
+
+const defaultPluginDialTimeout = 10 * time.Second
+
+// PluginCommandHandler executes the commands sent from the strategy plugin process
+type PluginCommandHandler interface {
+	SubmitOrders(ctx context.Context, submitOrders ...types.SubmitOrder) (types.OrderSlice, error)
+	CancelOrders(ctx context.Context, orderIDs ...uint64) error
+	CancelAllOrders(ctx context.Context) error
+	ClosePosition(ctx context.Context, percentage fixedpoint.Value, tag string) error
+}
+
+// PluginClient connects to an external strategy plugin process which implements StrategyPluginService.
+// The client keeps reconnecting to the plugin until the context is canceled,
+// the events are dropped when the plugin is not connected.
+type PluginClient struct {
+	Address            string
+	StrategyInstanceID string
+	ReconnectDelay     time.Duration
+
+	session *bbgo.ExchangeSession
+
+	mu     sync.Mutex
+	stream pb.StrategyPluginService_ConnectClient
+
+	connectCallbacks []func()
+
+	logger log.FieldLogger
+}
+
+func NewPluginClient(address, strategyInstanceID string, session *bbgo.ExchangeSession) *PluginClient {
+	return &PluginClient{
+		Address:            address,
+		StrategyInstanceID: strategyInstanceID,
+		ReconnectDelay:     defaultPluginReconnectDelay,
+		session:            session,
+		logger: log.WithFields(log.Fields{
+			"plugin":  address,
+			"session": session.Name,
+		}),
+	}
+}
+
+// OnConnect registers a callback that will be called after the plugin stream is connected,
+// usually the callback sends the INIT event.
+func (c *PluginClient) OnConnect(cb func()) {
+	c.connectCallbacks = append(c.connectCallbacks, cb)
+}
+
+func (c *PluginClient) emitConnect() {
+	for _, cb := range c.connectCallbacks {
+		cb()
+	}
+}
+
+// IsConnected returns true if the plugin stream is connected
+func (c *PluginClient) IsConnected() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stream != nil
+}
+
+// Run connects to the plugin and serves the plugin commands, it blocks until the context is canceled
+func (c *PluginClient) Run(ctx context.Context, handler PluginCommandHandler) {
+	for {
+		err := c.serve(ctx, handler)
+		if ctx.Err() != nil {
+			return
+		}
+
+		c.logger.WithError(err).Warnf("plugin disconnected, reconnecting in %s...", c.ReconnectDelay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.ReconnectDelay):
+		}
+	}
+}
+
+func (c *PluginClient) serve(ctx context.Context, handler PluginCommandHandler) error {
+	dialCtx, cancelDial := context.WithTimeout(ctx, defaultPluginDialTimeout)
+	defer cancelDial()
+
+	conn, err := grpc.DialContext(dialCtx, c.Address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock())
+	if err != nil {
+		return errors.Wrapf(err, "unable to dial plugin %s", c.Address)
+	}
+
+	defer func() {
+		if err := conn.Close(); err != nil {
+			c.logger.WithError(err).Error("plugin connection close error")
+		}
+	}()
+
+	stream, err := pb.NewStrategyPluginServiceClient(conn).Connect(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to connect plugin stream")
+	}
+
+	c.mu.Lock()
+	c.stream = stream
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		c.stream = nil
+		c.mu.Unlock()
+	}()
+
+	c.logger.Infof("plugin connected")
+	c.emitConnect()
+
+	for {
+		command, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		result := c.handleCommand(ctx, handler, command)
+		c.send(&pb.PluginEvent{
+			Type:   pb.PluginEventType_COMMAND_RESULT,
+			Result: result,
+		})
+	}
+}
+
+func (c *PluginClient) handleCommand(ctx context.Context, handler PluginCommandHandler, command *pb.PluginCommand) *pb.PluginCommandResult {
+	result := &pb.PluginCommandResult{CommandId: command.Id}
+
+	var err error
+	switch command.Type {
+
+	case pb.PluginCommandType_SUBMIT_ORDERS:
+		var submitOrders []types.SubmitOrder
+		for _, o := range command.SubmitOrders {
+			submitOrder, err2 := toSubmitOrder(o)
+			if err2 != nil {
+				err = err2
+				break
+			}
+
+			if len(submitOrder.Tag) == 0 {
+				submitOrder.Tag = command.Tag
+			}
+			submitOrders = append(submitOrders, *submitOrder)
+		}
+
+		if err == nil {
+			var createdOrders types.OrderSlice
+			createdOrders, err = handler.SubmitOrders(ctx, submitOrders...)
+			for _, createdOrder := range createdOrders {
+				result.Orders = append(result.Orders, transOrder(c.session, createdOrder))
+			}
+		}
+
+	case pb.PluginCommandType_CANCEL_ORDERS:
+		var orderIDs []uint64
+		for _, id := range command.OrderIds {
+			orderID, err2 := strconv.ParseUint(id, 10, 64)
+			if err2 != nil {
+				err = fmt.Errorf("invalid order id %q: %w", id, err2)
+				break
+			}
+			orderIDs = append(orderIDs, orderID)
+		}
+
+		if err == nil {
+			err = handler.CancelOrders(ctx, orderIDs...)
+		}
+
+	case pb.PluginCommandType_CANCEL_ALL_ORDERS:
+		err = handler.CancelAllOrders(ctx)
+
+	case pb.PluginCommandType_CLOSE_POSITION:
+		percentage := fixedpoint.One
+		if len(command.Percentage) > 0 {
+			percentage, err = fixedpoint.NewFromString(command.Percentage)
+		}
+
+		if err == nil {
+			err = handler.ClosePosition(ctx, percentage, command.Tag)
+		}
+
+	default:
+		err = fmt.Errorf("unsupported plugin command type: %s", command.Type)
+	}
+
+	if err != nil {
+		c.logger.WithError(err).Errorf("plugin command %s %s error", command.Id, command.Type)
+		result.Error = &pb.Error{ErrorMessage: err.Error()}
+	}
+
+	return result
+}
+
+func (c *PluginClient) send(event *pb.PluginEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stream == nil {
+		c.logger.Debugf("plugin is not connected, dropping event %s", event.Type)
+		return
+	}
+
+	event.Session = c.session.Name
+	event.StrategyInstanceId = c.StrategyInstanceID
+	if event.Time == 0 {
+		event.Time = time.Now().UnixMilli()
+	}
+
+	if err := c.stream.Send(event); err != nil {
+		c.logger.WithError(err).Errorf("unable to send plugin event %s", event.Type)
+	}
+}
+
+func (c *PluginClient) SendInit(market types.Market, position *types.Position, balances types.BalanceMap, params map[string]string) {
+	c.send(&pb.PluginEvent{
+		Type:     pb.PluginEventType_INIT,
+		Market:   transMarket(market),
+		Position: transPosition(c.session, position),
+		Balances: transBalances(c.session, balances),
+		Params:   params,
+	})
+}
+
+func (c *PluginClient) SendKLine(kline types.KLine) {
+	c.send(&pb.PluginEvent{
+		Type:  pb.PluginEventType_KLINE_CLOSED,
+		Time:  kline.EndTime.UnixMilli(),
+		Kline: transKLine(c.session, kline),
+	})
+}
+
+func (c *PluginClient) SendBookSnapshot(book types.SliceOrderBook) {
+	c.send(&pb.PluginEvent{
+		Type:  pb.PluginEventType_BOOK_SNAPSHOT,
+		Depth: transDepth(c.session, book),
+	})
+}
+
+func (c *PluginClient) SendBookUpdate(book types.SliceOrderBook) {
+	c.send(&pb.PluginEvent{
+		Type:  pb.PluginEventType_BOOK_UPDATE,
+		Depth: transDepth(c.session, book),
+	})
+}
+
+func (c *PluginClient) SendMarketTrade(trade types.Trade) {
+	c.send(&pb.PluginEvent{
+		Type:  pb.PluginEventType_MARKET_TRADE,
+		Trade: transTrade(c.session, trade),
+	})
+}
+
+func (c *PluginClient) SendOrderUpdate(order types.Order) {
+	c.send(&pb.PluginEvent{
+		Type:  pb.PluginEventType_ORDER_UPDATE,
+		Order: transOrder(c.session, order),
+	})
+}
+
+func (c *PluginClient) SendTradeUpdate(trade types.Trade) {
+	c.send(&pb.PluginEvent{
+		Type:  pb.PluginEventType_TRADE_UPDATE,
+		Trade: transTrade(c.session, trade),
+	})
+}
+
+func (c *PluginClient) SendPositionUpdate(position *types.Position) {
+	c.send(&pb.PluginEvent{
+		Type:     pb.PluginEventType_POSITION_UPDATE,
+		Position: transPosition(c.session, position),
+	})
+}
+
+func (c *PluginClient) SendBalanceUpdate(balances types.BalanceMap) {
+	c.send(&pb.PluginEvent{
+		Type:     pb.PluginEventType_BALANCE_UPDATE,
+		Balances: transBalances(c.session, balances),
+	})
+}
+
+func (c *PluginClient) SendShutdown() {
+	c.send(&pb.PluginEvent{
+		Type: pb.PluginEventType_SHUTDOWN,
+	})
+}
@@ -0,0 +1,146 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/pb"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// testPlugin sends a submit order command after receiving the INIT event
+type testPlugin struct {
+	pb.UnimplementedStrategyPluginServiceServer
+
+	events chan *pb.PluginEvent
+}
+
+func (p *testPlugin) Connect(stream pb.StrategyPluginService_ConnectServer) error {
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		p.events <- event
+
+		if event.Type == pb.PluginEventType_INIT {
+			err := stream.Send(&pb.PluginCommand{
+				Id:   "1",
+				Type: pb.PluginCommandType_SUBMIT_ORDERS,
+				SubmitOrders: []*pb.SubmitOrder{
+					{Side: pb.Side_BUY, OrderType: pb.OrderType_LIMIT, Price: "100", Quantity: "0.1"},
+				},
+				Tag: "test",
+			})
+			if err != nil {
+				return err
+			}
+
+			err = stream.Send(&pb.PluginCommand{
+				Id:         "2",
+				Type:       pb.PluginCommandType_CLOSE_POSITION,
+				Percentage: "abc",
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+type testCommandHandler struct {
+	submitOrders []types.SubmitOrder
+}
+
+func (h *testCommandHandler) SubmitOrders(ctx context.Context, submitOrders ...types.SubmitOrder) (types.OrderSlice, error) {
+	h.submitOrders = append(h.submitOrders, submitOrders...)
+
+	var orders types.OrderSlice
+	for i, submitOrder := range submitOrders {
+		orders = append(orders, types.Order{SubmitOrder: submitOrder, OrderID: uint64(i + 1)})
+	}
+	return orders, nil
+}
+
+func (h *testCommandHandler) CancelOrders(ctx context.Context, orderIDs ...uint64) error {
+	return nil
+}
+
+func (h *testCommandHandler) CancelAllOrders(ctx context.Context) error {
+	return nil
+}
+
+func (h *testCommandHandler) ClosePosition(ctx context.Context, percentage fixedpoint.Value, tag string) error {
+	return nil
+}
+
+func TestPluginClient(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	plugin := &testPlugin{events: make(chan *pb.PluginEvent, 10)}
+	server := grpc.NewServer()
+	pb.RegisterStrategyPluginServiceServer(server, plugin)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	session := &bbgo.ExchangeSession{}
+	session.Name = "test"
+
+	market := types.Market{Symbol: "BTCUSDT", BaseCurrency: "BTC", QuoteCurrency: "USDT"}
+	client := NewPluginClient(listener.Addr().String(), "plugin:test:BTCUSDT", session)
+	client.OnConnect(func() {
+		client.SendInit(market, types.NewPositionFromMarket(market), types.BalanceMap{}, map[string]string{"a": "b"})
+	})
+
+	handler := &testCommandHandler{}
+	go client.Run(ctx, handler)
+
+	nextEvent := func() *pb.PluginEvent {
+		select {
+		case e := <-plugin.events:
+			return e
+		case <-ctx.Done():
+			t.Fatal("timeout")
+		}
+		return nil
+	}
+
+	event := nextEvent()
+	assert.Equal(t, pb.PluginEventType_INIT, event.Type)
+	assert.Equal(t, "test", event.Session)
+	assert.Equal(t, "plugin:test:BTCUSDT", event.StrategyInstanceId)
+	assert.Equal(t, "BTCUSDT", event.Market.Symbol)
+	assert.Equal(t, "b", event.Params["a"])
+
+	event = nextEvent()
+	assert.Equal(t, pb.PluginEventType_COMMAND_RESULT, event.Type)
+	assert.Equal(t, "1", event.Result.CommandId)
+	assert.Nil(t, event.Result.Error)
+	if assert.Len(t, event.Result.Orders, 1) {
+		assert.Equal(t, "1", event.Result.Orders[0].Id)
+	}
+
+	if assert.Len(t, handler.submitOrders, 1) {
+		assert.Equal(t, types.SideTypeBuy, handler.submitOrders[0].Side)
+		assert.Equal(t, "test", handler.submitOrders[0].Tag)
+		assert.Equal(t, fixedpoint.NewFromFloat(0.1), handler.submitOrders[0].Quantity)
+	}
+
+	event = nextEvent()
+	assert.Equal(t, "2", event.Result.CommandId)
+	assert.NotNil(t, event.Result.Error)
+}
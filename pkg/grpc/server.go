@@ -4,16 +4,23 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 
 	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/interact"
 	"github.com/c9s/bbgo/pkg/pb"
+	"github.com/c9s/bbgo/pkg/service"
 	"github.com/c9s/bbgo/pkg/types"
 )
 
@@ -94,15 +101,195 @@ func (s *TradingService) CancelOrder(ctx context.Context, request *pb.CancelOrde
 }
 
 func (s *TradingService) QueryOrder(ctx context.Context, request *pb.QueryOrderRequest) (*pb.QueryOrderResponse, error) {
-	panic("implement me")
+	sessionName := request.Session
+
+	if len(sessionName) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "session name can not be empty")
+	}
+
+	if len(request.Id) == 0 && len(request.ClientOrderId) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "order id or client order id is required")
+	}
+
+	session, ok := s.Environ.Session(sessionName)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "session %s not found", sessionName)
+	}
+
+	// look up the order store first, the order store only keeps the open orders
+	for symbol, orderStore := range session.OrderStores() {
+		if len(request.Symbol) > 0 && symbol != request.Symbol {
+			continue
+		}
+
+		for _, order := range orderStore.Orders() {
+			if matchOrder(order, request.Id, request.ClientOrderId) {
+				return &pb.QueryOrderResponse{Order: transOrder(session, order)}, nil
+			}
+		}
+	}
+
+	queryService, ok := session.Exchange.(types.ExchangeOrderQueryService)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "order %s%s not found", request.Id, request.ClientOrderId)
+	}
+
+	order, err := queryService.QueryOrder(ctx, types.OrderQuery{
+		Symbol:        request.Symbol,
+		OrderID:       request.Id,
+		ClientOrderID: request.ClientOrderId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.QueryOrderResponse{Order: transOrder(session, *order)}, nil
 }
 
 func (s *TradingService) QueryOrders(ctx context.Context, request *pb.QueryOrdersRequest) (*pb.QueryOrdersResponse, error) {
-	panic("implement me")
+	sessionName := request.Session
+
+	if len(sessionName) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "session name can not be empty")
+	}
+
+	session, ok := s.Environ.Session(sessionName)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "session %s not found", sessionName)
+	}
+
+	var states = make(map[types.OrderStatus]struct{})
+	for _, state := range request.State {
+		states[types.OrderStatus(strings.ToUpper(state))] = struct{}{}
+	}
+
+	var orders []types.Order
+	for symbol, orderStore := range session.OrderStores() {
+		if len(request.Symbol) > 0 && symbol != request.Symbol {
+			continue
+		}
+
+		for _, order := range orderStore.Orders() {
+			if len(states) > 0 {
+				if _, ok := states[order.Status]; !ok {
+					continue
+				}
+			}
+
+			if request.GroupId != 0 && int64(order.GroupID) != request.GroupId {
+				continue
+			}
+
+			orders = append(orders, order)
+		}
+	}
+
+	desc := strings.EqualFold(request.OrderBy, "desc")
+	sort.Slice(orders, func(i, j int) bool {
+		a, b := orders[i].CreationTime.Time(), orders[j].CreationTime.Time()
+		if a.Equal(b) {
+			return (orders[i].OrderID < orders[j].OrderID) != desc
+		}
+		return a.Before(b) != desc
+	})
+
+	offset, limit := paginate(request.Pagination, request.Page, request.Limit, request.Offset)
+	resp := &pb.QueryOrdersResponse{}
+	for i := offset; i < len(orders) && (limit == 0 || i < offset+limit); i++ {
+		resp.Orders = append(resp.Orders, transOrder(session, orders[i]))
+	}
+
+	return resp, nil
 }
 
 func (s *TradingService) QueryTrades(ctx context.Context, request *pb.QueryTradesRequest) (*pb.QueryTradesResponse, error) {
-	panic("implement me")
+	if s.Environ.TradeService == nil {
+		return nil, status.Error(codes.FailedPrecondition, "database is not configured")
+	}
+
+	exchangeName, err := types.ValidExchangeName(request.Exchange)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var session *bbgo.ExchangeSession
+	for _, sess := range s.Environ.Sessions() {
+		if sess.ExchangeName == exchangeName {
+			session = sess
+			break
+		}
+	}
+
+	if session == nil {
+		return nil, status.Errorf(codes.NotFound, "session of exchange %s not found", exchangeName)
+	}
+
+	options := service.QueryTradesOptions{
+		Exchange: exchangeName,
+		Symbol:   request.Symbol,
+		Ordering: "ASC",
+	}
+
+	if strings.EqualFold(request.OrderBy, "desc") {
+		options.Ordering = "DESC"
+	}
+
+	from := request.From
+	if from == 0 {
+		from = request.Timestamp
+	}
+
+	if from != 0 {
+		since := time.UnixMilli(from)
+		options.Since = &since
+	}
+
+	if request.To != 0 {
+		until := time.UnixMilli(request.To)
+		options.Until = &until
+	}
+
+	offset, limit := paginate(request.Pagination, request.Page, request.Limit, request.Offset)
+	options.Offset = uint64(offset)
+	options.Limit = uint64(limit)
+
+	trades, err := s.Environ.TradeService.Query(options)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.QueryTradesResponse{}
+	for _, trade := range trades {
+		resp.Trades = append(resp.Trades, transTrade(session, trade))
+	}
+
+	return resp, nil
+}
+
+// matchOrder matches the order by the order id, the order uuid or the client order id
+func matchOrder(order types.Order, id, clientOrderID string) bool {
+	if len(id) > 0 && (strconv.FormatUint(order.OrderID, 10) == id || order.UUID == id) {
+		return true
+	}
+
+	return len(clientOrderID) > 0 && order.ClientOrderID == clientOrderID
+}
+
+// paginate returns the offset and the limit of the request, the page starts from 1,
+// and it's only used if the pagination is enabled and the offset is not set
+func paginate(pagination bool, page, limit, offset int64) (int, int) {
+	if limit < 0 {
+		limit = 0
+	}
+
+	if offset <= 0 {
+		offset = 0
+		if pagination && page > 1 {
+			offset = (page - 1) * limit
+		}
+	}
+
+	return int(offset), int(limit)
 }
 
 type UserDataService struct {
@@ -318,15 +505,34 @@ type Server struct {
 	Config  *bbgo.Config
 	Environ *bbgo.Environment
 	Trader  *bbgo.Trader
+
+	// Auth authenticates the requests with the "authorization" and the "x-otp" metadata,
+	// the strategy control rpcs are disabled if it's nil
+	Auth *interact.AuthInteract
+
+	// CertFile and KeyFile are the TLS certificate and key files, TLS is enabled if both of them are set
+	CertFile, KeyFile string
 }
 
-func (s *Server) ListenAndServe(bind string) error {
-	conn, err := net.Listen("tcp", bind)
-	if err != nil {
-		return errors.Wrapf(err, "failed to bind network at %s", bind)
+func (s *Server) newGrpcServer() (*grpc.Server, error) {
+	a := &authenticator{auth: s.Auth}
+	options := []grpc.ServerOption{
+		grpc.UnaryInterceptor(a.unaryInterceptor),
+		grpc.StreamInterceptor(a.streamInterceptor),
 	}
 
-	var grpcServer = grpc.NewServer()
+	if len(s.CertFile) > 0 || len(s.KeyFile) > 0 {
+		creds, err := credentials.NewServerTLSFromFile(s.CertFile, s.KeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load grpc tls credentials")
+		}
+
+		options = append(options, grpc.Creds(creds))
+	} else if s.Auth != nil {
+		log.Warn("grpc server authentication is enabled without TLS, the token is sent in plain text")
+	}
+
+	var grpcServer = grpc.NewServer(options...)
 	pb.RegisterMarketDataServiceServer(grpcServer, &MarketDataService{
 		Config:  s.Config,
 		Environ: s.Environ,
@@ -345,7 +551,30 @@ func (s *Server) ListenAndServe(bind string) error {
 		Trader:  s.Trader,
 	})
 
+	pb.RegisterStrategyServiceServer(grpcServer, &StrategyService{
+		Config:  s.Config,
+		Environ: s.Environ,
+		Trader:  s.Trader,
+	})
+
 	reflection.Register(grpcServer)
+	return grpcServer, nil
+}
+
+func (s *Server) ListenAndServe(bind string) error {
+	conn, err := net.Listen("tcp", bind)
+	if err != nil {
+		return errors.Wrapf(err, "failed to bind network at %s", bind)
+	}
+
+	return s.Serve(conn)
+}
+
+func (s *Server) Serve(conn net.Listener) error {
+	grpcServer, err := s.newGrpcServer()
+	if err != nil {
+		return err
+	}
 
 	if err := grpcServer.Serve(conn); err != nil {
 		return errors.Wrap(err, "failed to serve grpc connections")
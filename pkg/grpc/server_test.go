@@ -0,0 +1,226 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/core"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/interact"
+	"github.com/c9s/bbgo/pkg/pb"
+	"github.com/c9s/bbgo/pkg/types"
+	"github.com/c9s/bbgo/pkg/types/mocks"
+)
+
+type testStrategy struct {
+	Symbol   string `json:"symbol"`
+	Position *types.Position
+
+	status types.StrategyStatus
+}
+
+func (s *testStrategy) ID() string { return "test" }
+
+func (s *testStrategy) InstanceID() string { return "test:" + s.Symbol }
+
+func (s *testStrategy) Run(ctx context.Context, orderExecutor bbgo.OrderExecutor, session *bbgo.ExchangeSession) error {
+	return nil
+}
+
+func (s *testStrategy) GetStatus() types.StrategyStatus { return s.status }
+
+func (s *testStrategy) Suspend() error {
+	s.status = types.StrategyStatusStopped
+	return nil
+}
+
+func (s *testStrategy) Resume() error {
+	s.status = types.StrategyStatusRunning
+	return nil
+}
+
+func newTestServer(t *testing.T, auth *interact.AuthInteract) (*grpc.ClientConn, *testStrategy) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockEx := mocks.NewMockExchange(mockCtrl)
+	mockEx.EXPECT().NewStream().Return(&types.StandardStream{}).Times(2)
+
+	environ := bbgo.NewEnvironment()
+	session := environ.AddExchange("binance", mockEx)
+
+	orderStore := core.NewOrderStore("BTCUSDT")
+	now := time.Now()
+	for i := 1; i <= 3; i++ {
+		orderStore.Add(types.Order{
+			SubmitOrder: types.SubmitOrder{
+				Symbol:        "BTCUSDT",
+				Side:          types.SideTypeBuy,
+				Type:          types.OrderTypeLimit,
+				ClientOrderID: "client-" + string(rune('0'+i)),
+				GroupID:       uint32(i % 2),
+			},
+			OrderID:      uint64(i),
+			Status:       types.OrderStatusNew,
+			CreationTime: types.Time(now.Add(time.Duration(i) * time.Second)),
+		})
+	}
+	session.OrderStores()["BTCUSDT"] = orderStore
+
+	market := types.Market{Symbol: "BTCUSDT", BaseCurrency: "BTC", QuoteCurrency: "USDT"}
+	strategy := &testStrategy{Symbol: "BTCUSDT", Position: types.NewPositionFromMarket(market), status: types.StrategyStatusRunning}
+
+	trader := bbgo.NewTrader(environ)
+	assert.NoError(t, trader.AttachStrategyOn("binance", strategy))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	server := &Server{Environ: environ, Trader: trader, Auth: auth}
+	grpcServer, err := server.newGrpcServer()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn, strategy
+}
+
+func TestTradingService_QueryOrders(t *testing.T) {
+	conn, _ := newTestServer(t, nil)
+	client := pb.NewTradingServiceClient(conn)
+	ctx := context.Background()
+
+	resp, err := client.QueryOrders(ctx, &pb.QueryOrdersRequest{Session: "binance", OrderBy: "desc"})
+	if assert.NoError(t, err) && assert.Len(t, resp.Orders, 3) {
+		assert.Equal(t, "3", resp.Orders[0].Id)
+		assert.Equal(t, "1", resp.Orders[2].Id)
+	}
+
+	resp, err = client.QueryOrders(ctx, &pb.QueryOrdersRequest{Session: "binance", GroupId: 1})
+	if assert.NoError(t, err) && assert.Len(t, resp.Orders, 2) {
+		assert.Equal(t, "1", resp.Orders[0].Id)
+		assert.Equal(t, "3", resp.Orders[1].Id)
+	}
+
+	resp, err = client.QueryOrders(ctx, &pb.QueryOrdersRequest{Session: "binance", Pagination: true, Page: 2, Limit: 2})
+	if assert.NoError(t, err) && assert.Len(t, resp.Orders, 1) {
+		assert.Equal(t, "3", resp.Orders[0].Id)
+	}
+
+	resp, err = client.QueryOrders(ctx, &pb.QueryOrdersRequest{Session: "binance", State: []string{"filled"}})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Orders)
+
+	order, err := client.QueryOrder(ctx, &pb.QueryOrderRequest{Session: "binance", ClientOrderId: "client-2"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "2", order.Order.Id)
+	}
+
+	_, err = client.QueryOrder(ctx, &pb.QueryOrderRequest{Session: "max", Id: "1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.QueryTrades(ctx, &pb.QueryTradesRequest{Exchange: "binance"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestStrategyService(t *testing.T) {
+	t.Run("control is disabled without auth", func(t *testing.T) {
+		conn, _ := newTestServer(t, nil)
+		client := pb.NewStrategyServiceClient(conn)
+
+		resp, err := client.ListStrategies(context.Background(), &pb.ListStrategiesRequest{})
+		if assert.NoError(t, err) && assert.Len(t, resp.Strategies, 1) {
+			assert.Equal(t, "test:BTCUSDT", resp.Strategies[0].StrategyInstanceId)
+			assert.Equal(t, "RUNNING", resp.Strategies[0].Status)
+			assert.Equal(t, "BTCUSDT", resp.Strategies[0].Position.Symbol)
+			assert.Equal(t, []string{"suspend", "resume"}, resp.Strategies[0].Controls)
+		}
+
+		_, err = client.SuspendStrategy(context.Background(), &pb.StrategyRequest{StrategyInstanceId: "test:BTCUSDT"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		// the order rpcs are disabled too
+		tradingClient := pb.NewTradingServiceClient(conn)
+		_, err = tradingClient.SubmitOrder(context.Background(), &pb.SubmitOrderRequest{Session: "binance"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		_, err = tradingClient.CancelOrder(context.Background(), &pb.CancelOrderRequest{Session: "binance", OrderId: "1"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("token auth", func(t *testing.T) {
+		conn, strategy := newTestServer(t, interact.NewAuthInteract("secret", nil))
+		client := pb.NewStrategyServiceClient(conn)
+
+		_, err := client.ListStrategies(context.Background(), &pb.ListStrategiesRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), AuthorizationMetadataKey, "Bearer secret")
+		resp, err := client.SuspendStrategy(ctx, &pb.StrategyRequest{Session: "binance", StrategyInstanceId: "test:BTCUSDT"})
+		if assert.NoError(t, err) {
+			assert.Equal(t, "STOPPED", resp.Strategy.Status)
+			assert.Equal(t, types.StrategyStatusStopped, strategy.status)
+		}
+
+		_, err = client.ClosePosition(ctx, &pb.ClosePositionRequest{StrategyInstanceId: "test:BTCUSDT", Percentage: "0.5"})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+		_, err = client.GetStrategy(ctx, &pb.StrategyRequest{StrategyInstanceId: "test:ETHUSDT"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		subscribeCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		stream, err := client.Subscribe(subscribeCtx, &pb.SubscribeStrategyRequest{StrategyInstanceId: "test:BTCUSDT", Interval: 10})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		update, err := stream.Recv()
+		if assert.NoError(t, err) {
+			assert.Equal(t, "STOPPED", update.Status)
+			assert.Equal(t, "0", update.Position.Base)
+		}
+
+		strategy.Position.AddTrade(types.Trade{
+			Symbol:   "BTCUSDT",
+			Side:     types.SideTypeBuy,
+			Price:    fixedpoint.NewFromInt(100),
+			Quantity: fixedpoint.One,
+			Fee:      fixedpoint.Zero,
+		})
+
+		update, err = stream.Recv()
+		if assert.NoError(t, err) {
+			assert.Equal(t, "1", update.Position.Base)
+		}
+	})
+}
+
+func Test_strategySubscribeInterval(t *testing.T) {
+	assert.Equal(t, time.Second, strategySubscribeInterval(0))
+	assert.Equal(t, 100*time.Millisecond, strategySubscribeInterval(1))
+	assert.Equal(t, 500*time.Millisecond, strategySubscribeInterval(500))
+}
@@ -0,0 +1,209 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/pb"
+)
+
+const defaultStrategySubscribeInterval = time.Second
+
+// minStrategySubscribeInterval limits the polling of the strategy state, which walks the strategy fields by reflection
+const minStrategySubscribeInterval = 100 * time.Millisecond
+
+type StrategyService struct {
+	Config  *bbgo.Config
+	Environ *bbgo.Environment
+	Trader  *bbgo.Trader
+
+	pb.UnimplementedStrategyServiceServer
+}
+
+func (s *StrategyService) ListStrategies(ctx context.Context, request *pb.ListStrategiesRequest) (*pb.ListStrategiesResponse, error) {
+	if s.Trader == nil {
+		return nil, status.Error(codes.Unavailable, "trader is not running")
+	}
+
+	resp := &pb.ListStrategiesResponse{}
+	for _, instance := range s.Trader.StrategyInstances() {
+		if len(request.Session) > 0 && instance.Session != request.Session {
+			continue
+		}
+
+		resp.Strategies = append(resp.Strategies, s.transStrategy(instance.State()))
+	}
+
+	return resp, nil
+}
+
+func (s *StrategyService) GetStrategy(ctx context.Context, request *pb.StrategyRequest) (*pb.StrategyResponse, error) {
+	instance, err := s.findStrategyInstance(request.Session, request.StrategyInstanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.StrategyResponse{Strategy: s.transStrategy(instance.State())}, nil
+}
+
+func (s *StrategyService) SuspendStrategy(ctx context.Context, request *pb.StrategyRequest) (*pb.StrategyResponse, error) {
+	return s.control(request.Session, request.StrategyInstanceId, func(instance *bbgo.StrategyInstance) error {
+		return instance.Suspend()
+	})
+}
+
+func (s *StrategyService) ResumeStrategy(ctx context.Context, request *pb.StrategyRequest) (*pb.StrategyResponse, error) {
+	return s.control(request.Session, request.StrategyInstanceId, func(instance *bbgo.StrategyInstance) error {
+		return instance.Resume()
+	})
+}
+
+func (s *StrategyService) ClosePosition(ctx context.Context, request *pb.ClosePositionRequest) (*pb.StrategyResponse, error) {
+	percentage := fixedpoint.One
+	if len(request.Percentage) > 0 {
+		var err error
+		percentage, err = fixedpoint.NewFromString(request.Percentage)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid percentage %q: %v", request.Percentage, err)
+		}
+
+		if percentage.Sign() <= 0 || percentage.Compare(fixedpoint.One) > 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid percentage %s, it should be in (0, 1]", request.Percentage)
+		}
+	}
+
+	return s.control(request.Session, request.StrategyInstanceId, func(instance *bbgo.StrategyInstance) error {
+		return instance.ClosePosition(ctx, percentage)
+	})
+}
+
+// strategySubscribeInterval converts the requested interval in milliseconds, the interval is clamped to the minimal interval
+func strategySubscribeInterval(ms int64) time.Duration {
+	if ms <= 0 {
+		return defaultStrategySubscribeInterval
+	}
+
+	interval := time.Duration(ms) * time.Millisecond
+	if interval < minStrategySubscribeInterval {
+		return minStrategySubscribeInterval
+	}
+
+	return interval
+}
+
+// Subscribe polls the strategy instance at the interval, and sends the update when the status,
+// the position or the profit stats is changed
+func (s *StrategyService) Subscribe(request *pb.SubscribeStrategyRequest, server pb.StrategyService_SubscribeServer) error {
+	instance, err := s.findStrategyInstance(request.Session, request.StrategyInstanceId)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(strategySubscribeInterval(request.Interval))
+	defer ticker.Stop()
+
+	ctx := server.Context()
+
+	var last *pb.StrategyUpdate
+	for {
+		state := s.transStrategy(instance.State())
+		update := &pb.StrategyUpdate{
+			Session:            state.Session,
+			StrategyInstanceId: state.StrategyInstanceId,
+			Status:             state.Status,
+			Position:           state.Position,
+			ProfitStats:        state.ProfitStats,
+		}
+
+		if last == nil || !proto.Equal(last, update) {
+			last = update
+
+			// copy the update, so that the time is not compared
+			sent := proto.Clone(update).(*pb.StrategyUpdate)
+			sent.Time = time.Now().UnixMilli()
+			if err := server.Send(sent); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *StrategyService) findStrategyInstance(session, instanceID string) (*bbgo.StrategyInstance, error) {
+	if s.Trader == nil {
+		return nil, status.Error(codes.Unavailable, "trader is not running")
+	}
+
+	if len(instanceID) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "strategy instance id can not be empty")
+	}
+
+	instance, err := s.Trader.FindStrategyInstance(session, instanceID)
+	if err != nil {
+		if errors.Is(err, bbgo.ErrStrategyInstanceNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return instance, nil
+}
+
+func (s *StrategyService) control(session, instanceID string, control func(instance *bbgo.StrategyInstance) error) (*pb.StrategyResponse, error) {
+	instance, err := s.findStrategyInstance(session, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := control(instance); err != nil {
+		if errors.Is(err, bbgo.ErrStrategyControlNotSupported) {
+			return nil, status.Error(codes.Unimplemented, err.Error())
+		}
+		return nil, err
+	}
+
+	return &pb.StrategyResponse{Strategy: s.transStrategy(instance.State())}, nil
+}
+
+func (s *StrategyService) transStrategy(state bbgo.StrategyInstanceState) *pb.Strategy {
+	strategy := &pb.Strategy{
+		Session:            state.Session,
+		Strategy:           state.StrategyID,
+		StrategyInstanceId: state.InstanceID,
+		Status:             string(state.Status),
+		Controls:           state.Controls,
+	}
+
+	session, ok := s.Environ.Session(state.Session)
+	if !ok {
+		session = &bbgo.ExchangeSession{}
+		session.Name = state.Session
+	}
+
+	if state.Position != nil {
+		strategy.Position = transPosition(session, state.Position)
+	}
+
+	if state.ProfitStats != nil {
+		strategy.ProfitStats = transProfitStats(state.ProfitStats)
+	}
+
+	for _, order := range state.ActiveOrders {
+		strategy.ActiveOrders = append(strategy.ActiveOrders, transOrder(session, order))
+	}
+
+	return strategy
+}
@@ -1,6 +1,7 @@
 package interact
 
 import (
+	"crypto/subtle"
 	"errors"
 	"os"
 	"time"
@@ -34,6 +35,55 @@ type AuthInteract struct {
 	OneTimePasswordKey *otp.Key `json:"otpKey,omitempty"`
 }
 
+// NewAuthInteract returns the auth interact of the mode decided by the given token and otp key:
+// the strict mode if both of them are given, the token mode if only the token is given, and the otp mode
+// if only the otp key is given. nil is returned if none of them is given.
+func NewAuthInteract(token string, key *otp.Key) *AuthInteract {
+	switch {
+	case token != "" && key != nil:
+		return &AuthInteract{Strict: true, Mode: AuthModeToken, Token: token, OneTimePasswordKey: key}
+	case token != "":
+		return &AuthInteract{Mode: AuthModeToken, Token: token}
+	case key != nil:
+		return &AuthInteract{Mode: AuthModeOTP, OneTimePasswordKey: key}
+	}
+
+	return nil
+}
+
+// Verify checks the token and the one-time password code of the auth mode without the interaction,
+// both of them are checked in the strict mode. It's used by the API servers.
+func (it *AuthInteract) Verify(token, code string) error {
+	checkToken := func() bool {
+		return it.Token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(it.Token)) == 1
+	}
+
+	checkCode := func() bool {
+		return it.OneTimePasswordKey != nil && code != "" && totp.Validate(code, it.OneTimePasswordKey.Secret())
+	}
+
+	if it.Strict {
+		if checkToken() && checkCode() {
+			return nil
+		}
+		return ErrAuthenticationFailed
+	}
+
+	switch it.Mode {
+	case AuthModeToken:
+		if checkToken() {
+			return nil
+		}
+
+	case AuthModeOTP:
+		if checkCode() {
+			return nil
+		}
+	}
+
+	return ErrAuthenticationFailed
+}
+
 func (it *AuthInteract) Commands(interact *Interact) {
 	if it.Strict {
 		// generate a one-time-use otp
@@ -0,0 +1,55 @@
+package interact
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthInteract_Verify(t *testing.T) {
+	key, err := totp.Generate(totp.GenerateOpts{Issuer: "interact", AccountName: "test"})
+	assert.NoError(t, err)
+
+	code, err := totp.GenerateCode(key.Secret(), time.Now())
+	assert.NoError(t, err)
+
+	t.Run("token mode", func(t *testing.T) {
+		it := &AuthInteract{Mode: AuthModeToken, Token: "secret"}
+		assert.NoError(t, it.Verify("secret", ""))
+		assert.ErrorIs(t, it.Verify("wrong", ""), ErrAuthenticationFailed)
+		assert.ErrorIs(t, (&AuthInteract{Mode: AuthModeToken}).Verify("", ""), ErrAuthenticationFailed)
+	})
+
+	t.Run("otp mode", func(t *testing.T) {
+		it := &AuthInteract{Mode: AuthModeOTP, OneTimePasswordKey: key}
+		assert.NoError(t, it.Verify("", code))
+		assert.ErrorIs(t, it.Verify("", "000000x"), ErrAuthenticationFailed)
+	})
+
+	t.Run("strict mode", func(t *testing.T) {
+		it := &AuthInteract{Strict: true, Mode: AuthModeToken, Token: "secret", OneTimePasswordKey: key}
+		assert.NoError(t, it.Verify("secret", code))
+		assert.ErrorIs(t, it.Verify("secret", ""), ErrAuthenticationFailed)
+		assert.ErrorIs(t, it.Verify("", code), ErrAuthenticationFailed)
+	})
+}
+
+func TestNewAuthInteract(t *testing.T) {
+	key, err := totp.Generate(totp.GenerateOpts{Issuer: "interact", AccountName: "test"})
+	assert.NoError(t, err)
+
+	assert.Nil(t, NewAuthInteract("", nil))
+
+	it := NewAuthInteract("secret", nil)
+	assert.False(t, it.Strict)
+	assert.Equal(t, AuthModeToken, it.Mode)
+
+	it = NewAuthInteract("", key)
+	assert.False(t, it.Strict)
+	assert.Equal(t, AuthModeOTP, it.Mode)
+
+	it = NewAuthInteract("secret", key)
+	assert.True(t, it.Strict)
+}
@@ -1,5 +1,7 @@
 package interact
 
+import "bytes"
+
 type Button struct {
 	Text  string
 	Name  string
@@ -52,6 +54,12 @@ type KeyboardController interface {
 	RemoveKeyboard()
 }
 
+// PhotoReply is used when the messenger supports sending photos back to the client's session
+type PhotoReply interface {
+	// SendPhoto sends the photo directly to the client's session
+	SendPhoto(buffer *bytes.Buffer)
+}
+
 // ButtonReply can be used if your reply needs button user interface.
 type ButtonReply interface {
 	// AddButton adds the button to the reply
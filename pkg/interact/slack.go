@@ -1,6 +1,7 @@
 package interact
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -45,6 +46,17 @@ func (reply *SlackReply) Send(message string) {
 	}
 }
 
+func (reply *SlackReply) SendPhoto(buffer *bytes.Buffer) {
+	_, err := reply.client.UploadFile(slack.FileUploadParameters{
+		Reader:   bytes.NewReader(buffer.Bytes()),
+		Filename: "chart.png",
+		Channels: []string{reply.session.ChannelID},
+	})
+	if err != nil {
+		log.WithError(err).Errorf("slack upload file error: channel=%s", reply.session.ChannelID)
+	}
+}
+
 func (reply *SlackReply) InputText(prompt string, textFields ...TextField) {
 	reply.message = prompt
 	reply.textInputModalViewRequest = generateTextInputModalRequest(prompt, prompt, textFields...)
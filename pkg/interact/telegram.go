@@ -1,6 +1,7 @@
 package interact
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strings"
@@ -15,6 +16,7 @@ import (
 func init() {
 	// force interface type check
 	_ = Reply(&TelegramReply{})
+	_ = PhotoReply(&TelegramReply{})
 }
 
 var sendLimiter = rate.NewLimiter(10, 2)
@@ -79,6 +81,16 @@ func (r *TelegramReply) Send(message string) {
 	}
 }
 
+func (r *TelegramReply) SendPhoto(buffer *bytes.Buffer) {
+	if err := sendLimiter.Wait(context.Background()); err != nil {
+		log.WithError(err).Errorf("telegram send limit exceeded")
+		return
+	}
+
+	photo := &telebot.Photo{File: telebot.FromReader(bytes.NewReader(buffer.Bytes()))}
+	checkSendErr(r.bot.Send(r.session.Chat, photo))
+}
+
 func (r *TelegramReply) Message(message string) {
 	r.message = message
 	r.set = true
@@ -0,0 +1,39 @@
+package sqlite3
+
+import (
+	"context"
+
+	"github.com/c9s/rockhopper"
+)
+
+func init() {
+	AddMigration(upAddStrategyInstanceNavs, downAddStrategyInstanceNavs)
+
+}
+
+func upAddStrategyInstanceNavs(ctx context.Context, tx rockhopper.SQLExecutor) (err error) {
+	// This code is executed when the migration is applied.
+
+	_, err = tx.ExecContext(ctx, "CREATE TABLE `strategy_instance_navs`\n(\n    `gid`                  INTEGER PRIMARY KEY AUTOINCREMENT,\n    `strategy`             VARCHAR(32)    NOT NULL,\n    `strategy_instance_id` VARCHAR(64)    NOT NULL,\n    `session`              VARCHAR(30)    NOT NULL,\n    `time`                 DATETIME(3)    NOT NULL,\n    -- net_asset_value is the realized profit plus the unrealized profit minus the fee, in USD\n    `net_asset_value`      DECIMAL(32, 8) NOT NULL,\n    `realized_profit`      DECIMAL(32, 8) NOT NULL,\n    `unrealized_profit`    DECIMAL(32, 8) NOT NULL,\n    `fee_in_usd`           DECIMAL(32, 8) NOT NULL,\n    `position_value`       DECIMAL(32, 8) NOT NULL,\n    `num_of_trades`        INTEGER        NOT NULL DEFAULT 0\n);")
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "CREATE INDEX idx_strategy_instance_navs\n    ON strategy_instance_navs (strategy_instance_id, time);")
+	if err != nil {
+		return err
+	}
+
+	return err
+}
+
+func downAddStrategyInstanceNavs(ctx context.Context, tx rockhopper.SQLExecutor) (err error) {
+	// This code is executed when the migration is rolled back.
+
+	_, err = tx.ExecContext(ctx, "DROP TABLE IF EXISTS `strategy_instance_navs`;")
+	if err != nil {
+		return err
+	}
+
+	return err
+}
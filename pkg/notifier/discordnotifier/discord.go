@@ -0,0 +1,350 @@
+package discordnotifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/slack-go/slack"
+	"golang.org/x/time/rate"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// discord allows 5 requests per 2 seconds for a webhook
+var limiter = rate.NewLimiter(rate.Every(400*time.Millisecond), 5)
+
+var log = logrus.WithField("service", "discord")
+
+const (
+	// maxEmbeds is the max number of the embeds in a discord message
+	maxEmbeds = 10
+
+	// maxContentLength is the max length of the message content
+	maxContentLength = 2000
+)
+
+// EmbedField is a field of the discord embed
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+type EmbedFooter struct {
+	Text    string `json:"text"`
+	IconURL string `json:"icon_url,omitempty"`
+}
+
+// Embed is the rich content of the discord message
+type Embed struct {
+	Title       string       `json:"title,omitempty"`
+	Description string       `json:"description,omitempty"`
+	URL         string       `json:"url,omitempty"`
+	Color       int          `json:"color,omitempty"`
+	Fields      []EmbedField `json:"fields,omitempty"`
+	Footer      *EmbedFooter `json:"footer,omitempty"`
+	Timestamp   string       `json:"timestamp,omitempty"`
+}
+
+// WebhookMessage is the payload of the discord webhook
+type WebhookMessage struct {
+	Username string  `json:"username,omitempty"`
+	Content  string  `json:"content,omitempty"`
+	Embeds   []Embed `json:"embeds,omitempty"`
+}
+
+type notifyTask struct {
+	webhookURL  string
+	message     WebhookMessage
+	photoBuffer *bytes.Buffer
+}
+
+type Notifier struct {
+	client *http.Client
+
+	// webhookURL is the default webhook
+	webhookURL string
+
+	// channels maps the channel name to the webhook url
+	channels map[string]string
+
+	username string
+
+	taskC chan notifyTask
+}
+
+type Option func(notifier *Notifier)
+
+// WithChannels maps the channel names, e.g., the channels routed by the symbol or the session, to the webhook urls
+func WithChannels(channels map[string]string) Option {
+	return func(notifier *Notifier) {
+		for channel, webhookURL := range channels {
+			notifier.channels[channel] = webhookURL
+		}
+	}
+}
+
+// WithUsername overrides the default username of the webhook
+func WithUsername(username string) Option {
+	return func(notifier *Notifier) {
+		notifier.username = username
+	}
+}
+
+func WithHttpClient(client *http.Client) Option {
+	return func(notifier *Notifier) {
+		notifier.client = client
+	}
+}
+
+// New returns a discord notifier that posts the messages to the webhook url
+func New(webhookURL string, options ...Option) *Notifier {
+	notifier := &Notifier{
+		client:     &http.Client{Timeout: 15 * time.Second},
+		webhookURL: webhookURL,
+		channels:   make(map[string]string),
+		taskC:      make(chan notifyTask, 100),
+	}
+
+	for _, o := range options {
+		o(notifier)
+	}
+
+	go notifier.worker()
+
+	return notifier
+}
+
+func (n *Notifier) worker() {
+	ctx := context.Background()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case task := <-n.taskC:
+			limiter.Wait(ctx)
+
+			if err := n.send(ctx, task); err != nil {
+				log.WithError(err).Errorf("discord webhook error")
+			}
+		}
+	}
+}
+
+func (n *Notifier) send(ctx context.Context, task notifyTask) error {
+	payload, err := json.Marshal(task.message)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader = bytes.NewReader(payload)
+	var contentType = "application/json"
+
+	if task.photoBuffer != nil {
+		var buf bytes.Buffer
+		writer := multipart.NewWriter(&buf)
+		if err := writer.WriteField("payload_json", string(payload)); err != nil {
+			return err
+		}
+
+		part, err := writer.CreateFormFile("file", "image.png")
+		if err != nil {
+			return err
+		}
+
+		if _, err := part.Write(task.photoBuffer.Bytes()); err != nil {
+			return err
+		}
+
+		if err := writer.Close(); err != nil {
+			return err
+		}
+
+		body = &buf
+		contentType = writer.FormDataContentType()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, task.webhookURL, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected discord webhook response: %d %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+func (n *Notifier) Notify(obj interface{}, args ...interface{}) {
+	n.NotifyTo("", obj, args...)
+}
+
+// filterEmbeds converts the object arguments to the embeds, the arguments before the first object are
+// the format arguments.
+func filterEmbeds(args []interface{}) (embeds []Embed, pureArgs []interface{}) {
+	var firstObjectOffset = -1
+	for idx, arg := range args {
+		switch a := arg.(type) {
+
+		case slack.Attachment:
+			embeds = append(embeds, EmbedFromSlackAttachment(a))
+
+		case types.SlackAttachmentCreator:
+			embeds = append(embeds, EmbedFromSlackAttachment(a.SlackAttachment()))
+
+		case types.PlainText:
+			embeds = append(embeds, Embed{Description: a.PlainText()})
+
+		default:
+			continue
+		}
+
+		if firstObjectOffset == -1 {
+			firstObjectOffset = idx
+		}
+	}
+
+	pureArgs = args
+	if firstObjectOffset > -1 {
+		pureArgs = args[:firstObjectOffset]
+	}
+
+	return embeds, pureArgs
+}
+
+func (n *Notifier) NotifyTo(channel string, obj interface{}, args ...interface{}) {
+	embeds, pureArgs := filterEmbeds(args)
+
+	message := WebhookMessage{Username: n.username}
+
+	switch a := obj.(type) {
+	case string:
+		message.Content = fmt.Sprintf(a, pureArgs...)
+
+	case slack.Attachment:
+		embeds = append([]Embed{EmbedFromSlackAttachment(a)}, embeds...)
+
+	case types.SlackAttachmentCreator:
+		embeds = append([]Embed{EmbedFromSlackAttachment(a.SlackAttachment())}, embeds...)
+
+	case types.PlainText:
+		message.Content = a.PlainText()
+
+	case types.Stringer:
+		message.Content = a.String()
+
+	default:
+		log.Errorf("discord message conversion error, unsupported object: %T %+v", a, a)
+		return
+	}
+
+	if len(message.Content) > maxContentLength {
+		message.Content = message.Content[:maxContentLength]
+	}
+
+	if len(embeds) > maxEmbeds {
+		embeds = embeds[:maxEmbeds]
+	}
+
+	message.Embeds = embeds
+	n.enqueue(notifyTask{
+		webhookURL: n.route(channel),
+		message:    message,
+	})
+}
+
+func (n *Notifier) SendPhoto(buffer *bytes.Buffer) {
+	n.SendPhotoTo("", buffer)
+}
+
+func (n *Notifier) SendPhotoTo(channel string, buffer *bytes.Buffer) {
+	n.enqueue(notifyTask{
+		webhookURL:  n.route(channel),
+		message:     WebhookMessage{Username: n.username},
+		photoBuffer: buffer,
+	})
+}
+
+// route returns the webhook url of the channel, the default webhook is used if the channel is not configured
+func (n *Notifier) route(channel string) string {
+	if webhookURL, ok := n.channels[channel]; ok {
+		return webhookURL
+	}
+
+	return n.webhookURL
+}
+
+func (n *Notifier) enqueue(task notifyTask) {
+	select {
+	case n.taskC <- task:
+	case <-time.After(50 * time.Millisecond):
+		log.Error("[discord] cannot send task to notify")
+	}
+}
+
+// EmbedFromSlackAttachment converts the slack attachment to the discord embed
+func EmbedFromSlackAttachment(attachment slack.Attachment) Embed {
+	embed := Embed{
+		Title:       attachment.Title,
+		URL:         attachment.TitleLink,
+		Description: strings.TrimSpace(strings.Join([]string{attachment.Pretext, attachment.Text}, "\n")),
+		Color:       parseColor(attachment.Color),
+	}
+
+	for _, field := range attachment.Fields {
+		embed.Fields = append(embed.Fields, EmbedField{
+			Name:   field.Title,
+			Value:  field.Value,
+			Inline: field.Short,
+		})
+	}
+
+	if len(attachment.Footer) > 0 {
+		embed.Footer = &EmbedFooter{
+			Text:    attachment.Footer,
+			IconURL: attachment.FooterIcon,
+		}
+	}
+
+	return embed
+}
+
+// parseColor converts the slack color, e.g., "#228B22" or "good", to the discord color integer
+func parseColor(color string) int {
+	switch color {
+	case "good":
+		return 0x2EB886
+	case "warning":
+		return 0xDAA038
+	case "danger":
+		return 0xA30200
+	}
+
+	v, err := strconv.ParseInt(strings.TrimPrefix(color, "#"), 16, 32)
+	if err != nil {
+		return 0
+	}
+
+	return int(v)
+}
@@ -0,0 +1,36 @@
+package discordnotifier
+
+import (
+	"testing"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbedFromSlackAttachment(t *testing.T) {
+	embed := EmbedFromSlackAttachment(slack.Attachment{
+		Title:   "BTCUSDT Position",
+		Pretext: "position updated",
+		Text:    "long",
+		Color:   "#228B22",
+		Fields: []slack.AttachmentField{
+			{Title: "Base", Value: "0.1", Short: true},
+		},
+		Footer: "okex",
+	})
+
+	assert.Equal(t, "BTCUSDT Position", embed.Title)
+	assert.Equal(t, "position updated\nlong", embed.Description)
+	assert.Equal(t, 0x228B22, embed.Color)
+	assert.Equal(t, []EmbedField{{Name: "Base", Value: "0.1", Inline: true}}, embed.Fields)
+	if assert.NotNil(t, embed.Footer) {
+		assert.Equal(t, "okex", embed.Footer.Text)
+	}
+}
+
+func Test_parseColor(t *testing.T) {
+	assert.Equal(t, 0x2EB886, parseColor("good"))
+	assert.Equal(t, 0xA30200, parseColor("danger"))
+	assert.Equal(t, 0xDC143C, parseColor("#DC143C"))
+	assert.Equal(t, 0, parseColor("unknown"))
+}
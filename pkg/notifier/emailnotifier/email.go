@@ -0,0 +1,306 @@
+package emailnotifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/slack-go/slack"
+	"golang.org/x/time/rate"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+var limiter = rate.NewLimiter(rate.Every(time.Second), 1)
+
+var log = logrus.WithField("service", "email")
+
+// SendMailFunc is the signature of smtp.SendMail
+type SendMailFunc func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+
+type notifyTask struct {
+	to      []string
+	subject string
+	body    string
+}
+
+type Notifier struct {
+	addr string
+	auth smtp.Auth
+	from string
+
+	// to is the default recipients
+	to []string
+
+	// channels maps the channel name to the recipients
+	channels map[string][]string
+
+	subjectPrefix string
+
+	sendMail SendMailFunc
+
+	taskC chan notifyTask
+}
+
+type Option func(notifier *Notifier)
+
+// WithAuth uses the PLAIN authentication of the smtp server
+func WithAuth(username, password string) Option {
+	return func(notifier *Notifier) {
+		host, _, _ := net.SplitHostPort(notifier.addr)
+		notifier.auth = smtp.PlainAuth("", username, password, host)
+	}
+}
+
+// WithChannels maps the channel names, e.g., the channels routed by the symbol or the session, to the recipients
+func WithChannels(channels map[string][]string) Option {
+	return func(notifier *Notifier) {
+		for channel, to := range channels {
+			notifier.channels[channel] = to
+		}
+	}
+}
+
+func WithSubjectPrefix(prefix string) Option {
+	return func(notifier *Notifier) {
+		notifier.subjectPrefix = prefix
+	}
+}
+
+// WithSendMail replaces the function that sends the mail, it's used in the tests
+func WithSendMail(sendMail SendMailFunc) Option {
+	return func(notifier *Notifier) {
+		notifier.sendMail = sendMail
+	}
+}
+
+// New returns an email notifier that sends the notifications through the smtp server
+func New(host string, port int, from string, to []string, options ...Option) *Notifier {
+	notifier := &Notifier{
+		addr:          net.JoinHostPort(host, strconv.Itoa(port)),
+		from:          from,
+		to:            to,
+		channels:      make(map[string][]string),
+		subjectPrefix: "[bbgo]",
+		sendMail:      smtp.SendMail,
+		taskC:         make(chan notifyTask, 100),
+	}
+
+	for _, o := range options {
+		o(notifier)
+	}
+
+	go notifier.worker()
+
+	return notifier
+}
+
+func (n *Notifier) worker() {
+	ctx := context.Background()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case task := <-n.taskC:
+			limiter.Wait(ctx)
+
+			if err := n.sendMail(n.addr, n.auth, n.from, task.to, n.buildMessage(task)); err != nil {
+				log.WithError(err).Errorf("smtp send mail error")
+			}
+		}
+	}
+}
+
+func (n *Notifier) buildMessage(task notifyTask) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("From: " + n.from + "\r\n")
+	buf.WriteString("To: " + strings.Join(task.to, ", ") + "\r\n")
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", task.subject) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/html; charset=\"utf-8\"\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n")
+	buf.WriteString("\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(task.body))
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded + "\r\n")
+	return buf.Bytes()
+}
+
+func (n *Notifier) Notify(obj interface{}, args ...interface{}) {
+	n.NotifyTo("", obj, args...)
+}
+
+// filterSections renders the object arguments to the html sections, the arguments before the first object are
+// the format arguments.
+func filterSections(args []interface{}) (sections []string, pureArgs []interface{}) {
+	var firstObjectOffset = -1
+	for idx, arg := range args {
+		switch a := arg.(type) {
+
+		case slack.Attachment:
+			sections = append(sections, RenderSlackAttachment(a))
+
+		case types.SlackAttachmentCreator:
+			sections = append(sections, RenderSlackAttachment(a.SlackAttachment()))
+
+		case types.PlainText:
+			sections = append(sections, renderText(a.PlainText()))
+
+		default:
+			continue
+		}
+
+		if firstObjectOffset == -1 {
+			firstObjectOffset = idx
+		}
+	}
+
+	pureArgs = args
+	if firstObjectOffset > -1 {
+		pureArgs = args[:firstObjectOffset]
+	}
+
+	return sections, pureArgs
+}
+
+func (n *Notifier) NotifyTo(channel string, obj interface{}, args ...interface{}) {
+	sections, pureArgs := filterSections(args)
+
+	var subject string
+	switch a := obj.(type) {
+	case string:
+		subject = fmt.Sprintf(a, pureArgs...)
+		sections = append([]string{renderText(subject)}, sections...)
+
+	case slack.Attachment:
+		subject = a.Title
+		sections = append([]string{RenderSlackAttachment(a)}, sections...)
+
+	case types.SlackAttachmentCreator:
+		attachment := a.SlackAttachment()
+		subject = attachment.Title
+		sections = append([]string{RenderSlackAttachment(attachment)}, sections...)
+
+	case types.PlainText:
+		subject = a.PlainText()
+		sections = append([]string{renderText(subject)}, sections...)
+
+	case types.Stringer:
+		subject = a.String()
+		sections = append([]string{renderText(subject)}, sections...)
+
+	default:
+		log.Errorf("email message conversion error, unsupported object: %T %+v", a, a)
+		return
+	}
+
+	n.enqueue(notifyTask{
+		to:      n.route(channel),
+		subject: n.subject(subject),
+		body:    renderHTML(sections),
+	})
+}
+
+func (n *Notifier) SendPhoto(buffer *bytes.Buffer) {
+	n.SendPhotoTo("", buffer)
+}
+
+func (n *Notifier) SendPhotoTo(channel string, buffer *bytes.Buffer) {
+	n.enqueue(notifyTask{
+		to:      n.route(channel),
+		subject: n.subject("chart"),
+		body:    renderHTML([]string{`<img src="data:image/png;base64,` + base64.StdEncoding.EncodeToString(buffer.Bytes()) + `"/>`}),
+	})
+}
+
+func (n *Notifier) subject(text string) string {
+	// use the first line as the subject
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = text[:i]
+	}
+
+	return strings.TrimSpace(n.subjectPrefix + " " + text)
+}
+
+// route returns the recipients of the channel, the default recipients are used if the channel is not configured
+func (n *Notifier) route(channel string) []string {
+	if to, ok := n.channels[channel]; ok {
+		return to
+	}
+
+	return n.to
+}
+
+func (n *Notifier) enqueue(task notifyTask) {
+	if len(task.to) == 0 {
+		return
+	}
+
+	select {
+	case n.taskC <- task:
+	case <-time.After(50 * time.Millisecond):
+		log.Error("[email] cannot send task to notify")
+	}
+}
+
+func renderHTML(sections []string) string {
+	return "<html><body>" + strings.Join(sections, "\n") + "</body></html>"
+}
+
+func renderText(text string) string {
+	return "<p>" + strings.ReplaceAll(html.EscapeString(text), "\n", "<br/>") + "</p>"
+}
+
+// RenderSlackAttachment renders the slack attachment as a html table with the color bar
+func RenderSlackAttachment(attachment slack.Attachment) string {
+	var b strings.Builder
+
+	color := attachment.Color
+	if !strings.HasPrefix(color, "#") {
+		color = "#cccccc"
+	}
+
+	b.WriteString(`<div style="border-left: 4px solid ` + html.EscapeString(color) + `; padding-left: 8px; margin-bottom: 12px;">`)
+
+	if len(attachment.Title) > 0 {
+		b.WriteString("<h3>" + html.EscapeString(attachment.Title) + "</h3>")
+	}
+
+	if len(attachment.Pretext) > 0 {
+		b.WriteString(renderText(attachment.Pretext))
+	}
+
+	if len(attachment.Text) > 0 {
+		b.WriteString(renderText(attachment.Text))
+	}
+
+	if len(attachment.Fields) > 0 {
+		b.WriteString("<table>")
+		for _, field := range attachment.Fields {
+			b.WriteString("<tr><th align=\"left\">" + html.EscapeString(field.Title) + "</th><td>" + html.EscapeString(field.Value) + "</td></tr>")
+		}
+		b.WriteString("</table>")
+	}
+
+	if len(attachment.Footer) > 0 {
+		b.WriteString("<p><small>" + html.EscapeString(attachment.Footer) + "</small></p>")
+	}
+
+	b.WriteString("</div>")
+	return b.String()
+}
@@ -0,0 +1,134 @@
+package emailnotifier
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func init() {
+	// do not wait for the rate limiter in the tests
+	limiter = rate.NewLimiter(rate.Inf, 1)
+}
+
+type sentMail struct {
+	addr    string
+	from    string
+	to      []string
+	subject string
+	body    string
+}
+
+func newTestNotifier(t *testing.T, err error, options ...Option) (*Notifier, chan sentMail) {
+	mailC := make(chan sentMail, 10)
+	sendMail := func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		// the mail is sent by the worker goroutine, so the errors are asserted without stopping the test here
+		m, parseErr := mail.ReadMessage(bytes.NewReader(msg))
+		if !assert.NoError(t, parseErr) {
+			return parseErr
+		}
+
+		subject, decodeErr := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+		assert.NoError(t, decodeErr)
+
+		assert.Equal(t, "base64", m.Header.Get("Content-Transfer-Encoding"))
+		encoded, readErr := io.ReadAll(m.Body)
+		assert.NoError(t, readErr)
+
+		body, decodeErr := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+		assert.NoError(t, decodeErr)
+
+		mailC <- sentMail{addr: addr, from: from, to: to, subject: subject, body: string(body)}
+		return err
+	}
+
+	options = append([]Option{WithSendMail(sendMail)}, options...)
+	return New("smtp.example.com", 587, "bbgo@example.com", []string{"ops@example.com"}, options...), mailC
+}
+
+func receiveMail(t *testing.T, mailC chan sentMail) sentMail {
+	select {
+	case m := <-mailC:
+		return m
+	case <-time.After(3 * time.Second):
+		t.Fatal("the mail is not sent")
+	}
+	return sentMail{}
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	notifier, mailC := newTestNotifier(t, nil)
+
+	notifier.Notify("order %s <filled>\nsecond line", "#1", slack.Attachment{
+		Title:  "BTCUSDT",
+		Color:  "#00ff00",
+		Fields: []slack.AttachmentField{{Title: "Price", Value: "30000"}},
+	})
+
+	m := receiveMail(t, mailC)
+	assert.Equal(t, "smtp.example.com:587", m.addr)
+	assert.Equal(t, "bbgo@example.com", m.from)
+	assert.Equal(t, []string{"ops@example.com"}, m.to)
+
+	// the first line is used as the subject
+	assert.Equal(t, "[bbgo] order #1 <filled>", m.subject)
+	assert.Contains(t, m.body, "<p>order #1 &lt;filled&gt;<br/>second line</p>")
+	assert.Contains(t, m.body, "<h3>BTCUSDT</h3>")
+	assert.Contains(t, m.body, `<tr><th align="left">Price</th><td>30000</td></tr>`)
+}
+
+func TestNotifier_NotifyTo(t *testing.T) {
+	notifier, mailC := newTestNotifier(t, nil,
+		WithSubjectPrefix("[grid]"),
+		WithChannels(map[string][]string{"#btc": {"btc@example.com", "desk@example.com"}}))
+
+	notifier.NotifyTo("#btc", "BTCUSDT grid opened")
+	m := receiveMail(t, mailC)
+	assert.Equal(t, []string{"btc@example.com", "desk@example.com"}, m.to)
+	assert.Equal(t, "[grid] BTCUSDT grid opened", m.subject)
+
+	// the unknown channel falls back to the default recipients
+	notifier.NotifyTo("#eth", "ETHUSDT grid opened")
+	m = receiveMail(t, mailC)
+	assert.Equal(t, []string{"ops@example.com"}, m.to)
+
+	notifier.SendPhotoTo("#btc", bytes.NewBufferString("png"))
+	m = receiveMail(t, mailC)
+	assert.Equal(t, []string{"btc@example.com", "desk@example.com"}, m.to)
+	assert.Equal(t, "[grid] chart", m.subject)
+	assert.Contains(t, m.body, `<img src="data:image/png;base64,`+base64.StdEncoding.EncodeToString([]byte("png"))+`"/>`)
+}
+
+func TestNotifier_SendMailError(t *testing.T) {
+	notifier, mailC := newTestNotifier(t, errors.New("connection refused"))
+
+	// the worker keeps sending the mails after the error
+	notifier.Notify("first")
+	assert.Equal(t, "[bbgo] first", receiveMail(t, mailC).subject)
+
+	notifier.Notify("second")
+	assert.Equal(t, "[bbgo] second", receiveMail(t, mailC).subject)
+}
+
+func TestNotifier_UnsupportedObject(t *testing.T) {
+	notifier, mailC := newTestNotifier(t, nil)
+
+	notifier.Notify(struct{}{})
+
+	select {
+	case m := <-mailC:
+		t.Fatalf("unexpected mail: %+v", m)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
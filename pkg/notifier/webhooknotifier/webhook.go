@@ -0,0 +1,311 @@
+package webhooknotifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/slack-go/slack"
+	"golang.org/x/time/rate"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+var limiter = rate.NewLimiter(rate.Every(100*time.Millisecond), 10)
+
+var log = logrus.WithField("service", "webhook")
+
+type AttachmentField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// Attachment is the rendered object, it's converted from the slack attachment
+type Attachment struct {
+	Title  string            `json:"title,omitempty"`
+	Text   string            `json:"text,omitempty"`
+	Color  string            `json:"color,omitempty"`
+	Fields []AttachmentField `json:"fields,omitempty"`
+	Footer string            `json:"footer,omitempty"`
+}
+
+// Object is the notified object, e.g., types.Trade or types.Position, with its type name
+type Object struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// Payload is the json body posted to the webhook
+type Payload struct {
+	Channel     string       `json:"channel,omitempty"`
+	Text        string       `json:"text,omitempty"`
+	Objects     []Object     `json:"objects,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+
+	// Image is the base64 encoded png image of SendPhoto
+	Image string `json:"image,omitempty"`
+
+	Time time.Time `json:"time"`
+}
+
+type notifyTask struct {
+	url     string
+	payload Payload
+}
+
+type Notifier struct {
+	client *http.Client
+
+	// url is the default webhook url
+	url string
+
+	// channels maps the channel name to the webhook url
+	channels map[string]string
+
+	headers map[string]string
+
+	taskC chan notifyTask
+}
+
+type Option func(notifier *Notifier)
+
+// WithChannels maps the channel names, e.g., the channels routed by the symbol or the session, to the webhook urls
+func WithChannels(channels map[string]string) Option {
+	return func(notifier *Notifier) {
+		for channel, url := range channels {
+			notifier.channels[channel] = url
+		}
+	}
+}
+
+// WithHeaders adds the http headers to the requests, e.g., the authorization header
+func WithHeaders(headers map[string]string) Option {
+	return func(notifier *Notifier) {
+		for key, value := range headers {
+			notifier.headers[key] = value
+		}
+	}
+}
+
+func WithHttpClient(client *http.Client) Option {
+	return func(notifier *Notifier) {
+		notifier.client = client
+	}
+}
+
+// New returns a webhook notifier that posts the notifications as json to the url
+func New(url string, options ...Option) *Notifier {
+	notifier := &Notifier{
+		client:   &http.Client{Timeout: 15 * time.Second},
+		url:      url,
+		channels: make(map[string]string),
+		headers:  make(map[string]string),
+		taskC:    make(chan notifyTask, 100),
+	}
+
+	for _, o := range options {
+		o(notifier)
+	}
+
+	go notifier.worker()
+
+	return notifier
+}
+
+func (n *Notifier) worker() {
+	ctx := context.Background()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case task := <-n.taskC:
+			limiter.Wait(ctx)
+
+			if err := n.post(ctx, task); err != nil {
+				log.WithError(err).Errorf("webhook post error")
+			}
+		}
+	}
+}
+
+func (n *Notifier) post(ctx context.Context, task notifyTask) error {
+	body, err := json.Marshal(task.payload)
+	if err != nil {
+		// the object could be not serializable, post the rendered attachments only
+		log.WithError(err).Warnf("unable to encode the webhook objects, the objects are dropped")
+
+		task.payload.Objects = nil
+		if body, err = json.Marshal(task.payload); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, task.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range n.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected webhook response: %d %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+func (n *Notifier) Notify(obj interface{}, args ...interface{}) {
+	n.NotifyTo("", obj, args...)
+}
+
+// filterObjects converts the object arguments to the objects and the attachments, the arguments before the first
+// object are the format arguments.
+func filterObjects(args []interface{}) (objects []Object, attachments []Attachment, pureArgs []interface{}) {
+	var firstObjectOffset = -1
+	for idx, arg := range args {
+		switch a := arg.(type) {
+
+		case slack.Attachment:
+			attachments = append(attachments, AttachmentFromSlackAttachment(a))
+
+		case types.SlackAttachmentCreator:
+			objects = append(objects, newObject(a))
+			attachments = append(attachments, AttachmentFromSlackAttachment(a.SlackAttachment()))
+
+		case types.PlainText:
+			objects = append(objects, newObject(a))
+			attachments = append(attachments, Attachment{Text: a.PlainText()})
+
+		default:
+			continue
+		}
+
+		if firstObjectOffset == -1 {
+			firstObjectOffset = idx
+		}
+	}
+
+	pureArgs = args
+	if firstObjectOffset > -1 {
+		pureArgs = args[:firstObjectOffset]
+	}
+
+	return objects, attachments, pureArgs
+}
+
+func (n *Notifier) NotifyTo(channel string, obj interface{}, args ...interface{}) {
+	objects, attachments, pureArgs := filterObjects(args)
+
+	payload := Payload{
+		Channel: channel,
+		Time:    time.Now(),
+	}
+
+	switch a := obj.(type) {
+	case string:
+		payload.Text = fmt.Sprintf(a, pureArgs...)
+
+	case slack.Attachment:
+		attachments = append([]Attachment{AttachmentFromSlackAttachment(a)}, attachments...)
+
+	case types.SlackAttachmentCreator:
+		objects = append([]Object{newObject(a)}, objects...)
+		attachments = append([]Attachment{AttachmentFromSlackAttachment(a.SlackAttachment())}, attachments...)
+
+	case types.PlainText:
+		objects = append([]Object{newObject(a)}, objects...)
+		payload.Text = a.PlainText()
+
+	case types.Stringer:
+		objects = append([]Object{newObject(a)}, objects...)
+		payload.Text = a.String()
+
+	default:
+		log.Errorf("webhook message conversion error, unsupported object: %T %+v", a, a)
+		return
+	}
+
+	payload.Objects = objects
+	payload.Attachments = attachments
+	n.enqueue(notifyTask{
+		url:     n.route(channel),
+		payload: payload,
+	})
+}
+
+func (n *Notifier) SendPhoto(buffer *bytes.Buffer) {
+	n.SendPhotoTo("", buffer)
+}
+
+func (n *Notifier) SendPhotoTo(channel string, buffer *bytes.Buffer) {
+	n.enqueue(notifyTask{
+		url: n.route(channel),
+		payload: Payload{
+			Channel: channel,
+			Image:   base64.StdEncoding.EncodeToString(buffer.Bytes()),
+			Time:    time.Now(),
+		},
+	})
+}
+
+// route returns the webhook url of the channel, the default url is used if the channel is not configured
+func (n *Notifier) route(channel string) string {
+	if url, ok := n.channels[channel]; ok {
+		return url
+	}
+
+	return n.url
+}
+
+func (n *Notifier) enqueue(task notifyTask) {
+	select {
+	case n.taskC <- task:
+	case <-time.After(50 * time.Millisecond):
+		log.Error("[webhook] cannot send task to notify")
+	}
+}
+
+func newObject(obj interface{}) Object {
+	return Object{
+		Type: strings.TrimPrefix(fmt.Sprintf("%T", obj), "*"),
+		Data: obj,
+	}
+}
+
+// AttachmentFromSlackAttachment converts the slack attachment to the webhook attachment
+func AttachmentFromSlackAttachment(attachment slack.Attachment) Attachment {
+	a := Attachment{
+		Title:  attachment.Title,
+		Text:   strings.TrimSpace(strings.Join([]string{attachment.Pretext, attachment.Text}, "\n")),
+		Color:  attachment.Color,
+		Footer: attachment.Footer,
+	}
+
+	for _, field := range attachment.Fields {
+		a.Fields = append(a.Fields, AttachmentField{
+			Title: field.Title,
+			Value: field.Value,
+		})
+	}
+
+	return a
+}
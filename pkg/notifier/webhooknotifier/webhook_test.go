@@ -0,0 +1,56 @@
+package webhooknotifier
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+func TestNotifier_NotifyTo(t *testing.T) {
+	payloadC := make(chan Payload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "/btc", r.URL.Path)
+
+		var payload Payload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		payloadC <- payload
+	}))
+	defer server.Close()
+
+	notifier := New(server.URL+"/default",
+		WithChannels(map[string]string{"#btc": server.URL + "/btc"}),
+		WithHeaders(map[string]string{"Authorization": "Bearer token"}))
+
+	trade := types.Trade{
+		ID:            1,
+		Exchange:      types.ExchangeMax,
+		Symbol:        "BTCUSDT",
+		Side:          types.SideTypeBuy,
+		Price:         fixedpoint.NewFromFloat(30000.0),
+		Quantity:      fixedpoint.NewFromFloat(0.1),
+		QuoteQuantity: fixedpoint.NewFromFloat(3000.0),
+		Time:          types.Time(time.Now()),
+	}
+	notifier.NotifyTo("#btc", "trade %s", "filled", trade)
+
+	select {
+	case payload := <-payloadC:
+		assert.Equal(t, "#btc", payload.Channel)
+		assert.Equal(t, "trade filled", payload.Text)
+		require.Len(t, payload.Objects, 1)
+		assert.Equal(t, "types.Trade", payload.Objects[0].Type)
+		require.Len(t, payload.Attachments, 1)
+		assert.NotEmpty(t, payload.Attachments[0].Fields)
+	case <-time.After(3 * time.Second):
+		t.Fatal("webhook is not posted")
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/c9s/bbgo/pkg/backtest"
 	"github.com/c9s/bbgo/pkg/fixedpoint"
 )
 
@@ -45,6 +46,9 @@ type Config struct {
 	Algorithm     string           `yaml:"algorithm,omitempty"`
 	Objective     string           `yaml:"objectiveBy,omitempty"`
 	MaxEvaluation int              `yaml:"maxEvaluation"`
+
+	// MonteCarloFilter runs the monte carlo analysis in every back-test and rejects the parameters that are not robust
+	MonteCarloFilter *MonteCarloFilter `yaml:"monteCarloFilter,omitempty"`
 }
 
 var defaultExecutorConfig = &ExecutorConfig{
@@ -79,12 +83,21 @@ func LoadConfig(yamlConfigFileName string) (*Config, error) {
 	switch objective := strings.ToLower(optConfig.Objective); objective {
 	case "", "default":
 		optConfig.Objective = HpOptimizerObjectiveEquity
-	case HpOptimizerObjectiveEquity, HpOptimizerObjectiveProfit, HpOptimizerObjectiveVolume, HpOptimizerObjectiveProfitFactor:
+	case HpOptimizerObjectiveEquity, HpOptimizerObjectiveProfit, HpOptimizerObjectiveVolume, HpOptimizerObjectiveProfitFactor,
+		HpOptimizerObjectiveCalmar, HpOptimizerObjectiveDrawdown:
 		optConfig.Objective = objective
 	default:
 		return nil, fmt.Errorf(`unknown objective "%s"`, optConfig.Objective)
 	}
 
+	if f := optConfig.MonteCarloFilter; f != nil {
+		switch f.Method {
+		case "", backtest.MonteCarloShuffle, backtest.MonteCarloBootstrap, backtest.MonteCarloPerturbation:
+		default:
+			return nil, fmt.Errorf(`unknown monte carlo method "%s"`, f.Method)
+		}
+	}
+
 	if optConfig.MaxEvaluation <= 0 {
 		optConfig.MaxEvaluation = 100
 	}
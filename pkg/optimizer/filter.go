@@ -0,0 +1,82 @@
+package optimizer
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+
+	"github.com/c9s/bbgo/pkg/backtest"
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+)
+
+// MonteCarloFilter rejects the parameters that are not robust in the monte carlo analysis of the back-test trades,
+// the zero thresholds are not checked
+type MonteCarloFilter struct {
+	bbgo.BacktestMonteCarlo `yaml:",inline"`
+
+	// Method is the simulation method to check, default bootstrap
+	Method backtest.MonteCarloMethod `json:"method,omitempty" yaml:"method,omitempty"`
+
+	// MaxRiskOfRuin rejects the parameters of which the risk of ruin is greater than it
+	MaxRiskOfRuin fixedpoint.Value `json:"maxRiskOfRuin,omitempty" yaml:"maxRiskOfRuin,omitempty"`
+
+	// MaxDrawdown rejects the parameters of which the upper bound of the max drawdown is greater than it
+	MaxDrawdown fixedpoint.Value `json:"maxDrawdown,omitempty" yaml:"maxDrawdown,omitempty"`
+
+	// MinFinalEquityRatio rejects the parameters of which the lower bound of the final equity divided by
+	// the initial equity is less than it, e.g. 1.0 rejects the parameters that could lose money
+	MinFinalEquityRatio fixedpoint.Value `json:"minFinalEquityRatio,omitempty" yaml:"minFinalEquityRatio,omitempty"`
+}
+
+// PatchConfig enables the monte carlo analysis in the backtest config
+func (f *MonteCarloFilter) PatchConfig(configJson []byte) ([]byte, error) {
+	value, err := json.Marshal(f.BacktestMonteCarlo)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonOp := []byte(fmt.Sprintf(`[{"op": "add", "path": "/backtest/monteCarlo", "value": %s }]`, value))
+	patch, err := jsonpatch.DecodePatch(jsonOp)
+	if err != nil {
+		return nil, err
+	}
+
+	return patch.ApplyIndent(configJson, "  ")
+}
+
+// Reject returns the reason if the summary report does not pass the filter
+func (f *MonteCarloFilter) Reject(summaryReport *backtest.SummaryReport) (string, bool) {
+	if summaryReport.MonteCarlo == nil {
+		return "monte carlo analysis is not found in the summary report", true
+	}
+
+	method := f.Method
+	if method == "" {
+		method = backtest.MonteCarloBootstrap
+	}
+
+	result := summaryReport.MonteCarlo.Result(method)
+	if result == nil {
+		return fmt.Sprintf("no %s simulation result, the parameters made no profit", method), true
+	}
+
+	if f.MaxRiskOfRuin.Sign() > 0 && result.RiskOfRuin.Compare(f.MaxRiskOfRuin) > 0 {
+		return fmt.Sprintf("risk of ruin %s > %s", result.RiskOfRuin.String(), f.MaxRiskOfRuin.String()), true
+	}
+
+	if f.MaxDrawdown.Sign() > 0 && result.MaxDrawdown.Upper.Compare(f.MaxDrawdown) > 0 {
+		return fmt.Sprintf("max drawdown upper bound %s > %s", result.MaxDrawdown.Upper.String(), f.MaxDrawdown.String()), true
+	}
+
+	if f.MinFinalEquityRatio.Sign() > 0 {
+		initialEquity := summaryReport.MonteCarlo.InitialEquity
+		if initialEquity.Sign() <= 0 || result.FinalEquity.Lower.Div(initialEquity).Compare(f.MinFinalEquityRatio) < 0 {
+			return fmt.Sprintf("final equity lower bound %s < %s of initial equity %s",
+				result.FinalEquity.Lower.String(), f.MinFinalEquityRatio.String(), initialEquity.String()), true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,77 @@
+package optimizer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/c9s/bbgo/pkg/backtest"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+)
+
+func TestMonteCarloFilter_PatchConfig(t *testing.T) {
+	var filter MonteCarloFilter
+	err := yaml.Unmarshal([]byte(`
+iterations: 200
+slippage: 0.001
+maxRiskOfRuin: 0.05
+`), &filter)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 200, filter.Iterations)
+	assert.Equal(t, "0.05", filter.MaxRiskOfRuin.String())
+
+	patched, err := filter.PatchConfig([]byte(`{"backtest": {"symbols": ["BTCUSDT"]}}`))
+	if assert.NoError(t, err) {
+		var config struct {
+			Backtest struct {
+				Symbols    []string `json:"symbols"`
+				MonteCarlo struct {
+					Iterations int              `json:"iterations"`
+					Slippage   fixedpoint.Value `json:"slippage"`
+				} `json:"monteCarlo"`
+			} `json:"backtest"`
+		}
+
+		assert.NoError(t, json.Unmarshal(patched, &config))
+		assert.Equal(t, []string{"BTCUSDT"}, config.Backtest.Symbols)
+		assert.Equal(t, 200, config.Backtest.MonteCarlo.Iterations)
+		assert.Equal(t, "0.001", config.Backtest.MonteCarlo.Slippage.String())
+	}
+}
+
+func TestMonteCarloFilter_Reject(t *testing.T) {
+	report := &backtest.SummaryReport{
+		MonteCarlo: &backtest.MonteCarloReport{
+			InitialEquity: fixedpoint.NewFromFloat(1000.0),
+			Results: []backtest.MonteCarloResult{
+				{
+					Method:      backtest.MonteCarloBootstrap,
+					FinalEquity: backtest.MonteCarloInterval{Lower: fixedpoint.NewFromFloat(950.0)},
+					MaxDrawdown: backtest.MonteCarloInterval{Upper: fixedpoint.NewFromFloat(0.2)},
+					RiskOfRuin:  fixedpoint.NewFromFloat(0.01),
+				},
+			},
+		},
+	}
+
+	_, rejected := (&MonteCarloFilter{MaxRiskOfRuin: fixedpoint.NewFromFloat(0.05), MaxDrawdown: fixedpoint.NewFromFloat(0.3)}).Reject(report)
+	assert.False(t, rejected)
+
+	reason, rejected := (&MonteCarloFilter{MaxDrawdown: fixedpoint.NewFromFloat(0.1)}).Reject(report)
+	assert.True(t, rejected)
+	assert.Contains(t, reason, "max drawdown")
+
+	_, rejected = (&MonteCarloFilter{MinFinalEquityRatio: fixedpoint.One}).Reject(report)
+	assert.True(t, rejected, "the lower bound could lose money")
+
+	_, rejected = (&MonteCarloFilter{Method: backtest.MonteCarloPerturbation}).Reject(report)
+	assert.True(t, rejected, "no perturbation result")
+
+	_, rejected = (&MonteCarloFilter{}).Reject(&backtest.SummaryReport{})
+	assert.True(t, rejected)
+}
@@ -53,6 +53,15 @@ var ProfitFactorMetricValueFunc = func(summaryReport *backtest.SummaryReport) fl
 	return pf*0.9 + win*0.1
 }
 
+var CalmarRatioMetricValueFunc = func(summaryReport *backtest.SummaryReport) float64 {
+	return summaryReport.CalmarRatio.Float64()
+}
+
+// NegativeMaxDrawdownMetricValueFunc returns the negative max drawdown, so that maximizing the metric minimizes the drawdown
+var NegativeMaxDrawdownMetricValueFunc = func(summaryReport *backtest.SummaryReport) float64 {
+	return -summaryReport.MaxDrawdown.Float64()
+}
+
 type Metric struct {
 	// Labels is the labels of the given parameters
 	Labels []string `json:"labels,omitempty"`
@@ -208,11 +217,20 @@ func (o *GridOptimizer) buildOps() []OpFunc {
 func (o *GridOptimizer) Run(executor Executor, configJson []byte) (map[string][]Metric, error) {
 	o.CurrentParams = make([]interface{}, len(o.Config.Matrix))
 
+	if o.Config.MonteCarloFilter != nil {
+		var err error
+		if configJson, err = o.Config.MonteCarloFilter.PatchConfig(configJson); err != nil {
+			return nil, err
+		}
+	}
+
 	var valueFunctions = map[string]MetricValueFunc{
-		"totalProfit":     TotalProfitMetricValueFunc,
-		"totalVolume":     TotalVolume,
-		"totalEquityDiff": TotalEquityDiff,
-		"profitFactor":    ProfitFactorMetricValueFunc,
+		"totalProfit":         TotalProfitMetricValueFunc,
+		"totalVolume":         TotalVolume,
+		"totalEquityDiff":     TotalEquityDiff,
+		"profitFactor":        ProfitFactorMetricValueFunc,
+		"calmarRatio":         CalmarRatioMetricValueFunc,
+		"negativeMaxDrawdown": NegativeMaxDrawdownMetricValueFunc,
 	}
 	var metrics = map[string][]Metric{}
 
@@ -283,6 +301,13 @@ func (o *GridOptimizer) Run(executor Executor, configJson []byte) (map[string][]
 			continue
 		}
 
+		if o.Config.MonteCarloFilter != nil {
+			if reason, rejected := o.Config.MonteCarloFilter.Reject(result.Report); rejected {
+				log.Infof("params %+v are rejected by the monte carlo filter: %s", result.Params, reason)
+				continue
+			}
+		}
+
 		for metricKey, metricFunc := range valueFunctions {
 			var metricValue = metricFunc(result.Report)
 			bar.Set("log", fmt.Sprintf("params: %+v => %s %+v", result.Params, metricKey, metricValue))
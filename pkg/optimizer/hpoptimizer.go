@@ -26,6 +26,10 @@ const (
 	HpOptimizerObjectiveVolume = "volume"
 	// HpOptimizerObjectiveProfitFactor optimize the parameters to maximize profit factor
 	HpOptimizerObjectiveProfitFactor = "profitfactor"
+	// HpOptimizerObjectiveCalmar optimize the parameters to maximize the calmar ratio of the equity curve
+	HpOptimizerObjectiveCalmar = "calmar"
+	// HpOptimizerObjectiveDrawdown optimize the parameters to minimize the max drawdown of the equity curve
+	HpOptimizerObjectiveDrawdown = "drawdown"
 )
 
 const (
@@ -204,6 +208,10 @@ func (o *HyperparameterOptimizer) buildObjective(executor Executor, configJson [
 		metricValueFunc = TotalEquityDiff
 	case HpOptimizerObjectiveProfitFactor:
 		metricValueFunc = ProfitFactorMetricValueFunc
+	case HpOptimizerObjectiveCalmar:
+		metricValueFunc = CalmarRatioMetricValueFunc
+	case HpOptimizerObjectiveDrawdown:
+		metricValueFunc = NegativeMaxDrawdownMetricValueFunc
 	}
 
 	return func(trial goptuna.Trial) (float64, error) {
@@ -230,12 +238,26 @@ func (o *HyperparameterOptimizer) buildObjective(executor Executor, configJson [
 		if err != nil {
 			return 0.0, err
 		}
+
+		if o.Config.MonteCarloFilter != nil {
+			if reason, rejected := o.Config.MonteCarloFilter.Reject(summary); rejected {
+				log.Debugf("trial #%d is pruned by the monte carlo filter: %s", trial.ID, reason)
+				return 0.0, goptuna.ErrTrialPruned
+			}
+		}
 		// By config, the Goptuna optimize the parameters by maximize the objective output.
 		return metricValueFunc(summary), nil
 	}
 }
 
 func (o *HyperparameterOptimizer) Run(ctx context.Context, executor Executor, configJson []byte) (*HyperparameterOptimizeReport, error) {
+	if o.Config.MonteCarloFilter != nil {
+		var err error
+		if configJson, err = o.Config.MonteCarloFilter.PatchConfig(configJson); err != nil {
+			return nil, err
+		}
+	}
+
 	labelPaths, paramDomains := o.buildParamDomains()
 	objective := o.buildObjective(executor, configJson, paramDomains)
 
@@ -262,7 +284,7 @@ func (o *HyperparameterOptimizer) Run(ctx context.Context, executor Executor, co
 			if result.State == goptuna.TrialStateFail {
 				log.WithFields(result.Params).Errorf("failed at trial #%d", result.ID)
 			}
-			if result.Value > bestVal {
+			if result.State == goptuna.TrialStateComplete && result.Value > bestVal {
 				bestVal = result.Value
 			}
 			bar.Set("log", fmt.Sprintf("best value: %v", bestVal))
@@ -508,3 +508,417 @@ var TradingService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/pb/bbgo.proto",
 }
+
+// StrategyServiceClient is the client API for StrategyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StrategyServiceClient interface {
+	ListStrategies(ctx context.Context, in *ListStrategiesRequest, opts ...grpc.CallOption) (*ListStrategiesResponse, error)
+	GetStrategy(ctx context.Context, in *StrategyRequest, opts ...grpc.CallOption) (*StrategyResponse, error)
+	SuspendStrategy(ctx context.Context, in *StrategyRequest, opts ...grpc.CallOption) (*StrategyResponse, error)
+	ResumeStrategy(ctx context.Context, in *StrategyRequest, opts ...grpc.CallOption) (*StrategyResponse, error)
+	ClosePosition(ctx context.Context, in *ClosePositionRequest, opts ...grpc.CallOption) (*StrategyResponse, error)
+	// Subscribe streams the position and the profit stats of the strategy instance when they are changed
+	Subscribe(ctx context.Context, in *SubscribeStrategyRequest, opts ...grpc.CallOption) (StrategyService_SubscribeClient, error)
+}
+
+type strategyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStrategyServiceClient(cc grpc.ClientConnInterface) StrategyServiceClient {
+	return &strategyServiceClient{cc}
+}
+
+func (c *strategyServiceClient) ListStrategies(ctx context.Context, in *ListStrategiesRequest, opts ...grpc.CallOption) (*ListStrategiesResponse, error) {
+	out := new(ListStrategiesResponse)
+	err := c.cc.Invoke(ctx, "/bbgo.StrategyService/ListStrategies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *strategyServiceClient) GetStrategy(ctx context.Context, in *StrategyRequest, opts ...grpc.CallOption) (*StrategyResponse, error) {
+	out := new(StrategyResponse)
+	err := c.cc.Invoke(ctx, "/bbgo.StrategyService/GetStrategy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *strategyServiceClient) SuspendStrategy(ctx context.Context, in *StrategyRequest, opts ...grpc.CallOption) (*StrategyResponse, error) {
+	out := new(StrategyResponse)
+	err := c.cc.Invoke(ctx, "/bbgo.StrategyService/SuspendStrategy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *strategyServiceClient) ResumeStrategy(ctx context.Context, in *StrategyRequest, opts ...grpc.CallOption) (*StrategyResponse, error) {
+	out := new(StrategyResponse)
+	err := c.cc.Invoke(ctx, "/bbgo.StrategyService/ResumeStrategy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *strategyServiceClient) ClosePosition(ctx context.Context, in *ClosePositionRequest, opts ...grpc.CallOption) (*StrategyResponse, error) {
+	out := new(StrategyResponse)
+	err := c.cc.Invoke(ctx, "/bbgo.StrategyService/ClosePosition", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *strategyServiceClient) Subscribe(ctx context.Context, in *SubscribeStrategyRequest, opts ...grpc.CallOption) (StrategyService_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StrategyService_ServiceDesc.Streams[0], "/bbgo.StrategyService/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &strategyServiceSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StrategyService_SubscribeClient interface {
+	Recv() (*StrategyUpdate, error)
+	grpc.ClientStream
+}
+
+type strategyServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *strategyServiceSubscribeClient) Recv() (*StrategyUpdate, error) {
+	m := new(StrategyUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StrategyServiceServer is the server API for StrategyService service.
+// All implementations must embed UnimplementedStrategyServiceServer
+// for forward compatibility
+type StrategyServiceServer interface {
+	ListStrategies(context.Context, *ListStrategiesRequest) (*ListStrategiesResponse, error)
+	GetStrategy(context.Context, *StrategyRequest) (*StrategyResponse, error)
+	SuspendStrategy(context.Context, *StrategyRequest) (*StrategyResponse, error)
+	ResumeStrategy(context.Context, *StrategyRequest) (*StrategyResponse, error)
+	ClosePosition(context.Context, *ClosePositionRequest) (*StrategyResponse, error)
+	// Subscribe streams the position and the profit stats of the strategy instance when they are changed
+	Subscribe(*SubscribeStrategyRequest, StrategyService_SubscribeServer) error
+	mustEmbedUnimplementedStrategyServiceServer()
+}
+
+// UnimplementedStrategyServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedStrategyServiceServer struct {
+}
+
+func (UnimplementedStrategyServiceServer) ListStrategies(context.Context, *ListStrategiesRequest) (*ListStrategiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStrategies not implemented")
+}
+func (UnimplementedStrategyServiceServer) GetStrategy(context.Context, *StrategyRequest) (*StrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStrategy not implemented")
+}
+func (UnimplementedStrategyServiceServer) SuspendStrategy(context.Context, *StrategyRequest) (*StrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuspendStrategy not implemented")
+}
+func (UnimplementedStrategyServiceServer) ResumeStrategy(context.Context, *StrategyRequest) (*StrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeStrategy not implemented")
+}
+func (UnimplementedStrategyServiceServer) ClosePosition(context.Context, *ClosePositionRequest) (*StrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClosePosition not implemented")
+}
+func (UnimplementedStrategyServiceServer) Subscribe(*SubscribeStrategyRequest, StrategyService_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedStrategyServiceServer) mustEmbedUnimplementedStrategyServiceServer() {}
+
+// UnsafeStrategyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StrategyServiceServer will
+// result in compilation errors.
+type UnsafeStrategyServiceServer interface {
+	mustEmbedUnimplementedStrategyServiceServer()
+}
+
+func RegisterStrategyServiceServer(s grpc.ServiceRegistrar, srv StrategyServiceServer) {
+	s.RegisterService(&StrategyService_ServiceDesc, srv)
+}
+
+func _StrategyService_ListStrategies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStrategiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StrategyServiceServer).ListStrategies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bbgo.StrategyService/ListStrategies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StrategyServiceServer).ListStrategies(ctx, req.(*ListStrategiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StrategyService_GetStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StrategyServiceServer).GetStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bbgo.StrategyService/GetStrategy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StrategyServiceServer).GetStrategy(ctx, req.(*StrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StrategyService_SuspendStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StrategyServiceServer).SuspendStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bbgo.StrategyService/SuspendStrategy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StrategyServiceServer).SuspendStrategy(ctx, req.(*StrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StrategyService_ResumeStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StrategyServiceServer).ResumeStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bbgo.StrategyService/ResumeStrategy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StrategyServiceServer).ResumeStrategy(ctx, req.(*StrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StrategyService_ClosePosition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClosePositionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StrategyServiceServer).ClosePosition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bbgo.StrategyService/ClosePosition",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StrategyServiceServer).ClosePosition(ctx, req.(*ClosePositionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StrategyService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeStrategyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StrategyServiceServer).Subscribe(m, &strategyServiceSubscribeServer{stream})
+}
+
+type StrategyService_SubscribeServer interface {
+	Send(*StrategyUpdate) error
+	grpc.ServerStream
+}
+
+type strategyServiceSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *strategyServiceSubscribeServer) Send(m *StrategyUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// StrategyService_ServiceDesc is the grpc.ServiceDesc for StrategyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StrategyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bbgo.StrategyService",
+	HandlerType: (*StrategyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListStrategies",
+			Handler:    _StrategyService_ListStrategies_Handler,
+		},
+		{
+			MethodName: "GetStrategy",
+			Handler:    _StrategyService_GetStrategy_Handler,
+		},
+		{
+			MethodName: "SuspendStrategy",
+			Handler:    _StrategyService_SuspendStrategy_Handler,
+		},
+		{
+			MethodName: "ResumeStrategy",
+			Handler:    _StrategyService_ResumeStrategy_Handler,
+		},
+		{
+			MethodName: "ClosePosition",
+			Handler:    _StrategyService_ClosePosition_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _StrategyService_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/pb/bbgo.proto",
+}
+
+// StrategyPluginServiceClient is the client API for StrategyPluginService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StrategyPluginServiceClient interface {
+	Connect(ctx context.Context, opts ...grpc.CallOption) (StrategyPluginService_ConnectClient, error)
+}
+
+type strategyPluginServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStrategyPluginServiceClient(cc grpc.ClientConnInterface) StrategyPluginServiceClient {
+	return &strategyPluginServiceClient{cc}
+}
+
+func (c *strategyPluginServiceClient) Connect(ctx context.Context, opts ...grpc.CallOption) (StrategyPluginService_ConnectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StrategyPluginService_ServiceDesc.Streams[0], "/bbgo.StrategyPluginService/Connect", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &strategyPluginServiceConnectClient{stream}
+	return x, nil
+}
+
+type StrategyPluginService_ConnectClient interface {
+	Send(*PluginEvent) error
+	Recv() (*PluginCommand, error)
+	grpc.ClientStream
+}
+
+type strategyPluginServiceConnectClient struct {
+	grpc.ClientStream
+}
+
+func (x *strategyPluginServiceConnectClient) Send(m *PluginEvent) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *strategyPluginServiceConnectClient) Recv() (*PluginCommand, error) {
+	m := new(PluginCommand)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StrategyPluginServiceServer is the server API for StrategyPluginService service.
+// All implementations must embed UnimplementedStrategyPluginServiceServer
+// for forward compatibility
+type StrategyPluginServiceServer interface {
+	Connect(StrategyPluginService_ConnectServer) error
+	mustEmbedUnimplementedStrategyPluginServiceServer()
+}
+
+// UnimplementedStrategyPluginServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedStrategyPluginServiceServer struct {
+}
+
+func (UnimplementedStrategyPluginServiceServer) Connect(StrategyPluginService_ConnectServer) error {
+	return status.Errorf(codes.Unimplemented, "method Connect not implemented")
+}
+func (UnimplementedStrategyPluginServiceServer) mustEmbedUnimplementedStrategyPluginServiceServer() {}
+
+// UnsafeStrategyPluginServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StrategyPluginServiceServer will
+// result in compilation errors.
+type UnsafeStrategyPluginServiceServer interface {
+	mustEmbedUnimplementedStrategyPluginServiceServer()
+}
+
+func RegisterStrategyPluginServiceServer(s grpc.ServiceRegistrar, srv StrategyPluginServiceServer) {
+	s.RegisterService(&StrategyPluginService_ServiceDesc, srv)
+}
+
+func _StrategyPluginService_Connect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StrategyPluginServiceServer).Connect(&strategyPluginServiceConnectServer{stream})
+}
+
+type StrategyPluginService_ConnectServer interface {
+	Send(*PluginCommand) error
+	Recv() (*PluginEvent, error)
+	grpc.ServerStream
+}
+
+type strategyPluginServiceConnectServer struct {
+	grpc.ServerStream
+}
+
+func (x *strategyPluginServiceConnectServer) Send(m *PluginCommand) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *strategyPluginServiceConnectServer) Recv() (*PluginEvent, error) {
+	m := new(PluginEvent)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StrategyPluginService_ServiceDesc is the grpc.ServiceDesc for StrategyPluginService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StrategyPluginService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bbgo.StrategyPluginService",
+	HandlerType: (*StrategyPluginServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Connect",
+			Handler:       _StrategyPluginService_Connect_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pkg/pb/bbgo.proto",
+}
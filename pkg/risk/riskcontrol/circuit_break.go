@@ -10,6 +10,7 @@ import (
 	"github.com/c9s/bbgo/pkg/types"
 )
 
+//go:generate callbackgen -type CircuitBreakRiskControl
 type CircuitBreakRiskControl struct {
 	// Since price could be fluctuated large,
 	// use an EWMA to smooth it in running time
@@ -20,6 +21,8 @@ type CircuitBreakRiskControl struct {
 	haltedDuration time.Duration
 
 	haltedAt time.Time
+
+	haltCallbacks []func(t time.Time, realizedPnL, unrealizedPnL fixedpoint.Value)
 }
 
 func NewCircuitBreakRiskControl(
@@ -61,6 +64,7 @@ func (c *CircuitBreakRiskControl) IsHalted(t time.Time) bool {
 	isHalted := unrealized.Add(c.profitStats.TodayPnL).Compare(c.lossThreshold) <= 0
 	if isHalted {
 		c.haltedAt = t
+		c.EmitHalt(t, c.profitStats.TodayPnL, unrealized)
 	}
 
 	return isHalted
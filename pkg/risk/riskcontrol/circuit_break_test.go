@@ -75,7 +75,13 @@ func Test_IsHalted(t *testing.T) {
 			now := time.Now()
 			riskControl.profitStats.ResetToday(now)
 			riskControl.profitStats.TodayPnL = realizedPnL
+
+			halted := false
+			riskControl.OnHalt(func(t time.Time, realizedPnL, unrealizedPnL fixedpoint.Value) {
+				halted = true
+			})
 			assert.Equal(t, tc.isHalted, riskControl.IsHalted(now.Add(time.Hour)))
+			assert.Equal(t, tc.isHalted, halted)
 		})
 	}
 }
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	eventBufferSize = 256
+
+	eventWriteWait  = 10 * time.Second
+	eventPongWait   = 60 * time.Second
+	eventPingPeriod = eventPongWait * 9 / 10
+)
+
+var eventUpgrader = websocket.Upgrader{
+	// the origins are allowed by the cors config
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// eventRequest is sent by the client to change the subscribed topics
+type eventRequest struct {
+	// Action is subscribe or unsubscribe
+	Action string   `json:"action"`
+	Topics []string `json:"topics"`
+}
+
+// streamStrategyEvents streams the strategy events of the subscribed topics over the websocket,
+// the initial topics can be given by the comma-separated "topics" query parameter
+func (s *Server) streamStrategyEvents(c *gin.Context) {
+	hub := s.Environ.StrategyEventHub
+	if hub == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "strategy event hub is not enabled"})
+		return
+	}
+
+	conn, err := eventUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		logrus.WithError(err).Error("websocket upgrade error")
+		return
+	}
+
+	defer conn.Close()
+
+	sub := hub.NewSubscriber(eventBufferSize)
+	defer hub.RemoveSubscriber(sub)
+
+	if topics := c.Query("topics"); topics != "" {
+		sub.Subscribe(strings.Split(topics, ",")...)
+	}
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	replies := make(chan gin.H, 8)
+	go func() {
+		defer cancel()
+
+		conn.SetReadLimit(4096)
+		_ = conn.SetReadDeadline(time.Now().Add(eventPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(eventPongWait))
+		})
+
+		for {
+			var req eventRequest
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+
+			var reply gin.H
+			switch req.Action {
+			case "subscribe":
+				sub.Subscribe(req.Topics...)
+				reply = gin.H{"type": "subscribed", "topics": sub.Topics()}
+
+			case "unsubscribe":
+				sub.Unsubscribe(req.Topics...)
+				reply = gin.H{"type": "unsubscribed", "topics": sub.Topics()}
+
+			default:
+				reply = gin.H{"type": "error", "error": "unknown action " + req.Action}
+			}
+
+			select {
+			case replies <- reply:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(eventPingPeriod)
+	defer ticker.Stop()
+
+	var dropped int64
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+
+		case event := <-sub.C:
+			_ = conn.SetWriteDeadline(time.Now().Add(eventWriteWait))
+			err = conn.WriteJSON(event)
+
+		case reply := <-replies:
+			_ = conn.SetWriteDeadline(time.Now().Add(eventWriteWait))
+			err = conn.WriteJSON(reply)
+
+		case <-ticker.C:
+			// notify the client that the events are dropped because it can not catch up
+			if n := sub.Dropped(); n > dropped {
+				_ = conn.SetWriteDeadline(time.Now().Add(eventWriteWait))
+				if err = conn.WriteJSON(gin.H{"type": "dropped", "count": n - dropped}); err != nil {
+					break
+				}
+				dropped = n
+			}
+
+			err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(eventWriteWait))
+		}
+
+		if err != nil {
+			logrus.WithError(err).Debug("strategy event websocket write error")
+			return
+		}
+	}
+}
@@ -17,8 +17,10 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/sirupsen/logrus"
 
+	"github.com/c9s/bbgo/pkg/accounting/pnl"
 	"github.com/c9s/bbgo/pkg/bbgo"
 	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/interact"
 	"github.com/c9s/bbgo/pkg/service"
 	"github.com/c9s/bbgo/pkg/types"
 )
@@ -45,6 +47,9 @@ type Server struct {
 	Setup         *Setup
 	OpenInBrowser bool
 
+	// Auth authenticates the strategy api requests, the strategy control api is disabled if it's nil
+	Auth *interact.AuthInteract
+
 	srv *http.Server
 }
 
@@ -52,7 +57,7 @@ func (s *Server) newEngine(ctx context.Context) *gin.Engine {
 	r := gin.Default()
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
-		AllowHeaders:     []string{"Origin", "Content-Type"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", OTPHeader},
 		ExposeHeaders:    []string{"Content-Length"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE"},
 		AllowWebSockets:  true,
@@ -231,23 +236,26 @@ func (s *Server) newEngine(ctx context.Context) *gin.Engine {
 	r.GET("/api/sessions/:session/account/balances", s.getSessionAccountBalance)
 	r.GET("/api/sessions/:session/symbols", s.listSessionSymbols)
 
-	r.GET("/api/sessions/:session/pnl", func(c *gin.Context) {
-		c.JSON(200, gin.H{"message": "pong"})
-	})
+	r.GET("/api/sessions/:session/pnl", s.getSessionPnL)
+	r.GET("/api/sessions/:session/market/:symbol/open-orders", s.listSessionMarketOpenOrders)
+	r.GET("/api/sessions/:session/market/:symbol/trades", s.listSessionMarketTrades)
+	r.GET("/api/sessions/:session/market/:symbol/pnl", s.getSessionMarketPnL)
 
-	r.GET("/api/sessions/:session/market/:symbol/open-orders", func(c *gin.Context) {
-		c.JSON(200, gin.H{"message": "pong"})
-	})
+	r.GET("/api/strategies/single", s.listStrategies)
+	r.GET("/api/strategies/nav", s.listStrategyNAVs)
+	r.GET("/api/strategies/nav/:instanceID", s.listStrategyInstanceNAVHistory)
 
-	r.GET("/api/sessions/:session/market/:symbol/trades", func(c *gin.Context) {
-		c.JSON(200, gin.H{"message": "pong"})
-	})
+	r.GET("/api/strategies", s.authenticate(false), s.listStrategyInstances)
+	r.GET("/api/strategies/:id", s.authenticate(false), s.getStrategyInstance)
 
-	r.GET("/api/sessions/:session/market/:symbol/pnl", func(c *gin.Context) {
-		c.JSON(200, gin.H{"message": "pong"})
-	})
+	r.GET("/api/events", s.authenticate(false), s.streamStrategyEvents)
 
-	r.GET("/api/strategies/single", s.listStrategies)
+	control := r.Group("/api/strategies/:id", s.authenticate(true))
+	control.POST("/suspend", s.controlStrategyInstance(suspendStrategyInstance))
+	control.POST("/resume", s.controlStrategyInstance(resumeStrategyInstance))
+	control.POST("/emergency-stop", s.controlStrategyInstance(emergencyStopStrategyInstance))
+	control.POST("/close-position", s.closeStrategyInstancePosition)
+	control.POST("/reload", s.controlStrategyInstance(reloadStrategyInstance))
 	r.NoRoute(s.assetsHandler)
 	return r
 }
@@ -313,6 +321,62 @@ func (s *Server) listClosedOrders(c *gin.Context) {
 	})
 }
 
+func (s *Server) listStrategyNAVs(c *gin.Context) {
+	if s.Environ.StrategyInstanceNAVTracker == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "strategy nav is not configured"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"navs": s.Environ.StrategyInstanceNAVTracker.Last(),
+	})
+}
+
+func (s *Server) listStrategyInstanceNAVHistory(c *gin.Context) {
+	if s.Environ.StrategyInstanceNAVService == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "database is not configured"})
+		return
+	}
+
+	options := service.QueryStrategyInstanceNAVOptions{
+		StrategyInstanceID: c.Param("instanceID"),
+		Ordering:           "ASC",
+	}
+
+	for param, t := range map[string]**time.Time{"since": &options.Since, "until": &options.Until} {
+		if str := c.Query(param); str != "" {
+			v, err := time.Parse(time.RFC3339, str)
+			if err != nil {
+				c.Status(http.StatusBadRequest)
+				logrus.WithError(err).Errorf("%s format incorrect", param)
+				return
+			}
+			*t = &v
+		}
+	}
+
+	if str := c.Query("limit"); str != "" {
+		limit, err := strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			logrus.WithError(err).Error("limit parse error")
+			return
+		}
+		options.Limit = limit
+	}
+
+	navs, err := s.Environ.StrategyInstanceNAVService.Query(options)
+	if err != nil {
+		c.Status(http.StatusBadRequest)
+		logrus.WithError(err).Error("strategy instance nav query error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"navs": navs,
+	})
+}
+
 func (s *Server) listStrategies(c *gin.Context) {
 	var stashes []map[string]interface{}
 
@@ -421,6 +485,103 @@ func (s *Server) listSessionOpenOrders(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"orders": marketOrders})
 }
 
+func (s *Server) listSessionMarketOpenOrders(c *gin.Context) {
+	sessionName := c.Param("session")
+	session, ok := s.Environ.Session(sessionName)
+
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("session %s not found", sessionName)})
+		return
+	}
+
+	orders := []types.Order{}
+	if orderStore, ok := session.OrderStores()[c.Param("symbol")]; ok {
+		for _, order := range orderStore.Orders() {
+			if types.IsActiveOrder(order) {
+				orders = append(orders, order)
+			}
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"orders": orders})
+}
+
+func (s *Server) listSessionMarketTrades(c *gin.Context) {
+	sessionName := c.Param("session")
+	session, ok := s.Environ.Session(sessionName)
+
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("session %s not found", sessionName)})
+		return
+	}
+
+	trades := []types.Trade{}
+	if tradeSlice, ok := session.Trades[c.Param("symbol")]; ok {
+		trades = append(trades, tradeSlice.Copy()...)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"trades": trades})
+}
+
+func (s *Server) getSessionPnL(c *gin.Context) {
+	sessionName := c.Param("session")
+	session, ok := s.Environ.Session(sessionName)
+
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("session %s not found", sessionName)})
+		return
+	}
+
+	reports := make(map[string]*pnl.AverageCostPnLReport)
+	for symbol := range session.Trades {
+		if report, ok := calculateSessionPnL(session, symbol); ok {
+			reports[symbol] = report
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pnl": reports})
+}
+
+func (s *Server) getSessionMarketPnL(c *gin.Context) {
+	sessionName := c.Param("session")
+	session, ok := s.Environ.Session(sessionName)
+
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("session %s not found", sessionName)})
+		return
+	}
+
+	symbol := c.Param("symbol")
+	report, ok := calculateSessionPnL(session, symbol)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("market %s not found in session %s", symbol, sessionName)})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pnl": report})
+}
+
+// calculateSessionPnL calculates the average cost pnl of the collected trades of the session market with the last price
+func calculateSessionPnL(session *bbgo.ExchangeSession, symbol string) (*pnl.AverageCostPnLReport, bool) {
+	market, ok := session.Market(symbol)
+	if !ok {
+		return nil, false
+	}
+
+	var trades []types.Trade
+	if tradeSlice, ok := session.Trades[symbol]; ok {
+		trades = tradeSlice.Copy()
+	}
+
+	lastPrice, _ := session.LastPrice(symbol)
+
+	calculator := &pnl.AverageCostCalculator{
+		TradingFeeCurrency: session.Exchange.PlatformFeeCurrency(),
+		Market:             market,
+	}
+	return calculator.Calculate(symbol, trades, lastPrice), true
+}
+
 func genFakeAssets() types.AssetMap {
 
 	totalAssets := types.AssetMap{}
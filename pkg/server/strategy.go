@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+)
+
+// OTPHeader is the request header of the time-based one-time password code
+const OTPHeader = "X-OTP"
+
+// authenticate verifies the bearer token and the one-time password code with the auth modes of the server.
+// When required is false, the requests are allowed if the auth is not configured.
+func (s *Server) authenticate(required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if s.Auth == nil {
+			if required {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "api authentication is not configured"})
+				return
+			}
+
+			c.Next()
+			return
+		}
+
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		code := c.GetHeader(OTPHeader)
+
+		// the browsers can not set the headers of the websocket requests
+		if c.IsWebsocket() {
+			if token == "" {
+				token = c.Query("token")
+			}
+
+			if code == "" {
+				code = c.Query("otp")
+			}
+		}
+
+		if err := s.Auth.Verify(token, code); err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func (s *Server) findStrategyInstance(c *gin.Context) (*bbgo.StrategyInstance, bool) {
+	if s.Trader == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "trader is not running"})
+		return nil, false
+	}
+
+	instance, err := s.Trader.FindStrategyInstance(c.Query("session"), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, bbgo.ErrStrategyInstanceNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return nil, false
+	}
+
+	return instance, true
+}
+
+func (s *Server) listStrategyInstances(c *gin.Context) {
+	if s.Trader == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "trader is not running"})
+		return
+	}
+
+	var states = []bbgo.StrategyInstanceState{}
+	for _, instance := range s.Trader.StrategyInstances() {
+		states = append(states, instance.State())
+	}
+
+	c.JSON(http.StatusOK, gin.H{"strategies": states})
+}
+
+func (s *Server) getStrategyInstance(c *gin.Context) {
+	instance, ok := s.findStrategyInstance(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"strategy": instance.State()})
+}
+
+// controlStrategyInstance returns the handler that applies the control on the strategy instance
+func (s *Server) controlStrategyInstance(control func(ctx context.Context, instance *bbgo.StrategyInstance) error) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		instance, ok := s.findStrategyInstance(c)
+		if !ok {
+			return
+		}
+
+		if err := control(c.Request.Context(), instance); err != nil {
+			logrus.WithError(err).Errorf("strategy %s control error", instance.InstanceID)
+
+			if errors.Is(err, bbgo.ErrStrategyControlNotSupported) {
+				c.JSON(http.StatusNotImplemented, gin.H{"error": err.Error()})
+			} else {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"strategy": instance.State()})
+	}
+}
+
+func (s *Server) closeStrategyInstancePosition(c *gin.Context) {
+	var req struct {
+		// Percentage is the percentage of the position to close, default 1.0
+		Percentage fixedpoint.Value `json:"percentage"`
+	}
+
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logrus.WithError(err).Error("close position request parse error")
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	percentage := req.Percentage
+	if percentage.IsZero() {
+		percentage = fixedpoint.One
+	}
+
+	if percentage.Sign() < 0 || percentage.Compare(fixedpoint.One) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid percentage %s, it should be in (0, 1]", percentage.String())})
+		return
+	}
+
+	s.controlStrategyInstance(func(ctx context.Context, instance *bbgo.StrategyInstance) error {
+		return instance.ClosePosition(ctx, percentage)
+	})(c)
+}
+
+func suspendStrategyInstance(_ context.Context, instance *bbgo.StrategyInstance) error {
+	return instance.Suspend()
+}
+
+func resumeStrategyInstance(_ context.Context, instance *bbgo.StrategyInstance) error {
+	return instance.Resume()
+}
+
+func emergencyStopStrategyInstance(_ context.Context, instance *bbgo.StrategyInstance) error {
+	return instance.EmergencyStop()
+}
+
+func reloadStrategyInstance(ctx context.Context, instance *bbgo.StrategyInstance) error {
+	return instance.Reload(ctx)
+}
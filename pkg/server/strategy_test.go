@@ -0,0 +1,153 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/interact"
+	"github.com/c9s/bbgo/pkg/types"
+	"github.com/c9s/bbgo/pkg/types/mocks"
+)
+
+type testStrategy struct {
+	Symbol string `json:"symbol"`
+
+	status types.StrategyStatus
+}
+
+func (s *testStrategy) ID() string { return "test" }
+
+func (s *testStrategy) InstanceID() string { return "test:" + s.Symbol }
+
+func (s *testStrategy) Run(ctx context.Context, orderExecutor bbgo.OrderExecutor, session *bbgo.ExchangeSession) error {
+	return nil
+}
+
+func (s *testStrategy) GetStatus() types.StrategyStatus { return s.status }
+
+func (s *testStrategy) Suspend() error {
+	s.status = types.StrategyStatusStopped
+	return nil
+}
+
+func (s *testStrategy) Resume() error {
+	s.status = types.StrategyStatusRunning
+	return nil
+}
+
+func newTestEngine(t *testing.T, auth *interact.AuthInteract) (*gin.Engine, *testStrategy) {
+	gin.SetMode(gin.TestMode)
+
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockEx := mocks.NewMockExchange(mockCtrl)
+	mockEx.EXPECT().NewStream().Return(&types.StandardStream{}).Times(2)
+
+	environ := bbgo.NewEnvironment()
+	environ.AddExchange("binance", mockEx)
+
+	strategy := &testStrategy{Symbol: "BTCUSDT", status: types.StrategyStatusRunning}
+
+	trader := bbgo.NewTrader(environ)
+	assert.NoError(t, trader.AttachStrategyOn("binance", strategy))
+
+	server := &Server{Environ: environ, Trader: trader, Auth: auth}
+	return server.newEngine(context.Background()), strategy
+}
+
+func serveTestRequest(r *gin.Engine, method, target string, header http.Header) int {
+	req := httptest.NewRequest(method, target, nil)
+	for k, values := range header {
+		for _, v := range values {
+			req.Header.Add(k, v)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestServer_authenticate(t *testing.T) {
+	const suspendPath = "/api/strategies/test:BTCUSDT/suspend"
+
+	t.Run("auth is not configured", func(t *testing.T) {
+		r, strategy := newTestEngine(t, nil)
+
+		assert.Equal(t, http.StatusOK, serveTestRequest(r, http.MethodGet, "/api/strategies", nil))
+		assert.Equal(t, http.StatusForbidden, serveTestRequest(r, http.MethodPost, suspendPath, nil))
+		assert.Equal(t, types.StrategyStatusRunning, strategy.status)
+	})
+
+	t.Run("token mode", func(t *testing.T) {
+		r, strategy := newTestEngine(t, interact.NewAuthInteract("secret", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, serveTestRequest(r, http.MethodGet, "/api/strategies", nil))
+		assert.Equal(t, http.StatusUnauthorized, serveTestRequest(r, http.MethodPost, suspendPath, nil))
+		assert.Equal(t, http.StatusUnauthorized, serveTestRequest(r, http.MethodPost, suspendPath, http.Header{
+			"Authorization": {"Bearer wrong"},
+		}))
+		assert.Equal(t, types.StrategyStatusRunning, strategy.status)
+
+		assert.Equal(t, http.StatusOK, serveTestRequest(r, http.MethodPost, suspendPath, http.Header{
+			"Authorization": {"Bearer secret"},
+		}))
+		assert.Equal(t, types.StrategyStatusStopped, strategy.status)
+	})
+
+	t.Run("strict mode", func(t *testing.T) {
+		key, err := totp.Generate(totp.GenerateOpts{Issuer: "bbgo", AccountName: "test"})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		code, err := totp.GenerateCode(key.Secret(), time.Now())
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		r, strategy := newTestEngine(t, interact.NewAuthInteract("secret", key))
+
+		assert.Equal(t, http.StatusUnauthorized, serveTestRequest(r, http.MethodPost, suspendPath, http.Header{
+			"Authorization": {"Bearer secret"},
+		}))
+		assert.Equal(t, http.StatusUnauthorized, serveTestRequest(r, http.MethodPost, suspendPath, http.Header{
+			OTPHeader: {code},
+		}))
+		assert.Equal(t, types.StrategyStatusRunning, strategy.status)
+
+		assert.Equal(t, http.StatusOK, serveTestRequest(r, http.MethodPost, suspendPath, http.Header{
+			"Authorization": {"Bearer secret"},
+			OTPHeader:       {code},
+		}))
+		assert.Equal(t, types.StrategyStatusStopped, strategy.status)
+	})
+
+	t.Run("query token of the websocket requests", func(t *testing.T) {
+		r, strategy := newTestEngine(t, interact.NewAuthInteract("secret", nil))
+
+		websocketHeader := http.Header{
+			"Connection": {"Upgrade"},
+			"Upgrade":    {"websocket"},
+		}
+
+		// the event hub is not enabled, the authenticated request gets 500 from the handler
+		assert.Equal(t, http.StatusInternalServerError, serveTestRequest(r, http.MethodGet, "/api/events?token=secret", websocketHeader))
+		assert.Equal(t, http.StatusUnauthorized, serveTestRequest(r, http.MethodGet, "/api/events?token=wrong", websocketHeader))
+
+		// the query token is ignored for the other requests
+		assert.Equal(t, http.StatusUnauthorized, serveTestRequest(r, http.MethodGet, "/api/events?token=secret", nil))
+		assert.Equal(t, http.StatusUnauthorized, serveTestRequest(r, http.MethodPost, suspendPath+"?token=secret", nil))
+		assert.Equal(t, types.StrategyStatusRunning, strategy.status)
+	})
+}
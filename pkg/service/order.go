@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -128,6 +129,46 @@ func (s *OrderService) Query(options QueryOrdersOptions) ([]AggOrder, error) {
 	return s.scanAggRows(rows)
 }
 
+// QueryByOrderIDs returns the stored orders of the exchange by the order IDs, the orders are sorted by the creation time
+func (s *OrderService) QueryByOrderIDs(ex types.ExchangeName, orderIDs []uint64) ([]types.Order, error) {
+	// query in chunks to keep the number of the bound parameters in the limit of the database
+	const chunkSize = 500
+
+	var orders []types.Order
+	for start := 0; start < len(orderIDs); start += chunkSize {
+		end := start + chunkSize
+		if end > len(orderIDs) {
+			end = len(orderIDs)
+		}
+
+		sql, args, err := sq.Select("*").
+			From("orders").
+			Where(sq.Eq{"exchange": ex, "order_id": orderIDs[start:end]}).
+			ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		rows, err := s.DB.Queryx(sql, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		chunk, err := s.scanRows(rows)
+		_ = rows.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, chunk...)
+	}
+
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].CreationTime.Time().Before(orders[j].CreationTime.Time())
+	})
+	return orders, nil
+}
+
 func genOrderSQL(options QueryOrdersOptions) string {
 	// ascending
 	ordering := "ASC"
@@ -2,8 +2,13 @@ package service
 
 import (
 	"testing"
+	"time"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
 )
 
 func Test_genOrderSQL(t *testing.T) {
@@ -22,3 +27,45 @@ func Test_genOrderSQL(t *testing.T) {
 	})
 
 }
+
+func TestOrderService_QueryByOrderIDs(t *testing.T) {
+	db, err := prepareDB(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	xdb := sqlx.NewDb(db.DB, "sqlite3")
+	service := &OrderService{DB: xdb}
+
+	now := time.Now()
+	for i, ex := range []types.ExchangeName{types.ExchangeBinance, types.ExchangeBinance, types.ExchangeMax} {
+		err = service.Insert(types.Order{
+			SubmitOrder: types.SubmitOrder{
+				Symbol:   "BTCUSDT",
+				Side:     types.SideTypeBuy,
+				Type:     types.OrderTypeLimit,
+				Price:    fixedpoint.NewFromInt(20000),
+				Quantity: fixedpoint.NewFromFloat(0.1),
+			},
+			Exchange:     ex,
+			OrderID:      uint64(i + 1),
+			Status:       types.OrderStatusFilled,
+			CreationTime: types.Time(now.Add(-time.Duration(i) * time.Minute)),
+			UpdateTime:   types.Time(now),
+		})
+		assert.NoError(t, err)
+	}
+
+	orders, err := service.QueryByOrderIDs(types.ExchangeBinance, []uint64{1, 2, 3})
+	assert.NoError(t, err)
+	if assert.Len(t, orders, 2) {
+		assert.Equal(t, uint64(2), orders[0].OrderID)
+		assert.Equal(t, uint64(1), orders[1].OrderID)
+	}
+
+	orders, err = service.QueryByOrderIDs(types.ExchangeBinance, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, orders)
+}
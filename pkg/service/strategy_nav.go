@@ -0,0 +1,112 @@
+package service
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+type StrategyInstanceNAVService struct {
+	DB *sqlx.DB
+}
+
+type QueryStrategyInstanceNAVOptions struct {
+	Strategy           string
+	StrategyInstanceID string
+
+	// inclusive
+	Since *time.Time
+
+	// exclusive
+	Until *time.Time
+
+	// ASC or DESC
+	Ordering string
+	Limit    uint64
+}
+
+func (s *StrategyInstanceNAVService) Insert(nav types.StrategyInstanceNAV) error {
+	_, err := s.DB.NamedExec(`
+		INSERT INTO strategy_instance_navs (
+			strategy,
+			strategy_instance_id,
+			session,
+			time,
+			net_asset_value,
+			realized_profit,
+			unrealized_profit,
+			fee_in_usd,
+			position_value,
+			num_of_trades
+		) VALUES (
+			:strategy,
+			:strategy_instance_id,
+			:session,
+			:time,
+			:net_asset_value,
+			:realized_profit,
+			:unrealized_profit,
+			:fee_in_usd,
+			:position_value,
+			:num_of_trades
+		)`, nav)
+	return err
+}
+
+func (s *StrategyInstanceNAVService) Query(options QueryStrategyInstanceNAVOptions) ([]types.StrategyInstanceNAV, error) {
+	sel := sq.Select("*").
+		From("strategy_instance_navs")
+
+	if options.Strategy != "" {
+		sel = sel.Where(sq.Eq{"strategy": options.Strategy})
+	}
+
+	if options.StrategyInstanceID != "" {
+		sel = sel.Where(sq.Eq{"strategy_instance_id": options.StrategyInstanceID})
+	}
+
+	if options.Since != nil {
+		sel = sel.Where(sq.GtOrEq{"time": options.Since})
+	}
+
+	if options.Until != nil {
+		sel = sel.Where(sq.Lt{"time": options.Until})
+	}
+
+	if options.Ordering != "" {
+		sel = sel.OrderBy("time " + options.Ordering)
+	} else {
+		sel = sel.OrderBy("time ASC")
+	}
+
+	if options.Limit > 0 {
+		sel = sel.Limit(options.Limit)
+	}
+
+	sql, args, err := sel.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.DB.Queryx(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var navs []types.StrategyInstanceNAV
+	for rows.Next() {
+		var nav types.StrategyInstanceNAV
+		if err := rows.StructScan(&nav); err != nil {
+			return navs, err
+		}
+
+		navs = append(navs, nav)
+	}
+
+	return navs, rows.Err()
+}
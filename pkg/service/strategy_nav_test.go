@@ -0,0 +1,66 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+func TestStrategyInstanceNAVService(t *testing.T) {
+	db, err := prepareDB(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	xdb := sqlx.NewDb(db.DB, "sqlite3")
+	service := &StrategyInstanceNAVService{DB: xdb}
+
+	now := time.Now().Truncate(time.Second)
+	for i := 0; i < 3; i++ {
+		err = service.Insert(types.StrategyInstanceNAV{
+			Strategy:           "grid2",
+			StrategyInstanceID: "grid2-BTCUSDT-100-20000-30000",
+			Session:            "binance",
+			Time:               types.Time(now.Add(time.Duration(i) * time.Minute)),
+			NetAssetValue:      fixedpoint.NewFromFloat(10.5 + float64(i)),
+			RealizedProfit:     fixedpoint.NewFromFloat(11),
+			UnrealizedProfit:   fixedpoint.NewFromFloat(float64(i)),
+			FeeInUSD:           fixedpoint.NewFromFloat(0.5),
+			PositionValue:      fixedpoint.NewFromFloat(300),
+			NumOfTrades:        10 + i,
+		})
+		assert.NoError(t, err)
+	}
+
+	err = service.Insert(types.StrategyInstanceNAV{
+		Strategy:           "bollmaker",
+		StrategyInstanceID: "bollmaker:BTCUSDT",
+		Session:            "max",
+		Time:               types.Time(now),
+	})
+	assert.NoError(t, err)
+
+	since := now.Add(time.Minute)
+	navs, err := service.Query(QueryStrategyInstanceNAVOptions{
+		StrategyInstanceID: "grid2-BTCUSDT-100-20000-30000",
+		Since:              &since,
+		Ordering:           "DESC",
+	})
+	if assert.NoError(t, err) && assert.Len(t, navs, 2) {
+		assert.Equal(t, "12.5", navs[0].NetAssetValue.String())
+		assert.Equal(t, 12, navs[0].NumOfTrades)
+		assert.Equal(t, "binance", navs[0].Session)
+	}
+
+	navs, err = service.Query(QueryStrategyInstanceNAVOptions{Strategy: "bollmaker"})
+	if assert.NoError(t, err) {
+		assert.Len(t, navs, 1)
+	}
+}
@@ -24,6 +24,10 @@ type QueryTradesOptions struct {
 	Symbol   string
 	LastGID  int64
 
+	// StrategyInstanceID filters the trades tagged with the strategy instance ID,
+	// or recorded in the positions of the strategy instance
+	StrategyInstanceID string
+
 	// inclusive
 	Since *time.Time
 
@@ -33,6 +37,9 @@ type QueryTradesOptions struct {
 	// ASC or DESC
 	Ordering string
 	Limit    uint64
+
+	// Offset skips the first trades, it's only applied with Limit
+	Offset uint64
 }
 
 type TradingVolume struct {
@@ -300,6 +307,15 @@ func (s *TradeService) Query(options QueryTradesOptions) ([]types.Trade, error)
 		sel = sel.Where(sq.Eq{"exchange": options.Sessions})
 	}
 
+	if options.StrategyInstanceID != "" {
+		sel = sel.Where(sq.Or{
+			sq.Eq{"strategy": options.StrategyInstanceID},
+			sq.Expr(`EXISTS (SELECT 1 FROM positions WHERE positions.trade_id = trades.id
+				AND positions.exchange = trades.exchange AND positions.side = trades.side
+				AND positions.strategy_instance_id = ?)`, options.StrategyInstanceID),
+		})
+	}
+
 	if options.Ordering != "" {
 		sel = sel.OrderBy("traded_at " + options.Ordering)
 	} else {
@@ -308,6 +324,10 @@ func (s *TradeService) Query(options QueryTradesOptions) ([]types.Trade, error)
 
 	if options.Limit > 0 {
 		sel = sel.Limit(options.Limit)
+
+		if options.Offset > 0 {
+			sel = sel.Offset(options.Offset)
+		}
 	}
 
 	sql, args, err := sel.ToSql()
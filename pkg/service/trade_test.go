@@ -1,6 +1,7 @@
 package service
 
 import (
+	"database/sql"
 	"testing"
 	"time"
 
@@ -35,6 +36,44 @@ func Test_tradeService(t *testing.T) {
 		Time:          types.Time(time.Now()),
 	})
 	assert.NoError(t, err)
+
+	t.Run("filter by strategy instance", func(t *testing.T) {
+		tagged := types.Trade{
+			ID:          2,
+			OrderID:     2,
+			Exchange:    "binance",
+			Symbol:      "BTCUSDT",
+			Side:        "SELL",
+			FeeCurrency: "USDT",
+			Time:        types.Time(time.Now()),
+			StrategyID:  sql.NullString{String: "grid2:BTCUSDT", Valid: true},
+		}
+		assert.NoError(t, service.Insert(tagged))
+
+		positionService := &PositionService{DB: xdb}
+		assert.NoError(t, positionService.Insert(&types.Position{
+			Symbol:             "BTCUSDT",
+			Strategy:           "grid2",
+			StrategyInstanceID: "grid2:BTCUSDT",
+		}, types.Trade{ID: 1, Exchange: "binance", Side: "BUY", Time: types.Time(time.Now())}, fixedpoint.Zero))
+
+		trades, err := service.Query(QueryTradesOptions{StrategyInstanceID: "grid2:BTCUSDT"})
+		assert.NoError(t, err)
+		if assert.Len(t, trades, 2) {
+			assert.Equal(t, uint64(1), trades[0].ID)
+			assert.Equal(t, uint64(2), trades[1].ID)
+		}
+
+		trades, err = service.Query(QueryTradesOptions{StrategyInstanceID: "bollmaker:BTCUSDT"})
+		assert.NoError(t, err)
+		assert.Empty(t, trades)
+
+		trades, err = service.Query(QueryTradesOptions{Symbol: "BTCUSDT", Limit: 1, Offset: 1})
+		assert.NoError(t, err)
+		if assert.Len(t, trades, 1) {
+			assert.Equal(t, uint64(2), trades[0].ID)
+		}
+	})
 }
 
 func Test_queryTradingVolumeSQL(t *testing.T) {
@@ -2,6 +2,7 @@ package common
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -79,6 +80,13 @@ func (s *Strategy) Initialize(ctx context.Context, environ *bbgo.Environment, se
 		log.Infof("positionHardLimit and maxPositionQuantity are configured, setting up PositionRiskControl...")
 		s.positionRiskControl = riskcontrol.NewPositionRiskControl(s.OrderExecutor, s.PositionHardLimit, s.MaxPositionQuantity)
 		s.positionRiskControl.Initialize(ctx, session)
+		s.positionRiskControl.OnReleasePosition(func(quantity fixedpoint.Value, side types.SideType) {
+			s.OrderExecutor.PublishEvent(bbgo.StrategyEventTypeRisk, bbgo.StrategyRiskEvent{
+				RiskControl: "positionHardLimit",
+				Message:     fmt.Sprintf("position is over the hard limit %s, releasing %s %s", s.PositionHardLimit.String(), side, quantity.String()),
+				Data:        map[string]interface{}{"quantity": quantity, "side": side},
+			})
+		})
 	}
 
 	if !s.CircuitBreakLossThreshold.IsZero() {
@@ -89,6 +97,13 @@ func (s *Strategy) Initialize(ctx context.Context, environ *bbgo.Environment, se
 			s.CircuitBreakLossThreshold,
 			s.ProfitStats,
 			24*time.Hour)
+		s.circuitBreakRiskControl.OnHalt(func(t time.Time, realizedPnL, unrealizedPnL fixedpoint.Value) {
+			s.OrderExecutor.PublishEvent(bbgo.StrategyEventTypeRisk, bbgo.StrategyRiskEvent{
+				RiskControl: "circuitBreak",
+				Message:     fmt.Sprintf("circuit break is triggered, realized PnL %s, unrealized PnL %s", realizedPnL.String(), unrealizedPnL.String()),
+				Data:        map[string]interface{}{"realizedPnL": realizedPnL, "unrealizedPnL": unrealizedPnL},
+			})
+		})
 	}
 }
 
@@ -89,6 +89,18 @@ type Strategy struct {
 
 	midPrice fixedpoint.Value
 	lock     sync.RWMutex `ignore:"true"`
+
+	// paramLock guards the modifiable parameters, which can be changed by the /set command
+	paramLock sync.Mutex `ignore:"true"`
+}
+
+// Lock locks the modifiable parameters, it's used by the /set command
+func (s *Strategy) Lock() {
+	s.paramLock.Lock()
+}
+
+func (s *Strategy) Unlock() {
+	s.paramLock.Unlock()
 }
 
 func (s *Strategy) ID() string {
@@ -187,6 +199,10 @@ func (s *Strategy) initIndicators(store *bbgo.SerialMarketDataStore) error {
 
 // FIXME: stdevHigh
 func (s *Strategy) smartCancel(ctx context.Context, pricef float64) int {
+	s.paramLock.Lock()
+	pendingMinInterval := s.PendingMinInterval
+	s.paramLock.Unlock()
+
 	nonTraded := s.GeneralOrderExecutor.ActiveMakerOrders().Orders()
 	if len(nonTraded) > 0 {
 		left := 0
@@ -196,7 +212,7 @@ func (s *Strategy) smartCancel(ctx context.Context, pricef float64) int {
 			}
 			log.Warnf("%v | counter: %d, system: %d", order, s.orderPendingCounter[order.OrderID], s.counter)
 			toCancel := false
-			if s.counter-s.orderPendingCounter[order.OrderID] >= s.PendingMinInterval {
+			if s.counter-s.orderPendingCounter[order.OrderID] >= pendingMinInterval {
 				toCancel = true
 			} else if order.Side == types.SideTypeBuy {
 				if order.Price.Float64()+s.atr.Last(0)*2 <= pricef {
@@ -238,9 +254,19 @@ func (s *Strategy) trailingCheck(price float64, direction string) bool {
 	if isShort && s.sellPrice == 0 || !isShort && s.buyPrice == 0 {
 		return false
 	}
-	for i := len(s.TrailingCallbackRate) - 1; i >= 0; i-- {
-		trailingCallbackRate := s.TrailingCallbackRate[i]
-		trailingActivationRatio := s.TrailingActivationRatio[i]
+	s.paramLock.Lock()
+	callbackRates, activationRatios := s.TrailingCallbackRate, s.TrailingActivationRatio
+	s.paramLock.Unlock()
+
+	// the lengths could be different after one of them is changed by the /set command
+	n := len(callbackRates)
+	if len(activationRatios) < n {
+		n = len(activationRatios)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		trailingCallbackRate := callbackRates[i]
+		trailingActivationRatio := activationRatios[i]
 		if isShort {
 			if (s.sellPrice-s.lowestPrice)/s.lowestPrice > trailingActivationRatio {
 				return (price-s.lowestPrice)/s.lowestPrice > trailingCallbackRate
@@ -422,7 +448,9 @@ func (s *Strategy) klineHandlerMin(ctx context.Context, kline types.KLine) {
 		return
 	}
 
+	s.paramLock.Lock()
 	stoploss := s.Stoploss.Float64()
+	s.paramLock.Unlock()
 	price := s.getLastPrice()
 	pricef := price.Float64()
 	atr := s.atr.Last(0)
@@ -468,7 +496,9 @@ func (s *Strategy) klineHandler(ctx context.Context, kline types.KLine) {
 		return
 	}
 
+	s.paramLock.Lock()
 	stoploss := s.Stoploss.Float64()
+	s.paramLock.Unlock()
 	price := s.getLastPrice()
 	pricef := price.Float64()
 	lowf := math.Min(kline.Low.Float64(), pricef)
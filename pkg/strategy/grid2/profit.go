@@ -27,6 +27,11 @@ func (p *GridProfit) PlainText() string {
 	return fmt.Sprintf("Grid profit: %f %s @ %s orderID %d", p.Profit.Float64(), p.Currency, p.Time.String(), p.Order.OrderID)
 }
 
+// DigestProfit implements bbgo.ProfitDigester, the grid profits are summarized in the notification digest
+func (p *GridProfit) DigestProfit() (string, string, fixedpoint.Value) {
+	return ID + ":" + p.Order.Symbol, p.Currency, p.Profit
+}
+
 func (p *GridProfit) SlackAttachment() slack.Attachment {
 	title := fmt.Sprintf("Grid Profit %s %s", style.PnLSignString(p.Profit), p.Currency)
 	return slack.Attachment{
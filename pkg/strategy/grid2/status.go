@@ -0,0 +1,63 @@
+package grid2
+
+import (
+	"sort"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// numOfRecentFills is the number of the filled orders shown in the grid status
+const numOfRecentFills = 5
+
+// GridStatus implements bbgo.GridStatusReader for the /grid command
+func (s *Strategy) GridStatus() *bbgo.GridStatus {
+	s.mu.Lock()
+	grid := s.grid
+	s.mu.Unlock()
+
+	status := &bbgo.GridStatus{
+		Symbol:        s.Symbol,
+		QuoteCurrency: s.Market.QuoteCurrency,
+	}
+
+	if s.GridProfitStats != nil {
+		status.ArbitrageCount = s.GridProfitStats.ArbitrageCount
+		status.Profit = s.GridProfitStats.TotalQuoteProfit
+	}
+
+	if grid == nil {
+		return status
+	}
+
+	pinOrders := make(map[fixedpoint.Value]types.Order)
+	if s.orderExecutor != nil {
+		for _, order := range s.orderExecutor.ActiveMakerOrders().Orders() {
+			pinOrders[order.Price] = order
+		}
+	}
+
+	for i := len(grid.Pins) - 1; i >= 0; i-- {
+		pin := bbgo.GridPin{Price: fixedpoint.Value(grid.Pins[i])}
+		if order, ok := pinOrders[pin.Price]; ok {
+			pin.Order = &order
+		}
+		status.Pins = append(status.Pins, pin)
+	}
+
+	if s.filledOrderIDMap != nil {
+		fills := s.filledOrderIDMap.Orders()
+		sort.Slice(fills, func(i, j int) bool {
+			return fills[i].UpdateTime.After(fills[j].UpdateTime.Time())
+		})
+
+		if len(fills) > numOfRecentFills {
+			fills = fills[:numOfRecentFills]
+		}
+
+		status.RecentFills = fills
+	}
+
+	return status
+}
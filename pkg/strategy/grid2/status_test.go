@@ -0,0 +1,68 @@
+//go:build !dnum
+
+package grid2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	gridmocks "github.com/c9s/bbgo/pkg/strategy/grid2/mocks"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+func TestStrategy_GridStatus(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	s := newTestStrategy()
+	s.grid = s.newGrid()
+	s.GridProfitStats.ArbitrageCount = 2
+	s.GridProfitStats.TotalQuoteProfit = number(12.5)
+
+	activeOrders := bbgo.NewActiveOrderBook("BTCUSDT")
+	activeOrders.Add(types.Order{
+		SubmitOrder: types.SubmitOrder{
+			Symbol:   "BTCUSDT",
+			Side:     types.SideTypeBuy,
+			Price:    number(11_000.0),
+			Quantity: number(0.1),
+		},
+		OrderID: 1,
+		Status:  types.OrderStatusNew,
+	})
+
+	orderExecutor := gridmocks.NewMockOrderExecutor(mockCtrl)
+	orderExecutor.EXPECT().ActiveMakerOrders().Return(activeOrders)
+	s.orderExecutor = orderExecutor
+
+	now := time.Now()
+	for i := 1; i <= 7; i++ {
+		s.filledOrderIDMap.Add(types.Order{
+			OrderID:    uint64(10 + i),
+			Status:     types.OrderStatusFilled,
+			UpdateTime: types.Time(now.Add(time.Duration(i) * time.Minute)),
+		})
+	}
+
+	status := s.GridStatus()
+	assert.Equal(t, 2, status.ArbitrageCount)
+	assert.Equal(t, "12.5", status.Profit.String())
+
+	if assert.Len(t, status.Pins, 11) {
+		assert.Equal(t, "20000", status.Pins[0].Price.String())
+		assert.Equal(t, "10000", status.Pins[10].Price.String())
+		assert.Nil(t, status.Pins[0].Order)
+		if assert.NotNil(t, status.Pins[9].Order) {
+			assert.Equal(t, uint64(1), status.Pins[9].Order.OrderID)
+		}
+	}
+
+	if assert.Len(t, status.RecentFills, numOfRecentFills) {
+		assert.Equal(t, uint64(17), status.RecentFills[0].OrderID)
+		assert.Equal(t, uint64(13), status.RecentFills[4].OrderID)
+	}
+}
@@ -1043,6 +1043,21 @@ func (s *Strategy) CloseGrid(ctx context.Context) error {
 	return err
 }
 
+// Reload implements bbgo.StrategyReloader, it closes the grid and re-opens the grid with the current parameters
+func (s *Strategy) Reload(ctx context.Context) error {
+	if err := s.Validate(); err != nil {
+		return errors.Wrap(err, "invalid grid parameters, the grid is not reloaded")
+	}
+
+	s.logger.Infof("reloading %s grid", s.Symbol)
+
+	if err := s.CloseGrid(ctx); err != nil {
+		return errors.Wrap(err, "unable to close the grid")
+	}
+
+	return s.OpenGrid(ctx)
+}
+
 func (s *Strategy) newGrid() *Grid {
 	grid := NewGrid(s.LowerPrice, s.UpperPrice, fixedpoint.NewFromInt(s.GridNum), s.Market.TickSize)
 	grid.CalculateArithmeticPins()
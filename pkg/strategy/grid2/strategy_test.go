@@ -11,6 +11,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/c9s/bbgo/pkg/bbgo"
 	"github.com/c9s/bbgo/pkg/core"
 	"github.com/c9s/bbgo/pkg/fixedpoint"
 	gridmocks "github.com/c9s/bbgo/pkg/strategy/grid2/mocks"
@@ -1723,3 +1724,70 @@ func Test_verifyFilledGrid(t *testing.T) {
 
 }
 */
+
+func TestStrategy_Reload(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reopen the grid with the modified parameters", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		mockEx := mocks.NewMockExchange(mockCtrl)
+		mockEx.EXPECT().NewStream().Return(&types.StandardStream{}).Times(2)
+		mockEx.EXPECT().QueryTickers(ctx, "BTCUSDT").Return(map[string]types.Ticker{
+			"BTCUSDT": {Last: number(15_000.0)},
+		}, nil)
+
+		session := bbgo.NewExchangeSession("test", mockEx)
+		session.Account.UpdateBalances(types.BalanceMap{
+			"USDT": {Currency: "USDT", Available: number(100_000.0)},
+		})
+
+		s := newTestStrategy()
+		s.session = session
+		s.Quantity = number(0.01)
+		s.grid = s.newGrid()
+
+		// the upper price is modified after the grid is opened
+		s.UpperPrice = number(21_000.0)
+
+		orderExecutor := gridmocks.NewMockOrderExecutor(mockCtrl)
+		orderExecutor.EXPECT().GracefulCancel(ctx).Return(nil)
+		orderExecutor.EXPECT().ActiveMakerOrders().Return(bbgo.NewActiveOrderBook("BTCUSDT")).AnyTimes()
+		orderExecutor.EXPECT().SubmitOrders(ctx, gomock.Any()).DoAndReturn(func(
+			ctx context.Context, orders ...types.SubmitOrder,
+		) (types.OrderSlice, error) {
+			assert.NotEmpty(t, orders)
+
+			// the orders are placed on the pins of the new grid, the pins are 10000, 11100, ..., 21000
+			newGrid := s.newGrid()
+			for _, order := range orders {
+				assert.True(t, newGrid.HasPrice(order.Price), "%s is not on the new grid", order.Price.String())
+			}
+			return nil, nil
+		})
+		s.orderExecutor = orderExecutor
+
+		err := s.Reload(ctx)
+		assert.NoError(t, err)
+		if assert.NotNil(t, s.getGrid()) {
+			assert.Equal(t, number(21_000.0), s.getGrid().UpperPrice)
+		}
+	})
+
+	t.Run("invalid parameters keep the grid", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		s := newTestStrategy()
+		s.Quantity = number(0.01)
+		s.grid = s.newGrid()
+		s.orderExecutor = gridmocks.NewMockOrderExecutor(mockCtrl)
+
+		s.UpperPrice = number(9_000.0)
+
+		err := s.Reload(ctx)
+		assert.Error(t, err)
+		assert.NotNil(t, s.getGrid())
+	})
+}
@@ -0,0 +1,53 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/sirupsen/logrus"
+)
+
+// startProcess launches the plugin process, the process will be killed when the context is canceled.
+// The plugin address and the strategy instance ID are passed to the process through the environment variables.
+func startProcess(ctx context.Context, command []string, env []string, logger logrus.FieldLogger) (*exec.Cmd, error) {
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Env = append(os.Environ(), env...)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	go pipeLog(stdout, logger.Infof)
+	go pipeLog(stderr, logger.Warnf)
+
+	go func() {
+		if err := cmd.Wait(); err != nil && ctx.Err() == nil {
+			logger.WithError(err).Errorf("plugin process exited")
+			return
+		}
+
+		logger.Infof("plugin process exited")
+	}()
+
+	return cmd, nil
+}
+
+func pipeLog(r io.Reader, logf func(format string, args ...interface{})) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		logf("[plugin] %s", scanner.Text())
+	}
+}
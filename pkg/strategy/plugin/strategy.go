@@ -0,0 +1,299 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/grpc"
+	"github.com/c9s/bbgo/pkg/strategy/common"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+const ID = "plugin"
+
+var log = logrus.WithField("strategy", ID)
+
+var ErrHalted = errors.New("strategy is halted by the circuit breaker")
+
+func init() {
+	bbgo.RegisterStrategy(ID, &Strategy{})
+}
+
+// Strategy connects to an external strategy process (the plugin) over gRPC.
+// The plugin implements the StrategyPluginService defined in pkg/pb/bbgo.proto,
+// it receives the klines, the order book and the user data of the strategy,
+// and sends the order commands back.
+//
+// The orders are submitted through the GeneralOrderExecutor, so the plugin gets the same
+// position tracking, profit stats, persistence and risk controls as a native strategy.
+type Strategy struct {
+	*common.Strategy
+
+	Environment *bbgo.Environment
+	Market      types.Market
+
+	// Name is the name of the plugin, it's used in the instance ID, default to the address
+	Name string `json:"name"`
+
+	Symbol   string         `json:"symbol"`
+	Interval types.Interval `json:"interval"`
+
+	// Address is the gRPC address of the plugin, e.g. localhost:50052
+	Address string `json:"address"`
+
+	// Command launches the plugin process before connecting, e.g. ["python", "my_strategy.py"]
+	// BBGO_PLUGIN_ADDRESS and BBGO_STRATEGY_INSTANCE_ID are passed to the process as environment variables.
+	Command []string `json:"command,omitempty"`
+
+	// Book sends the order book snapshots and updates to the plugin
+	Book bool `json:"book"`
+
+	// MarketTrades sends the market trades to the plugin
+	MarketTrades bool `json:"marketTrades"`
+
+	// Params is sent to the plugin in the INIT event
+	Params map[string]string `json:"params,omitempty"`
+
+	// MaxOrderQuantity rejects the orders with quantity greater than this value
+	MaxOrderQuantity fixedpoint.Value `json:"maxOrderQuantity,omitempty"`
+
+	// MaxOrderAmount rejects the orders with amount (price * quantity) greater than this value
+	MaxOrderAmount fixedpoint.Value `json:"maxOrderAmount,omitempty"`
+
+	session *bbgo.ExchangeSession
+	client  *grpc.PluginClient
+
+	logger logrus.FieldLogger
+}
+
+func (s *Strategy) ID() string {
+	return ID
+}
+
+func (s *Strategy) InstanceID() string {
+	return fmt.Sprintf("%s:%s:%s", ID, s.Name, s.Symbol)
+}
+
+func (s *Strategy) Initialize() error {
+	if s.Strategy == nil {
+		s.Strategy = &common.Strategy{}
+	}
+
+	return nil
+}
+
+func (s *Strategy) Defaults() error {
+	if s.Interval == "" {
+		s.Interval = types.Interval1m
+	}
+
+	if s.Name == "" {
+		s.Name = s.Address
+	}
+
+	return nil
+}
+
+func (s *Strategy) Validate() error {
+	if len(s.Symbol) == 0 {
+		return errors.New("symbol is required")
+	}
+
+	if len(s.Address) == 0 {
+		return errors.New("plugin address is required")
+	}
+
+	return nil
+}
+
+func (s *Strategy) Subscribe(session *bbgo.ExchangeSession) {
+	session.Subscribe(types.KLineChannel, s.Symbol, types.SubscribeOptions{Interval: s.Interval})
+
+	if s.Book {
+		session.Subscribe(types.BookChannel, s.Symbol, types.SubscribeOptions{})
+	}
+
+	if s.MarketTrades {
+		session.Subscribe(types.MarketTradeChannel, s.Symbol, types.SubscribeOptions{})
+	}
+}
+
+func (s *Strategy) Run(ctx context.Context, _ bbgo.OrderExecutor, session *bbgo.ExchangeSession) error {
+	s.Strategy.Initialize(ctx, s.Environment, session, s.Market, ID, s.InstanceID())
+
+	s.session = session
+	s.logger = log.WithFields(logrus.Fields{
+		"symbol": s.Symbol,
+		"plugin": s.Name,
+	})
+
+	if bbgo.IsBackTesting {
+		s.logger.Warnf("the plugin responds asynchronously, the back-test result might not be deterministic")
+	}
+
+	s.client = grpc.NewPluginClient(s.Address, s.InstanceID(), session)
+	s.client.OnConnect(func() {
+		s.client.SendInit(s.Market, s.Position, session.GetAccount().Balances(), s.Params)
+	})
+
+	session.MarketDataStream.OnKLineClosed(types.KLineWith(s.Symbol, s.Interval, s.client.SendKLine))
+
+	if s.Book {
+		session.MarketDataStream.OnBookSnapshot(func(book types.SliceOrderBook) {
+			if book.Symbol == s.Symbol {
+				s.client.SendBookSnapshot(book)
+			}
+		})
+		session.MarketDataStream.OnBookUpdate(func(book types.SliceOrderBook) {
+			if book.Symbol == s.Symbol {
+				s.client.SendBookUpdate(book)
+			}
+		})
+	}
+
+	if s.MarketTrades {
+		session.MarketDataStream.OnMarketTrade(func(trade types.Trade) {
+			if trade.Symbol == s.Symbol {
+				s.client.SendMarketTrade(trade)
+			}
+		})
+	}
+
+	// only the orders submitted by this strategy are sent to the plugin
+	session.UserDataStream.OnOrderUpdate(func(order types.Order) {
+		if s.OrderExecutor.OrderStore().Exists(order.OrderID) {
+			s.client.SendOrderUpdate(order)
+		}
+	})
+
+	session.UserDataStream.OnBalanceUpdate(s.client.SendBalanceUpdate)
+
+	s.OrderExecutor.TradeCollector().OnTrade(func(trade types.Trade, _, _ fixedpoint.Value) {
+		s.client.SendTradeUpdate(trade)
+	})
+
+	s.OrderExecutor.TradeCollector().OnPositionUpdate(func(position *types.Position) {
+		s.client.SendPositionUpdate(position)
+		bbgo.Sync(ctx, s)
+	})
+
+	pluginCtx, cancelPlugin := context.WithCancel(ctx)
+
+	if len(s.Command) > 0 {
+		env := []string{
+			"BBGO_PLUGIN_ADDRESS=" + s.Address,
+			"BBGO_STRATEGY_INSTANCE_ID=" + s.InstanceID(),
+		}
+
+		if _, err := startProcess(pluginCtx, s.Command, env, s.logger); err != nil {
+			cancelPlugin()
+			return errors.Wrapf(err, "unable to start plugin process %v", s.Command)
+		}
+	}
+
+	go s.client.Run(pluginCtx, s)
+
+	bbgo.OnShutdown(ctx, func(ctx context.Context, wg *sync.WaitGroup) {
+		defer wg.Done()
+
+		s.client.SendShutdown()
+		cancelPlugin()
+
+		if err := s.OrderExecutor.GracefulCancel(ctx); err != nil {
+			s.logger.WithError(err).Errorf("graceful cancel order error")
+		}
+
+		bbgo.Sync(ctx, s)
+	})
+
+	return nil
+}
+
+// checkOrder applies the risk checks on the orders sent from the plugin
+func (s *Strategy) checkOrder(submitOrder *types.SubmitOrder) error {
+	if len(submitOrder.Symbol) == 0 {
+		submitOrder.Symbol = s.Symbol
+	} else if submitOrder.Symbol != s.Symbol {
+		return fmt.Errorf("order symbol %s does not match the strategy symbol %s", submitOrder.Symbol, s.Symbol)
+	}
+
+	submitOrder.Market = s.Market
+
+	if submitOrder.Quantity.Sign() <= 0 {
+		return fmt.Errorf("invalid order quantity %s", submitOrder.Quantity.String())
+	}
+
+	if s.MaxOrderQuantity.Sign() > 0 && submitOrder.Quantity.Compare(s.MaxOrderQuantity) > 0 {
+		return fmt.Errorf("order quantity %s exceeds the max order quantity %s", submitOrder.Quantity.String(), s.MaxOrderQuantity.String())
+	}
+
+	if s.MaxOrderAmount.Sign() > 0 {
+		price := submitOrder.Price
+		if price.IsZero() {
+			if lastPrice, ok := s.session.LastPrice(s.Symbol); ok {
+				price = lastPrice
+			}
+		}
+
+		if amount := price.Mul(submitOrder.Quantity); amount.Compare(s.MaxOrderAmount) > 0 {
+			return fmt.Errorf("order amount %s exceeds the max order amount %s", amount.String(), s.MaxOrderAmount.String())
+		}
+	}
+
+	if len(submitOrder.Tag) == 0 {
+		submitOrder.Tag = ID
+	}
+
+	return nil
+}
+
+func (s *Strategy) SubmitOrders(ctx context.Context, submitOrders ...types.SubmitOrder) (types.OrderSlice, error) {
+	if s.IsHalted(time.Now()) {
+		return nil, ErrHalted
+	}
+
+	for i := range submitOrders {
+		if err := s.checkOrder(&submitOrders[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.OrderExecutor.SubmitOrders(ctx, submitOrders...)
+}
+
+func (s *Strategy) CancelOrders(ctx context.Context, orderIDs ...uint64) error {
+	var orders []types.Order
+	for _, orderID := range orderIDs {
+		order, ok := s.OrderExecutor.ActiveMakerOrders().Get(orderID)
+		if !ok {
+			return fmt.Errorf("order %d is not an active order of this strategy", orderID)
+		}
+
+		orders = append(orders, order)
+	}
+
+	if len(orders) == 0 {
+		return nil
+	}
+
+	return s.OrderExecutor.GracefulCancel(ctx, orders...)
+}
+
+func (s *Strategy) CancelAllOrders(ctx context.Context) error {
+	return s.OrderExecutor.GracefulCancel(ctx)
+}
+
+func (s *Strategy) ClosePosition(ctx context.Context, percentage fixedpoint.Value, tag string) error {
+	if len(tag) == 0 {
+		tag = ID
+	}
+
+	return s.OrderExecutor.ClosePosition(ctx, percentage, tag)
+}
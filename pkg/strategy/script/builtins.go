@@ -0,0 +1,399 @@
+package script
+
+import (
+	"fmt"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	indicatorv2 "github.com/c9s/bbgo/pkg/indicator/v2"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+type float64Series interface {
+	Last(i int) float64
+	Length() int
+}
+
+// seriesValue wraps an indicator series as a starlark value
+// the script can read the values via series.last(i) and series.length()
+type seriesValue struct {
+	name   string
+	series float64Series
+}
+
+var _ starlark.HasAttrs = &seriesValue{}
+
+func (v *seriesValue) String() string        { return fmt.Sprintf("<series %s>", v.name) }
+func (v *seriesValue) Type() string          { return "series" }
+func (v *seriesValue) Freeze()               {}
+func (v *seriesValue) Truth() starlark.Bool  { return v.series.Length() > 0 }
+func (v *seriesValue) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable type: series") }
+
+func (v *seriesValue) AttrNames() []string { return []string{"last", "length"} }
+
+func (v *seriesValue) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "last":
+		return starlark.NewBuiltin(v.name+".last", func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var i int
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs, "i?", &i); err != nil {
+				return nil, err
+			}
+			return starlark.Float(v.series.Last(i)), nil
+		}), nil
+
+	case "length":
+		return starlark.NewBuiltin(v.name+".length", func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+				return nil, err
+			}
+			return starlark.MakeInt(v.series.Length()), nil
+		}), nil
+	}
+
+	return nil, nil
+}
+
+type builtinFunc func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error)
+
+func newModule(name string, funcs map[string]builtinFunc) *starlarkstruct.Module {
+	members := make(starlark.StringDict, len(funcs))
+	for fn, f := range funcs {
+		members[fn] = starlark.NewBuiltin(name+"."+fn, f)
+	}
+
+	return &starlarkstruct.Module{Name: name, Members: members}
+}
+
+// predeclared returns the global names that are visible to the script
+func (s *Strategy) predeclared() (starlark.StringDict, error) {
+	params, err := toStarlark(s.Params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid script params: %w", err)
+	}
+	params.Freeze()
+
+	return starlark.StringDict{
+		"symbol":     starlark.String(s.Symbol),
+		"interval":   starlark.String(s.Interval),
+		"params":     params,
+		"state":      s.stateDict,
+		"notify":     starlark.NewBuiltin("notify", s.builtinNotify),
+		"session":    s.sessionModule(),
+		"indicators": s.indicatorsModule(),
+		"orders":     s.ordersModule(),
+	}, nil
+}
+
+func (s *Strategy) builtinNotify(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var msg string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "msg", &msg); err != nil {
+		return nil, err
+	}
+
+	bbgo.Notify("[%s] %s", s.InstanceID(), msg)
+	return starlark.None, nil
+}
+
+func (s *Strategy) sessionModule() *starlarkstruct.Module {
+	return newModule("session", map[string]builtinFunc{
+		"last_price": func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var symbol = s.Symbol
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs, "symbol?", &symbol); err != nil {
+				return nil, err
+			}
+
+			price, ok := s.session.LastPrice(symbol)
+			if !ok {
+				return starlark.None, nil
+			}
+
+			return floatValue(price), nil
+		},
+
+		"balance": func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var currency string
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs, "currency", &currency); err != nil {
+				return nil, err
+			}
+
+			balance, ok := s.session.GetAccount().Balance(currency)
+			if !ok {
+				balance = types.Balance{Currency: currency}
+			}
+
+			return starlarkstruct.FromStringDict(starlark.String("balance"), starlark.StringDict{
+				"currency":  starlark.String(balance.Currency),
+				"available": floatValue(balance.Available),
+				"locked":    floatValue(balance.Locked),
+				"total":     floatValue(balance.Total()),
+			}), nil
+		},
+
+		"market": func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+				return nil, err
+			}
+
+			return starlarkstruct.FromStringDict(starlark.String("market"), starlark.StringDict{
+				"symbol":         starlark.String(s.Market.Symbol),
+				"base_currency":  starlark.String(s.Market.BaseCurrency),
+				"quote_currency": starlark.String(s.Market.QuoteCurrency),
+				"tick_size":      floatValue(s.Market.TickSize),
+				"step_size":      floatValue(s.Market.StepSize),
+				"min_quantity":   floatValue(s.Market.MinQuantity),
+				"min_notional":   floatValue(s.Market.MinNotional),
+			}), nil
+		},
+	})
+}
+
+// indicator returns the cached series value, the indicators are bound to the stream when they are created,
+// so we should not create the same indicator twice.
+func (s *Strategy) indicator(key string, create func() float64Series) *seriesValue {
+	if v, ok := s.indicators[key]; ok {
+		return v
+	}
+
+	v := &seriesValue{name: key, series: create()}
+	s.indicators[key] = v
+	return v
+}
+
+func (s *Strategy) indicatorsModule() *starlarkstruct.Module {
+	set := s.session.Indicators(s.Symbol)
+
+	priceFunc := func(name string, f func(interval types.Interval) *indicatorv2.PriceStream) builtinFunc {
+		return func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var interval = string(s.Interval)
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs, "interval?", &interval); err != nil {
+				return nil, err
+			}
+
+			key := fmt.Sprintf("%s:%s", name, interval)
+			return s.indicator(key, func() float64Series {
+				return f(types.Interval(interval))
+			}), nil
+		}
+	}
+
+	windowFunc := func(name string, f func(iw types.IntervalWindow) float64Series) builtinFunc {
+		return func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var interval string
+			var window intValue
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs, "interval", &interval, "window", &window); err != nil {
+				return nil, err
+			}
+
+			if window <= 0 {
+				return nil, fmt.Errorf("%s: window must be greater than 0", b.Name())
+			}
+
+			key := fmt.Sprintf("%s:%s:%d", name, interval, window)
+			return s.indicator(key, func() float64Series {
+				return f(types.IntervalWindow{Interval: types.Interval(interval), Window: int(window)})
+			}), nil
+		}
+	}
+
+	return newModule("indicators", map[string]builtinFunc{
+		"open":   priceFunc("open", set.OPEN),
+		"high":   priceFunc("high", set.HIGH),
+		"low":    priceFunc("low", set.LOW),
+		"close":  priceFunc("close", set.CLOSE),
+		"volume": priceFunc("volume", set.VOLUME),
+		"sma": windowFunc("sma", func(iw types.IntervalWindow) float64Series {
+			return indicatorv2.SMA(set.CLOSE(iw.Interval), iw.Window)
+		}),
+		"ewma": windowFunc("ewma", func(iw types.IntervalWindow) float64Series {
+			return set.EWMA(iw)
+		}),
+		"rsi": windowFunc("rsi", func(iw types.IntervalWindow) float64Series {
+			return set.RSI(iw)
+		}),
+		"atr": windowFunc("atr", func(iw types.IntervalWindow) float64Series {
+			return set.ATR(iw.Interval, iw.Window)
+		}),
+		"atrp": windowFunc("atrp", func(iw types.IntervalWindow) float64Series {
+			return set.ATRP(iw.Interval, iw.Window)
+		}),
+		"boll": func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var interval string
+			var window intValue
+			var k = 2.0
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs, "interval", &interval, "window", &window, "k?", &k); err != nil {
+				return nil, err
+			}
+
+			if window <= 0 {
+				return nil, fmt.Errorf("%s: window must be greater than 0", b.Name())
+			}
+
+			key := fmt.Sprintf("boll:%s:%d:%g", interval, window, k)
+			if _, ok := s.indicators[key+":mid"]; !ok {
+				boll := set.BOLL(types.IntervalWindow{Interval: types.Interval(interval), Window: int(window)}, k)
+				s.indicators[key+":mid"] = &seriesValue{name: key + ":mid", series: boll.SMA}
+				s.indicators[key+":up"] = &seriesValue{name: key + ":up", series: boll.UpBand}
+				s.indicators[key+":down"] = &seriesValue{name: key + ":down", series: boll.DownBand}
+			}
+
+			return starlarkstruct.FromStringDict(starlark.String("boll"), starlark.StringDict{
+				"mid":  s.indicators[key+":mid"],
+				"up":   s.indicators[key+":up"],
+				"down": s.indicators[key+":down"],
+			}), nil
+		},
+	})
+}
+
+func (s *Strategy) ordersModule() *starlarkstruct.Module {
+	return newModule("orders", map[string]builtinFunc{
+		"position": func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+				return nil, err
+			}
+
+			return newPositionStruct(s.Position), nil
+		},
+
+		"open_position": func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var long, short, limitOrder bool
+			var quantity, price starlark.Value = starlark.None, starlark.None
+			var tags *starlark.List
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs,
+				"long?", &long,
+				"short?", &short,
+				"quantity?", &quantity,
+				"price?", &price,
+				"limit_order?", &limitOrder,
+				"tags?", &tags); err != nil {
+				return nil, err
+			}
+
+			opts := bbgo.OpenPositionOptions{
+				Long:       long,
+				Short:      short,
+				LimitOrder: limitOrder,
+				Tags:       []string{ID},
+			}
+
+			var err error
+			if opts.Quantity, err = toFixedpoint(quantity); err != nil {
+				return nil, err
+			}
+
+			if opts.Price, err = toFixedpoint(price); err != nil {
+				return nil, err
+			}
+
+			if opts.Price.IsZero() {
+				if lastPrice, ok := s.session.LastPrice(s.Symbol); ok {
+					opts.Price = lastPrice
+				}
+			}
+
+			if tags != nil {
+				for i := 0; i < tags.Len(); i++ {
+					tag, ok := starlark.AsString(tags.Index(i))
+					if !ok {
+						return nil, fmt.Errorf("%s: tags must be strings", b.Name())
+					}
+					opts.Tags = append(opts.Tags, tag)
+				}
+			}
+
+			createdOrders, err := s.OrderExecutor.OpenPosition(s.ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+
+			return starlark.MakeInt(len(createdOrders)), nil
+		},
+
+		"close_position": func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var percentage starlark.Value = starlark.Float(1.0)
+			var tag = ID
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs, "percentage?", &percentage, "tag?", &tag); err != nil {
+				return nil, err
+			}
+
+			p, err := toFixedpoint(percentage)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := s.OrderExecutor.ClosePosition(s.ctx, p, tag); err != nil {
+				return nil, err
+			}
+
+			return starlark.None, nil
+		},
+
+		"submit_order": func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var side string
+			var orderType = string(types.OrderTypeLimit)
+			var quantity, price starlark.Value = starlark.None, starlark.None
+			var tag = ID
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs,
+				"side", &side,
+				"quantity", &quantity,
+				"price?", &price,
+				"type?", &orderType,
+				"tag?", &tag); err != nil {
+				return nil, err
+			}
+
+			submitOrder := types.SubmitOrder{
+				Symbol: s.Symbol,
+				Side:   types.SideType(strings.ToUpper(side)),
+				Type:   types.OrderType(strings.ToUpper(orderType)),
+				Market: s.Market,
+				Tag:    tag,
+			}
+
+			var err error
+			if submitOrder.Quantity, err = toFixedpoint(quantity); err != nil {
+				return nil, err
+			}
+
+			if submitOrder.Price, err = toFixedpoint(price); err != nil {
+				return nil, err
+			}
+
+			createdOrders, err := s.OrderExecutor.SubmitOrders(s.ctx, submitOrder)
+			if err != nil {
+				return nil, err
+			}
+
+			var orderIDs []starlark.Value
+			for _, o := range createdOrders {
+				orderIDs = append(orderIDs, starlark.MakeUint64(o.OrderID))
+			}
+
+			return starlark.NewList(orderIDs), nil
+		},
+
+		"cancel_all": func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+				return nil, err
+			}
+
+			if err := s.OrderExecutor.GracefulCancel(s.ctx); err != nil {
+				return nil, err
+			}
+
+			return starlark.None, nil
+		},
+
+		"num_active_orders": func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+				return nil, err
+			}
+
+			return starlark.MakeInt(s.OrderExecutor.ActiveMakerOrders().NumOfOrders()), nil
+		},
+	})
+}
@@ -0,0 +1,214 @@
+package script
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// toStarlark converts the plain go values (the values decoded from json or yaml) into starlark values
+func toStarlark(v interface{}) (starlark.Value, error) {
+	switch tv := v.(type) {
+	case nil:
+		return starlark.None, nil
+	case bool:
+		return starlark.Bool(tv), nil
+	case string:
+		return starlark.String(tv), nil
+	case int:
+		return starlark.MakeInt(tv), nil
+	case int64:
+		return starlark.MakeInt64(tv), nil
+	case float64:
+		return starlark.Float(tv), nil
+	case fixedpoint.Value:
+		return starlark.Float(tv.Float64()), nil
+	case []interface{}:
+		var elems []starlark.Value
+		for _, e := range tv {
+			sv, err := toStarlark(e)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, sv)
+		}
+		return starlark.NewList(elems), nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(tv))
+		for k := range tv {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		dict := starlark.NewDict(len(tv))
+		for _, k := range keys {
+			sv, err := toStarlark(tv[k])
+			if err != nil {
+				return nil, err
+			}
+
+			if err := dict.SetKey(starlark.String(k), sv); err != nil {
+				return nil, err
+			}
+		}
+		return dict, nil
+	}
+
+	return nil, fmt.Errorf("unsupported value type %T", v)
+}
+
+// fromStarlark converts the starlark values back to the plain go values, so that they can be persisted as json
+func fromStarlark(v starlark.Value) (interface{}, error) {
+	switch tv := v.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case starlark.Bool:
+		return bool(tv), nil
+	case starlark.String:
+		return string(tv), nil
+	case starlark.Int:
+		i, ok := tv.Int64()
+		if !ok {
+			return nil, fmt.Errorf("integer %s overflows int64", tv.String())
+		}
+		return i, nil
+	case starlark.Float:
+		return float64(tv), nil
+	case *starlark.List:
+		var elems []interface{}
+		for i := 0; i < tv.Len(); i++ {
+			e, err := fromStarlark(tv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, e)
+		}
+		return elems, nil
+	case starlark.Tuple:
+		var elems []interface{}
+		for _, sv := range tv {
+			e, err := fromStarlark(sv)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, e)
+		}
+		return elems, nil
+	case *starlark.Dict:
+		m := make(map[string]interface{}, tv.Len())
+		for _, item := range tv.Items() {
+			k, ok := item[0].(starlark.String)
+			if !ok {
+				return nil, fmt.Errorf("dict key %s is not a string", item[0].String())
+			}
+
+			e, err := fromStarlark(item[1])
+			if err != nil {
+				return nil, err
+			}
+			m[string(k)] = e
+		}
+		return m, nil
+	}
+
+	return nil, fmt.Errorf("unsupported starlark type %s", v.Type())
+}
+
+// toFixedpoint converts the starlark int or float value into fixedpoint.Value
+func toFixedpoint(v starlark.Value) (fixedpoint.Value, error) {
+	switch tv := v.(type) {
+	case starlark.NoneType:
+		return fixedpoint.Zero, nil
+	case starlark.Int:
+		i, ok := tv.Int64()
+		if !ok {
+			return fixedpoint.Zero, fmt.Errorf("integer %s overflows int64", tv.String())
+		}
+		return fixedpoint.NewFromInt(i), nil
+	case starlark.Float:
+		return fixedpoint.NewFromFloat(float64(tv)), nil
+	case starlark.String:
+		return fixedpoint.NewFromString(string(tv))
+	}
+
+	return fixedpoint.Zero, fmt.Errorf("can not convert %s to number", v.Type())
+}
+
+// intValue unpacks a starlark int or a whole-number float,
+// the numbers of the params are decoded from JSON/YAML as floats, e.g. params.get("window", 7) could be 7.0
+type intValue int
+
+var _ starlark.Unpacker = (*intValue)(nil)
+
+func (v *intValue) Unpack(x starlark.Value) error {
+	switch tv := x.(type) {
+	case starlark.Int:
+		i, err := starlark.AsInt32(tv)
+		if err != nil {
+			return err
+		}
+		*v = intValue(i)
+		return nil
+
+	case starlark.Float:
+		f := float64(tv)
+		if f != math.Trunc(f) || f > math.MaxInt32 || f < math.MinInt32 {
+			return fmt.Errorf("got float %s, want whole number", tv.String())
+		}
+		*v = intValue(f)
+		return nil
+	}
+
+	return fmt.Errorf("got %s, want int", x.Type())
+}
+
+func floatValue(v fixedpoint.Value) starlark.Float {
+	return starlark.Float(v.Float64())
+}
+
+func newKLineStruct(k types.KLine) *starlarkstruct.Struct {
+	return starlarkstruct.FromStringDict(starlark.String("kline"), starlark.StringDict{
+		"symbol":     starlark.String(k.Symbol),
+		"interval":   starlark.String(k.Interval),
+		"start_time": starlark.MakeInt64(k.StartTime.Time().UnixMilli()),
+		"end_time":   starlark.MakeInt64(k.EndTime.Time().UnixMilli()),
+		"open":       floatValue(k.Open),
+		"high":       floatValue(k.High),
+		"low":        floatValue(k.Low),
+		"close":      floatValue(k.Close),
+		"volume":     floatValue(k.Volume),
+	})
+}
+
+func newTradeStruct(trade types.Trade) *starlarkstruct.Struct {
+	return starlarkstruct.FromStringDict(starlark.String("trade"), starlark.StringDict{
+		"id":           starlark.MakeUint64(trade.ID),
+		"order_id":     starlark.MakeUint64(trade.OrderID),
+		"symbol":       starlark.String(trade.Symbol),
+		"side":         starlark.String(trade.Side),
+		"price":        floatValue(trade.Price),
+		"quantity":     floatValue(trade.Quantity),
+		"fee":          floatValue(trade.Fee),
+		"fee_currency": starlark.String(trade.FeeCurrency),
+		"is_maker":     starlark.Bool(trade.IsMaker),
+		"time":         starlark.MakeInt64(trade.Time.Time().UnixMilli()),
+	})
+}
+
+func newPositionStruct(position *types.Position) *starlarkstruct.Struct {
+	return starlarkstruct.FromStringDict(starlark.String("position"), starlark.StringDict{
+		"symbol":       starlark.String(position.Symbol),
+		"base":         floatValue(position.GetBase()),
+		"quote":        floatValue(position.Quote),
+		"average_cost": floatValue(position.AverageCost),
+		"is_long":      starlark.Bool(position.IsLong()),
+		"is_short":     starlark.Bool(position.IsShort()),
+		"is_closed":    starlark.Bool(position.IsClosed()),
+	})
+}
@@ -0,0 +1,188 @@
+package script
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+// DefaultMaxExecutionSteps is the default step limit of a single script function call,
+// it protects the strategy from infinite loops in the user script.
+const DefaultMaxExecutionSteps = 1_000_000
+
+// fileOptions enables the language features that are disabled by default in starlark,
+// so that the users can write while loops and top-level if statements.
+var fileOptions = &syntax.FileOptions{
+	Set:             true,
+	While:           true,
+	TopLevelControl: true,
+	Recursion:       true,
+}
+
+// Runtime runs a starlark script in a sandboxed thread.
+// The script can not load other modules or access the file system and the network,
+// it can only use the builtin functions and the predeclared modules given by the strategy.
+type Runtime struct {
+	Name string
+
+	// mu protects the thread, the starlark thread is not goroutine safe,
+	// and the callbacks can be triggered from different goroutines (stream and trade collector)
+	mu sync.Mutex
+
+	thread   *starlark.Thread
+	globals  starlark.StringDict
+	maxSteps uint64
+
+	// queueMu protects the dispatch queue
+	queueMu     sync.Mutex
+	queue       []scriptCall
+	dispatching bool
+
+	// afterCall is called after each dispatched call
+	afterCall func(name string)
+
+	logger logrus.FieldLogger
+}
+
+type scriptCall struct {
+	name string
+	args starlark.Tuple
+}
+
+func NewRuntime(name string, maxSteps uint64, logger logrus.FieldLogger) *Runtime {
+	if maxSteps == 0 {
+		maxSteps = DefaultMaxExecutionSteps
+	}
+
+	r := &Runtime{
+		Name:     name,
+		maxSteps: maxSteps,
+		logger:   logger,
+	}
+
+	r.thread = &starlark.Thread{
+		Name: name,
+		Print: func(thread *starlark.Thread, msg string) {
+			r.logger.Infof("[%s] %s", name, msg)
+		},
+		Load: func(thread *starlark.Thread, module string) (starlark.StringDict, error) {
+			return nil, fmt.Errorf("load(%q) is not allowed in the script", module)
+		},
+	}
+	r.thread.SetMaxExecutionSteps(maxSteps)
+	return r
+}
+
+// Load executes the top-level statements of the given script source
+// src could be nil (read from the filename), a string or a byte slice.
+func (r *Runtime) Load(filename string, src interface{}, predeclared starlark.StringDict) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.resetSteps()
+
+	globals, err := starlark.ExecFileOptions(fileOptions, r.thread, filename, src, predeclared)
+	if err != nil {
+		return wrapEvalError(err)
+	}
+
+	r.globals = globals
+	return nil
+}
+
+// HasFunction checks if the script defines the given function
+func (r *Runtime) HasFunction(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.function(name)
+	return ok
+}
+
+// Call calls the script function by name, if the function is not defined, Call returns None without error.
+func (r *Runtime) Call(name string, args ...starlark.Value) (starlark.Value, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fn, ok := r.function(name)
+	if !ok {
+		return starlark.None, nil
+	}
+
+	r.resetSteps()
+
+	ret, err := starlark.Call(r.thread, fn, args, nil)
+	if err != nil {
+		return nil, wrapEvalError(err)
+	}
+
+	return ret, nil
+}
+
+// OnAfterCall registers a callback which is called after each dispatched call
+func (r *Runtime) OnAfterCall(cb func(name string)) {
+	r.afterCall = cb
+}
+
+// Dispatch queues the function call and runs the queued calls in order.
+// A builtin function (for example, orders.submit_order) may trigger another event synchronously,
+// like a trade update in back-testing. Instead of re-entering the script,
+// the nested event is queued and called after the current call returns.
+func (r *Runtime) Dispatch(name string, args ...starlark.Value) {
+	r.queueMu.Lock()
+	r.queue = append(r.queue, scriptCall{name: name, args: args})
+	if r.dispatching {
+		r.queueMu.Unlock()
+		return
+	}
+	r.dispatching = true
+	r.queueMu.Unlock()
+
+	for {
+		r.queueMu.Lock()
+		if len(r.queue) == 0 {
+			r.dispatching = false
+			r.queueMu.Unlock()
+			return
+		}
+
+		c := r.queue[0]
+		r.queue = r.queue[1:]
+		r.queueMu.Unlock()
+
+		if _, err := r.Call(c.name, c.args...); err != nil {
+			r.logger.WithError(err).Errorf("script function %s error", c.name)
+		}
+
+		if r.afterCall != nil {
+			r.afterCall(c.name)
+		}
+	}
+}
+
+func (r *Runtime) function(name string) (starlark.Callable, bool) {
+	v, ok := r.globals[name]
+	if !ok {
+		return nil, false
+	}
+
+	fn, ok := v.(starlark.Callable)
+	return fn, ok
+}
+
+// resetSteps resets the step counter so that the max execution step limit is applied per call
+func (r *Runtime) resetSteps() {
+	r.thread.Steps = 0
+	r.thread.Uncancel()
+}
+
+func wrapEvalError(err error) error {
+	if evalErr, ok := err.(*starlark.EvalError); ok {
+		return fmt.Errorf("%s\n%s", evalErr.Msg, evalErr.CallStack.String())
+	}
+
+	return err
+}
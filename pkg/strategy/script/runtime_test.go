@@ -0,0 +1,111 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.starlark.net/starlark"
+)
+
+func TestRuntime_Dispatch(t *testing.T) {
+	state := starlark.NewDict(1)
+	var events []string
+
+	predeclared := starlark.StringDict{
+		"state": state,
+		"emit": starlark.NewBuiltin("emit", func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var name string
+			if err := starlark.UnpackArgs(b.Name(), args, kwargs, "name", &name); err != nil {
+				return nil, err
+			}
+			events = append(events, name)
+			return starlark.None, nil
+		}),
+	}
+
+	r := NewRuntime("test", 0, logrus.New())
+	err := r.Load("test.star", `
+def on_kline(k):
+    state["count"] = state.get("count", 0) + 1
+    emit("kline")
+
+def on_trade(t):
+    emit("trade")
+`, predeclared)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.True(t, r.HasFunction("on_kline"))
+	assert.False(t, r.HasFunction("on_start"))
+
+	var afterCalls []string
+	r.OnAfterCall(func(name string) {
+		afterCalls = append(afterCalls, name)
+	})
+
+	// undefined functions are ignored
+	r.Dispatch("on_start")
+	r.Dispatch("on_kline", starlark.None)
+	r.Dispatch("on_trade", starlark.None)
+	r.Dispatch("on_kline", starlark.None)
+
+	assert.Equal(t, []string{"kline", "trade", "kline"}, events)
+	assert.Equal(t, []string{"on_start", "on_kline", "on_trade", "on_kline"}, afterCalls)
+
+	v, err := fromStarlark(state)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"count": int64(2)}, v)
+}
+
+func TestRuntime_Sandbox(t *testing.T) {
+	r := NewRuntime("test", 1000, logrus.New())
+	err := r.Load("test.star", `load("os.star", "system")`, nil)
+	assert.Error(t, err)
+
+	r = NewRuntime("test", 1000, logrus.New())
+	err = r.Load("test.star", `
+def loop():
+    while True:
+        pass
+`, nil)
+	assert.NoError(t, err)
+
+	_, err = r.Call("loop")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "too many steps")
+	}
+
+	// the step counter is reset for each call
+	_, err = r.Call("loop")
+	assert.Error(t, err)
+}
+
+func TestConvert(t *testing.T) {
+	in := map[string]interface{}{
+		"a": true,
+		"b": "str",
+		"c": 1.5,
+		"d": []interface{}{int64(1), "x"},
+		"e": map[string]interface{}{"f": nil},
+	}
+
+	sv, err := toStarlark(in)
+	assert.NoError(t, err)
+
+	out, err := fromStarlark(sv)
+	assert.NoError(t, err)
+	assert.Equal(t, in, out)
+
+	_, err = fromStarlark(starlark.NewBuiltin("f", nil))
+	assert.Error(t, err)
+
+	v, err := toFixedpoint(starlark.MakeInt(3))
+	assert.NoError(t, err)
+	assert.Equal(t, "3", v.String())
+
+	v, err = toFixedpoint(starlark.Float(0.25))
+	assert.NoError(t, err)
+	assert.Equal(t, "0.25", v.String())
+}
@@ -0,0 +1,190 @@
+package script
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"go.starlark.net/starlark"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+	"github.com/c9s/bbgo/pkg/strategy/common"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+const ID = "script"
+
+var log = logrus.WithField("strategy", ID)
+
+func init() {
+	bbgo.RegisterStrategy(ID, &Strategy{})
+}
+
+// Strategy runs a user strategy written in starlark (a python dialect).
+//
+// The script may define the following callback functions:
+//
+//	def on_start():            called once after the script is loaded
+//	def on_kline(k):           called when the kline of the strategy symbol and interval is closed
+//	def on_trade(t):           called when a trade of this strategy is received
+//	def on_shutdown():         called when bbgo is shutting down
+//
+// The following modules and values are predeclared:
+//
+//	symbol, interval, params, state, notify(msg),
+//	session.last_price(symbol?), session.balance(currency), session.market(),
+//	indicators.{open,high,low,close,volume}(interval?), indicators.{sma,ewma,rsi,atr,atrp}(interval, window),
+//	indicators.boll(interval, window, k?),
+//	orders.position(), orders.open_position(...), orders.close_position(percentage?, tag?),
+//	orders.submit_order(side, quantity, price?, type?, tag?), orders.cancel_all(), orders.num_active_orders()
+//
+// The state dict is persisted, only None, bool, int, float, string, list and dict values can be stored.
+type Strategy struct {
+	*common.Strategy
+
+	Environment *bbgo.Environment
+	Market      types.Market
+
+	Symbol   string         `json:"symbol"`
+	Interval types.Interval `json:"interval"`
+
+	// Intervals are the additional kline intervals that the script uses for the indicators
+	Intervals []types.Interval `json:"intervals,omitempty"`
+
+	// Script is the path of the starlark script file
+	Script string `json:"script"`
+
+	// Params is passed to the script as a frozen dict named "params"
+	Params map[string]interface{} `json:"params,omitempty"`
+
+	// MaxExecutionSteps limits the steps of a single callback call
+	MaxExecutionSteps uint64 `json:"maxExecutionSteps,omitempty"`
+
+	State map[string]interface{} `json:"state,omitempty" persistence:"script_state"`
+
+	ctx       context.Context
+	session   *bbgo.ExchangeSession
+	runtime   *Runtime
+	stateDict *starlark.Dict
+
+	indicators map[string]*seriesValue
+
+	logger logrus.FieldLogger
+}
+
+func (s *Strategy) Initialize() error {
+	if s.Strategy == nil {
+		s.Strategy = &common.Strategy{}
+	}
+
+	s.logger = log.WithField("symbol", s.Symbol)
+	return nil
+}
+
+func (s *Strategy) ID() string {
+	return ID
+}
+
+func (s *Strategy) InstanceID() string {
+	return fmt.Sprintf("%s:%s:%s", ID, s.Symbol, s.Script)
+}
+
+func (s *Strategy) Validate() error {
+	if len(s.Symbol) == 0 {
+		return fmt.Errorf("symbol is required")
+	}
+
+	if len(s.Script) == 0 {
+		return fmt.Errorf("script is required")
+	}
+
+	if _, err := os.Stat(s.Script); err != nil {
+		return fmt.Errorf("script file %s error: %w", s.Script, err)
+	}
+
+	return nil
+}
+
+func (s *Strategy) Defaults() error {
+	if s.Interval == "" {
+		s.Interval = types.Interval1m
+	}
+
+	return nil
+}
+
+func (s *Strategy) Subscribe(session *bbgo.ExchangeSession) {
+	session.Subscribe(types.KLineChannel, s.Symbol, types.SubscribeOptions{Interval: s.Interval})
+
+	for _, interval := range s.Intervals {
+		session.Subscribe(types.KLineChannel, s.Symbol, types.SubscribeOptions{Interval: interval})
+	}
+}
+
+func (s *Strategy) Run(ctx context.Context, _ bbgo.OrderExecutor, session *bbgo.ExchangeSession) error {
+	s.Strategy.Initialize(ctx, s.Environment, session, s.Market, ID, s.InstanceID())
+
+	s.ctx = ctx
+	s.session = session
+	s.indicators = make(map[string]*seriesValue)
+
+	if s.State == nil {
+		s.State = make(map[string]interface{})
+	}
+
+	stateValue, err := toStarlark(s.State)
+	if err != nil {
+		return fmt.Errorf("unable to restore script state: %w", err)
+	}
+	s.stateDict = stateValue.(*starlark.Dict)
+
+	predeclared, err := s.predeclared()
+	if err != nil {
+		return err
+	}
+
+	s.runtime = NewRuntime(s.InstanceID(), s.MaxExecutionSteps, s.logger)
+	s.runtime.OnAfterCall(func(name string) {
+		s.syncState()
+	})
+	if err := s.runtime.Load(s.Script, nil, predeclared); err != nil {
+		return fmt.Errorf("unable to load script %s: %w", s.Script, err)
+	}
+
+	s.call("on_start")
+
+	session.MarketDataStream.OnKLineClosed(types.KLineWith(s.Symbol, s.Interval, func(kline types.KLine) {
+		s.call("on_kline", newKLineStruct(kline))
+	}))
+
+	s.OrderExecutor.TradeCollector().OnTrade(func(trade types.Trade, _, _ fixedpoint.Value) {
+		s.call("on_trade", newTradeStruct(trade))
+	})
+
+	bbgo.OnShutdown(ctx, func(ctx context.Context, wg *sync.WaitGroup) {
+		defer wg.Done()
+
+		s.call("on_shutdown")
+	})
+
+	return nil
+}
+
+// call dispatches the script function call, the script state is synced after each call
+func (s *Strategy) call(name string, args ...starlark.Value) {
+	s.runtime.Dispatch(name, args...)
+}
+
+func (s *Strategy) syncState() {
+	state, err := fromStarlark(s.stateDict)
+	if err != nil {
+		s.logger.WithError(err).Errorf("unable to convert the script state")
+		return
+	}
+
+	s.State = state.(map[string]interface{})
+	bbgo.Sync(s.ctx, s)
+}
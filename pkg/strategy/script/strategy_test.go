@@ -0,0 +1,76 @@
+package script
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.starlark.net/starlark"
+
+	"github.com/c9s/bbgo/pkg/bbgo"
+	"github.com/c9s/bbgo/pkg/types"
+	"github.com/c9s/bbgo/pkg/types/mocks"
+)
+
+func newTestStrategy(t *testing.T, params string) *Strategy {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockEx := mocks.NewMockExchange(mockCtrl)
+	mockEx.EXPECT().NewStream().Return(&types.StandardStream{}).Times(2)
+
+	s := &Strategy{
+		Symbol:   "BTCUSDT",
+		Interval: types.Interval1m,
+	}
+
+	// the params are decoded from the config, so the numbers are float64
+	if err := json.Unmarshal([]byte(params), &s.Params); err != nil {
+		t.Fatal(err)
+	}
+
+	s.session = bbgo.NewExchangeSession("binance", mockEx)
+	s.indicators = make(map[string]*seriesValue)
+	s.stateDict = starlark.NewDict(0)
+	return s
+}
+
+func TestStrategy_LoadExampleScript(t *testing.T) {
+	s := newTestStrategy(t, `{"fastWindow": 5, "slowWindow": 20, "quantity": 0.01}`)
+
+	predeclared, err := s.predeclared()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	r := NewRuntime("test", 0, logrus.New())
+	err = r.Load("../../../config/script/emacross.star", nil, predeclared)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.True(t, r.HasFunction("on_kline"))
+	assert.Contains(t, s.indicators, "ewma:1m:5")
+	assert.Contains(t, s.indicators, "ewma:1m:20")
+}
+
+func TestStrategy_IndicatorWindow(t *testing.T) {
+	s := newTestStrategy(t, `{}`)
+
+	predeclared, err := s.predeclared()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	r := NewRuntime("test", 0, logrus.New())
+	assert.NoError(t, r.Load("int.star", `s = indicators.sma("1m", 7)`, predeclared))
+	assert.Contains(t, s.indicators, "sma:1m:7")
+
+	r = NewRuntime("test", 0, logrus.New())
+	err = r.Load("float.star", `s = indicators.sma("1m", 7.5)`, predeclared)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "want whole number")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -98,6 +99,33 @@ type ExchangeOrderQueryService interface {
 	QueryOrderTrades(ctx context.Context, q OrderQuery) ([]Trade, error)
 }
 
+// ErrOrderAmendNotSupported is returned by the ExchangeOrderAmendService when the order can not be amended,
+// e.g., the API is only available for the spot orders.
+var ErrOrderAmendNotSupported = errors.New("order amendment is not supported")
+
+// ExchangeOrderAmendService provides an interface for amending the price and the quantity of an open order.
+// A zero price or a zero quantity means the field is not changed.
+// Some exchanges implement this by the atomic cancel-replace API, the returned order might have a new order ID.
+type ExchangeOrderAmendService interface {
+	AmendOrder(ctx context.Context, order Order, newPrice, newQuantity fixedpoint.Value) (*Order, error)
+}
+
+// ExchangeBatchOrderService provides the native batch APIs for submitting and canceling multiple orders in a few requests.
+// The batch requests might be partially successful:
+// BatchSubmitOrders returns the created orders in the same sequence as the successful submit orders,
+// the indexes of the failed submit orders, and the joined error of the failed submit orders.
+// BatchCancelOrders returns the orders that are failed to cancel and the joined error.
+type ExchangeBatchOrderService interface {
+	BatchSubmitOrders(ctx context.Context, orders ...SubmitOrder) (createdOrders OrderSlice, errIndexes []int, err error)
+	BatchCancelOrders(ctx context.Context, orders ...Order) (failedOrders []Order, err error)
+}
+
+// ExchangeCancelAllOrdersService provides the native API for canceling all the open orders of the symbol,
+// the canceled orders are returned.
+type ExchangeCancelAllOrdersService interface {
+	CancelOrdersBySymbol(ctx context.Context, symbol string) ([]Order, error)
+}
+
 type ExchangeAccountService interface {
 	QueryAccount(ctx context.Context) (*Account, error)
 
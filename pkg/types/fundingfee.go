@@ -0,0 +1,24 @@
+package types
+
+import (
+	"context"
+	"time"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+)
+
+// FundingFee is the funding fee settled on a futures position,
+// a negative amount means the fee is paid, a positive amount means the fee is received.
+type FundingFee struct {
+	Exchange      ExchangeName     `json:"exchange"`
+	Symbol        string           `json:"symbol"`
+	Asset         string           `json:"asset"`
+	Amount        fixedpoint.Value `json:"amount"`
+	TransactionID string           `json:"transactionID"`
+	Time          Time             `json:"time"`
+}
+
+// FundingFeeHistoryService provides the service of querying the funding fee history of the futures positions
+type FundingFeeHistoryService interface {
+	QueryFundingFeeHistory(ctx context.Context, symbol string, startTime, endTime *time.Time) ([]FundingFee, error)
+}
@@ -323,6 +323,40 @@ func (o Order) Backup() SubmitOrder {
 	return so
 }
 
+// Amend returns a copy of the order with the new price and quantity, a zero price or quantity means the field is not changed.
+// The quantity of a partially filled order defaults to the remaining quantity, so the executed quantity is not submitted again.
+// An error is returned if the quantity is below the minimal quantity or the minimal notional of the market.
+func (o Order) Amend(newPrice, newQuantity fixedpoint.Value) (Order, error) {
+	amended := o
+	if !newPrice.IsZero() {
+		amended.Price = newPrice
+	}
+
+	if !newQuantity.IsZero() {
+		amended.Quantity = newQuantity
+	} else {
+		amended.Quantity = o.Quantity.Sub(o.ExecutedQuantity)
+	}
+
+	if amended.Quantity.Sign() <= 0 {
+		return amended, fmt.Errorf("order #%d has no remaining quantity to amend", o.OrderID)
+	}
+
+	if o.Market.Symbol != "" {
+		if amended.Quantity.Compare(o.Market.MinQuantity) < 0 {
+			return amended, fmt.Errorf("amended quantity %s of order #%d is less than the min quantity %s",
+				amended.Quantity.String(), o.OrderID, o.Market.MinQuantity.String())
+		}
+
+		if !amended.Price.IsZero() && amended.Quantity.Mul(amended.Price).Compare(o.Market.MinNotional) < 0 {
+			return amended, fmt.Errorf("amended notional %s of order #%d is less than the min notional %s",
+				amended.Quantity.Mul(amended.Price).String(), o.OrderID, o.Market.MinNotional.String())
+		}
+	}
+
+	return amended, nil
+}
+
 func (o Order) String() string {
 	var orderID string
 	if o.UUID != "" {
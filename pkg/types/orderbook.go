@@ -143,8 +143,11 @@ type StreamOrderBook struct {
 
 	C chan BookSignal
 
-	updateCallbacks   []func(update SliceOrderBook)
-	snapshotCallbacks []func(snapshot SliceOrderBook)
+	updateCallbacks         []func(update SliceOrderBook)
+	snapshotCallbacks       []func(snapshot SliceOrderBook)
+	integrityIssueCallbacks []func(issue BookIntegrityIssue)
+
+	integrity *bookIntegrity
 }
 
 func NewStreamBook(symbol string) *StreamOrderBook {
@@ -161,6 +164,10 @@ func (sb *StreamOrderBook) BindStream(stream Stream) {
 		}
 
 		sb.Load(book)
+		if !sb.verifyIntegrity(book, true) {
+			return
+		}
+
 		sb.EmitSnapshot(book)
 
 		// when it's snapshot, it's very important to push the snapshot signal to the caller
@@ -172,7 +179,16 @@ func (sb *StreamOrderBook) BindStream(stream Stream) {
 			return
 		}
 
+		// the diffs can not be applied to the reset book, wait for the new snapshot
+		if sb.isBroken() {
+			return
+		}
+
 		sb.Update(book)
+		if !sb.verifyIntegrity(book, false) {
+			return
+		}
+
 		sb.EmitUpdate(book)
 
 		select {
@@ -0,0 +1,227 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BookIntegrityIssueType is the type of the order book integrity issue
+type BookIntegrityIssueType string
+
+const (
+	BookIntegrityIssueChecksumMismatch BookIntegrityIssueType = "checksum_mismatch"
+	BookIntegrityIssueCrossed          BookIntegrityIssueType = "crossed"
+	BookIntegrityIssueStale            BookIntegrityIssueType = "stale"
+)
+
+// BookIntegrityIssue is the incident that the stream order book is found broken
+type BookIntegrityIssue struct {
+	Symbol  string
+	Type    BookIntegrityIssueType
+	Message string
+	Time    time.Time
+}
+
+func (i BookIntegrityIssue) String() string {
+	return fmt.Sprintf("%s order book integrity issue: %s, %s", i.Symbol, i.Type, i.Message)
+}
+
+// BookChecksumCalculator is implemented by the streams of the exchanges that publish the order book checksum,
+// the checksum is calculated from the local book with the algorithm of the exchange.
+// The market is used for formatting the price and the volume in the way the exchange does.
+type BookChecksumCalculator interface {
+	BookChecksum(market Market, bids, asks PriceVolumeSlice) int64
+}
+
+// BookIntegrityConfig is the config of the order book integrity check
+type BookIntegrityConfig struct {
+	// StaleTimeout is the max duration without any book update, the stale check is disabled if it's zero
+	StaleTimeout Duration `json:"staleTimeout,omitempty" yaml:"staleTimeout,omitempty"`
+
+	// ResnapshotCoolDown is the min interval between two resnapshots, default is 1 minute
+	ResnapshotCoolDown Duration `json:"resnapshotCoolDown,omitempty" yaml:"resnapshotCoolDown,omitempty"`
+}
+
+const defaultResnapshotCoolDown = time.Minute
+
+// bookIntegrity verifies the stream order book after each update. When the book is broken, the book is reset
+// so that the broken book is not used, and the stream is reconnected to receive a new snapshot.
+type bookIntegrity struct {
+	config     BookIntegrityConfig
+	stream     Stream
+	market     Market
+	calculator BookChecksumCalculator
+
+	mu             sync.Mutex
+	broken         bool
+	lastResnapshot time.Time
+
+	// resnapshotTimer requests the snapshot when the cool-down ends
+	resnapshotTimer *time.Timer
+}
+
+// EnableIntegrityCheck enables the integrity check of the book, the book should be bound to the given stream.
+// The stale check runs until the context is done.
+func (sb *StreamOrderBook) EnableIntegrityCheck(ctx context.Context, stream Stream, market Market, config BookIntegrityConfig) {
+	if config.ResnapshotCoolDown == 0 {
+		config.ResnapshotCoolDown = Duration(defaultResnapshotCoolDown)
+	}
+
+	integrity := &bookIntegrity{
+		config: config,
+		stream: stream,
+		market: market,
+	}
+
+	if calculator, ok := stream.(BookChecksumCalculator); ok {
+		integrity.calculator = calculator
+	}
+
+	sb.integrity = integrity
+
+	if config.StaleTimeout > 0 {
+		go sb.checkStaleness(ctx, config.StaleTimeout.Duration())
+	}
+}
+
+func (sb *StreamOrderBook) checkStaleness(ctx context.Context, timeout time.Duration) {
+	ticker := time.NewTicker(timeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			// keep watching the broken book, the snapshot might not be received after the reconnection
+			if broken, lastResnapshot := sb.integrity.resnapshotOverdue(timeout); broken {
+				if !lastResnapshot.IsZero() {
+					sb.handleIntegrityIssue(BookIntegrityIssueStale, fmt.Sprintf("no snapshot since %s", lastResnapshot))
+				}
+				continue
+			}
+
+			lastUpdateTime := sb.LastUpdateTime()
+			if lastUpdateTime.IsZero() {
+				continue
+			}
+
+			if d := time.Since(lastUpdateTime); d > timeout {
+				sb.handleIntegrityIssue(BookIntegrityIssueStale, fmt.Sprintf("no update since %s", lastUpdateTime))
+			}
+		}
+	}
+}
+
+// isBroken returns true if the book is broken and waiting for the new snapshot,
+// the updates should not be applied to the book in this state
+func (sb *StreamOrderBook) isBroken() bool {
+	return sb.integrity != nil && sb.integrity.isBroken()
+}
+
+// verifyIntegrity checks the book after the snapshot or the update is applied,
+// it returns false if the book is broken, so that the book is not emitted
+func (sb *StreamOrderBook) verifyIntegrity(book SliceOrderBook, isSnapshot bool) bool {
+	integrity := sb.integrity
+	if integrity == nil {
+		return true
+	}
+
+	integrity.mu.Lock()
+	if integrity.broken && !isSnapshot {
+		// ignore the updates until the new snapshot is received
+		integrity.mu.Unlock()
+		return false
+	}
+	integrity.broken = false
+	integrity.mu.Unlock()
+
+	bid, ask, ok := sb.BestBidAndAsk()
+	if ok && bid.Price.Compare(ask.Price) >= 0 {
+		sb.handleIntegrityIssue(BookIntegrityIssueCrossed, fmt.Sprintf("best bid %s >= best ask %s", bid.Price, ask.Price))
+		return false
+	}
+
+	if integrity.calculator != nil && book.Checksum != 0 {
+		checksum := integrity.calculator.BookChecksum(integrity.market, sb.SideBook(SideTypeBuy), sb.SideBook(SideTypeSell))
+		if checksum != book.Checksum {
+			sb.handleIntegrityIssue(BookIntegrityIssueChecksumMismatch, fmt.Sprintf("checksum %d != exchange checksum %d", checksum, book.Checksum))
+			return false
+		}
+	}
+
+	return true
+}
+
+// handleIntegrityIssue resets the broken book, emits the issue and requests a new snapshot by reconnecting the stream.
+// If the issue happens in the cool-down, the snapshot is requested when the cool-down ends.
+func (sb *StreamOrderBook) handleIntegrityIssue(issueType BookIntegrityIssueType, message string) {
+	integrity := sb.integrity
+
+	now := time.Now()
+	integrity.mu.Lock()
+	integrity.broken = true
+	wait := integrity.config.ResnapshotCoolDown.Duration() - now.Sub(integrity.lastResnapshot)
+	resnapshot := wait <= 0
+	if resnapshot {
+		integrity.lastResnapshot = now
+	} else if integrity.resnapshotTimer == nil {
+		integrity.resnapshotTimer = time.AfterFunc(wait, sb.resnapshotAfterCoolDown)
+	}
+	integrity.mu.Unlock()
+
+	sb.Reset()
+	sb.EmitIntegrityIssue(BookIntegrityIssue{
+		Symbol:  sb.Symbol,
+		Type:    issueType,
+		Message: message,
+		Time:    now,
+	})
+
+	if resnapshot {
+		sb.integrity.stream.Reconnect()
+	}
+}
+
+// resnapshotAfterCoolDown reconnects the stream if the book is still broken when the cool-down ends
+func (sb *StreamOrderBook) resnapshotAfterCoolDown() {
+	integrity := sb.integrity
+
+	integrity.mu.Lock()
+	integrity.resnapshotTimer = nil
+	broken := integrity.broken
+	if broken {
+		integrity.lastResnapshot = time.Now()
+	}
+	integrity.mu.Unlock()
+
+	if broken {
+		integrity.stream.Reconnect()
+	}
+}
+
+// resnapshotOverdue returns true if the book is broken. The last resnapshot time is returned
+// if no snapshot is received in the timeout after it and no delayed resnapshot is scheduled.
+func (i *bookIntegrity) resnapshotOverdue(timeout time.Duration) (broken bool, lastResnapshot time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if !i.broken {
+		return false, time.Time{}
+	}
+
+	if i.resnapshotTimer == nil && time.Since(i.lastResnapshot) > timeout {
+		return true, i.lastResnapshot
+	}
+
+	return true, time.Time{}
+}
+
+func (i *bookIntegrity) isBroken() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.broken
+}
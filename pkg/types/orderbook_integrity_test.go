@@ -0,0 +1,220 @@
+package types
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// checksumStream returns the sum of the bid and ask levels as the checksum
+type checksumStream struct {
+	*StandardStream
+
+	reconnected int32
+}
+
+func (s *checksumStream) BookChecksum(market Market, bids, asks PriceVolumeSlice) int64 {
+	return int64(len(bids)*100 + len(asks))
+}
+
+func (s *checksumStream) Reconnect() {
+	atomic.AddInt32(&s.reconnected, 1)
+}
+
+func newIntegrityTestBook(config BookIntegrityConfig) (*StreamOrderBook, *checksumStream, *[]BookIntegrityIssue) {
+	stream := &checksumStream{StandardStream: &StandardStream{}}
+
+	book := NewStreamBook("BTCUSDT")
+	book.BindStream(stream)
+	book.EnableIntegrityCheck(context.Background(), stream, Market{Symbol: "BTCUSDT"}, config)
+
+	// the snapshot signals are sent to the book channel in the blocking way
+	go func() {
+		for range book.C {
+		}
+	}()
+
+	var issues []BookIntegrityIssue
+	book.OnIntegrityIssue(func(issue BookIntegrityIssue) {
+		issues = append(issues, issue)
+	})
+
+	return book, stream, &issues
+}
+
+func TestStreamOrderBook_IntegrityChecksum(t *testing.T) {
+	book, stream, issues := newIntegrityTestBook(BookIntegrityConfig{})
+
+	stream.EmitBookSnapshot(SliceOrderBook{
+		Symbol:   "BTCUSDT",
+		Bids:     PriceVolumeSlice{{Price: number(100.0), Volume: number(1.0)}},
+		Asks:     PriceVolumeSlice{{Price: number(101.0), Volume: number(1.0)}},
+		Checksum: 101,
+	})
+	assert.Empty(t, *issues)
+	assert.NotEmpty(t, book.SideBook(SideTypeBuy))
+
+	// the checksum of 2 bids and 1 ask is 201
+	stream.EmitBookUpdate(SliceOrderBook{
+		Symbol:   "BTCUSDT",
+		Bids:     PriceVolumeSlice{{Price: number(99.0), Volume: number(1.0)}},
+		Checksum: 202,
+	})
+
+	if assert.Len(t, *issues, 1) {
+		assert.Equal(t, BookIntegrityIssueChecksumMismatch, (*issues)[0].Type)
+	}
+	assert.Empty(t, book.SideBook(SideTypeBuy))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&stream.reconnected))
+
+	// the updates are ignored until the book is re-snapshotted
+	stream.EmitBookUpdate(SliceOrderBook{
+		Symbol:   "BTCUSDT",
+		Bids:     PriceVolumeSlice{{Price: number(98.0), Volume: number(1.0)}},
+		Checksum: 1,
+	})
+	assert.Len(t, *issues, 1)
+
+	stream.EmitBookSnapshot(SliceOrderBook{
+		Symbol:   "BTCUSDT",
+		Bids:     PriceVolumeSlice{{Price: number(100.0), Volume: number(1.0)}},
+		Asks:     PriceVolumeSlice{{Price: number(101.0), Volume: number(1.0)}},
+		Checksum: 101,
+	})
+	assert.Len(t, *issues, 1)
+	assert.NotEmpty(t, book.SideBook(SideTypeBuy))
+}
+
+func TestStreamOrderBook_IntegrityCrossed(t *testing.T) {
+	book, stream, issues := newIntegrityTestBook(BookIntegrityConfig{})
+
+	stream.EmitBookSnapshot(SliceOrderBook{
+		Symbol: "BTCUSDT",
+		Bids:   PriceVolumeSlice{{Price: number(100.0), Volume: number(1.0)}},
+		Asks:   PriceVolumeSlice{{Price: number(101.0), Volume: number(1.0)}},
+	})
+
+	stream.EmitBookUpdate(SliceOrderBook{
+		Symbol: "BTCUSDT",
+		Bids:   PriceVolumeSlice{{Price: number(101.5), Volume: number(1.0)}},
+	})
+
+	if assert.Len(t, *issues, 1) {
+		assert.Equal(t, BookIntegrityIssueCrossed, (*issues)[0].Type)
+	}
+	assert.Empty(t, book.SideBook(SideTypeBuy))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&stream.reconnected))
+}
+
+func TestStreamOrderBook_IntegrityResnapshotCoolDown(t *testing.T) {
+	book, stream, issues := newIntegrityTestBook(BookIntegrityConfig{
+		ResnapshotCoolDown: Duration(50 * time.Millisecond),
+	})
+
+	var updates int32
+	book.OnUpdate(func(update SliceOrderBook) {
+		atomic.AddInt32(&updates, 1)
+	})
+
+	crossed := SliceOrderBook{
+		Symbol: "BTCUSDT",
+		Bids:   PriceVolumeSlice{{Price: number(102.0), Volume: number(1.0)}},
+		Asks:   PriceVolumeSlice{{Price: number(101.0), Volume: number(1.0)}},
+	}
+
+	stream.EmitBookSnapshot(crossed)
+	stream.EmitBookSnapshot(crossed)
+
+	assert.Len(t, *issues, 2)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&stream.reconnected))
+
+	// the updates are neither applied nor emitted while the book is broken
+	stream.EmitBookUpdate(SliceOrderBook{
+		Symbol: "BTCUSDT",
+		Bids:   PriceVolumeSlice{{Price: number(99.0), Volume: number(1.0)}},
+	})
+	assert.Empty(t, book.SideBook(SideTypeBuy))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&updates))
+
+	// the issue in the cool-down is resnapshotted when the cool-down ends
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&stream.reconnected) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	stream.EmitBookSnapshot(SliceOrderBook{
+		Symbol: "BTCUSDT",
+		Bids:   PriceVolumeSlice{{Price: number(100.0), Volume: number(1.0)}},
+		Asks:   PriceVolumeSlice{{Price: number(101.0), Volume: number(1.0)}},
+	})
+
+	stream.EmitBookUpdate(SliceOrderBook{
+		Symbol: "BTCUSDT",
+		Bids:   PriceVolumeSlice{{Price: number(99.0), Volume: number(1.0)}},
+	})
+
+	assert.Len(t, *issues, 2)
+	assert.Len(t, book.SideBook(SideTypeBuy), 2)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&updates))
+}
+
+func TestStreamOrderBook_IntegrityStaleBrokenBook(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := &checksumStream{StandardStream: &StandardStream{}}
+	book := NewStreamBook("BTCUSDT")
+	book.BindStream(stream)
+	book.EnableIntegrityCheck(ctx, stream, Market{Symbol: "BTCUSDT"}, BookIntegrityConfig{
+		StaleTimeout:       Duration(20 * time.Millisecond),
+		ResnapshotCoolDown: Duration(time.Millisecond),
+	})
+
+	go func() {
+		for range book.C {
+		}
+	}()
+
+	stream.EmitBookSnapshot(SliceOrderBook{
+		Symbol: "BTCUSDT",
+		Bids:   PriceVolumeSlice{{Price: number(102.0), Volume: number(1.0)}},
+		Asks:   PriceVolumeSlice{{Price: number(101.0), Volume: number(1.0)}},
+	})
+
+	// the snapshot is never received after the reconnection, so the broken book is resnapshotted again
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&stream.reconnected) >= 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestStreamOrderBook_IntegrityStale(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := &checksumStream{StandardStream: &StandardStream{}}
+	book := NewStreamBook("BTCUSDT")
+	book.BindStream(stream)
+
+	staled := make(chan BookIntegrityIssue, 1)
+	book.OnIntegrityIssue(func(issue BookIntegrityIssue) {
+		staled <- issue
+	})
+	book.EnableIntegrityCheck(ctx, stream, Market{Symbol: "BTCUSDT"}, BookIntegrityConfig{
+		StaleTimeout: Duration(20 * time.Millisecond),
+	})
+
+	stream.EmitBookSnapshot(SliceOrderBook{
+		Symbol: "BTCUSDT",
+		Bids:   PriceVolumeSlice{{Price: number(100.0), Volume: number(1.0)}},
+		Asks:   PriceVolumeSlice{{Price: number(101.0), Volume: number(1.0)}},
+	})
+
+	select {
+	case issue := <-staled:
+		assert.Equal(t, BookIntegrityIssueStale, issue.Type)
+	case <-time.After(time.Second):
+		t.Fatal("the stale book is not detected")
+	}
+}
@@ -182,6 +182,10 @@ type ProfitStats struct {
 	TodayGrossProfit fixedpoint.Value `json:"todayGrossProfit,omitempty"`
 	TodayGrossLoss   fixedpoint.Value `json:"todayGrossLoss,omitempty"`
 	TodaySince       int64            `json:"todaySince,omitempty"`
+
+	ThisWeekPnL       fixedpoint.Value `json:"thisWeekPnL,omitempty"`
+	ThisWeekNetProfit fixedpoint.Value `json:"thisWeekNetProfit,omitempty"`
+	ThisWeekSince     int64            `json:"thisWeekSince,omitempty"`
 }
 
 func NewProfitStats(market Market) *ProfitStats {
@@ -221,6 +225,10 @@ func (s *ProfitStats) AddProfit(profit Profit) {
 		s.ResetToday(profit.TradedAt)
 	}
 
+	if s.IsOverOneWeek() {
+		s.ResetThisWeek(profit.TradedAt)
+	}
+
 	// since field guard
 	if s.AccumulatedSince == 0 {
 		s.AccumulatedSince = profit.TradedAt.Unix()
@@ -235,6 +243,8 @@ func (s *ProfitStats) AddProfit(profit Profit) {
 	s.AccumulatedNetProfit = s.AccumulatedNetProfit.Add(profit.NetProfit)
 	s.TodayPnL = s.TodayPnL.Add(profit.Profit)
 	s.TodayNetProfit = s.TodayNetProfit.Add(profit.NetProfit)
+	s.ThisWeekPnL = s.ThisWeekPnL.Add(profit.Profit)
+	s.ThisWeekNetProfit = s.ThisWeekNetProfit.Add(profit.NetProfit)
 
 	if profit.Profit.Sign() > 0 {
 		s.AccumulatedGrossProfit = s.AccumulatedGrossProfit.Add(profit.Profit)
@@ -270,6 +280,17 @@ func (s *ProfitStats) ResetToday(t time.Time) {
 	s.TodaySince = beginningOfTheDay.Unix()
 }
 
+// IsOverOneWeek checks if the week since time is over 7 days
+func (s *ProfitStats) IsOverOneWeek() bool {
+	return time.Since(time.Unix(s.ThisWeekSince, 0)) >= 7*24*time.Hour
+}
+
+func (s *ProfitStats) ResetThisWeek(t time.Time) {
+	s.ThisWeekPnL = fixedpoint.Zero
+	s.ThisWeekNetProfit = fixedpoint.Zero
+	s.ThisWeekSince = BeginningOfTheWeek(t.Local()).Unix()
+}
+
 func (s *ProfitStats) PlainText() string {
 	since := time.Unix(s.AccumulatedSince, 0).Local()
 	return fmt.Sprintf("%s Profit Today\n"+
@@ -21,6 +21,9 @@ type SliceOrderBook struct {
 	// Time represents the server time. If empty, it indicates that the server does not provide this information.
 	Time time.Time
 
+	// Checksum is the checksum of the book after the update published by the exchange, zero means not provided.
+	Checksum int64
+
 	lastUpdateTime time.Time
 
 	loadCallbacks   []func(book *SliceOrderBook)
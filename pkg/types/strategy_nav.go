@@ -0,0 +1,34 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/c9s/bbgo/pkg/fixedpoint"
+)
+
+// StrategyInstanceNAV is the mark-to-market net asset value of a strategy instance at a time,
+// all the values are in USD
+type StrategyInstanceNAV struct {
+	GID                int64  `json:"gid,omitempty" db:"gid"`
+	Strategy           string `json:"strategy" db:"strategy"`
+	StrategyInstanceID string `json:"strategyInstanceID" db:"strategy_instance_id"`
+	Session            string `json:"session" db:"session"`
+	Time               Time   `json:"time" db:"time"`
+
+	// NetAssetValue is the realized profit plus the unrealized profit minus the fee
+	NetAssetValue    fixedpoint.Value `json:"netAssetValue" db:"net_asset_value"`
+	RealizedProfit   fixedpoint.Value `json:"realizedProfit" db:"realized_profit"`
+	UnrealizedProfit fixedpoint.Value `json:"unrealizedProfit" db:"unrealized_profit"`
+	FeeInUSD         fixedpoint.Value `json:"feeInUSD" db:"fee_in_usd"`
+
+	// PositionValue is the market value of the open positions
+	PositionValue fixedpoint.Value `json:"positionValue" db:"position_value"`
+	NumOfTrades   int              `json:"numOfTrades" db:"num_of_trades"`
+}
+
+func (n StrategyInstanceNAV) String() string {
+	return fmt.Sprintf("StrategyInstanceNAV %s %s NAV: %s (realized %s, unrealized %s, fee %s) position: %s trades: %d",
+		n.StrategyInstanceID, n.Time.Time().Format("2006-01-02 15:04:05"),
+		n.NetAssetValue.String(), n.RealizedProfit.String(), n.UnrealizedProfit.String(), n.FeeInUSD.String(),
+		n.PositionValue.String(), n.NumOfTrades)
+}
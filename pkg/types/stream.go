@@ -232,9 +232,6 @@ func (s *StandardStream) Read(ctx context.Context, conn *websocket.Conn, cancel
 	// flag format: debug-{component}-{message type}
 	debugRawMessage := viper.GetBool("debug-websocket-raw-message")
 
-	hasParser := s.parser != nil
-	hasDispatcher := s.dispatcher != nil
-
 	for {
 		select {
 
@@ -289,29 +286,34 @@ func (s *StandardStream) Read(ctx context.Context, conn *websocket.Conn, cancel
 				log.Info(string(message))
 			}
 
-			if !hasParser {
-				s.EmitRawMessage(message)
-				continue
-			}
+			s.HandleMessage(message)
+		}
+	}
+}
 
-			var e interface{}
-			e, err = s.parser(message)
-			if err != nil {
-				log.WithError(err).Errorf("websocket event parse error, message: %s", message)
-				// emit raw message even if occurs error, because we want anything can be detected
-				s.EmitRawMessage(message)
-				continue
-			}
+// HandleMessage parses the text message and dispatches the parsed event, the raw message is emitted as well.
+// It's used by the websocket reader, and the replay stream feeds the captured messages through it.
+func (s *StandardStream) HandleMessage(message []byte) {
+	if s.parser == nil {
+		s.EmitRawMessage(message)
+		return
+	}
 
-			// skip pong event to avoid the message like spam
-			if _, ok := e.(*WebsocketPongEvent); !ok {
-				s.EmitRawMessage(message)
-			}
+	e, err := s.parser(message)
+	if err != nil {
+		log.WithError(err).Errorf("websocket event parse error, message: %s", message)
+		// emit raw message even if occurs error, because we want anything can be detected
+		s.EmitRawMessage(message)
+		return
+	}
 
-			if hasDispatcher {
-				s.dispatcher(e)
-			}
-		}
+	// skip pong event to avoid the message like spam
+	if _, ok := e.(*WebsocketPongEvent); !ok {
+		s.EmitRawMessage(message)
+	}
+
+	if s.dispatcher != nil {
+		s.dispatcher(e)
 	}
 }
 
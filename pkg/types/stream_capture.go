@@ -0,0 +1,167 @@
+package types
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// CaptureFileExtension is the extension of the websocket capture files, each line is a json encoded CaptureFrame
+const CaptureFileExtension = ".jsonl.gz"
+
+// CaptureFrame is a raw websocket message captured from the stream
+type CaptureFrame struct {
+	Time    time.Time `json:"time"`
+	Session string    `json:"session,omitempty"`
+
+	// Stream is the name of the stream in the session, e.g., user or market
+	Stream string `json:"stream,omitempty"`
+
+	Message string `json:"message"`
+}
+
+// CaptureWriter writes the raw messages of the streams to a gzip compressed json lines file
+type CaptureWriter struct {
+	mu      sync.Mutex
+	file    io.WriteCloser
+	gzip    *gzip.Writer
+	encoder *json.Encoder
+}
+
+func NewCaptureWriter(w io.WriteCloser) *CaptureWriter {
+	gz := gzip.NewWriter(w)
+	return &CaptureWriter{
+		file:    w,
+		gzip:    gz,
+		encoder: json.NewEncoder(gz),
+	}
+}
+
+// CreateCaptureFile creates the capture file of the session in the given directory,
+// the file name is like "binance-20231018T100000.jsonl.gz"
+func CreateCaptureFile(dir, session string) (*CaptureWriter, string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, "", err
+	}
+
+	fileName := fmt.Sprintf("%s-%s%s", session, time.Now().Format("20060102T150405"), CaptureFileExtension)
+	filePath := filepath.Join(dir, fileName)
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return NewCaptureWriter(f), filePath, nil
+}
+
+// Record captures the raw messages of the stream with the session name and the stream name
+func (w *CaptureWriter) Record(session, streamName string, stream Stream) {
+	stream.OnRawMessage(func(raw []byte) {
+		if err := w.Write(CaptureFrame{
+			Time:    time.Now(),
+			Session: session,
+			Stream:  streamName,
+			Message: string(raw),
+		}); err != nil {
+			log.WithError(err).Errorf("unable to write the websocket capture frame")
+		}
+	})
+}
+
+func (w *CaptureWriter) Write(frame CaptureFrame) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.encoder.Encode(frame)
+}
+
+// Close flushes the compressed frames and closes the file
+func (w *CaptureWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.gzip.Close(); err != nil {
+		return err
+	}
+
+	return w.file.Close()
+}
+
+// CaptureReader reads the frames from the capture file
+type CaptureReader struct {
+	file    io.ReadCloser
+	gzip    *gzip.Reader
+	scanner *bufio.Scanner
+}
+
+func NewCaptureReader(r io.ReadCloser) (*CaptureReader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(gz)
+
+	// the order book snapshot messages could be larger than the default buffer
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	return &CaptureReader{
+		file:    r,
+		gzip:    gz,
+		scanner: scanner,
+	}, nil
+}
+
+func OpenCaptureFile(filePath string) (*CaptureReader, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := NewCaptureReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+// Next returns the next frame, io.EOF is returned when there is no more frame
+func (r *CaptureReader) Next() (*CaptureFrame, error) {
+	for r.scanner.Scan() {
+		line := r.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var frame CaptureFrame
+		if err := json.Unmarshal(line, &frame); err != nil {
+			return nil, err
+		}
+
+		return &frame, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, io.EOF
+}
+
+func (r *CaptureReader) Close() error {
+	if err := r.gzip.Close(); err != nil {
+		return err
+	}
+
+	return r.file.Close()
+}
@@ -0,0 +1,108 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+func writeTestCapture(t *testing.T, frames ...CaptureFrame) *CaptureReader {
+	var buf bytes.Buffer
+	writer := NewCaptureWriter(nopWriteCloser{Writer: &buf})
+	for _, frame := range frames {
+		assert.NoError(t, writer.Write(frame))
+	}
+	assert.NoError(t, writer.Close())
+
+	reader, err := NewCaptureReader(io.NopCloser(&buf))
+	assert.NoError(t, err)
+	return reader
+}
+
+func TestCaptureWriter_Record(t *testing.T) {
+	stream := &StandardStream{}
+
+	var buf bytes.Buffer
+	writer := NewCaptureWriter(nopWriteCloser{Writer: &buf})
+	writer.Record("binance", "market", stream)
+
+	stream.EmitRawMessage([]byte(`{"e":"trade"}`))
+	stream.EmitRawMessage([]byte(`{"e":"depthUpdate"}`))
+	assert.NoError(t, writer.Close())
+
+	reader, err := NewCaptureReader(io.NopCloser(&buf))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var messages []string
+	for {
+		frame, err := reader.Next()
+		if err == io.EOF {
+			break
+		} else if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, "binance", frame.Session)
+		assert.Equal(t, "market", frame.Stream)
+		assert.False(t, frame.Time.IsZero())
+		messages = append(messages, frame.Message)
+	}
+
+	assert.Equal(t, []string{`{"e":"trade"}`, `{"e":"depthUpdate"}`}, messages)
+}
+
+func TestReplayStream_Replay(t *testing.T) {
+	now := time.Now()
+	reader := writeTestCapture(t,
+		CaptureFrame{Time: now, Session: "binance", Stream: "market", Message: "1"},
+		CaptureFrame{Time: now.Add(20 * time.Millisecond), Session: "binance", Stream: "user", Message: "2"},
+		CaptureFrame{Time: now.Add(40 * time.Millisecond), Session: "binance", Stream: "market", Message: "3"},
+		CaptureFrame{Time: now.Add(60 * time.Millisecond), Session: "max", Stream: "market", Message: "4"},
+	)
+
+	stream := NewStandardStream()
+	stream.SetParser(func(message []byte) (interface{}, error) {
+		return string(message), nil
+	})
+
+	var events []interface{}
+	stream.SetDispatcher(func(e interface{}) {
+		events = append(events, e)
+	})
+
+	replay, err := NewReplayStream(&stream, reader)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	replay.Session = "binance"
+	replay.StreamName = "market"
+	replay.Speed = 1.0
+
+	startTime := time.Now()
+	assert.NoError(t, replay.Connect(context.Background()))
+
+	select {
+	case <-replay.Done():
+	case <-time.After(time.Second):
+		t.Fatal("the replay is not finished")
+	}
+
+	assert.NoError(t, replay.Err())
+	assert.Equal(t, []interface{}{"1", "3"}, events)
+
+	// the frames are replayed with the original interval
+	assert.GreaterOrEqual(t, time.Since(startTime), 40*time.Millisecond)
+}
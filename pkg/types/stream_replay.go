@@ -0,0 +1,131 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// MessageHandler is implemented by the streams that parse and dispatch the raw messages, e.g., StandardStream
+type MessageHandler interface {
+	HandleMessage(message []byte)
+}
+
+// ReplayStream feeds the captured raw messages back through the parser and the dispatcher of the exchange stream,
+// so the callbacks registered on the replay stream receive the same events as the live stream did.
+// The replay stream does not dial the websocket server, the connect event is not emitted since the exchange
+// streams send the subscription requests on connect.
+type ReplayStream struct {
+	Stream
+
+	// Speed is the replay speed, 1 replays the frames with the original intervals, 10 replays 10x faster,
+	// and zero replays the frames without waiting.
+	Speed float64
+
+	// Session filters the frames by the session name, empty means the frames of all sessions are replayed
+	Session string
+
+	// StreamName filters the frames by the stream name, e.g., user or market
+	StreamName string
+
+	handler MessageHandler
+	reader  *CaptureReader
+
+	doneOnce sync.Once
+	doneC    chan struct{}
+	err      error
+}
+
+// NewReplayStream creates the replay stream from the exchange stream, e.g., the stream created by
+// exchange.NewStream(), the exchange stream should embed StandardStream.
+func NewReplayStream(stream Stream, reader *CaptureReader) (*ReplayStream, error) {
+	handler, ok := stream.(MessageHandler)
+	if !ok {
+		return nil, fmt.Errorf("stream %T does not support the message replay", stream)
+	}
+
+	return &ReplayStream{
+		Stream:  stream,
+		handler: handler,
+		reader:  reader,
+		doneC:   make(chan struct{}),
+	}, nil
+}
+
+// Connect starts replaying the frames in the background, Done is closed when all the frames are replayed
+func (s *ReplayStream) Connect(ctx context.Context) error {
+	go func() {
+		if err := s.Replay(ctx); err != nil {
+			log.WithError(err).Errorf("websocket replay error")
+		}
+	}()
+
+	return nil
+}
+
+// Replay replays the frames until the end of the capture or the context is done
+func (s *ReplayStream) Replay(ctx context.Context) (err error) {
+	defer s.doneOnce.Do(func() {
+		s.err = err
+		close(s.doneC)
+	})
+
+	var lastFrameTime time.Time
+	for {
+		frame, err := s.reader.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if len(s.Session) > 0 && frame.Session != s.Session {
+			continue
+		}
+
+		if len(s.StreamName) > 0 && frame.Stream != s.StreamName {
+			continue
+		}
+
+		if s.Speed > 0 && !lastFrameTime.IsZero() {
+			if d := time.Duration(float64(frame.Time.Sub(lastFrameTime)) / s.Speed); d > 0 {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(d):
+				}
+			}
+		}
+
+		lastFrameTime = frame.Time
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		s.handler.HandleMessage([]byte(frame.Message))
+	}
+}
+
+// Done is closed when the replay is finished
+func (s *ReplayStream) Done() <-chan struct{} {
+	return s.doneC
+}
+
+// Err returns the error of the replay, it should be called after Done is closed
+func (s *ReplayStream) Err() error {
+	return s.err
+}
+
+// Reconnect is a no-op since there is no connection to re-connect
+func (s *ReplayStream) Reconnect() {}
+
+func (s *ReplayStream) Close() error {
+	return s.reader.Close()
+}
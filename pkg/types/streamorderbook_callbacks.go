@@ -23,3 +23,13 @@ func (sb *StreamOrderBook) EmitSnapshot(snapshot SliceOrderBook) {
 		cb(snapshot)
 	}
 }
+
+func (sb *StreamOrderBook) OnIntegrityIssue(cb func(issue BookIntegrityIssue)) {
+	sb.integrityIssueCallbacks = append(sb.integrityIssueCallbacks, cb)
+}
+
+func (sb *StreamOrderBook) EmitIntegrityIssue(issue BookIntegrityIssue) {
+	for _, cb := range sb.integrityIssueCallbacks {
+		cb(issue)
+	}
+}
@@ -358,6 +358,12 @@ func BeginningOfTheDay(t time.Time) time.Time {
 	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
 }
 
+// BeginningOfTheWeek returns the beginning of the monday of the week
+func BeginningOfTheWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return BeginningOfTheDay(t.AddDate(0, 0, -offset))
+}
+
 func Over24Hours(since time.Time) bool {
 	return time.Since(since) >= 24*time.Hour
 }
@@ -87,3 +87,10 @@ func TestMillisecondTimestamp_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestBeginningOfTheWeek(t *testing.T) {
+	monday := time.Date(2023, time.June, 5, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, monday, BeginningOfTheWeek(time.Date(2023, time.June, 5, 13, 30, 0, 0, time.UTC)))
+	assert.Equal(t, monday, BeginningOfTheWeek(time.Date(2023, time.June, 8, 1, 0, 0, 0, time.UTC)))
+	assert.Equal(t, monday, BeginningOfTheWeek(time.Date(2023, time.June, 11, 23, 59, 0, 0, time.UTC)))
+}
@@ -15,7 +15,7 @@ _sym_db = _symbol_database.Default()
 
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\nbbgo.proto\x12\x04\x62\x62go\"\x07\n\x05\x45mpty\"2\n\x05\x45rror\x12\x12\n\nerror_code\x18\x01 \x01(\x03\x12\x15\n\rerror_message\x18\x02 \x01(\t\"\"\n\x0fUserDataRequest\x12\x0f\n\x07session\x18\x01 \x01(\t\"\xc4\x01\n\x08UserData\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x10\n\x08\x65xchange\x18\x02 \x01(\t\x12\x1e\n\x07\x63hannel\x18\x03 \x01(\x0e\x32\r.bbgo.Channel\x12\x1a\n\x05\x65vent\x18\x04 \x01(\x0e\x32\x0b.bbgo.Event\x12\x1f\n\x08\x62\x61lances\x18\x05 \x03(\x0b\x32\r.bbgo.Balance\x12\x1b\n\x06trades\x18\x06 \x03(\x0b\x32\x0b.bbgo.Trade\x12\x1b\n\x06orders\x18\x07 \x03(\x0b\x32\x0b.bbgo.Order\"=\n\x10SubscribeRequest\x12)\n\rsubscriptions\x18\x01 \x03(\x0b\x32\x12.bbgo.Subscription\"q\n\x0cSubscription\x12\x10\n\x08\x65xchange\x18\x01 \x01(\t\x12\x1e\n\x07\x63hannel\x18\x02 \x01(\x0e\x32\r.bbgo.Channel\x12\x0e\n\x06symbol\x18\x03 \x01(\t\x12\r\n\x05\x64\x65pth\x18\x04 \x01(\t\x12\x10\n\x08interval\x18\x05 \x01(\t\"\xa1\x02\n\nMarketData\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x10\n\x08\x65xchange\x18\x02 \x01(\t\x12\x0e\n\x06symbol\x18\x03 \x01(\t\x12\x1e\n\x07\x63hannel\x18\x04 \x01(\x0e\x32\r.bbgo.Channel\x12\x1a\n\x05\x65vent\x18\x05 \x01(\x0e\x32\x0b.bbgo.Event\x12\x1a\n\x05\x64\x65pth\x18\x06 \x01(\x0b\x32\x0b.bbgo.Depth\x12\x1a\n\x05kline\x18\x07 \x01(\x0b\x32\x0b.bbgo.KLine\x12\x1c\n\x06ticker\x18\t \x01(\x0b\x32\x0c.bbgo.Ticker\x12\x1b\n\x06trades\x18\x08 \x03(\x0b\x32\x0b.bbgo.Trade\x12\x15\n\rsubscribed_at\x18\x0c \x01(\x03\x12\x1a\n\x05\x65rror\x18\r \x01(\x0b\x32\x0b.bbgo.Error\"k\n\x05\x44\x65pth\x12\x10\n\x08\x65xchange\x18\x01 \x01(\t\x12\x0e\n\x06symbol\x18\x02 \x01(\t\x12\x1f\n\x04\x61sks\x18\x03 \x03(\x0b\x32\x11.bbgo.PriceVolume\x12\x1f\n\x04\x62ids\x18\x04 \x03(\x0b\x32\x11.bbgo.PriceVolume\",\n\x0bPriceVolume\x12\r\n\x05price\x18\x01 \x01(\t\x12\x0e\n\x06volume\x18\x02 \x01(\t\"\xc7\x01\n\x05Trade\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x10\n\x08\x65xchange\x18\x02 \x01(\t\x12\x0e\n\x06symbol\x18\x03 \x01(\t\x12\n\n\x02id\x18\x04 \x01(\t\x12\r\n\x05price\x18\x05 \x01(\t\x12\x10\n\x08quantity\x18\x06 \x01(\t\x12\x12\n\ncreated_at\x18\x07 \x01(\x03\x12\x18\n\x04side\x18\x08 \x01(\x0e\x32\n.bbgo.Side\x12\x14\n\x0c\x66\x65\x65_currency\x18\t \x01(\t\x12\x0b\n\x03\x66\x65\x65\x18\n \x01(\t\x12\r\n\x05maker\x18\x0b \x01(\x08\"r\n\x06Ticker\x12\x10\n\x08\x65xchange\x18\x01 \x01(\t\x12\x0e\n\x06symbol\x18\x02 \x01(\t\x12\x0c\n\x04open\x18\x03 \x01(\x01\x12\x0c\n\x04high\x18\x04 \x01(\x01\x12\x0b\n\x03low\x18\x05 \x01(\x01\x12\r\n\x05\x63lose\x18\x06 \x01(\x01\x12\x0e\n\x06volume\x18\x07 \x01(\x01\"\x93\x02\n\x05Order\x12\x10\n\x08\x65xchange\x18\x01 \x01(\t\x12\x0e\n\x06symbol\x18\x02 \x01(\t\x12\n\n\x02id\x18\x03 \x01(\t\x12\x18\n\x04side\x18\x04 \x01(\x0e\x32\n.bbgo.Side\x12#\n\norder_type\x18\x05 \x01(\x0e\x32\x0f.bbgo.OrderType\x12\r\n\x05price\x18\x06 \x01(\t\x12\x12\n\nstop_price\x18\x07 \x01(\t\x12\x0e\n\x06status\x18\t \x01(\t\x12\x10\n\x08quantity\x18\x0b \x01(\t\x12\x19\n\x11\x65xecuted_quantity\x18\x0c \x01(\t\x12\x17\n\x0f\x63lient_order_id\x18\x0e \x01(\t\x12\x10\n\x08group_id\x18\x0f \x01(\x03\x12\x12\n\ncreated_at\x18\n \x01(\x03\"\xdf\x01\n\x0bSubmitOrder\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x10\n\x08\x65xchange\x18\x02 \x01(\t\x12\x0e\n\x06symbol\x18\x03 \x01(\t\x12\x18\n\x04side\x18\x04 \x01(\x0e\x32\n.bbgo.Side\x12\r\n\x05price\x18\x06 \x01(\t\x12\x10\n\x08quantity\x18\x05 \x01(\t\x12\x12\n\nstop_price\x18\x07 \x01(\t\x12#\n\norder_type\x18\x08 \x01(\x0e\x32\x0f.bbgo.OrderType\x12\x17\n\x0f\x63lient_order_id\x18\t \x01(\t\x12\x10\n\x08group_id\x18\n \x01(\x03\"s\n\x07\x42\x61lance\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x10\n\x08\x65xchange\x18\x02 \x01(\t\x12\x10\n\x08\x63urrency\x18\x03 \x01(\t\x12\x11\n\tavailable\x18\x04 \x01(\t\x12\x0e\n\x06locked\x18\x05 \x01(\t\x12\x10\n\x08\x62orrowed\x18\x06 \x01(\t\"O\n\x12SubmitOrderRequest\x12\x0f\n\x07session\x18\x01 \x01(\t\x12(\n\rsubmit_orders\x18\x02 \x03(\x0b\x32\x11.bbgo.SubmitOrder\"_\n\x13SubmitOrderResponse\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x1b\n\x06orders\x18\x02 \x03(\x0b\x32\x0b.bbgo.Order\x12\x1a\n\x05\x65rror\x18\x03 \x01(\x0b\x32\x0b.bbgo.Error\"P\n\x12\x43\x61ncelOrderRequest\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x10\n\x08order_id\x18\x02 \x01(\t\x12\x17\n\x0f\x63lient_order_id\x18\x03 \x01(\t\"M\n\x13\x43\x61ncelOrderResponse\x12\x1a\n\x05order\x18\x01 \x01(\x0b\x32\x0b.bbgo.Order\x12\x1a\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x0b.bbgo.Error\"I\n\x11QueryOrderRequest\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\n\n\x02id\x18\x02 \x01(\t\x12\x17\n\x0f\x63lient_order_id\x18\x03 \x01(\t\"L\n\x12QueryOrderResponse\x12\x1a\n\x05order\x18\x01 \x01(\x0b\x32\x0b.bbgo.Order\x12\x1a\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x0b.bbgo.Error\"\xa9\x01\n\x12QueryOrdersRequest\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x0e\n\x06symbol\x18\x02 \x01(\t\x12\r\n\x05state\x18\x03 \x03(\t\x12\x10\n\x08order_by\x18\x04 \x01(\t\x12\x10\n\x08group_id\x18\x05 \x01(\x03\x12\x12\n\npagination\x18\x06 \x01(\x08\x12\x0c\n\x04page\x18\x07 \x01(\x03\x12\r\n\x05limit\x18\x08 \x01(\x03\x12\x0e\n\x06offset\x18\t \x01(\x03\"N\n\x13QueryOrdersResponse\x12\x1b\n\x06orders\x18\x01 \x03(\x0b\x32\x0b.bbgo.Order\x12\x1a\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x0b.bbgo.Error\"\xb6\x01\n\x12QueryTradesRequest\x12\x10\n\x08\x65xchange\x18\x01 \x01(\t\x12\x0e\n\x06symbol\x18\x02 \x01(\t\x12\x11\n\ttimestamp\x18\x03 \x01(\x03\x12\x0c\n\x04\x66rom\x18\x04 \x01(\x03\x12\n\n\x02to\x18\x05 \x01(\x03\x12\x10\n\x08order_by\x18\x06 \x01(\t\x12\x12\n\npagination\x18\x07 \x01(\x08\x12\x0c\n\x04page\x18\x08 \x01(\x03\x12\r\n\x05limit\x18\t \x01(\x03\x12\x0e\n\x06offset\x18\n \x01(\x03\"N\n\x13QueryTradesResponse\x12\x1b\n\x06trades\x18\x01 \x03(\x0b\x32\x0b.bbgo.Trade\x12\x1a\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x0b.bbgo.Error\"}\n\x12QueryKLinesRequest\x12\x10\n\x08\x65xchange\x18\x01 \x01(\t\x12\x0e\n\x06symbol\x18\x02 \x01(\t\x12\x10\n\x08interval\x18\x03 \x01(\t\x12\x12\n\nstart_time\x18\x04 \x01(\x03\x12\x10\n\x08\x65nd_time\x18\x05 \x01(\x03\x12\r\n\x05limit\x18\x06 \x01(\x03\"N\n\x13QueryKLinesResponse\x12\x1b\n\x06klines\x18\x01 \x03(\x0b\x32\x0b.bbgo.KLine\x12\x1a\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x0b.bbgo.Error\"\xce\x01\n\x05KLine\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x10\n\x08\x65xchange\x18\x02 \x01(\t\x12\x0e\n\x06symbol\x18\x03 \x01(\t\x12\x0c\n\x04open\x18\x04 \x01(\t\x12\x0c\n\x04high\x18\x05 \x01(\t\x12\x0b\n\x03low\x18\x06 \x01(\t\x12\r\n\x05\x63lose\x18\x07 \x01(\t\x12\x0e\n\x06volume\x18\x08 \x01(\t\x12\x14\n\x0cquote_volume\x18\t \x01(\t\x12\x12\n\nstart_time\x18\n \x01(\x03\x12\x10\n\x08\x65nd_time\x18\x0b \x01(\x03\x12\x0e\n\x06\x63losed\x18\x0c \x01(\x08*n\n\x05\x45vent\x12\x0b\n\x07UNKNOWN\x10\x00\x12\x0e\n\nSUBSCRIBED\x10\x01\x12\x10\n\x0cUNSUBSCRIBED\x10\x02\x12\x0c\n\x08SNAPSHOT\x10\x03\x12\n\n\x06UPDATE\x10\x04\x12\x11\n\rAUTHENTICATED\x10\x05\x12\t\n\x05\x45RROR\x10\x63*M\n\x07\x43hannel\x12\x08\n\x04\x42OOK\x10\x00\x12\t\n\x05TRADE\x10\x01\x12\n\n\x06TICKER\x10\x02\x12\t\n\x05KLINE\x10\x03\x12\x0b\n\x07\x42\x41LANCE\x10\x04\x12\t\n\x05ORDER\x10\x05*\x19\n\x04Side\x12\x07\n\x03\x42UY\x10\x00\x12\x08\n\x04SELL\x10\x01*a\n\tOrderType\x12\n\n\x06MARKET\x10\x00\x12\t\n\x05LIMIT\x10\x01\x12\x0f\n\x0bSTOP_MARKET\x10\x02\x12\x0e\n\nSTOP_LIMIT\x10\x03\x12\r\n\tPOST_ONLY\x10\x04\x12\r\n\tIOC_LIMIT\x10\x05\x32\x94\x01\n\x11MarketDataService\x12\x39\n\tSubscribe\x12\x16.bbgo.SubscribeRequest\x1a\x10.bbgo.MarketData\"\x00\x30\x01\x12\x44\n\x0bQueryKLines\x12\x18.bbgo.QueryKLinesRequest\x1a\x19.bbgo.QueryKLinesResponse\"\x00\x32I\n\x0fUserDataService\x12\x36\n\tSubscribe\x12\x15.bbgo.UserDataRequest\x1a\x0e.bbgo.UserData\"\x00\x30\x01\x32\xeb\x02\n\x0eTradingService\x12\x44\n\x0bSubmitOrder\x12\x18.bbgo.SubmitOrderRequest\x1a\x19.bbgo.SubmitOrderResponse\"\x00\x12\x44\n\x0b\x43\x61ncelOrder\x12\x18.bbgo.CancelOrderRequest\x1a\x19.bbgo.CancelOrderResponse\"\x00\x12\x41\n\nQueryOrder\x12\x17.bbgo.QueryOrderRequest\x1a\x18.bbgo.QueryOrderResponse\"\x00\x12\x44\n\x0bQueryOrders\x12\x18.bbgo.QueryOrdersRequest\x1a\x19.bbgo.QueryOrdersResponse\"\x00\x12\x44\n\x0bQueryTrades\x12\x18.bbgo.QueryTradesRequest\x1a\x19.bbgo.QueryTradesResponse\"\x00\x42\x07Z\x05../pbb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\nbbgo.proto\x12\x04\x62\x62go\"\x07\n\x05\x45mpty\"2\n\x05\x45rror\x12\x12\n\nerror_code\x18\x01 \x01(\x03\x12\x15\n\rerror_message\x18\x02 \x01(\t\"\"\n\x0fUserDataRequest\x12\x0f\n\x07session\x18\x01 \x01(\t\"\xc4\x01\n\x08UserData\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x10\n\x08\x65xchange\x18\x02 \x01(\t\x12\x1e\n\x07\x63hannel\x18\x03 \x01(\x0e\x32\r.bbgo.Channel\x12\x1a\n\x05\x65vent\x18\x04 \x01(\x0e\x32\x0b.bbgo.Event\x12\x1f\n\x08\x62\x61lances\x18\x05 \x03(\x0b\x32\r.bbgo.Balance\x12\x1b\n\x06trades\x18\x06 \x03(\x0b\x32\x0b.bbgo.Trade\x12\x1b\n\x06orders\x18\x07 \x03(\x0b\x32\x0b.bbgo.Order\"=\n\x10SubscribeRequest\x12)\n\rsubscriptions\x18\x01 \x03(\x0b\x32\x12.bbgo.Subscription\"q\n\x0cSubscription\x12\x10\n\x08\x65xchange\x18\x01 \x01(\t\x12\x1e\n\x07\x63hannel\x18\x02 \x01(\x0e\x32\r.bbgo.Channel\x12\x0e\n\x06symbol\x18\x03 \x01(\t\x12\r\n\x05\x64\x65pth\x18\x04 \x01(\t\x12\x10\n\x08interval\x18\x05 \x01(\t\"\xa1\x02\n\nMarketData\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x10\n\x08\x65xchange\x18\x02 \x01(\t\x12\x0e\n\x06symbol\x18\x03 \x01(\t\x12\x1e\n\x07\x63hannel\x18\x04 \x01(\x0e\x32\r.bbgo.Channel\x12\x1a\n\x05\x65vent\x18\x05 \x01(\x0e\x32\x0b.bbgo.Event\x12\x1a\n\x05\x64\x65pth\x18\x06 \x01(\x0b\x32\x0b.bbgo.Depth\x12\x1a\n\x05kline\x18\x07 \x01(\x0b\x32\x0b.bbgo.KLine\x12\x1c\n\x06ticker\x18\t \x01(\x0b\x32\x0c.bbgo.Ticker\x12\x1b\n\x06trades\x18\x08 \x03(\x0b\x32\x0b.bbgo.Trade\x12\x15\n\rsubscribed_at\x18\x0c \x01(\x03\x12\x1a\n\x05\x65rror\x18\r \x01(\x0b\x32\x0b.bbgo.Error\"k\n\x05\x44\x65pth\x12\x10\n\x08\x65xchange\x18\x01 \x01(\t\x12\x0e\n\x06symbol\x18\x02 \x01(\t\x12\x1f\n\x04\x61sks\x18\x03 \x03(\x0b\x32\x11.bbgo.PriceVolume\x12\x1f\n\x04\x62ids\x18\x04 \x03(\x0b\x32\x11.bbgo.PriceVolume\",\n\x0bPriceVolume\x12\r\n\x05price\x18\x01 \x01(\t\x12\x0e\n\x06volume\x18\x02 \x01(\t\"\xc7\x01\n\x05Trade\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x10\n\x08\x65xchange\x18\x02 \x01(\t\x12\x0e\n\x06symbol\x18\x03 \x01(\t\x12\n\n\x02id\x18\x04 \x01(\t\x12\r\n\x05price\x18\x05 \x01(\t\x12\x10\n\x08quantity\x18\x06 \x01(\t\x12\x12\n\ncreated_at\x18\x07 \x01(\x03\x12\x18\n\x04side\x18\x08 \x01(\x0e\x32\n.bbgo.Side\x12\x14\n\x0c\x66\x65\x65_currency\x18\t \x01(\t\x12\x0b\n\x03\x66\x65\x65\x18\n \x01(\t\x12\r\n\x05maker\x18\x0b \x01(\x08\"r\n\x06Ticker\x12\x10\n\x08\x65xchange\x18\x01 \x01(\t\x12\x0e\n\x06symbol\x18\x02 \x01(\t\x12\x0c\n\x04open\x18\x03 \x01(\x01\x12\x0c\n\x04high\x18\x04 \x01(\x01\x12\x0b\n\x03low\x18\x05 \x01(\x01\x12\r\n\x05\x63lose\x18\x06 \x01(\x01\x12\x0e\n\x06volume\x18\x07 \x01(\x01\"\x93\x02\n\x05Order\x12\x10\n\x08\x65xchange\x18\x01 \x01(\t\x12\x0e\n\x06symbol\x18\x02 \x01(\t\x12\n\n\x02id\x18\x03 \x01(\t\x12\x18\n\x04side\x18\x04 \x01(\x0e\x32\n.bbgo.Side\x12#\n\norder_type\x18\x05 \x01(\x0e\x32\x0f.bbgo.OrderType\x12\r\n\x05price\x18\x06 \x01(\t\x12\x12\n\nstop_price\x18\x07 \x01(\t\x12\x0e\n\x06status\x18\t \x01(\t\x12\x10\n\x08quantity\x18\x0b \x01(\t\x12\x19\n\x11\x65xecuted_quantity\x18\x0c \x01(\t\x12\x17\n\x0f\x63lient_order_id\x18\x0e \x01(\t\x12\x10\n\x08group_id\x18\x0f \x01(\x03\x12\x12\n\ncreated_at\x18\n \x01(\x03\"\xdf\x01\n\x0bSubmitOrder\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x10\n\x08\x65xchange\x18\x02 \x01(\t\x12\x0e\n\x06symbol\x18\x03 \x01(\t\x12\x18\n\x04side\x18\x04 \x01(\x0e\x32\n.bbgo.Side\x12\r\n\x05price\x18\x06 \x01(\t\x12\x10\n\x08quantity\x18\x05 \x01(\t\x12\x12\n\nstop_price\x18\x07 \x01(\t\x12#\n\norder_type\x18\x08 \x01(\x0e\x32\x0f.bbgo.OrderType\x12\x17\n\x0f\x63lient_order_id\x18\t \x01(\t\x12\x10\n\x08group_id\x18\n \x01(\x03\"s\n\x07\x42\x61lance\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x10\n\x08\x65xchange\x18\x02 \x01(\t\x12\x10\n\x08\x63urrency\x18\x03 \x01(\t\x12\x11\n\tavailable\x18\x04 \x01(\t\x12\x0e\n\x06locked\x18\x05 \x01(\t\x12\x10\n\x08\x62orrowed\x18\x06 \x01(\t\"O\n\x12SubmitOrderRequest\x12\x0f\n\x07session\x18\x01 \x01(\t\x12(\n\rsubmit_orders\x18\x02 \x03(\x0b\x32\x11.bbgo.SubmitOrder\"_\n\x13SubmitOrderResponse\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x1b\n\x06orders\x18\x02 \x03(\x0b\x32\x0b.bbgo.Order\x12\x1a\n\x05\x65rror\x18\x03 \x01(\x0b\x32\x0b.bbgo.Error\"P\n\x12\x43\x61ncelOrderRequest\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x10\n\x08order_id\x18\x02 \x01(\t\x12\x17\n\x0f\x63lient_order_id\x18\x03 \x01(\t\"M\n\x13\x43\x61ncelOrderResponse\x12\x1a\n\x05order\x18\x01 \x01(\x0b\x32\x0b.bbgo.Order\x12\x1a\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x0b.bbgo.Error\"Y\n\x11QueryOrderRequest\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\n\n\x02id\x18\x02 \x01(\t\x12\x17\n\x0f\x63lient_order_id\x18\x03 \x01(\t\x12\x0e\n\x06symbol\x18\x04 \x01(\t\"L\n\x12QueryOrderResponse\x12\x1a\n\x05order\x18\x01 \x01(\x0b\x32\x0b.bbgo.Order\x12\x1a\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x0b.bbgo.Error\"\xa9\x01\n\x12QueryOrdersRequest\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x0e\n\x06symbol\x18\x02 \x01(\t\x12\r\n\x05state\x18\x03 \x03(\t\x12\x10\n\x08order_by\x18\x04 \x01(\t\x12\x10\n\x08group_id\x18\x05 \x01(\x03\x12\x12\n\npagination\x18\x06 \x01(\x08\x12\x0c\n\x04page\x18\x07 \x01(\x03\x12\r\n\x05limit\x18\x08 \x01(\x03\x12\x0e\n\x06offset\x18\t \x01(\x03\"N\n\x13QueryOrdersResponse\x12\x1b\n\x06orders\x18\x01 \x03(\x0b\x32\x0b.bbgo.Order\x12\x1a\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x0b.bbgo.Error\"\xb6\x01\n\x12QueryTradesRequest\x12\x10\n\x08\x65xchange\x18\x01 \x01(\t\x12\x0e\n\x06symbol\x18\x02 \x01(\t\x12\x11\n\ttimestamp\x18\x03 \x01(\x03\x12\x0c\n\x04\x66rom\x18\x04 \x01(\x03\x12\n\n\x02to\x18\x05 \x01(\x03\x12\x10\n\x08order_by\x18\x06 \x01(\t\x12\x12\n\npagination\x18\x07 \x01(\x08\x12\x0c\n\x04page\x18\x08 \x01(\x03\x12\r\n\x05limit\x18\t \x01(\x03\x12\x0e\n\x06offset\x18\n \x01(\x03\"N\n\x13QueryTradesResponse\x12\x1b\n\x06trades\x18\x01 \x03(\x0b\x32\x0b.bbgo.Trade\x12\x1a\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x0b.bbgo.Error\"}\n\x12QueryKLinesRequest\x12\x10\n\x08\x65xchange\x18\x01 \x01(\t\x12\x0e\n\x06symbol\x18\x02 \x01(\t\x12\x10\n\x08interval\x18\x03 \x01(\t\x12\x12\n\nstart_time\x18\x04 \x01(\x03\x12\x10\n\x08\x65nd_time\x18\x05 \x01(\x03\x12\r\n\x05limit\x18\x06 \x01(\x03\"N\n\x13QueryKLinesResponse\x12\x1b\n\x06klines\x18\x01 \x03(\x0b\x32\x0b.bbgo.KLine\x12\x1a\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x0b.bbgo.Error\"\xce\x01\n\x05KLine\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x10\n\x08\x65xchange\x18\x02 \x01(\t\x12\x0e\n\x06symbol\x18\x03 \x01(\t\x12\x0c\n\x04open\x18\x04 \x01(\t\x12\x0c\n\x04high\x18\x05 \x01(\t\x12\x0b\n\x03low\x18\x06 \x01(\t\x12\r\n\x05\x63lose\x18\x07 \x01(\t\x12\x0e\n\x06volume\x18\x08 \x01(\t\x12\x14\n\x0cquote_volume\x18\t \x01(\t\x12\x12\n\nstart_time\x18\n \x01(\x03\x12\x10\n\x08\x65nd_time\x18\x0b \x01(\x03\x12\x0e\n\x06\x63losed\x18\x0c \x01(\x08\"\x8f\x01\n\x08Position\x12\x10\n\x08\x65xchange\x18\x01 \x01(\t\x12\x0e\n\x06symbol\x18\x02 \x01(\t\x12\x0c\n\x04\x62\x61se\x18\x03 \x01(\t\x12\r\n\x05quote\x18\x04 \x01(\t\x12\x14\n\x0c\x61verage_cost\x18\x05 \x01(\t\x12\x10\n\x08strategy\x18\x06 \x01(\t\x12\x1c\n\x14strategy_instance_id\x18\x07 \x01(\t\"\xcc\x01\n\x06Market\x12\x0e\n\x06symbol\x18\x01 \x01(\t\x12\x15\n\rbase_currency\x18\x02 \x01(\t\x12\x16\n\x0equote_currency\x18\x03 \x01(\t\x12\x11\n\ttick_size\x18\x04 \x01(\t\x12\x11\n\tstep_size\x18\x05 \x01(\t\x12\x14\n\x0cmin_notional\x18\x06 \x01(\t\x12\x14\n\x0cmin_quantity\x18\x07 \x01(\t\x12\x17\n\x0fprice_precision\x18\x08 \x01(\x05\x12\x18\n\x10volume_precision\x18\t \x01(\x05\"\xf6\x02\n\x0bProfitStats\x12\x0e\n\x06symbol\x18\x01 \x01(\t\x12\x16\n\x0equote_currency\x18\x02 \x01(\t\x12\x15\n\rbase_currency\x18\x03 \x01(\t\x12\x17\n\x0f\x61\x63\x63umulated_pnl\x18\x04 \x01(\t\x12\x1e\n\x16\x61\x63\x63umulated_net_profit\x18\x05 \x01(\t\x12 \n\x18\x61\x63\x63umulated_gross_profit\x18\x06 \x01(\t\x12\x1e\n\x16\x61\x63\x63umulated_gross_loss\x18\x07 \x01(\t\x12\x1a\n\x12\x61\x63\x63umulated_volume\x18\x08 \x01(\t\x12\x19\n\x11\x61\x63\x63umulated_since\x18\t \x01(\x03\x12\x11\n\ttoday_pnl\x18\n \x01(\t\x12\x18\n\x10today_net_profit\x18\x0b \x01(\t\x12\x1a\n\x12today_gross_profit\x18\x0c \x01(\t\x12\x18\n\x10today_gross_loss\x18\r \x01(\t\x12\x13\n\x0btoday_since\x18\x0e \x01(\x03\"\xdc\x01\n\x08Strategy\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x10\n\x08strategy\x18\x02 \x01(\t\x12\x1c\n\x14strategy_instance_id\x18\x03 \x01(\t\x12\x0e\n\x06status\x18\x04 \x01(\t\x12 \n\x08position\x18\x05 \x01(\x0b\x32\x0e.bbgo.Position\x12\'\n\x0cprofit_stats\x18\x06 \x01(\x0b\x32\x11.bbgo.ProfitStats\x12\"\n\ractive_orders\x18\x07 \x03(\x0b\x32\x0b.bbgo.Order\x12\x10\n\x08\x63ontrols\x18\x08 \x03(\t\"(\n\x15ListStrategiesRequest\x12\x0f\n\x07session\x18\x01 \x01(\t\"X\n\x16ListStrategiesResponse\x12\"\n\nstrategies\x18\x01 \x03(\x0b\x32\x0e.bbgo.Strategy\x12\x1a\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x0b.bbgo.Error\"@\n\x0fStrategyRequest\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x1c\n\x14strategy_instance_id\x18\x02 \x01(\t\"P\n\x10StrategyResponse\x12 \n\x08strategy\x18\x01 \x01(\x0b\x32\x0e.bbgo.Strategy\x12\x1a\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x0b.bbgo.Error\"Y\n\x14\x43losePositionRequest\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x1c\n\x14strategy_instance_id\x18\x02 \x01(\t\x12\x12\n\npercentage\x18\x03 \x01(\t\"[\n\x18SubscribeStrategyRequest\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x1c\n\x14strategy_instance_id\x18\x02 \x01(\t\x12\x10\n\x08interval\x18\x03 \x01(\x03\"\xa8\x01\n\x0eStrategyUpdate\x12\x0f\n\x07session\x18\x01 \x01(\t\x12\x1c\n\x14strategy_instance_id\x18\x02 \x01(\t\x12\x0c\n\x04time\x18\x03 \x01(\x03\x12\x0e\n\x06status\x18\x04 \x01(\t\x12 \n\x08position\x18\x05 \x01(\x0b\x32\x0e.bbgo.Position\x12\'\n\x0cprofit_stats\x18\x06 \x01(\x0b\x32\x11.bbgo.ProfitStats\"\xc9\x03\n\x0bPluginEvent\x12#\n\x04type\x18\x01 \x01(\x0e\x32\x15.bbgo.PluginEventType\x12\x0f\n\x07session\x18\x02 \x01(\t\x12\x1c\n\x14strategy_instance_id\x18\x03 \x01(\t\x12\x0c\n\x04time\x18\x04 \x01(\x03\x12\x1c\n\x06market\x18\x05 \x01(\x0b\x32\x0c.bbgo.Market\x12-\n\x06params\x18\x06 \x03(\x0b\x32\x1d.bbgo.PluginEvent.ParamsEntry\x12\x1a\n\x05kline\x18\x07 \x01(\x0b\x32\x0b.bbgo.KLine\x12\x1a\n\x05\x64\x65pth\x18\x08 \x01(\x0b\x32\x0b.bbgo.Depth\x12\x1a\n\x05trade\x18\t \x01(\x0b\x32\x0b.bbgo.Trade\x12\x1a\n\x05order\x18\n \x01(\x0b\x32\x0b.bbgo.Order\x12 \n\x08position\x18\x0b \x01(\x0b\x32\x0e.bbgo.Position\x12\x1f\n\x08\x62\x61lances\x18\x0c \x03(\x0b\x32\r.bbgo.Balance\x12)\n\x06result\x18\r \x01(\x0b\x32\x19.bbgo.PluginCommandResult\x1a-\n\x0bParamsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"\xa0\x01\n\rPluginCommand\x12\n\n\x02id\x18\x01 \x01(\t\x12%\n\x04type\x18\x02 \x01(\x0e\x32\x17.bbgo.PluginCommandType\x12(\n\rsubmit_orders\x18\x03 \x03(\x0b\x32\x11.bbgo.SubmitOrder\x12\x11\n\torder_ids\x18\x04 \x03(\t\x12\x12\n\npercentage\x18\x05 \x01(\t\x12\x0b\n\x03tag\x18\x06 \x01(\t\"b\n\x13PluginCommandResult\x12\x12\n\ncommand_id\x18\x01 \x01(\t\x12\x1b\n\x06orders\x18\x02 \x03(\x0b\x32\x0b.bbgo.Order\x12\x1a\n\x05\x65rror\x18\x03 \x01(\x0b\x32\x0b.bbgo.Error*n\n\x05\x45vent\x12\x0b\n\x07UNKNOWN\x10\x00\x12\x0e\n\nSUBSCRIBED\x10\x01\x12\x10\n\x0cUNSUBSCRIBED\x10\x02\x12\x0c\n\x08SNAPSHOT\x10\x03\x12\n\n\x06UPDATE\x10\x04\x12\x11\n\rAUTHENTICATED\x10\x05\x12\t\n\x05\x45RROR\x10\x63*M\n\x07\x43hannel\x12\x08\n\x04\x42OOK\x10\x00\x12\t\n\x05TRADE\x10\x01\x12\n\n\x06TICKER\x10\x02\x12\t\n\x05KLINE\x10\x03\x12\x0b\n\x07\x42\x41LANCE\x10\x04\x12\t\n\x05ORDER\x10\x05*\x19\n\x04Side\x12\x07\n\x03\x42UY\x10\x00\x12\x08\n\x04SELL\x10\x01*a\n\tOrderType\x12\n\n\x06MARKET\x10\x00\x12\t\n\x05LIMIT\x10\x01\x12\x0f\n\x0bSTOP_MARKET\x10\x02\x12\x0e\n\nSTOP_LIMIT\x10\x03\x12\r\n\tPOST_ONLY\x10\x04\x12\r\n\tIOC_LIMIT\x10\x05*\xd2\x01\n\x0fPluginEventType\x12\x08\n\x04INIT\x10\x00\x12\x10\n\x0cKLINE_CLOSED\x10\x01\x12\x11\n\rBOOK_SNAPSHOT\x10\x02\x12\x0f\n\x0b\x42OOK_UPDATE\x10\x03\x12\x10\n\x0cMARKET_TRADE\x10\x04\x12\x10\n\x0cORDER_UPDATE\x10\x05\x12\x10\n\x0cTRADE_UPDATE\x10\x06\x12\x13\n\x0fPOSITION_UPDATE\x10\x07\x12\x12\n\x0e\x42\x41LANCE_UPDATE\x10\x08\x12\x12\n\x0e\x43OMMAND_RESULT\x10\t\x12\x0c\n\x08SHUTDOWN\x10\n*d\n\x11PluginCommandType\x12\x11\n\rSUBMIT_ORDERS\x10\x00\x12\x11\n\rCANCEL_ORDERS\x10\x01\x12\x15\n\x11\x43\x41NCEL_ALL_ORDERS\x10\x02\x12\x12\n\x0e\x43LOSE_POSITION\x10\x03\x32\x94\x01\n\x11MarketDataService\x12\x39\n\tSubscribe\x12\x16.bbgo.SubscribeRequest\x1a\x10.bbgo.MarketData\"\x00\x30\x01\x12\x44\n\x0bQueryKLines\x12\x18.bbgo.QueryKLinesRequest\x1a\x19.bbgo.QueryKLinesResponse\"\x00\x32I\n\x0fUserDataService\x12\x36\n\tSubscribe\x12\x15.bbgo.UserDataRequest\x1a\x0e.bbgo.UserData\"\x00\x30\x01\x32\xeb\x02\n\x0eTradingService\x12\x44\n\x0bSubmitOrder\x12\x18.bbgo.SubmitOrderRequest\x1a\x19.bbgo.SubmitOrderResponse\"\x00\x12\x44\n\x0b\x43\x61ncelOrder\x12\x18.bbgo.CancelOrderRequest\x1a\x19.bbgo.CancelOrderResponse\"\x00\x12\x41\n\nQueryOrder\x12\x17.bbgo.QueryOrderRequest\x1a\x18.bbgo.QueryOrderResponse\"\x00\x12\x44\n\x0bQueryOrders\x12\x18.bbgo.QueryOrdersRequest\x1a\x19.bbgo.QueryOrdersResponse\"\x00\x12\x44\n\x0bQueryTrades\x12\x18.bbgo.QueryTradesRequest\x1a\x19.bbgo.QueryTradesResponse\"\x00\x32\xb5\x03\n\x0fStrategyService\x12M\n\x0eListStrategies\x12\x1b.bbgo.ListStrategiesRequest\x1a\x1c.bbgo.ListStrategiesResponse\"\x00\x12>\n\x0bGetStrategy\x12\x15.bbgo.StrategyRequest\x1a\x16.bbgo.StrategyResponse\"\x00\x12\x42\n\x0fSuspendStrategy\x12\x15.bbgo.StrategyRequest\x1a\x16.bbgo.StrategyResponse\"\x00\x12\x41\n\x0eResumeStrategy\x12\x15.bbgo.StrategyRequest\x1a\x16.bbgo.StrategyResponse\"\x00\x12\x45\n\rClosePosition\x12\x1a.bbgo.ClosePositionRequest\x1a\x16.bbgo.StrategyResponse\"\x00\x12\x45\n\tSubscribe\x12\x1e.bbgo.SubscribeStrategyRequest\x1a\x14.bbgo.StrategyUpdate\"\x00\x30\x01\x32P\n\x15StrategyPluginService\x12\x37\n\x07\x43onnect\x12\x11.bbgo.PluginEvent\x1a\x13.bbgo.PluginCommand\"\x00(\x01\x30\x01\x42\x07Z\x05../pbb\x06proto3')
 
 _EVENT = DESCRIPTOR.enum_types_by_name['Event']
 Event = enum_type_wrapper.EnumTypeWrapper(_EVENT)
@@ -25,6 +25,10 @@ _SIDE = DESCRIPTOR.enum_types_by_name['Side']
 Side = enum_type_wrapper.EnumTypeWrapper(_SIDE)
 _ORDERTYPE = DESCRIPTOR.enum_types_by_name['OrderType']
 OrderType = enum_type_wrapper.EnumTypeWrapper(_ORDERTYPE)
+_PLUGINEVENTTYPE = DESCRIPTOR.enum_types_by_name['PluginEventType']
+PluginEventType = enum_type_wrapper.EnumTypeWrapper(_PLUGINEVENTTYPE)
+_PLUGINCOMMANDTYPE = DESCRIPTOR.enum_types_by_name['PluginCommandType']
+PluginCommandType = enum_type_wrapper.EnumTypeWrapper(_PLUGINCOMMANDTYPE)
 UNKNOWN = 0
 SUBSCRIBED = 1
 UNSUBSCRIBED = 2
@@ -46,6 +50,21 @@ STOP_MARKET = 2
 STOP_LIMIT = 3
 POST_ONLY = 4
 IOC_LIMIT = 5
+INIT = 0
+KLINE_CLOSED = 1
+BOOK_SNAPSHOT = 2
+BOOK_UPDATE = 3
+MARKET_TRADE = 4
+ORDER_UPDATE = 5
+TRADE_UPDATE = 6
+POSITION_UPDATE = 7
+BALANCE_UPDATE = 8
+COMMAND_RESULT = 9
+SHUTDOWN = 10
+SUBMIT_ORDERS = 0
+CANCEL_ORDERS = 1
+CANCEL_ALL_ORDERS = 2
+CLOSE_POSITION = 3
 
 
 _EMPTY = DESCRIPTOR.message_types_by_name['Empty']
@@ -75,6 +94,21 @@ _QUERYTRADESRESPONSE = DESCRIPTOR.message_types_by_name['QueryTradesResponse']
 _QUERYKLINESREQUEST = DESCRIPTOR.message_types_by_name['QueryKLinesRequest']
 _QUERYKLINESRESPONSE = DESCRIPTOR.message_types_by_name['QueryKLinesResponse']
 _KLINE = DESCRIPTOR.message_types_by_name['KLine']
+_POSITION = DESCRIPTOR.message_types_by_name['Position']
+_MARKET = DESCRIPTOR.message_types_by_name['Market']
+_PROFITSTATS = DESCRIPTOR.message_types_by_name['ProfitStats']
+_STRATEGY = DESCRIPTOR.message_types_by_name['Strategy']
+_LISTSTRATEGIESREQUEST = DESCRIPTOR.message_types_by_name['ListStrategiesRequest']
+_LISTSTRATEGIESRESPONSE = DESCRIPTOR.message_types_by_name['ListStrategiesResponse']
+_STRATEGYREQUEST = DESCRIPTOR.message_types_by_name['StrategyRequest']
+_STRATEGYRESPONSE = DESCRIPTOR.message_types_by_name['StrategyResponse']
+_CLOSEPOSITIONREQUEST = DESCRIPTOR.message_types_by_name['ClosePositionRequest']
+_SUBSCRIBESTRATEGYREQUEST = DESCRIPTOR.message_types_by_name['SubscribeStrategyRequest']
+_STRATEGYUPDATE = DESCRIPTOR.message_types_by_name['StrategyUpdate']
+_PLUGINEVENT = DESCRIPTOR.message_types_by_name['PluginEvent']
+_PLUGINEVENT_PARAMSENTRY = _PLUGINEVENT.nested_types_by_name['ParamsEntry']
+_PLUGINCOMMAND = DESCRIPTOR.message_types_by_name['PluginCommand']
+_PLUGINCOMMANDRESULT = DESCRIPTOR.message_types_by_name['PluginCommandResult']
 Empty = _reflection.GeneratedProtocolMessageType('Empty', (_message.Message,), {
   'DESCRIPTOR' : _EMPTY,
   '__module__' : 'bbgo_pb2'
@@ -264,21 +298,135 @@ KLine = _reflection.GeneratedProtocolMessageType('KLine', (_message.Message,), {
   })
 _sym_db.RegisterMessage(KLine)
 
+Position = _reflection.GeneratedProtocolMessageType('Position', (_message.Message,), {
+  'DESCRIPTOR' : _POSITION,
+  '__module__' : 'bbgo_pb2'
+  # @@protoc_insertion_point(class_scope:bbgo.Position)
+  })
+_sym_db.RegisterMessage(Position)
+
+Market = _reflection.GeneratedProtocolMessageType('Market', (_message.Message,), {
+  'DESCRIPTOR' : _MARKET,
+  '__module__' : 'bbgo_pb2'
+  # @@protoc_insertion_point(class_scope:bbgo.Market)
+  })
+_sym_db.RegisterMessage(Market)
+
+ProfitStats = _reflection.GeneratedProtocolMessageType('ProfitStats', (_message.Message,), {
+  'DESCRIPTOR' : _PROFITSTATS,
+  '__module__' : 'bbgo_pb2'
+  # @@protoc_insertion_point(class_scope:bbgo.ProfitStats)
+  })
+_sym_db.RegisterMessage(ProfitStats)
+
+Strategy = _reflection.GeneratedProtocolMessageType('Strategy', (_message.Message,), {
+  'DESCRIPTOR' : _STRATEGY,
+  '__module__' : 'bbgo_pb2'
+  # @@protoc_insertion_point(class_scope:bbgo.Strategy)
+  })
+_sym_db.RegisterMessage(Strategy)
+
+ListStrategiesRequest = _reflection.GeneratedProtocolMessageType('ListStrategiesRequest', (_message.Message,), {
+  'DESCRIPTOR' : _LISTSTRATEGIESREQUEST,
+  '__module__' : 'bbgo_pb2'
+  # @@protoc_insertion_point(class_scope:bbgo.ListStrategiesRequest)
+  })
+_sym_db.RegisterMessage(ListStrategiesRequest)
+
+ListStrategiesResponse = _reflection.GeneratedProtocolMessageType('ListStrategiesResponse', (_message.Message,), {
+  'DESCRIPTOR' : _LISTSTRATEGIESRESPONSE,
+  '__module__' : 'bbgo_pb2'
+  # @@protoc_insertion_point(class_scope:bbgo.ListStrategiesResponse)
+  })
+_sym_db.RegisterMessage(ListStrategiesResponse)
+
+StrategyRequest = _reflection.GeneratedProtocolMessageType('StrategyRequest', (_message.Message,), {
+  'DESCRIPTOR' : _STRATEGYREQUEST,
+  '__module__' : 'bbgo_pb2'
+  # @@protoc_insertion_point(class_scope:bbgo.StrategyRequest)
+  })
+_sym_db.RegisterMessage(StrategyRequest)
+
+StrategyResponse = _reflection.GeneratedProtocolMessageType('StrategyResponse', (_message.Message,), {
+  'DESCRIPTOR' : _STRATEGYRESPONSE,
+  '__module__' : 'bbgo_pb2'
+  # @@protoc_insertion_point(class_scope:bbgo.StrategyResponse)
+  })
+_sym_db.RegisterMessage(StrategyResponse)
+
+ClosePositionRequest = _reflection.GeneratedProtocolMessageType('ClosePositionRequest', (_message.Message,), {
+  'DESCRIPTOR' : _CLOSEPOSITIONREQUEST,
+  '__module__' : 'bbgo_pb2'
+  # @@protoc_insertion_point(class_scope:bbgo.ClosePositionRequest)
+  })
+_sym_db.RegisterMessage(ClosePositionRequest)
+
+SubscribeStrategyRequest = _reflection.GeneratedProtocolMessageType('SubscribeStrategyRequest', (_message.Message,), {
+  'DESCRIPTOR' : _SUBSCRIBESTRATEGYREQUEST,
+  '__module__' : 'bbgo_pb2'
+  # @@protoc_insertion_point(class_scope:bbgo.SubscribeStrategyRequest)
+  })
+_sym_db.RegisterMessage(SubscribeStrategyRequest)
+
+StrategyUpdate = _reflection.GeneratedProtocolMessageType('StrategyUpdate', (_message.Message,), {
+  'DESCRIPTOR' : _STRATEGYUPDATE,
+  '__module__' : 'bbgo_pb2'
+  # @@protoc_insertion_point(class_scope:bbgo.StrategyUpdate)
+  })
+_sym_db.RegisterMessage(StrategyUpdate)
+
+PluginEvent = _reflection.GeneratedProtocolMessageType('PluginEvent', (_message.Message,), {
+
+  'ParamsEntry' : _reflection.GeneratedProtocolMessageType('ParamsEntry', (_message.Message,), {
+    'DESCRIPTOR' : _PLUGINEVENT_PARAMSENTRY,
+    '__module__' : 'bbgo_pb2'
+    # @@protoc_insertion_point(class_scope:bbgo.PluginEvent.ParamsEntry)
+    })
+  ,
+  'DESCRIPTOR' : _PLUGINEVENT,
+  '__module__' : 'bbgo_pb2'
+  # @@protoc_insertion_point(class_scope:bbgo.PluginEvent)
+  })
+_sym_db.RegisterMessage(PluginEvent)
+_sym_db.RegisterMessage(PluginEvent.ParamsEntry)
+
+PluginCommand = _reflection.GeneratedProtocolMessageType('PluginCommand', (_message.Message,), {
+  'DESCRIPTOR' : _PLUGINCOMMAND,
+  '__module__' : 'bbgo_pb2'
+  # @@protoc_insertion_point(class_scope:bbgo.PluginCommand)
+  })
+_sym_db.RegisterMessage(PluginCommand)
+
+PluginCommandResult = _reflection.GeneratedProtocolMessageType('PluginCommandResult', (_message.Message,), {
+  'DESCRIPTOR' : _PLUGINCOMMANDRESULT,
+  '__module__' : 'bbgo_pb2'
+  # @@protoc_insertion_point(class_scope:bbgo.PluginCommandResult)
+  })
+_sym_db.RegisterMessage(PluginCommandResult)
+
 _MARKETDATASERVICE = DESCRIPTOR.services_by_name['MarketDataService']
 _USERDATASERVICE = DESCRIPTOR.services_by_name['UserDataService']
 _TRADINGSERVICE = DESCRIPTOR.services_by_name['TradingService']
+_STRATEGYSERVICE = DESCRIPTOR.services_by_name['StrategyService']
+_STRATEGYPLUGINSERVICE = DESCRIPTOR.services_by_name['StrategyPluginService']
 if _descriptor._USE_C_DESCRIPTORS == False:
 
   DESCRIPTOR._options = None
   DESCRIPTOR._serialized_options = b'Z\005../pb'
-  _EVENT._serialized_start=3305
-  _EVENT._serialized_end=3415
-  _CHANNEL._serialized_start=3417
-  _CHANNEL._serialized_end=3494
-  _SIDE._serialized_start=3496
-  _SIDE._serialized_end=3521
-  _ORDERTYPE._serialized_start=3523
-  _ORDERTYPE._serialized_end=3620
+  _PLUGINEVENT_PARAMSENTRY._options = None
+  _PLUGINEVENT_PARAMSENTRY._serialized_options = b'8\001'
+  _EVENT._serialized_start=5632
+  _EVENT._serialized_end=5742
+  _CHANNEL._serialized_start=5744
+  _CHANNEL._serialized_end=5821
+  _SIDE._serialized_start=5823
+  _SIDE._serialized_end=5848
+  _ORDERTYPE._serialized_start=5850
+  _ORDERTYPE._serialized_end=5947
+  _PLUGINEVENTTYPE._serialized_start=5950
+  _PLUGINEVENTTYPE._serialized_end=6160
+  _PLUGINCOMMANDTYPE._serialized_start=6162
+  _PLUGINCOMMANDTYPE._serialized_end=6262
   _EMPTY._serialized_start=20
   _EMPTY._serialized_end=27
   _ERROR._serialized_start=29
@@ -316,27 +464,61 @@ if _descriptor._USE_C_DESCRIPTORS == False:
   _CANCELORDERRESPONSE._serialized_start=2140
   _CANCELORDERRESPONSE._serialized_end=2217
   _QUERYORDERREQUEST._serialized_start=2219
-  _QUERYORDERREQUEST._serialized_end=2292
-  _QUERYORDERRESPONSE._serialized_start=2294
-  _QUERYORDERRESPONSE._serialized_end=2370
-  _QUERYORDERSREQUEST._serialized_start=2373
-  _QUERYORDERSREQUEST._serialized_end=2542
-  _QUERYORDERSRESPONSE._serialized_start=2544
-  _QUERYORDERSRESPONSE._serialized_end=2622
-  _QUERYTRADESREQUEST._serialized_start=2625
-  _QUERYTRADESREQUEST._serialized_end=2807
-  _QUERYTRADESRESPONSE._serialized_start=2809
-  _QUERYTRADESRESPONSE._serialized_end=2887
-  _QUERYKLINESREQUEST._serialized_start=2889
-  _QUERYKLINESREQUEST._serialized_end=3014
-  _QUERYKLINESRESPONSE._serialized_start=3016
-  _QUERYKLINESRESPONSE._serialized_end=3094
-  _KLINE._serialized_start=3097
-  _KLINE._serialized_end=3303
-  _MARKETDATASERVICE._serialized_start=3623
-  _MARKETDATASERVICE._serialized_end=3771
-  _USERDATASERVICE._serialized_start=3773
-  _USERDATASERVICE._serialized_end=3846
-  _TRADINGSERVICE._serialized_start=3849
-  _TRADINGSERVICE._serialized_end=4212
+  _QUERYORDERREQUEST._serialized_end=2308
+  _QUERYORDERRESPONSE._serialized_start=2310
+  _QUERYORDERRESPONSE._serialized_end=2386
+  _QUERYORDERSREQUEST._serialized_start=2389
+  _QUERYORDERSREQUEST._serialized_end=2558
+  _QUERYORDERSRESPONSE._serialized_start=2560
+  _QUERYORDERSRESPONSE._serialized_end=2638
+  _QUERYTRADESREQUEST._serialized_start=2641
+  _QUERYTRADESREQUEST._serialized_end=2823
+  _QUERYTRADESRESPONSE._serialized_start=2825
+  _QUERYTRADESRESPONSE._serialized_end=2903
+  _QUERYKLINESREQUEST._serialized_start=2905
+  _QUERYKLINESREQUEST._serialized_end=3030
+  _QUERYKLINESRESPONSE._serialized_start=3032
+  _QUERYKLINESRESPONSE._serialized_end=3110
+  _KLINE._serialized_start=3113
+  _KLINE._serialized_end=3319
+  _POSITION._serialized_start=3322
+  _POSITION._serialized_end=3465
+  _MARKET._serialized_start=3468
+  _MARKET._serialized_end=3672
+  _PROFITSTATS._serialized_start=3675
+  _PROFITSTATS._serialized_end=4049
+  _STRATEGY._serialized_start=4052
+  _STRATEGY._serialized_end=4272
+  _LISTSTRATEGIESREQUEST._serialized_start=4274
+  _LISTSTRATEGIESREQUEST._serialized_end=4314
+  _LISTSTRATEGIESRESPONSE._serialized_start=4316
+  _LISTSTRATEGIESRESPONSE._serialized_end=4404
+  _STRATEGYREQUEST._serialized_start=4406
+  _STRATEGYREQUEST._serialized_end=4470
+  _STRATEGYRESPONSE._serialized_start=4472
+  _STRATEGYRESPONSE._serialized_end=4552
+  _CLOSEPOSITIONREQUEST._serialized_start=4554
+  _CLOSEPOSITIONREQUEST._serialized_end=4643
+  _SUBSCRIBESTRATEGYREQUEST._serialized_start=4645
+  _SUBSCRIBESTRATEGYREQUEST._serialized_end=4736
+  _STRATEGYUPDATE._serialized_start=4739
+  _STRATEGYUPDATE._serialized_end=4907
+  _PLUGINEVENT._serialized_start=4910
+  _PLUGINEVENT._serialized_end=5367
+  _PLUGINEVENT_PARAMSENTRY._serialized_start=5322
+  _PLUGINEVENT_PARAMSENTRY._serialized_end=5367
+  _PLUGINCOMMAND._serialized_start=5370
+  _PLUGINCOMMAND._serialized_end=5530
+  _PLUGINCOMMANDRESULT._serialized_start=5532
+  _PLUGINCOMMANDRESULT._serialized_end=5630
+  _MARKETDATASERVICE._serialized_start=6265
+  _MARKETDATASERVICE._serialized_end=6413
+  _USERDATASERVICE._serialized_start=6415
+  _USERDATASERVICE._serialized_end=6488
+  _TRADINGSERVICE._serialized_start=6491
+  _TRADINGSERVICE._serialized_end=6854
+  _STRATEGYSERVICE._serialized_start=6857
+  _STRATEGYSERVICE._serialized_end=7294
+  _STRATEGYPLUGINSERVICE._serialized_start=7296
+  _STRATEGYPLUGINSERVICE._serialized_end=7376
 # @@protoc_insertion_point(module_scope)
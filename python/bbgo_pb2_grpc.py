@@ -352,3 +352,312 @@ class TradingService(object):
             bbgo__pb2.QueryTradesResponse.FromString,
             options, channel_credentials,
             insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+
+class StrategyServiceStub(object):
+    """StrategyService manages the strategy instances running in bbgo.
+    The strategy instance is identified by the session and the instance ID, the session can be omitted
+    if the instance ID is unique.
+    """
+
+    def __init__(self, channel):
+        """Constructor.
+
+        Args:
+            channel: A grpc.Channel.
+        """
+        self.ListStrategies = channel.unary_unary(
+                '/bbgo.StrategyService/ListStrategies',
+                request_serializer=bbgo__pb2.ListStrategiesRequest.SerializeToString,
+                response_deserializer=bbgo__pb2.ListStrategiesResponse.FromString,
+                )
+        self.GetStrategy = channel.unary_unary(
+                '/bbgo.StrategyService/GetStrategy',
+                request_serializer=bbgo__pb2.StrategyRequest.SerializeToString,
+                response_deserializer=bbgo__pb2.StrategyResponse.FromString,
+                )
+        self.SuspendStrategy = channel.unary_unary(
+                '/bbgo.StrategyService/SuspendStrategy',
+                request_serializer=bbgo__pb2.StrategyRequest.SerializeToString,
+                response_deserializer=bbgo__pb2.StrategyResponse.FromString,
+                )
+        self.ResumeStrategy = channel.unary_unary(
+                '/bbgo.StrategyService/ResumeStrategy',
+                request_serializer=bbgo__pb2.StrategyRequest.SerializeToString,
+                response_deserializer=bbgo__pb2.StrategyResponse.FromString,
+                )
+        self.ClosePosition = channel.unary_unary(
+                '/bbgo.StrategyService/ClosePosition',
+                request_serializer=bbgo__pb2.ClosePositionRequest.SerializeToString,
+                response_deserializer=bbgo__pb2.StrategyResponse.FromString,
+                )
+        self.Subscribe = channel.unary_stream(
+                '/bbgo.StrategyService/Subscribe',
+                request_serializer=bbgo__pb2.SubscribeStrategyRequest.SerializeToString,
+                response_deserializer=bbgo__pb2.StrategyUpdate.FromString,
+                )
+
+
+class StrategyServiceServicer(object):
+    """StrategyService manages the strategy instances running in bbgo.
+    The strategy instance is identified by the session and the instance ID, the session can be omitted
+    if the instance ID is unique.
+    """
+
+    def ListStrategies(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def GetStrategy(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def SuspendStrategy(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def ResumeStrategy(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def ClosePosition(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def Subscribe(self, request, context):
+        """Subscribe streams the position and the profit stats of the strategy instance when they are changed
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+
+def add_StrategyServiceServicer_to_server(servicer, server):
+    rpc_method_handlers = {
+            'ListStrategies': grpc.unary_unary_rpc_method_handler(
+                    servicer.ListStrategies,
+                    request_deserializer=bbgo__pb2.ListStrategiesRequest.FromString,
+                    response_serializer=bbgo__pb2.ListStrategiesResponse.SerializeToString,
+            ),
+            'GetStrategy': grpc.unary_unary_rpc_method_handler(
+                    servicer.GetStrategy,
+                    request_deserializer=bbgo__pb2.StrategyRequest.FromString,
+                    response_serializer=bbgo__pb2.StrategyResponse.SerializeToString,
+            ),
+            'SuspendStrategy': grpc.unary_unary_rpc_method_handler(
+                    servicer.SuspendStrategy,
+                    request_deserializer=bbgo__pb2.StrategyRequest.FromString,
+                    response_serializer=bbgo__pb2.StrategyResponse.SerializeToString,
+            ),
+            'ResumeStrategy': grpc.unary_unary_rpc_method_handler(
+                    servicer.ResumeStrategy,
+                    request_deserializer=bbgo__pb2.StrategyRequest.FromString,
+                    response_serializer=bbgo__pb2.StrategyResponse.SerializeToString,
+            ),
+            'ClosePosition': grpc.unary_unary_rpc_method_handler(
+                    servicer.ClosePosition,
+                    request_deserializer=bbgo__pb2.ClosePositionRequest.FromString,
+                    response_serializer=bbgo__pb2.StrategyResponse.SerializeToString,
+            ),
+            'Subscribe': grpc.unary_stream_rpc_method_handler(
+                    servicer.Subscribe,
+                    request_deserializer=bbgo__pb2.SubscribeStrategyRequest.FromString,
+                    response_serializer=bbgo__pb2.StrategyUpdate.SerializeToString,
+            ),
+    }
+    generic_handler = grpc.method_handlers_generic_handler(
+            'bbgo.StrategyService', rpc_method_handlers)
+    server.add_generic_rpc_handlers((generic_handler,))
+
+
+ # This class is part of an EXPERIMENTAL API.
+class StrategyService(object):
+    """StrategyService manages the strategy instances running in bbgo.
+    The strategy instance is identified by the session and the instance ID, the session can be omitted
+    if the instance ID is unique.
+    """
+
+    @staticmethod
+    def ListStrategies(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/bbgo.StrategyService/ListStrategies',
+            bbgo__pb2.ListStrategiesRequest.SerializeToString,
+            bbgo__pb2.ListStrategiesResponse.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+    @staticmethod
+    def GetStrategy(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/bbgo.StrategyService/GetStrategy',
+            bbgo__pb2.StrategyRequest.SerializeToString,
+            bbgo__pb2.StrategyResponse.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+    @staticmethod
+    def SuspendStrategy(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/bbgo.StrategyService/SuspendStrategy',
+            bbgo__pb2.StrategyRequest.SerializeToString,
+            bbgo__pb2.StrategyResponse.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+    @staticmethod
+    def ResumeStrategy(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/bbgo.StrategyService/ResumeStrategy',
+            bbgo__pb2.StrategyRequest.SerializeToString,
+            bbgo__pb2.StrategyResponse.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+    @staticmethod
+    def ClosePosition(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/bbgo.StrategyService/ClosePosition',
+            bbgo__pb2.ClosePositionRequest.SerializeToString,
+            bbgo__pb2.StrategyResponse.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+    @staticmethod
+    def Subscribe(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_stream(request, target, '/bbgo.StrategyService/Subscribe',
+            bbgo__pb2.SubscribeStrategyRequest.SerializeToString,
+            bbgo__pb2.StrategyUpdate.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+
+class StrategyPluginServiceStub(object):
+    """StrategyPluginService is implemented by the external strategy plugin process.
+    bbgo connects to the plugin and opens a bi-directional stream:
+    bbgo sends the market data and the user data events to the plugin,
+    and the plugin sends the order commands back to bbgo.
+    """
+
+    def __init__(self, channel):
+        """Constructor.
+
+        Args:
+            channel: A grpc.Channel.
+        """
+        self.Connect = channel.stream_stream(
+                '/bbgo.StrategyPluginService/Connect',
+                request_serializer=bbgo__pb2.PluginEvent.SerializeToString,
+                response_deserializer=bbgo__pb2.PluginCommand.FromString,
+                )
+
+
+class StrategyPluginServiceServicer(object):
+    """StrategyPluginService is implemented by the external strategy plugin process.
+    bbgo connects to the plugin and opens a bi-directional stream:
+    bbgo sends the market data and the user data events to the plugin,
+    and the plugin sends the order commands back to bbgo.
+    """
+
+    def Connect(self, request_iterator, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+
+def add_StrategyPluginServiceServicer_to_server(servicer, server):
+    rpc_method_handlers = {
+            'Connect': grpc.stream_stream_rpc_method_handler(
+                    servicer.Connect,
+                    request_deserializer=bbgo__pb2.PluginEvent.FromString,
+                    response_serializer=bbgo__pb2.PluginCommand.SerializeToString,
+            ),
+    }
+    generic_handler = grpc.method_handlers_generic_handler(
+            'bbgo.StrategyPluginService', rpc_method_handlers)
+    server.add_generic_rpc_handlers((generic_handler,))
+
+
+ # This class is part of an EXPERIMENTAL API.
+class StrategyPluginService(object):
+    """StrategyPluginService is implemented by the external strategy plugin process.
+    bbgo connects to the plugin and opens a bi-directional stream:
+    bbgo sends the market data and the user data events to the plugin,
+    and the plugin sends the order commands back to bbgo.
+    """
+
+    @staticmethod
+    def Connect(request_iterator,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.stream_stream(request_iterator, target, '/bbgo.StrategyPluginService/Connect',
+            bbgo__pb2.PluginEvent.SerializeToString,
+            bbgo__pb2.PluginCommand.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)